@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+
+	"go.uber.org/zap"
+
+	"github.com/NarthurN/habbr/internal/config"
+	"github.com/NarthurN/habbr/internal/repository/postgres"
+)
+
+// usage описывает подкоманды утилиты
+const usage = `Usage: migrate <command> [arguments]
+
+Commands:
+  up             apply all pending migrations
+  down [N]       revert the last N applied migrations (default 1)
+  status         show applied and pending migrations
+  redo           revert and re-apply the last applied migration
+  baseline [V]   record checksums of migrations applied by the legacy runner,
+                 or record migrations up to version V for a schema created
+                 without the migrator (only after the schema was brought
+                 up to date manually)
+`
+
+// main является точкой входа утилиты миграций базы данных.
+//
+// Утилита использует те же переменные окружения DATABASE_*, что и сервер,
+// и те же SQL файлы из каталога migrations, встроенные в бинарник.
+// Сервер применяет миграции при старте сам; утилита нужна для отката,
+// просмотра состояния и применения миграций до выкладки новой версии.
+//
+// Примеры:
+//
+//	export DATABASE_HOST=localhost
+//	export DATABASE_NAME=habbr
+//	go run ./cmd/migrate status
+//	go run ./cmd/migrate down 2
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	logger, err := setupLogger(cfg.Logger)
+	if err != nil {
+		log.Fatalf("Failed to setup logger: %v", err)
+	}
+	defer logger.Sync()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, cfg, os.Args[1], os.Args[2:], logger); err != nil {
+		logger.Error("Migration command failed", zap.String("command", os.Args[1]), zap.Error(err))
+		os.Exit(1)
+	}
+}
+
+// run подключается к базе данных и выполняет подкоманду
+func run(ctx context.Context, cfg *config.Config, command string, args []string, logger *zap.Logger) error {
+	steps := 1
+	baselineVersion := 0
+	switch command {
+	case "up", "status", "redo":
+		if len(args) > 0 {
+			return fmt.Errorf("%s takes no arguments", command)
+		}
+	case "down":
+		if len(args) > 1 {
+			return fmt.Errorf("down takes at most one argument")
+		}
+		if len(args) == 1 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid number of steps: %q", args[0])
+			}
+			steps = n
+		}
+	case "baseline":
+		if len(args) > 1 {
+			return fmt.Errorf("baseline takes at most one argument")
+		}
+		if len(args) == 1 {
+			v, err := strconv.Atoi(args[0])
+			if err != nil || v <= 0 {
+				return fmt.Errorf("invalid migration version: %q", args[0])
+			}
+			baselineVersion = v
+		}
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command: %s", command)
+	}
+
+	manager, err := postgres.NewManager(ctx, &cfg.Database, logger.Named("postgres"))
+	if err != nil {
+		return err
+	}
+	defer manager.Close(context.Background())
+
+	migrator, err := manager.Migrator()
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Applied %d migration(s)\n", applied)
+	case "down":
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Reverted %d migration(s)\n", reverted)
+	case "redo":
+		if err := migrator.Redo(ctx); err != nil {
+			return err
+		}
+		fmt.Println("Last migration re-applied")
+	case "baseline":
+		recorded, err := migrator.Baseline(ctx, baselineVersion)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Recorded %d migration(s)\n", recorded)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printStatus(statuses)
+	}
+
+	return nil
+}
+
+// printStatus выводит состояние миграций таблицей
+func printStatus(statuses []postgres.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+
+	for _, status := range statuses {
+		state := "pending"
+		switch {
+		case status.Missing:
+			state = "applied (file missing)"
+		case status.Drifted:
+			state = "applied (modified)"
+		case status.Unverified:
+			state = "applied (no checksum)"
+		case status.Applied:
+			state = "applied"
+		}
+
+		appliedAt := "-"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+		}
+
+		fmt.Fprintf(w, "%03d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+
+	w.Flush()
+}
+
+// setupLogger создает логгер по конфигурации приложения
+func setupLogger(cfg config.LoggerConfig) (*zap.Logger, error) {
+	var zapConfig zap.Config
+
+	switch cfg.Format {
+	case "console":
+		zapConfig = zap.NewDevelopmentConfig()
+	default: // json
+		zapConfig = zap.NewProductionConfig()
+	}
+
+	if level, err := zap.ParseAtomicLevel(cfg.Level); err == nil {
+		zapConfig.Level = level
+	}
+
+	zapConfig.DisableCaller = !cfg.EnableCaller
+
+	return zapConfig.Build()
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
@@ -15,14 +16,22 @@ import (
 	"github.com/99designs/gqlgen/graphql/handler/lru"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/redis/go-redis/v9"
 	"github.com/vektah/gqlparser/v2/ast"
 	"go.uber.org/zap"
 
+	"github.com/NarthurN/habbr/internal/api/graphql/directive"
 	"github.com/NarthurN/habbr/internal/api/graphql/generated"
+	"github.com/NarthurN/habbr/internal/api/graphql/limit"
 	"github.com/NarthurN/habbr/internal/api/graphql/resolver"
+	"github.com/NarthurN/habbr/internal/auth"
 	"github.com/NarthurN/habbr/internal/config"
+	"github.com/NarthurN/habbr/internal/dataloader"
+	"github.com/NarthurN/habbr/internal/pubsub"
+	"github.com/NarthurN/habbr/internal/ratelimit"
 	"github.com/NarthurN/habbr/internal/repository"
 	"github.com/NarthurN/habbr/internal/repository/memory"
+	"github.com/NarthurN/habbr/internal/repository/postgres"
 	"github.com/NarthurN/habbr/internal/service"
 )
 
@@ -58,6 +67,7 @@ import (
 //	export DATABASE_PASSWORD=secret
 //	export LOGGER_LEVEL=warn
 //	export LOGGER_FORMAT=json
+//	export AUTH_HMAC_SECRET=very-long-random-secret-of-32-bytes
 //	./habbr-server
 func main() {
 	// Загрузка конфигурации
@@ -80,7 +90,7 @@ func main() {
 	)
 
 	// Инициализация репозиториев
-	repoManager, err := setupRepositories(cfg)
+	repoManager, err := setupRepositories(cfg, logger)
 	if err != nil {
 		logger.Fatal("Failed to setup repositories", zap.Error(err))
 	}
@@ -90,17 +100,36 @@ func main() {
 		}
 	}()
 
+	// Настройка аутентификации
+	verifier, err := setupAuth(cfg.Auth, logger)
+	if err != nil {
+		logger.Fatal("Failed to setup authentication", zap.Error(err))
+	}
+
+	// Настройка ограничения частоты запросов
+	limiter := setupRateLimiter(cfg.RateLimit, logger)
+	if limiter != nil {
+		defer limiter.Close()
+	}
+
+	// Настройка брокера сообщений для подписок
+	broker, err := setupBroker(cfg.Subscription, repoManager, logger)
+	if err != nil {
+		logger.Fatal("Failed to setup subscription broker", zap.Error(err))
+	}
+	defer broker.Close()
+
 	// Инициализация сервисов
-	serviceManager := service.NewManager(repoManager.GetRepositories(), logger)
+	serviceManager := service.NewManager(repoManager.GetRepositories(), repoManager, cfg.Subscription, broker, cfg.Analytics, logger)
 	defer serviceManager.Close()
 
 	// Настройка GraphQL сервера
-	srv := setupGraphQLServer(cfg, serviceManager.GetServices(), logger)
+	srv := setupGraphQLServer(cfg, serviceManager.GetServices(), verifier, limiter, logger)
 
 	// Настройка HTTP сервера
 	httpServer := &http.Server{
 		Addr:         cfg.GetServerAddress(),
-		Handler:      setupHTTPHandlers(cfg, srv),
+		Handler:      setupHTTPHandlers(cfg, srv, verifier, limiter, serviceManager, logger),
 		ReadTimeout:  cfg.Server.ReadTimeout,
 		WriteTimeout: cfg.Server.WriteTimeout,
 		IdleTimeout:  cfg.Server.IdleTimeout,
@@ -187,7 +216,7 @@ func setupLogger(cfg config.LoggerConfig) (*zap.Logger, error) {
 // Функция создает подходящий менеджер репозиториев в зависимости от типа
 // базы данных, указанного в конфигурации. Поддерживает:
 // - "memory": In-memory хранилище для разработки и тестирования
-// - "postgres": PostgreSQL база данных для продакшена (будущая реализация)
+// - "postgres": PostgreSQL база данных для продакшена (с применением миграций)
 //
 // Менеджер репозиториев обеспечивает:
 // - Единый интерфейс доступа к данным
@@ -196,13 +225,13 @@ func setupLogger(cfg config.LoggerConfig) (*zap.Logger, error) {
 //
 // Параметры:
 //   - cfg: полная конфигурация приложения
+//   - logger: логгер для PostgreSQL менеджера
 //
 // Возвращает:
 //   - interface{}: менеджер репозиториев с методами GetRepositories() и Close()
 //   - error: ошибка инициализации репозиториев
 //
 // Возможные ошибки:
-//   - "PostgreSQL repository not implemented yet": PostgreSQL пока не реализован
 //   - "unsupported database type: X": неподдерживаемый тип базы данных
 //   - Ошибки подключения к базе данных (для PostgreSQL)
 //
@@ -213,7 +242,7 @@ func setupLogger(cfg config.LoggerConfig) (*zap.Logger, error) {
 //
 // Пример использования:
 //
-//	repoManager, err := setupRepositories(cfg)
+//	repoManager, err := setupRepositories(cfg, logger)
 //	if err != nil {
 //	    return fmt.Errorf("не удалось инициализировать репозитории: %w", err)
 //	}
@@ -221,26 +250,126 @@ func setupLogger(cfg config.LoggerConfig) (*zap.Logger, error) {
 //
 //	repos := repoManager.GetRepositories()
 //	post, err := repos.Post.GetByID(ctx, postID)
-func setupRepositories(cfg *config.Config) (interface {
-	GetRepositories() *repository.Repositories
-	Close(context.Context) error
-}, error) {
+func setupRepositories(cfg *config.Config, logger *zap.Logger) (repository.RepositoryManager, error) {
 	switch cfg.Database.Type {
 	case "memory":
 		return memory.NewManager(), nil
 	case "postgres":
-		// TODO: Реализовать PostgreSQL менеджер
-		return nil, fmt.Errorf("PostgreSQL repository not implemented yet")
+		ctx := context.Background()
+		manager, err := postgres.NewManager(ctx, &cfg.Database, logger.Named("postgres"))
+		if err != nil {
+			return nil, err
+		}
+		if err := manager.Migrate(ctx); err != nil {
+			manager.Close(ctx)
+			return nil, fmt.Errorf("failed to migrate database: %w", err)
+		}
+		return manager, nil
 	default:
 		return nil, fmt.Errorf("unsupported database type: %s", cfg.Database.Type)
 	}
 }
 
+// setupBroker создает брокер сообщений, через который события подписок доходят до всех реплик.
+//
+// Брокер "postgres" использует пул соединений PostgreSQL менеджера репозиториев,
+// поэтому доступен только вместе с DATABASE_TYPE=postgres (проверяется в config.Validate).
+//
+// Параметры:
+//   - cfg: конфигурация подписок
+//   - repoManager: менеджер репозиториев, созданный setupRepositories
+//   - logger: логгер брокера
+//
+// Возвращает:
+//   - pubsub.Broker: брокер сообщений
+//   - error: ошибка подключения к PostgreSQL или Redis
+func setupBroker(cfg config.SubscriptionConfig, repoManager any, logger *zap.Logger) (pubsub.Broker, error) {
+	logger.Info("Subscription broker selected", zap.String("broker", cfg.Broker))
+
+	switch cfg.Broker {
+	case pubsub.BrokerMemory:
+		return pubsub.NewMemoryBroker(), nil
+	case pubsub.BrokerPostgres:
+		manager, ok := repoManager.(*postgres.Manager)
+		if !ok {
+			return nil, fmt.Errorf("postgres broker requires postgres repositories")
+		}
+		return pubsub.NewPostgresBroker(context.Background(), manager.Pool(), logger.Named("pubsub"))
+	case pubsub.BrokerRedis:
+		return pubsub.NewRedisBroker(context.Background(), &redis.Options{
+			Addr:     cfg.RedisAddr,
+			Password: cfg.RedisPassword,
+			DB:       cfg.RedisDB,
+		}, logger.Named("pubsub"))
+	default:
+		return nil, fmt.Errorf("unsupported subscription broker: %s", cfg.Broker)
+	}
+}
+
+// setupAuth создает проверяющего JWT токены на основе конфигурации аутентификации.
+//
+// Если ни HMAC секрет, ни JWKS файл не заданы, возвращает nil: все запросы
+// обрабатываются как анонимные, а мутации, требующие владельца, отклоняются
+// с ошибкой UNAUTHORIZED. Такой режим допустим только для разработки,
+// поэтому в лог записывается предупреждение.
+//
+// Параметры:
+//   - cfg: конфигурация аутентификации
+//   - logger: логгер для записи выбранного режима
+//
+// Возвращает:
+//   - *auth.Verifier: проверяющий токены или nil, если аутентификация отключена
+//   - error: ошибка загрузки ключей (например, некорректный JWKS файл)
+func setupAuth(cfg config.AuthConfig, logger *zap.Logger) (*auth.Verifier, error) {
+	if !cfg.IsEnabled() {
+		logger.Warn("Authentication is not configured, all requests are anonymous")
+		return nil, nil
+	}
+
+	verifier, err := auth.NewVerifier(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("JWT authentication enabled",
+		zap.Bool("hs256", cfg.HMACSecret != ""),
+		zap.Bool("rs256", cfg.JWKSFile != ""),
+	)
+
+	return verifier, nil
+}
+
+// setupRateLimiter создает лимитер частоты запросов для директивы @rateLimit.
+//
+// Состояние корзин токенов хранится в памяти процесса, поэтому при запуске
+// нескольких экземпляров сервиса лимиты считаются для каждого экземпляра отдельно.
+// Для согласованных лимитов достаточно реализовать ratelimit.Store поверх Redis.
+//
+// Параметры:
+//   - cfg: конфигурация ограничения частоты запросов
+//   - logger: логгер для записи ошибок хранилища
+//
+// Возвращает:
+//   - *ratelimit.Limiter: лимитер или nil, если ограничение отключено
+func setupRateLimiter(cfg config.RateLimitConfig, logger *zap.Logger) *ratelimit.Limiter {
+	if !cfg.Enabled {
+		logger.Warn("Rate limiting is disabled")
+		return nil
+	}
+
+	logger.Info("Rate limiting enabled",
+		zap.Bool("trust_forwarded_for", cfg.TrustForwardedFor),
+		zap.Duration("idle_ttl", cfg.IdleTTL),
+	)
+
+	return ratelimit.NewLimiter(ratelimit.NewMemoryStore(cfg.IdleTTL), logger.Named("ratelimit"))
+}
+
 // setupGraphQLServer создает и настраивает GraphQL сервер с полной функциональностью.
 //
 // Функция выполняет комплексную настройку GraphQL сервера включая:
 // - Создание резолверов с внедрением зависимостей сервисов
-// - Настройку исполняемой схемы с типами и мутациями
+// - Настройку исполняемой схемы с типами, мутациями и директивами (@auth, @rateLimit)
 // - Добавление транспортов (HTTP, WebSocket для подписок)
 // - Конфигурацию кэширования запросов и схем
 // - Подключение расширений (introspection, APQ)
@@ -254,11 +383,14 @@ func setupRepositories(cfg *config.Config) (interface {
 // Функции производительности:
 //   - LRU кэш для скомпилированных запросов (1000 элементов)
 //   - Automatic Persisted Queries для экономии трафика
+//   - Ограничение глубины и стоимости запросов (cfg.QueryLimit)
 //   - Introspection отключается в продакшене для безопасности
 //
 // Параметры:
 //   - cfg: конфигурация сервера с настройками безопасности
 //   - services: инициализированные сервисы бизнес-логики
+//   - verifier: проверяющий JWT токены для WebSocket соединений (может быть nil)
+//   - limiter: лимитер для директивы @rateLimit (nil - без ограничений)
 //   - logger: логгер для отслеживания операций GraphQL
 //
 // Возвращает:
@@ -271,26 +403,36 @@ func setupRepositories(cfg *config.Config) (interface {
 //
 // Пример использования:
 //
-//	srv := setupGraphQLServer(cfg, services, logger)
+//	srv := setupGraphQLServer(cfg, services, verifier, limiter, logger)
 //	http.Handle("/graphql", srv)
 //
 //	// Для тестирования подписок:
 //	http.Handle("/ws", srv) // WebSocket endpoint
-func setupGraphQLServer(cfg *config.Config, services *service.Services, logger *zap.Logger) *handler.Server {
+func setupGraphQLServer(cfg *config.Config, services *service.Services, verifier *auth.Verifier, limiter *ratelimit.Limiter, logger *zap.Logger) *handler.Server {
 	// Создаем резолвер с внедренными зависимостями
 	resolverImpl := resolver.NewResolver(services, logger.Named("graphql"))
 
 	// Создаем исполняемую схему
+	// Директивы схемы (@auth, @rateLimit) проверяют доступ до вызова резолверов
 	executableSchema := generated.NewExecutableSchema(generated.Config{
 		Resolvers: resolverImpl,
+		Directives: generated.DirectiveRoot{
+			Auth:      directive.Auth(logger.Named("directive")),
+			RateLimit: directive.RateLimit(limiter, logger.Named("directive")),
+		},
+		Complexity: limit.Complexity(limit.Weights{
+			Stats: cfg.QueryLimit.StatsWeight,
+		}),
 	})
 
 	// Создаем сервер
 	srv := handler.New(executableSchema)
 
 	// Добавляем транспорты
+	// Токен WebSocket соединения передается в payload connection_init
 	srv.AddTransport(transport.Websocket{
 		KeepAlivePingInterval: 10 * time.Second,
+		InitFunc:              auth.WebsocketInitFunc(verifier, logger.Named("auth")),
 	})
 	srv.AddTransport(transport.Options{})
 	srv.AddTransport(transport.GET{})
@@ -309,9 +451,14 @@ func setupGraphQLServer(cfg *config.Config, services *service.Services, logger *
 		Cache: lru.New[string](100),
 	})
 
+	// Глубина и стоимость проверяются до выполнения резолверов
+	srv.Use(limit.New(cfg.QueryLimit.MaxDepth, cfg.QueryLimit.MaxComplexity, logger.Named("query_limit")))
+
 	logger.Info("GraphQL server configured successfully",
 		zap.Bool("introspection", cfg.Server.EnableIntrospection),
 		zap.Bool("playground", cfg.Server.EnablePlayground),
+		zap.Int("max_depth", cfg.QueryLimit.MaxDepth),
+		zap.Int("max_complexity", cfg.QueryLimit.MaxComplexity),
 	)
 
 	return srv
@@ -335,6 +482,11 @@ func setupGraphQLServer(cfg *config.Config, services *service.Services, logger *
 // Параметры:
 //   - cfg: конфигурация сервера с настройками endpoints
 //   - graphqlServer: настроенный GraphQL сервер для обработки запросов
+//   - verifier: проверяющий JWT токены из заголовка Authorization (может быть nil)
+//   - limiter: лимитер частоты запросов, метрики которого отдаются в /metrics (может быть nil)
+//   - serviceManager: менеджер сервисов, метрики подписок которого отдаются в /metrics,
+//     а сервис комментариев используется загрузчиками запроса (может быть nil)
+//   - logger: логгер для записи отклоненных токенов
 //
 // Возвращает:
 //   - http.Handler: маршрутизатор с настроенными endpoints
@@ -348,21 +500,31 @@ func setupGraphQLServer(cfg *config.Config, services *service.Services, logger *
 //	{"service":"habbr-graphql-api","status":"running","endpoints":["/query","/health"]}
 //
 //	GET /metrics:
-//	{"service":"habbr-graphql-api","uptime":"unknown"}
+//	{"service":"habbr-graphql-api","uptime":"1h2m3s","rate_limiter":{"allowed":120,"rejected":3,...},
+//	 "subscription":{"total_subscribers":2,"messages_dropped":5,"subscriber_drops":{"<id>":5},...}}
 //
 // Пример использования:
 //
-//	handler := setupHTTPHandlers(cfg, graphqlServer)
+//	handler := setupHTTPHandlers(cfg, graphqlServer, verifier, limiter, serviceManager, logger)
 //	server := &http.Server{
 //	    Addr:    ":8080",
 //	    Handler: handler,
 //	}
 //	server.ListenAndServe()
-func setupHTTPHandlers(cfg *config.Config, graphqlServer *handler.Server) http.Handler {
+func setupHTTPHandlers(cfg *config.Config, graphqlServer *handler.Server, verifier *auth.Verifier, limiter *ratelimit.Limiter, serviceManager *service.Manager, logger *zap.Logger) http.Handler {
 	mux := http.NewServeMux()
-
-	// GraphQL endpoint
-	mux.Handle("/query", graphqlServer)
+	startedAt := time.Now()
+
+	// GraphQL endpoint с аутентификацией по Bearer токену.
+	// IP клиента сохраняется в контексте как ключ лимита для анонимных запросов.
+	queryHandler := auth.Middleware(verifier, logger.Named("auth"))(graphqlServer)
+	queryHandler = ratelimit.Middleware(cfg.RateLimit.TrustForwardedFor)(queryHandler)
+	// Загрузчики создаются на каждый запрос и объединяют загрузку связей
+	// Post.comments и Comment.children в пакетные запросы к хранилищу.
+	if serviceManager != nil {
+		queryHandler = dataloader.Middleware(serviceManager.GetServices().Comment)(queryHandler)
+	}
+	mux.Handle("/query", queryHandler)
 
 	// GraphQL Playground (только в режиме разработки)
 	if cfg.Server.EnablePlayground {
@@ -381,11 +543,26 @@ func setupHTTPHandlers(cfg *config.Config, graphqlServer *handler.Server) http.H
 		fmt.Fprintf(w, `{"status":"ok","service":"habbr-graphql-api","timestamp":"%s"}`, time.Now().Format(time.RFC3339))
 	})
 
-	// Metrics endpoint (базовый)
+	// Metrics endpoint
 	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		metrics := map[string]interface{}{
+			"service": "habbr-graphql-api",
+			"uptime":  time.Since(startedAt).Round(time.Second).String(),
+		}
+		if limiter != nil {
+			metrics["rate_limiter"] = limiter.Stats(r.Context())
+		}
+		if serviceManager != nil {
+			for name, serviceMetrics := range serviceManager.GetMetrics() {
+				metrics[name] = serviceMetrics
+			}
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
-		fmt.Fprintf(w, `{"service":"habbr-graphql-api","uptime":"unknown"}`)
+		if err := json.NewEncoder(w).Encode(metrics); err != nil {
+			logger.Error("Failed to encode metrics", zap.Error(err))
+		}
 	})
 
 	return mux
@@ -0,0 +1,47 @@
+package converter
+
+import (
+	"github.com/NarthurN/habbr/internal/api/graphql/generated"
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/google/uuid"
+)
+
+// PostAnalyticsFilterFromGraphQL конвертирует GraphQL фильтр и порядок аналитики постов в domain модель
+func PostAnalyticsFilterFromGraphQL(filter *generated.PostAnalyticsFilter, orderBy *generated.PostAnalyticsOrder) (model.PostAnalyticsFilter, error) {
+	result := model.PostAnalyticsFilter{Order: PostAnalyticsOrderFromGraphQL(orderBy)}
+	if filter == nil {
+		return result, nil
+	}
+
+	if filter.AuthorID != nil {
+		authorID, err := uuid.Parse(*filter.AuthorID)
+		if err != nil {
+			return model.PostAnalyticsFilter{}, err
+		}
+		result.AuthorID = &authorID
+	}
+
+	result.CreatedAfter = filter.CreatedAfter
+	result.CreatedBefore = filter.CreatedBefore
+	result.MinComments = filter.MinComments
+
+	return result, nil
+}
+
+// PostAnalyticsToGraphQL конвертирует аналитику постов в GraphQL
+func PostAnalyticsToGraphQL(items []*model.PostAnalytics) []*generated.PostAnalytics {
+	result := make([]*generated.PostAnalytics, len(items))
+	for i, item := range items {
+		result[i] = &generated.PostAnalytics{
+			PostID:          item.PostID.String(),
+			Title:           item.Title,
+			AuthorID:        item.AuthorID.String(),
+			CreatedAt:       item.CreatedAt,
+			TotalComments:   item.TotalComments,
+			RootComments:    item.RootComments,
+			MaxCommentDepth: item.MaxCommentDepth,
+			LastCommentAt:   item.LastCommentAt,
+		}
+	}
+	return result
+}
@@ -2,6 +2,7 @@ package converter
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/NarthurN/habbr/internal/api/graphql/generated"
 	"github.com/NarthurN/habbr/internal/model"
@@ -20,15 +21,24 @@ func CommentToGraphQL(comment *model.Comment) *generated.Comment {
 		parentID = &id
 	}
 
+	// Автор надгробия скрыт
+	authorID := comment.AuthorID.String()
+	if comment.IsDeleted() {
+		authorID = ""
+	}
+
 	return &generated.Comment{
 		ID:        comment.ID.String(),
 		PostID:    comment.PostID.String(),
 		ParentID:  parentID,
 		Content:   comment.Content,
-		AuthorID:  comment.AuthorID.String(),
+		AuthorID:  authorID,
 		Depth:     comment.Depth,
+		Language:  LanguageToGraphQL(comment.Language),
 		CreatedAt: comment.CreatedAt,
 		UpdatedAt: comment.UpdatedAt,
+		IsDeleted: comment.IsDeleted(),
+		DeletedAt: comment.DeletedAt,
 	}
 }
 
@@ -121,6 +131,54 @@ func CommentConnectionToGraphQL(conn *model.CommentConnection) *generated.Commen
 	}
 }
 
+// CommentTreeToGraphQL конвертирует domain CommentTree в GraphQL
+func CommentTreeToGraphQL(tree *model.CommentTree) *generated.CommentTree {
+	if tree == nil {
+		return &generated.CommentTree{
+			Nodes:    []*generated.CommentTreeNode{},
+			PageInfo: &generated.PageInfo{},
+		}
+	}
+
+	nodes := make([]*generated.CommentTreeNode, len(tree.Nodes))
+	for i, node := range tree.Nodes {
+		nodes[i] = &generated.CommentTreeNode{
+			Comment:           CommentToGraphQL(node.Comment),
+			Cursor:            node.Cursor,
+			ReplyCount:        node.ReplyCount,
+			LoadedReplyCount:  node.LoadedReplyCount,
+			MoreRepliesCursor: node.MoreRepliesCursor,
+			ContinueThread:    node.ContinueThread,
+		}
+	}
+
+	return &generated.CommentTree{
+		Nodes: nodes,
+		PageInfo: &generated.PageInfo{
+			HasNextPage:     tree.PageInfo.HasNextPage,
+			HasPreviousPage: tree.PageInfo.HasPreviousPage,
+			StartCursor:     tree.PageInfo.StartCursor,
+			EndCursor:       tree.PageInfo.EndCursor,
+		},
+	}
+}
+
+// CommentContextToGraphQL конвертирует domain CommentContext в GraphQL
+func CommentContextToGraphQL(commentCtx *model.CommentContext) *generated.CommentContext {
+	ancestors := make([]*generated.Comment, len(commentCtx.Ancestors))
+	for i, ancestor := range commentCtx.Ancestors {
+		ancestors[i] = CommentToGraphQL(ancestor)
+	}
+
+	return &generated.CommentContext{
+		Comment:          CommentToGraphQL(commentCtx.Comment),
+		Ancestors:        ancestors,
+		HasMoreAncestors: commentCtx.HasMoreAncestors,
+		Replies:          CommentTreeToGraphQL(commentCtx.Replies),
+		TotalReplies:     commentCtx.TotalReplies,
+	}
+}
+
 // CommentResultToGraphQL конвертирует результат операции с комментарием в GraphQL
 func CommentResultToGraphQL(comment *model.Comment, err error) *generated.CommentResult {
 	if err != nil {
@@ -165,6 +223,73 @@ func BatchDeleteResultToGraphQL(deletedIDs []uuid.UUID, errors []error) *generat
 	}
 }
 
+// CommentBatchDeleteResultToGraphQL конвертирует результат массового удаления комментариев в GraphQL.
+//
+// Итоги выводятся в порядке requestedIDs, повторы объединяются. ID, которые не удалось
+// разобрать, получают итог INVALID_ID; остальные берутся из result.
+func CommentBatchDeleteResultToGraphQL(requestedIDs []string, result *model.CommentBatchDeleteResult, err error) *generated.CommentBatchDeleteResult {
+	if err != nil {
+		return &generated.CommentBatchDeleteResult{
+			Success:    false,
+			DeletedIDs: []string{},
+			Results:    []*generated.CommentDeleteItemResult{},
+			Error:      stringPtr(err.Error()),
+		}
+	}
+
+	items := make(map[uuid.UUID]*model.CommentDeleteItem, len(result.Items))
+	for _, item := range result.Items {
+		items[item.ID] = item
+	}
+
+	success := true
+	results := make([]*generated.CommentDeleteItemResult, 0, len(requestedIDs))
+	seen := make(map[string]bool, len(requestedIDs))
+	for _, idStr := range requestedIDs {
+		if seen[idStr] {
+			continue
+		}
+		seen[idStr] = true
+
+		itemResult := &generated.CommentDeleteItemResult{ID: idStr}
+		id, parseErr := ParseID(idStr)
+		item, found := items[id]
+		switch {
+		case parseErr != nil:
+			itemResult.Status = generated.CommentDeleteStatusInvalidID
+			itemResult.Message = stringPtr(parseErr.Error())
+		case !found:
+			// Разные записи одного UUID объединяются с первой
+			continue
+		default:
+			// Значения статусов domain и GraphQL совпадают
+			itemResult.ID = id.String()
+			itemResult.Status = generated.CommentDeleteStatus(item.Status)
+			if item.Message != "" {
+				itemResult.Message = stringPtr(item.Message)
+			}
+			delete(items, id)
+		}
+
+		if itemResult.Status != generated.CommentDeleteStatusDeleted {
+			success = false
+		}
+		results = append(results, itemResult)
+	}
+
+	deletedIDs := make([]string, len(result.DeletedIDs))
+	for i, id := range result.DeletedIDs {
+		deletedIDs[i] = id.String()
+	}
+
+	return &generated.CommentBatchDeleteResult{
+		Success:      success,
+		DeletedCount: len(deletedIDs),
+		DeletedIDs:   deletedIDs,
+		Results:      results,
+	}
+}
+
 // CommentEventToGraphQL конвертирует событие комментария в GraphQL
 func CommentEventToGraphQL(eventType string, comment *model.Comment) (*generated.CommentEvent, error) {
 	var gqlEventType generated.CommentEventType
@@ -176,6 +301,8 @@ func CommentEventToGraphQL(eventType string, comment *model.Comment) (*generated
 		gqlEventType = generated.CommentEventTypeUpdated
 	case "DELETED":
 		gqlEventType = generated.CommentEventTypeDeleted
+	case "TOMBSTONED":
+		gqlEventType = generated.CommentEventTypeTombstoned
 	default:
 		return nil, fmt.Errorf("unknown event type: %s", eventType)
 	}
@@ -186,3 +313,28 @@ func CommentEventToGraphQL(eventType string, comment *model.Comment) (*generated
 		PostID:  comment.PostID.String(),
 	}, nil
 }
+
+// CommentPayloadToGraphQL конвертирует событие подписки на комментарии в GraphQL событие
+func CommentPayloadToGraphQL(payload *model.CommentSubscriptionPayload) (*generated.CommentEvent, error) {
+	event, err := CommentEventToGraphQL(payload.ActionType, payload.Comment)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.EventID != 0 {
+		eventID := strconv.FormatInt(payload.EventID, 10)
+		event.EventID = &eventID
+	}
+
+	return event, nil
+}
+
+// ParseEventID парсит идентификатор события подписки из строки
+func ParseEventID(id string) (int64, error) {
+	eventID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || eventID < 0 {
+		return 0, fmt.Errorf("invalid event ID format: %s", id)
+	}
+
+	return eventID, nil
+}
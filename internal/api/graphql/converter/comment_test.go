@@ -9,6 +9,7 @@ import (
 	"github.com/NarthurN/habbr/internal/model"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCommentToGraphQL(t *testing.T) {
@@ -38,6 +39,7 @@ func TestCommentToGraphQL(t *testing.T) {
 				Content:   "Test Comment",
 				AuthorID:  authorID,
 				Depth:     0,
+				Language:  model.LanguageEnglish,
 				CreatedAt: createdAt,
 				UpdatedAt: updatedAt,
 			},
@@ -48,6 +50,7 @@ func TestCommentToGraphQL(t *testing.T) {
 				Content:   "Test Comment",
 				AuthorID:  authorID.String(),
 				Depth:     0,
+				Language:  generated.LanguageEnglish,
 				CreatedAt: createdAt,
 				UpdatedAt: updatedAt,
 			},
@@ -61,6 +64,7 @@ func TestCommentToGraphQL(t *testing.T) {
 				Content:   "Child Comment",
 				AuthorID:  authorID,
 				Depth:     1,
+				Language:  model.LanguageEnglish,
 				CreatedAt: createdAt,
 				UpdatedAt: updatedAt,
 			},
@@ -71,6 +75,7 @@ func TestCommentToGraphQL(t *testing.T) {
 				Content:   "Child Comment",
 				AuthorID:  authorID.String(),
 				Depth:     1,
+				Language:  generated.LanguageEnglish,
 				CreatedAt: createdAt,
 				UpdatedAt: updatedAt,
 			},
@@ -451,6 +456,57 @@ func TestBatchDeleteResultToGraphQL(t *testing.T) {
 	}
 }
 
+func TestCommentBatchDeleteResultToGraphQL(t *testing.T) {
+	id1 := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	id2 := uuid.MustParse("123e4567-e89b-12d3-a456-426614174001")
+	reply := uuid.MustParse("123e4567-e89b-12d3-a456-426614174002")
+
+	t.Run("results follow request order", func(t *testing.T) {
+		result := &model.CommentBatchDeleteResult{
+			Items: []*model.CommentDeleteItem{
+				{ID: id1, Status: model.CommentDeleteStatusDeleted},
+				{ID: id2, Status: model.CommentDeleteStatusForbidden, Message: "forbidden"},
+			},
+			DeletedIDs: []uuid.UUID{id1, reply},
+		}
+
+		gqlResult := CommentBatchDeleteResultToGraphQL([]string{id2.String(), "bad", id1.String(), id2.String()}, result, nil)
+
+		assert.False(t, gqlResult.Success)
+		assert.Equal(t, 2, gqlResult.DeletedCount)
+		assert.Equal(t, []string{id1.String(), reply.String()}, gqlResult.DeletedIDs)
+		assert.Nil(t, gqlResult.Error)
+		require.Len(t, gqlResult.Results, 3)
+		assert.Equal(t, id2.String(), gqlResult.Results[0].ID)
+		assert.Equal(t, generated.CommentDeleteStatusForbidden, gqlResult.Results[0].Status)
+		assert.Equal(t, "forbidden", *gqlResult.Results[0].Message)
+		assert.Equal(t, "bad", gqlResult.Results[1].ID)
+		assert.Equal(t, generated.CommentDeleteStatusInvalidID, gqlResult.Results[1].Status)
+		assert.NotNil(t, gqlResult.Results[1].Message)
+		assert.Equal(t, generated.CommentDeleteStatusDeleted, gqlResult.Results[2].Status)
+		assert.Nil(t, gqlResult.Results[2].Message)
+	})
+
+	t.Run("all deleted", func(t *testing.T) {
+		result := &model.CommentBatchDeleteResult{
+			Items:      []*model.CommentDeleteItem{{ID: id1, Status: model.CommentDeleteStatusDeleted}},
+			DeletedIDs: []uuid.UUID{id1},
+		}
+
+		gqlResult := CommentBatchDeleteResultToGraphQL([]string{id1.String()}, result, nil)
+		assert.True(t, gqlResult.Success)
+		assert.Equal(t, 1, gqlResult.DeletedCount)
+	})
+
+	t.Run("operation error", func(t *testing.T) {
+		gqlResult := CommentBatchDeleteResultToGraphQL([]string{id1.String()}, nil, model.NewUnauthorizedError())
+		assert.False(t, gqlResult.Success)
+		assert.Empty(t, gqlResult.Results)
+		assert.Empty(t, gqlResult.DeletedIDs)
+		require.NotNil(t, gqlResult.Error)
+	})
+}
+
 func TestCommentEventToGraphQL(t *testing.T) {
 	comment := &model.Comment{
 		ID:       uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
@@ -500,6 +556,17 @@ func TestCommentEventToGraphQL(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name:      "tombstoned event",
+			eventType: "TOMBSTONED",
+			comment:   comment,
+			expected: &generated.CommentEvent{
+				Type:    generated.CommentEventTypeTombstoned,
+				Comment: CommentToGraphQL(comment),
+				PostID:  comment.PostID.String(),
+			},
+			expectError: false,
+		},
 		{
 			name:        "unknown event type",
 			eventType:   "UNKNOWN",
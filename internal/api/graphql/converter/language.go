@@ -0,0 +1,39 @@
+package converter
+
+import (
+	"github.com/NarthurN/habbr/internal/api/graphql/generated"
+	"github.com/NarthurN/habbr/internal/model"
+)
+
+// LanguageFromGraphQL конвертирует GraphQL язык в domain.
+//
+// Для nil возвращается пустой язык: при создании поста язык определяется по тексту,
+// при поиске - ищутся документы на всех языках.
+func LanguageFromGraphQL(language *generated.Language) model.Language {
+	if language == nil {
+		return ""
+	}
+
+	switch *language {
+	case generated.LanguageRussian:
+		return model.LanguageRussian
+	case generated.LanguageEnglish:
+		return model.LanguageEnglish
+	case generated.LanguageSimple:
+		return model.LanguageSimple
+	default:
+		return ""
+	}
+}
+
+// LanguageToGraphQL конвертирует domain язык в GraphQL
+func LanguageToGraphQL(language model.Language) generated.Language {
+	switch language {
+	case model.LanguageRussian:
+		return generated.LanguageRussian
+	case model.LanguageEnglish:
+		return generated.LanguageEnglish
+	default:
+		return generated.LanguageSimple
+	}
+}
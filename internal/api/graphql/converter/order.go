@@ -0,0 +1,72 @@
+package converter
+
+import (
+	"github.com/NarthurN/habbr/internal/api/graphql/generated"
+	"github.com/NarthurN/habbr/internal/model"
+)
+
+// PostOrderFromGraphQL конвертирует GraphQL порядок сортировки постов в domain.
+//
+// Для nil возвращается пустой порядок, сервис подставляет порядок по умолчанию.
+func PostOrderFromGraphQL(order *generated.PostOrder) model.PostOrder {
+	if order == nil {
+		return ""
+	}
+
+	switch *order {
+	case generated.PostOrderNewest:
+		return model.PostOrderNewest
+	case generated.PostOrderOldest:
+		return model.PostOrderOldest
+	case generated.PostOrderRecentlyUpdated:
+		return model.PostOrderRecentlyUpdated
+	case generated.PostOrderMostCommented:
+		return model.PostOrderMostCommented
+	default:
+		return ""
+	}
+}
+
+// CommentOrderFromGraphQL конвертирует GraphQL порядок сортировки комментариев в domain.
+//
+// Для nil возвращается пустой порядок, сервис подставляет порядок по умолчанию.
+func CommentOrderFromGraphQL(order *generated.CommentOrder) model.CommentOrder {
+	if order == nil {
+		return ""
+	}
+
+	switch *order {
+	case generated.CommentOrderOldest:
+		return model.CommentOrderOldest
+	case generated.CommentOrderNewest:
+		return model.CommentOrderNewest
+	case generated.CommentOrderRecentlyUpdated:
+		return model.CommentOrderRecentlyUpdated
+	case generated.CommentOrderMostReplies:
+		return model.CommentOrderMostReplies
+	default:
+		return ""
+	}
+}
+
+// PostAnalyticsOrderFromGraphQL конвертирует GraphQL порядок сортировки аналитики постов в domain.
+//
+// Для nil возвращается пустой порядок, сервис подставляет порядок по умолчанию.
+func PostAnalyticsOrderFromGraphQL(order *generated.PostAnalyticsOrder) model.PostAnalyticsOrder {
+	if order == nil {
+		return ""
+	}
+
+	switch *order {
+	case generated.PostAnalyticsOrderMostCommented:
+		return model.PostAnalyticsOrderMostCommented
+	case generated.PostAnalyticsOrderRecentlyCommented:
+		return model.PostAnalyticsOrderRecentlyCommented
+	case generated.PostAnalyticsOrderDeepest:
+		return model.PostAnalyticsOrderDeepest
+	case generated.PostAnalyticsOrderNewest:
+		return model.PostAnalyticsOrderNewest
+	default:
+		return ""
+	}
+}
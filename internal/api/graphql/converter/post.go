@@ -1,6 +1,8 @@
 package converter
 
 import (
+	"fmt"
+
 	"github.com/NarthurN/habbr/internal/api/graphql/generated"
 	"github.com/NarthurN/habbr/internal/model"
 	"github.com/google/uuid"
@@ -18,6 +20,7 @@ func PostToGraphQL(post *model.Post) *generated.Post {
 		Content:         post.Content,
 		AuthorID:        post.AuthorID.String(),
 		CommentsEnabled: post.CommentsEnabled,
+		Language:        LanguageToGraphQL(post.Language),
 		CreatedAt:       post.CreatedAt,
 		UpdatedAt:       post.UpdatedAt,
 	}
@@ -35,15 +38,23 @@ func PostInputFromGraphQL(input generated.PostInput) (*model.PostInput, error) {
 		Content:         input.Content,
 		AuthorID:        authorID,
 		CommentsEnabled: input.CommentsEnabled,
+		Language:        LanguageFromGraphQL(input.Language),
 	}, nil
 }
 
 // PostUpdateInputFromGraphQL конвертирует GraphQL PostUpdateInput в domain модель
 func PostUpdateInputFromGraphQL(input generated.PostUpdateInput) (*model.PostUpdateInput, error) {
+	var language *model.Language
+	if input.Language != nil {
+		lang := LanguageFromGraphQL(input.Language)
+		language = &lang
+	}
+
 	return &model.PostUpdateInput{
 		Title:           input.Title,
 		Content:         input.Content,
 		CommentsEnabled: input.CommentsEnabled,
+		Language:        language,
 	}, nil
 }
 
@@ -135,6 +146,68 @@ func DeleteResultToGraphQL(deletedID uuid.UUID, err error) *generated.DeleteResu
 	}
 }
 
+// PostEventToGraphQL конвертирует событие жизненного цикла поста в GraphQL
+func PostEventToGraphQL(eventType string, post *model.Post) (*generated.PostEvent, error) {
+	var gqlEventType generated.PostEventType
+
+	switch eventType {
+	case model.PostEventCreated:
+		gqlEventType = generated.PostEventTypeCreated
+	case model.PostEventUpdated:
+		gqlEventType = generated.PostEventTypeUpdated
+	case model.PostEventDeleted:
+		gqlEventType = generated.PostEventTypeDeleted
+	case model.PostEventCommentsToggled:
+		gqlEventType = generated.PostEventTypeCommentsToggled
+	default:
+		return nil, fmt.Errorf("unknown event type: %s", eventType)
+	}
+
+	return &generated.PostEvent{
+		Type:   gqlEventType,
+		Post:   PostToGraphQL(post),
+		PostID: post.ID.String(),
+	}, nil
+}
+
+// PostStatsToGraphQL конвертирует статистику поста в GraphQL
+func PostStatsToGraphQL(stats *model.PostStats) *generated.PostStats {
+	if stats == nil {
+		return nil
+	}
+
+	return &generated.PostStats{
+		TotalComments:   stats.TotalComments,
+		RootComments:    stats.RootComments,
+		Replies:         stats.Replies,
+		CommentsEnabled: stats.CommentsEnabled,
+		LastCommentAt:   stats.LastCommentAt,
+	}
+}
+
+// CommentStatsToGraphQL конвертирует статистику комментариев поста в GraphQL
+func CommentStatsToGraphQL(stats *model.CommentStats) *generated.CommentStats {
+	if stats == nil {
+		return nil
+	}
+
+	histogram := make([]*generated.DepthCount, len(stats.DepthHistogram))
+	for i, level := range stats.DepthHistogram {
+		histogram[i] = &generated.DepthCount{Depth: level.Depth, Count: level.Count}
+	}
+
+	return &generated.CommentStats{
+		TotalComments:    stats.TotalComments,
+		RootComments:     stats.RootComments,
+		Replies:          stats.Replies,
+		MaxDepth:         stats.MaxDepth,
+		AverageDepth:     stats.AverageDepth,
+		UniqueCommenters: stats.UniqueCommenters,
+		LastCommentAt:    stats.LastCommentAt,
+		DepthHistogram:   histogram,
+	}
+}
+
 // PaginationFromGraphQL конвертирует GraphQL пагинацию в domain модель
 func PaginationFromGraphQL(first, last *int, after, before *string) *model.PaginationInput {
 	return &model.PaginationInput{
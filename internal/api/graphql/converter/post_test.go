@@ -29,6 +29,7 @@ func TestPostToGraphQL(t *testing.T) {
 				Content:         "Test Content",
 				AuthorID:        uuid.MustParse("123e4567-e89b-12d3-a456-426614174001"),
 				CommentsEnabled: true,
+				Language:        model.LanguageEnglish,
 				CreatedAt:       time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
 				UpdatedAt:       time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
 			},
@@ -38,6 +39,7 @@ func TestPostToGraphQL(t *testing.T) {
 				Content:         "Test Content",
 				AuthorID:        "123e4567-e89b-12d3-a456-426614174001",
 				CommentsEnabled: true,
+				Language:        generated.LanguageEnglish,
 				CreatedAt:       time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
 				UpdatedAt:       time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
 			},
@@ -107,6 +109,8 @@ func TestPostUpdateInputFromGraphQL(t *testing.T) {
 	title := "Updated Title"
 	content := "Updated Content"
 	commentsEnabled := false
+	graphqlLanguage := generated.LanguageRussian
+	language := model.LanguageRussian
 
 	tests := []struct {
 		name     string
@@ -128,11 +132,13 @@ func TestPostUpdateInputFromGraphQL(t *testing.T) {
 				Title:           &title,
 				Content:         &content,
 				CommentsEnabled: &commentsEnabled,
+				Language:        &graphqlLanguage,
 			},
 			expected: &model.PostUpdateInput{
 				Title:           &title,
 				Content:         &content,
 				CommentsEnabled: &commentsEnabled,
+				Language:        &language,
 			},
 		},
 	}
@@ -0,0 +1,70 @@
+package converter
+
+import (
+	"github.com/NarthurN/habbr/internal/api/graphql/generated"
+	"github.com/NarthurN/habbr/internal/model"
+)
+
+// PostSearchConnectionToGraphQL конвертирует domain результаты поиска постов в GraphQL
+func PostSearchConnectionToGraphQL(conn *model.PostSearchConnection) *generated.PostSearchConnection {
+	if conn == nil {
+		return &generated.PostSearchConnection{
+			Edges:    []*generated.PostSearchEdge{},
+			PageInfo: &generated.PageInfo{},
+		}
+	}
+
+	edges := make([]*generated.PostSearchEdge, len(conn.Edges))
+	for i, edge := range conn.Edges {
+		edges[i] = &generated.PostSearchEdge{
+			Node:    PostToGraphQL(edge.Node),
+			Cursor:  edge.Cursor,
+			Rank:    edge.Rank,
+			Snippet: edge.Snippet,
+		}
+	}
+
+	return &generated.PostSearchConnection{
+		Edges:    edges,
+		PageInfo: pageInfoToGraphQL(conn.PageInfo),
+	}
+}
+
+// CommentSearchConnectionToGraphQL конвертирует domain результаты поиска комментариев в GraphQL
+func CommentSearchConnectionToGraphQL(conn *model.CommentSearchConnection) *generated.CommentSearchConnection {
+	if conn == nil {
+		return &generated.CommentSearchConnection{
+			Edges:    []*generated.CommentSearchEdge{},
+			PageInfo: &generated.PageInfo{},
+		}
+	}
+
+	edges := make([]*generated.CommentSearchEdge, len(conn.Edges))
+	for i, edge := range conn.Edges {
+		edges[i] = &generated.CommentSearchEdge{
+			Node:    CommentToGraphQL(edge.Node),
+			Cursor:  edge.Cursor,
+			Rank:    edge.Rank,
+			Snippet: edge.Snippet,
+		}
+	}
+
+	return &generated.CommentSearchConnection{
+		Edges:    edges,
+		PageInfo: pageInfoToGraphQL(conn.PageInfo),
+	}
+}
+
+// pageInfoToGraphQL конвертирует domain PageInfo в GraphQL
+func pageInfoToGraphQL(pageInfo *model.PageInfo) *generated.PageInfo {
+	if pageInfo == nil {
+		return &generated.PageInfo{}
+	}
+
+	return &generated.PageInfo{
+		HasNextPage:     pageInfo.HasNextPage,
+		HasPreviousPage: pageInfo.HasPreviousPage,
+		StartCursor:     pageInfo.StartCursor,
+		EndCursor:       pageInfo.EndCursor,
+	}
+}
@@ -0,0 +1,28 @@
+package converter
+
+import (
+	"github.com/NarthurN/habbr/internal/api/graphql/generated"
+	"github.com/NarthurN/habbr/internal/model"
+)
+
+// SlowConsumerPolicyFromGraphQL конвертирует GraphQL политику медленного подписчика в domain.
+//
+// Для nil возвращается пустая политика: сервис подписок использует политику из конфигурации.
+func SlowConsumerPolicyFromGraphQL(policy *generated.SlowConsumerPolicy) model.SlowConsumerPolicy {
+	if policy == nil {
+		return ""
+	}
+
+	switch *policy {
+	case generated.SlowConsumerPolicyDropNewest:
+		return model.SlowConsumerDropNewest
+	case generated.SlowConsumerPolicyDropOldest:
+		return model.SlowConsumerDropOldest
+	case generated.SlowConsumerPolicyBlock:
+		return model.SlowConsumerBlock
+	case generated.SlowConsumerPolicyDisconnect:
+		return model.SlowConsumerDisconnect
+	default:
+		return ""
+	}
+}
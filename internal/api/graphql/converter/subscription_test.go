@@ -0,0 +1,32 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/NarthurN/habbr/internal/api/graphql/generated"
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlowConsumerPolicyFromGraphQL(t *testing.T) {
+	policy := func(p generated.SlowConsumerPolicy) *generated.SlowConsumerPolicy { return &p }
+
+	tests := []struct {
+		name     string
+		input    *generated.SlowConsumerPolicy
+		expected model.SlowConsumerPolicy
+	}{
+		{name: "not set", input: nil, expected: ""},
+		{name: "drop newest", input: policy(generated.SlowConsumerPolicyDropNewest), expected: model.SlowConsumerDropNewest},
+		{name: "drop oldest", input: policy(generated.SlowConsumerPolicyDropOldest), expected: model.SlowConsumerDropOldest},
+		{name: "block", input: policy(generated.SlowConsumerPolicyBlock), expected: model.SlowConsumerBlock},
+		{name: "disconnect", input: policy(generated.SlowConsumerPolicyDisconnect), expected: model.SlowConsumerDisconnect},
+		{name: "unknown", input: policy("UNKNOWN"), expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SlowConsumerPolicyFromGraphQL(tt.input))
+		})
+	}
+}
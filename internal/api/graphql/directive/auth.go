@@ -0,0 +1,95 @@
+package directive
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.uber.org/zap"
+
+	"github.com/NarthurN/habbr/internal/auth"
+	"github.com/NarthurN/habbr/internal/model"
+)
+
+// Коды ошибок, возвращаемые директивами в extensions.code
+const (
+	CodeUnauthenticated = "UNAUTHENTICATED"
+	CodeForbidden       = "FORBIDDEN"
+)
+
+// AuthFunc описывает сигнатуру реализации директивы @auth в generated.DirectiveRoot
+type AuthFunc func(ctx context.Context, obj interface{}, next graphql.Resolver, role *string) (interface{}, error)
+
+// Auth создает реализацию директивы @auth(role: String).
+//
+// Директива проверяет Principal из контекста запроса перед вызовом резолвера поля:
+//   - Запрос анонимный: ошибка с кодом UNAUTHENTICATED
+//   - Аргумент role не задан: достаточно любой аутентификации
+//   - Роль пользователя ниже требуемой: ошибка с кодом FORBIDDEN
+//
+// Ошибки возвращаются как типизированные GraphQL ошибки с путем поля и
+// extensions, чтобы клиент мог отличить отказ в доступе от ошибок бизнес-логики.
+//
+// Параметры:
+//   - logger: логгер для записи отказов в доступе
+//
+// Пример использования:
+//
+//	generated.NewExecutableSchema(generated.Config{
+//	    Resolvers: resolverImpl,
+//	    Directives: generated.DirectiveRoot{
+//	        Auth: directive.Auth(logger),
+//	    },
+//	})
+//
+// В схеме:
+//
+//	allCommentEvents: CommentEvent! @auth(role: "admin")
+func Auth(logger *zap.Logger) AuthFunc {
+	return func(ctx context.Context, obj interface{}, next graphql.Resolver, role *string) (interface{}, error) {
+		principal, ok := auth.PrincipalFromContext(ctx)
+		if !ok {
+			return nil, newError(ctx, CodeUnauthenticated, "authentication required", nil)
+		}
+
+		if role == nil {
+			return next(ctx)
+		}
+
+		required, err := model.ParseRole(*role)
+		if err != nil {
+			// Ошибка в схеме: поле закрывается полностью, а не открывается
+			logger.Error("Unknown role in @auth directive", zap.String("role", *role))
+			return nil, fmt.Errorf("field access is misconfigured")
+		}
+
+		if !principal.HasRole(required) {
+			logger.Warn("Access denied by @auth directive",
+				zap.String("user_id", principal.UserID.String()),
+				zap.String("role", principal.Role.String()),
+				zap.String("required_role", required.String()),
+			)
+			return nil, newError(ctx, CodeForbidden,
+				fmt.Sprintf("role %q is required", required),
+				map[string]interface{}{"requiredRole": required.String()},
+			)
+		}
+
+		return next(ctx)
+	}
+}
+
+// newError создает GraphQL ошибку с кодом в extensions и путем текущего поля
+func newError(ctx context.Context, code, message string, extensions map[string]interface{}) *gqlerror.Error {
+	if extensions == nil {
+		extensions = make(map[string]interface{})
+	}
+	extensions["code"] = code
+
+	return &gqlerror.Error{
+		Message:    message,
+		Path:       graphql.GetPath(ctx),
+		Extensions: extensions,
+	}
+}
@@ -0,0 +1,90 @@
+package directive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.uber.org/zap"
+
+	"github.com/NarthurN/habbr/internal/auth"
+	"github.com/NarthurN/habbr/internal/model"
+)
+
+func TestAuthDirective(t *testing.T) {
+	admin := "admin"
+	unknown := "superuser"
+
+	tests := []struct {
+		name      string
+		principal *model.Principal
+		role      *string
+		wantCode  string
+		wantErr   bool
+	}{
+		{
+			name:     "anonymous request",
+			role:     &admin,
+			wantCode: CodeUnauthenticated,
+			wantErr:  true,
+		},
+		{
+			name:      "authenticated without role requirement",
+			principal: &model.Principal{UserID: uuid.New(), Role: model.RoleReader},
+		},
+		{
+			name:      "insufficient role",
+			principal: &model.Principal{UserID: uuid.New(), Role: model.RoleModerator},
+			role:      &admin,
+			wantCode:  CodeForbidden,
+			wantErr:   true,
+		},
+		{
+			name:      "sufficient role",
+			principal: &model.Principal{UserID: uuid.New(), Role: model.RoleAdmin},
+			role:      &admin,
+		},
+		{
+			name:      "unknown role in schema",
+			principal: &model.Principal{UserID: uuid.New(), Role: model.RoleAdmin},
+			role:      &unknown,
+			wantErr:   true,
+		},
+	}
+
+	directive := Auth(zap.NewNop())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = auth.WithPrincipal(ctx, tt.principal)
+			}
+
+			called := false
+			next := func(ctx context.Context) (interface{}, error) {
+				called = true
+				return "ok", nil
+			}
+
+			res, err := directive(ctx, nil, next, tt.role)
+			if !tt.wantErr {
+				require.NoError(t, err)
+				assert.True(t, called)
+				assert.Equal(t, "ok", res)
+				return
+			}
+
+			require.Error(t, err)
+			assert.False(t, called)
+			if tt.wantCode != "" {
+				var gqlErr *gqlerror.Error
+				require.ErrorAs(t, err, &gqlErr)
+				assert.Equal(t, tt.wantCode, gqlErr.Extensions["code"])
+			}
+		})
+	}
+}
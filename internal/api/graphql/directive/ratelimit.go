@@ -0,0 +1,92 @@
+package directive
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"go.uber.org/zap"
+
+	"github.com/NarthurN/habbr/internal/ratelimit"
+)
+
+// CodeRateLimited - код ошибки превышения лимита запросов
+const CodeRateLimited = "RATE_LIMITED"
+
+// RateLimitFunc описывает сигнатуру реализации директивы @rateLimit в generated.DirectiveRoot
+type RateLimitFunc func(ctx context.Context, obj interface{}, next graphql.Resolver, max int, window string) (interface{}, error)
+
+// RateLimit создает реализацию директивы @rateLimit(max: Int!, window: String!).
+//
+// Директива ограничивает частоту вызова поля для каждого клиента: не более max
+// вызовов за window (строка в формате time.ParseDuration, например "1m" или "30s").
+// Лимит считается отдельно для каждого поля схемы и каждого клиента
+// (пользователя или IP адреса для анонимных запросов).
+//
+// При превышении лимита возвращается GraphQL ошибка с extensions:
+//   - code: "RATE_LIMITED"
+//   - retryAfter: через сколько секунд можно повторить запрос
+//   - limit, window: параметры нарушенного ограничения
+//
+// Если limiter равен nil, директива ничего не ограничивает.
+//
+// Пример использования:
+//
+//	Directives: generated.DirectiveRoot{
+//	    RateLimit: directive.RateLimit(limiter, logger),
+//	}
+//
+// В схеме:
+//
+//	createComment(input: CommentInput!): CommentResult! @rateLimit(max: 30, window: "1m")
+func RateLimit(limiter *ratelimit.Limiter, logger *zap.Logger) RateLimitFunc {
+	return func(ctx context.Context, obj interface{}, next graphql.Resolver, max int, window string) (interface{}, error) {
+		if limiter == nil {
+			return next(ctx)
+		}
+
+		windowDuration, err := time.ParseDuration(window)
+		if err != nil || windowDuration <= 0 || max <= 0 {
+			// Ошибка в схеме: поле закрывается полностью, а не остается без лимита
+			logger.Error("Invalid @rateLimit directive arguments",
+				zap.Int("max", max),
+				zap.String("window", window),
+			)
+			return nil, fmt.Errorf("field rate limit is misconfigured")
+		}
+
+		operation := fieldName(ctx)
+		result, err := limiter.Allow(ctx, operation, ratelimit.Limit{Max: max, Window: windowDuration})
+		if err == nil && !result.Allowed {
+			retryAfter := int(math.Ceil(result.RetryAfter.Seconds()))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+
+			logger.Debug("Request rejected by rate limit",
+				zap.String("operation", operation),
+				zap.String("client", ratelimit.ClientKey(ctx)),
+				zap.Int("retry_after", retryAfter),
+			)
+
+			return nil, newError(ctx, CodeRateLimited, "rate limit exceeded", map[string]interface{}{
+				"retryAfter": retryAfter,
+				"limit":      max,
+				"window":     window,
+			})
+		}
+
+		return next(ctx)
+	}
+}
+
+// fieldName возвращает полное имя поля вида "Mutation.createComment"
+func fieldName(ctx context.Context) string {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil {
+		return "unknown"
+	}
+	return fc.Object + "." + fc.Field.Name
+}
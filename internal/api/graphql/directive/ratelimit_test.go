@@ -0,0 +1,60 @@
+package directive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.uber.org/zap"
+
+	"github.com/NarthurN/habbr/internal/auth"
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/NarthurN/habbr/internal/ratelimit"
+)
+
+func TestRateLimitDirective(t *testing.T) {
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(0), zap.NewNop())
+	directive := RateLimit(limiter, zap.NewNop())
+
+	fieldCtx := func(ctx context.Context) context.Context {
+		return graphql.WithFieldContext(ctx, &graphql.FieldContext{
+			Object: "Mutation",
+			Field:  graphql.CollectedField{Field: &ast.Field{Name: "createComment"}},
+		})
+	}
+	next := func(ctx context.Context) (interface{}, error) { return "ok", nil }
+
+	userCtx := fieldCtx(auth.WithPrincipal(context.Background(), &model.Principal{UserID: uuid.New()}))
+	otherCtx := fieldCtx(ratelimit.WithClientIP(context.Background(), "10.0.0.1"))
+
+	for i := 0; i < 2; i++ {
+		res, err := directive(userCtx, nil, next, 2, "1m")
+		require.NoError(t, err)
+		assert.Equal(t, "ok", res)
+	}
+
+	_, err := directive(userCtx, nil, next, 2, "1m")
+	var gqlErr *gqlerror.Error
+	require.ErrorAs(t, err, &gqlErr)
+	assert.Equal(t, CodeRateLimited, gqlErr.Extensions["code"])
+	assert.Equal(t, 30, gqlErr.Extensions["retryAfter"])
+
+	// Лимит другого клиента не затронут
+	_, err = directive(otherCtx, nil, next, 2, "1m")
+	assert.NoError(t, err)
+
+	// Некорректное окно в схеме закрывает поле
+	_, err = directive(otherCtx, nil, next, 2, "minute")
+	assert.Error(t, err)
+
+	stats := limiter.Stats(context.Background())
+	assert.Equal(t, int64(3), stats.Allowed)
+	assert.Equal(t, int64(1), stats.Rejected)
+	assert.Equal(t, int64(1), stats.RejectedBy["Mutation.createComment"])
+	assert.Equal(t, 2, stats.TrackedKeys)
+}
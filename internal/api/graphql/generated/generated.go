@@ -39,7 +39,9 @@ type Config struct {
 }
 
 type ResolverRoot interface {
+	Comment() CommentResolver
 	Mutation() MutationResolver
+	Post() PostResolver
 	Query() QueryResolver
 	Subscription() SubscriptionResolver
 }
@@ -62,19 +64,44 @@ type ComplexityRoot struct {
 		Children  func(childComplexity int, first *int, after *string, last *int, before *string) int
 		Content   func(childComplexity int) int
 		CreatedAt func(childComplexity int) int
+		DeletedAt func(childComplexity int) int
 		Depth     func(childComplexity int) int
 		ID        func(childComplexity int) int
+		IsDeleted func(childComplexity int) int
+		Language  func(childComplexity int) int
 		ParentID  func(childComplexity int) int
 		PostID    func(childComplexity int) int
 		UpdatedAt func(childComplexity int) int
 	}
 
+	CommentBatchDeleteResult struct {
+		DeletedCount func(childComplexity int) int
+		DeletedIDs   func(childComplexity int) int
+		Error        func(childComplexity int) int
+		Results      func(childComplexity int) int
+		Success      func(childComplexity int) int
+	}
+
 	CommentConnection struct {
 		Edges      func(childComplexity int) int
 		PageInfo   func(childComplexity int) int
 		TotalCount func(childComplexity int) int
 	}
 
+	CommentContext struct {
+		Ancestors        func(childComplexity int) int
+		Comment          func(childComplexity int) int
+		HasMoreAncestors func(childComplexity int) int
+		Replies          func(childComplexity int) int
+		TotalReplies     func(childComplexity int) int
+	}
+
+	CommentDeleteItemResult struct {
+		ID      func(childComplexity int) int
+		Message func(childComplexity int) int
+		Status  func(childComplexity int) int
+	}
+
 	CommentEdge struct {
 		Cursor func(childComplexity int) int
 		Node   func(childComplexity int) int
@@ -82,6 +109,7 @@ type ComplexityRoot struct {
 
 	CommentEvent struct {
 		Comment func(childComplexity int) int
+		EventID func(childComplexity int) int
 		PostID  func(childComplexity int) int
 		Type    func(childComplexity int) int
 	}
@@ -92,23 +120,60 @@ type ComplexityRoot struct {
 		Success func(childComplexity int) int
 	}
 
+	CommentSearchConnection struct {
+		Edges    func(childComplexity int) int
+		PageInfo func(childComplexity int) int
+	}
+
+	CommentSearchEdge struct {
+		Cursor  func(childComplexity int) int
+		Node    func(childComplexity int) int
+		Rank    func(childComplexity int) int
+		Snippet func(childComplexity int) int
+	}
+
 	CommentStats struct {
-		AverageDepth  func(childComplexity int) int
-		MaxDepth      func(childComplexity int) int
-		TotalComments func(childComplexity int) int
+		AverageDepth     func(childComplexity int) int
+		DepthHistogram   func(childComplexity int) int
+		LastCommentAt    func(childComplexity int) int
+		MaxDepth         func(childComplexity int) int
+		Replies          func(childComplexity int) int
+		RootComments     func(childComplexity int) int
+		TotalComments    func(childComplexity int) int
+		UniqueCommenters func(childComplexity int) int
+	}
+
+	CommentTree struct {
+		Nodes    func(childComplexity int) int
+		PageInfo func(childComplexity int) int
+	}
+
+	CommentTreeNode struct {
+		Comment           func(childComplexity int) int
+		ContinueThread    func(childComplexity int) int
+		Cursor            func(childComplexity int) int
+		LoadedReplyCount  func(childComplexity int) int
+		MoreRepliesCursor func(childComplexity int) int
+		ReplyCount        func(childComplexity int) int
 	}
 
 	DeleteResult struct {
 		DeletedID func(childComplexity int) int
 		Error     func(childComplexity int) int
 		Success   func(childComplexity int) int
+		Tombstone func(childComplexity int) int
+	}
+
+	DepthCount struct {
+		Count func(childComplexity int) int
+		Depth func(childComplexity int) int
 	}
 
 	Mutation struct {
 		CreateComment       func(childComplexity int, input CommentInput) int
 		CreatePost          func(childComplexity int, input PostInput) int
-		DeleteComment       func(childComplexity int, id string) int
-		DeleteCommentsBatch func(childComplexity int, postID string, commentIDs []string) int
+		DeleteComment       func(childComplexity int, id string, soft *bool) int
+		DeleteCommentsBatch func(childComplexity int, postID string, commentIDs []string, atomic *bool) int
 		DeleteCommentsTree  func(childComplexity int, commentID string) int
 		DeletePost          func(childComplexity int, id string) int
 		DisableComments     func(childComplexity int, postID string) int
@@ -131,10 +196,22 @@ type ComplexityRoot struct {
 		Content         func(childComplexity int) int
 		CreatedAt       func(childComplexity int) int
 		ID              func(childComplexity int) int
+		Language        func(childComplexity int) int
 		Title           func(childComplexity int) int
 		UpdatedAt       func(childComplexity int) int
 	}
 
+	PostAnalytics struct {
+		AuthorID        func(childComplexity int) int
+		CreatedAt       func(childComplexity int) int
+		LastCommentAt   func(childComplexity int) int
+		MaxCommentDepth func(childComplexity int) int
+		PostID          func(childComplexity int) int
+		RootComments    func(childComplexity int) int
+		Title           func(childComplexity int) int
+		TotalComments   func(childComplexity int) int
+	}
+
 	PostConnection struct {
 		Edges      func(childComplexity int) int
 		PageInfo   func(childComplexity int) int
@@ -146,39 +223,64 @@ type ComplexityRoot struct {
 		Node   func(childComplexity int) int
 	}
 
+	PostEvent struct {
+		Post   func(childComplexity int) int
+		PostID func(childComplexity int) int
+		Type   func(childComplexity int) int
+	}
+
 	PostResult struct {
 		Error   func(childComplexity int) int
 		Post    func(childComplexity int) int
 		Success func(childComplexity int) int
 	}
 
+	PostSearchConnection struct {
+		Edges    func(childComplexity int) int
+		PageInfo func(childComplexity int) int
+	}
+
+	PostSearchEdge struct {
+		Cursor  func(childComplexity int) int
+		Node    func(childComplexity int) int
+		Rank    func(childComplexity int) int
+		Snippet func(childComplexity int) int
+	}
+
 	PostStats struct {
 		CommentsEnabled func(childComplexity int) int
 		LastCommentAt   func(childComplexity int) int
+		Replies         func(childComplexity int) int
+		RootComments    func(childComplexity int) int
 		TotalComments   func(childComplexity int) int
 	}
 
 	Query struct {
 		Comment        func(childComplexity int, id string) int
+		CommentContext func(childComplexity int, id string, ancestors *int, replies *int) int
 		CommentStats   func(childComplexity int, postID string) int
-		CommentTree    func(childComplexity int, postID string, maxDepth *int, filter *CommentFilter) int
-		Comments       func(childComplexity int, postID string, first *int, after *string, last *int, before *string, filter *CommentFilter) int
+		CommentTree    func(childComplexity int, postID string, parentID *string, maxDepth *int, filter *CommentFilter, orderBy *CommentOrder, first *int, after *string, repliesFirst *int) int
+		Comments       func(childComplexity int, postID string, first *int, after *string, last *int, before *string, filter *CommentFilter, orderBy *CommentOrder) int
 		Post           func(childComplexity int, id string) int
+		PostAnalytics  func(childComplexity int, filter *PostAnalyticsFilter, orderBy *PostAnalyticsOrder, first *int) int
 		PostStats      func(childComplexity int, id string) int
-		Posts          func(childComplexity int, first *int, after *string, last *int, before *string, filter *PostFilter) int
-		SearchComments func(childComplexity int, postID string, query string, first *int, after *string) int
-		SearchPosts    func(childComplexity int, query string, first *int, after *string) int
+		Posts          func(childComplexity int, first *int, after *string, last *int, before *string, filter *PostFilter, orderBy *PostOrder) int
+		SearchComments func(childComplexity int, postID string, query string, language *Language, first *int, after *string) int
+		SearchPosts    func(childComplexity int, query string, language *Language, first *int, after *string) int
 	}
 
 	Subscription struct {
-		AllCommentEvents func(childComplexity int) int
-		CommentEvents    func(childComplexity int, postID string) int
-		NewPosts         func(childComplexity int) int
-		PostStatsUpdates func(childComplexity int, postID string) int
-		PostUpdates      func(childComplexity int, postID string) int
+		AllCommentEvents func(childComplexity int, slowConsumer *SlowConsumerPolicy) int
+		CommentEvents    func(childComplexity int, postID string, afterEventID *string, slowConsumer *SlowConsumerPolicy) int
+		NewPosts         func(childComplexity int, slowConsumer *SlowConsumerPolicy) int
+		PostStatsUpdates func(childComplexity int, postID string, slowConsumer *SlowConsumerPolicy) int
+		PostUpdates      func(childComplexity int, postID string, slowConsumer *SlowConsumerPolicy) int
 	}
 }
 
+type CommentResolver interface {
+	Children(ctx context.Context, obj *Comment, first *int, after *string, last *int, before *string) (*CommentConnection, error)
+}
 type MutationResolver interface {
 	CreatePost(ctx context.Context, input PostInput) (*PostResult, error)
 	UpdatePost(ctx context.Context, id string, input PostUpdateInput) (*PostResult, error)
@@ -187,27 +289,32 @@ type MutationResolver interface {
 	DisableComments(ctx context.Context, postID string) (*PostResult, error)
 	CreateComment(ctx context.Context, input CommentInput) (*CommentResult, error)
 	UpdateComment(ctx context.Context, id string, input CommentUpdateInput) (*CommentResult, error)
-	DeleteComment(ctx context.Context, id string) (*DeleteResult, error)
-	DeleteCommentsBatch(ctx context.Context, postID string, commentIDs []string) (*BatchDeleteResult, error)
+	DeleteComment(ctx context.Context, id string, soft *bool) (*DeleteResult, error)
+	DeleteCommentsBatch(ctx context.Context, postID string, commentIDs []string, atomic *bool) (*CommentBatchDeleteResult, error)
 	DeleteCommentsTree(ctx context.Context, commentID string) (*BatchDeleteResult, error)
 }
+type PostResolver interface {
+	Comments(ctx context.Context, obj *Post, first *int, after *string, last *int, before *string, filter *CommentFilter) (*CommentConnection, error)
+}
 type QueryResolver interface {
-	Posts(ctx context.Context, first *int, after *string, last *int, before *string, filter *PostFilter) (*PostConnection, error)
+	Posts(ctx context.Context, first *int, after *string, last *int, before *string, filter *PostFilter, orderBy *PostOrder) (*PostConnection, error)
 	Post(ctx context.Context, id string) (*Post, error)
-	Comments(ctx context.Context, postID string, first *int, after *string, last *int, before *string, filter *CommentFilter) (*CommentConnection, error)
+	Comments(ctx context.Context, postID string, first *int, after *string, last *int, before *string, filter *CommentFilter, orderBy *CommentOrder) (*CommentConnection, error)
 	Comment(ctx context.Context, id string) (*Comment, error)
-	CommentTree(ctx context.Context, postID string, maxDepth *int, filter *CommentFilter) ([]*Comment, error)
+	CommentContext(ctx context.Context, id string, ancestors *int, replies *int) (*CommentContext, error)
+	CommentTree(ctx context.Context, postID string, parentID *string, maxDepth *int, filter *CommentFilter, orderBy *CommentOrder, first *int, after *string, repliesFirst *int) (*CommentTree, error)
 	PostStats(ctx context.Context, id string) (*PostStats, error)
 	CommentStats(ctx context.Context, postID string) (*CommentStats, error)
-	SearchPosts(ctx context.Context, query string, first *int, after *string) (*PostConnection, error)
-	SearchComments(ctx context.Context, postID string, query string, first *int, after *string) (*CommentConnection, error)
+	PostAnalytics(ctx context.Context, filter *PostAnalyticsFilter, orderBy *PostAnalyticsOrder, first *int) ([]*PostAnalytics, error)
+	SearchPosts(ctx context.Context, query string, language *Language, first *int, after *string) (*PostSearchConnection, error)
+	SearchComments(ctx context.Context, postID string, query string, language *Language, first *int, after *string) (*CommentSearchConnection, error)
 }
 type SubscriptionResolver interface {
-	CommentEvents(ctx context.Context, postID string) (<-chan *CommentEvent, error)
-	AllCommentEvents(ctx context.Context) (<-chan *CommentEvent, error)
-	NewPosts(ctx context.Context) (<-chan *Post, error)
-	PostUpdates(ctx context.Context, postID string) (<-chan *Post, error)
-	PostStatsUpdates(ctx context.Context, postID string) (<-chan *PostStats, error)
+	CommentEvents(ctx context.Context, postID string, afterEventID *string, slowConsumer *SlowConsumerPolicy) (<-chan *CommentEvent, error)
+	AllCommentEvents(ctx context.Context, slowConsumer *SlowConsumerPolicy) (<-chan *CommentEvent, error)
+	NewPosts(ctx context.Context, slowConsumer *SlowConsumerPolicy) (<-chan *Post, error)
+	PostUpdates(ctx context.Context, postID string, slowConsumer *SlowConsumerPolicy) (<-chan *PostEvent, error)
+	PostStatsUpdates(ctx context.Context, postID string, slowConsumer *SlowConsumerPolicy) (<-chan *PostStats, error)
 }
 
 type executableSchema struct {
@@ -290,6 +397,13 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.Comment.CreatedAt(childComplexity), true
 
+	case "Comment.deletedAt":
+		if e.complexity.Comment.DeletedAt == nil {
+			break
+		}
+
+		return e.complexity.Comment.DeletedAt(childComplexity), true
+
 	case "Comment.depth":
 		if e.complexity.Comment.Depth == nil {
 			break
@@ -304,6 +418,20 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.Comment.ID(childComplexity), true
 
+	case "Comment.isDeleted":
+		if e.complexity.Comment.IsDeleted == nil {
+			break
+		}
+
+		return e.complexity.Comment.IsDeleted(childComplexity), true
+
+	case "Comment.language":
+		if e.complexity.Comment.Language == nil {
+			break
+		}
+
+		return e.complexity.Comment.Language(childComplexity), true
+
 	case "Comment.parentID":
 		if e.complexity.Comment.ParentID == nil {
 			break
@@ -325,6 +453,41 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.Comment.UpdatedAt(childComplexity), true
 
+	case "CommentBatchDeleteResult.deletedCount":
+		if e.complexity.CommentBatchDeleteResult.DeletedCount == nil {
+			break
+		}
+
+		return e.complexity.CommentBatchDeleteResult.DeletedCount(childComplexity), true
+
+	case "CommentBatchDeleteResult.deletedIDs":
+		if e.complexity.CommentBatchDeleteResult.DeletedIDs == nil {
+			break
+		}
+
+		return e.complexity.CommentBatchDeleteResult.DeletedIDs(childComplexity), true
+
+	case "CommentBatchDeleteResult.error":
+		if e.complexity.CommentBatchDeleteResult.Error == nil {
+			break
+		}
+
+		return e.complexity.CommentBatchDeleteResult.Error(childComplexity), true
+
+	case "CommentBatchDeleteResult.results":
+		if e.complexity.CommentBatchDeleteResult.Results == nil {
+			break
+		}
+
+		return e.complexity.CommentBatchDeleteResult.Results(childComplexity), true
+
+	case "CommentBatchDeleteResult.success":
+		if e.complexity.CommentBatchDeleteResult.Success == nil {
+			break
+		}
+
+		return e.complexity.CommentBatchDeleteResult.Success(childComplexity), true
+
 	case "CommentConnection.edges":
 		if e.complexity.CommentConnection.Edges == nil {
 			break
@@ -346,6 +509,62 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.CommentConnection.TotalCount(childComplexity), true
 
+	case "CommentContext.ancestors":
+		if e.complexity.CommentContext.Ancestors == nil {
+			break
+		}
+
+		return e.complexity.CommentContext.Ancestors(childComplexity), true
+
+	case "CommentContext.comment":
+		if e.complexity.CommentContext.Comment == nil {
+			break
+		}
+
+		return e.complexity.CommentContext.Comment(childComplexity), true
+
+	case "CommentContext.hasMoreAncestors":
+		if e.complexity.CommentContext.HasMoreAncestors == nil {
+			break
+		}
+
+		return e.complexity.CommentContext.HasMoreAncestors(childComplexity), true
+
+	case "CommentContext.replies":
+		if e.complexity.CommentContext.Replies == nil {
+			break
+		}
+
+		return e.complexity.CommentContext.Replies(childComplexity), true
+
+	case "CommentContext.totalReplies":
+		if e.complexity.CommentContext.TotalReplies == nil {
+			break
+		}
+
+		return e.complexity.CommentContext.TotalReplies(childComplexity), true
+
+	case "CommentDeleteItemResult.id":
+		if e.complexity.CommentDeleteItemResult.ID == nil {
+			break
+		}
+
+		return e.complexity.CommentDeleteItemResult.ID(childComplexity), true
+
+	case "CommentDeleteItemResult.message":
+		if e.complexity.CommentDeleteItemResult.Message == nil {
+			break
+		}
+
+		return e.complexity.CommentDeleteItemResult.Message(childComplexity), true
+
+	case "CommentDeleteItemResult.status":
+		if e.complexity.CommentDeleteItemResult.Status == nil {
+			break
+		}
+
+		return e.complexity.CommentDeleteItemResult.Status(childComplexity), true
+
 	case "CommentEdge.cursor":
 		if e.complexity.CommentEdge.Cursor == nil {
 			break
@@ -367,6 +586,13 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.CommentEvent.Comment(childComplexity), true
 
+	case "CommentEvent.eventID":
+		if e.complexity.CommentEvent.EventID == nil {
+			break
+		}
+
+		return e.complexity.CommentEvent.EventID(childComplexity), true
+
 	case "CommentEvent.postID":
 		if e.complexity.CommentEvent.PostID == nil {
 			break
@@ -402,6 +628,48 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.CommentResult.Success(childComplexity), true
 
+	case "CommentSearchConnection.edges":
+		if e.complexity.CommentSearchConnection.Edges == nil {
+			break
+		}
+
+		return e.complexity.CommentSearchConnection.Edges(childComplexity), true
+
+	case "CommentSearchConnection.pageInfo":
+		if e.complexity.CommentSearchConnection.PageInfo == nil {
+			break
+		}
+
+		return e.complexity.CommentSearchConnection.PageInfo(childComplexity), true
+
+	case "CommentSearchEdge.cursor":
+		if e.complexity.CommentSearchEdge.Cursor == nil {
+			break
+		}
+
+		return e.complexity.CommentSearchEdge.Cursor(childComplexity), true
+
+	case "CommentSearchEdge.node":
+		if e.complexity.CommentSearchEdge.Node == nil {
+			break
+		}
+
+		return e.complexity.CommentSearchEdge.Node(childComplexity), true
+
+	case "CommentSearchEdge.rank":
+		if e.complexity.CommentSearchEdge.Rank == nil {
+			break
+		}
+
+		return e.complexity.CommentSearchEdge.Rank(childComplexity), true
+
+	case "CommentSearchEdge.snippet":
+		if e.complexity.CommentSearchEdge.Snippet == nil {
+			break
+		}
+
+		return e.complexity.CommentSearchEdge.Snippet(childComplexity), true
+
 	case "CommentStats.averageDepth":
 		if e.complexity.CommentStats.AverageDepth == nil {
 			break
@@ -409,6 +677,20 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.CommentStats.AverageDepth(childComplexity), true
 
+	case "CommentStats.depthHistogram":
+		if e.complexity.CommentStats.DepthHistogram == nil {
+			break
+		}
+
+		return e.complexity.CommentStats.DepthHistogram(childComplexity), true
+
+	case "CommentStats.lastCommentAt":
+		if e.complexity.CommentStats.LastCommentAt == nil {
+			break
+		}
+
+		return e.complexity.CommentStats.LastCommentAt(childComplexity), true
+
 	case "CommentStats.maxDepth":
 		if e.complexity.CommentStats.MaxDepth == nil {
 			break
@@ -416,6 +698,20 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.CommentStats.MaxDepth(childComplexity), true
 
+	case "CommentStats.replies":
+		if e.complexity.CommentStats.Replies == nil {
+			break
+		}
+
+		return e.complexity.CommentStats.Replies(childComplexity), true
+
+	case "CommentStats.rootComments":
+		if e.complexity.CommentStats.RootComments == nil {
+			break
+		}
+
+		return e.complexity.CommentStats.RootComments(childComplexity), true
+
 	case "CommentStats.totalComments":
 		if e.complexity.CommentStats.TotalComments == nil {
 			break
@@ -423,6 +719,69 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.CommentStats.TotalComments(childComplexity), true
 
+	case "CommentStats.uniqueCommenters":
+		if e.complexity.CommentStats.UniqueCommenters == nil {
+			break
+		}
+
+		return e.complexity.CommentStats.UniqueCommenters(childComplexity), true
+
+	case "CommentTree.nodes":
+		if e.complexity.CommentTree.Nodes == nil {
+			break
+		}
+
+		return e.complexity.CommentTree.Nodes(childComplexity), true
+
+	case "CommentTree.pageInfo":
+		if e.complexity.CommentTree.PageInfo == nil {
+			break
+		}
+
+		return e.complexity.CommentTree.PageInfo(childComplexity), true
+
+	case "CommentTreeNode.comment":
+		if e.complexity.CommentTreeNode.Comment == nil {
+			break
+		}
+
+		return e.complexity.CommentTreeNode.Comment(childComplexity), true
+
+	case "CommentTreeNode.continueThread":
+		if e.complexity.CommentTreeNode.ContinueThread == nil {
+			break
+		}
+
+		return e.complexity.CommentTreeNode.ContinueThread(childComplexity), true
+
+	case "CommentTreeNode.cursor":
+		if e.complexity.CommentTreeNode.Cursor == nil {
+			break
+		}
+
+		return e.complexity.CommentTreeNode.Cursor(childComplexity), true
+
+	case "CommentTreeNode.loadedReplyCount":
+		if e.complexity.CommentTreeNode.LoadedReplyCount == nil {
+			break
+		}
+
+		return e.complexity.CommentTreeNode.LoadedReplyCount(childComplexity), true
+
+	case "CommentTreeNode.moreRepliesCursor":
+		if e.complexity.CommentTreeNode.MoreRepliesCursor == nil {
+			break
+		}
+
+		return e.complexity.CommentTreeNode.MoreRepliesCursor(childComplexity), true
+
+	case "CommentTreeNode.replyCount":
+		if e.complexity.CommentTreeNode.ReplyCount == nil {
+			break
+		}
+
+		return e.complexity.CommentTreeNode.ReplyCount(childComplexity), true
+
 	case "DeleteResult.deletedID":
 		if e.complexity.DeleteResult.DeletedID == nil {
 			break
@@ -444,6 +803,27 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.DeleteResult.Success(childComplexity), true
 
+	case "DeleteResult.tombstone":
+		if e.complexity.DeleteResult.Tombstone == nil {
+			break
+		}
+
+		return e.complexity.DeleteResult.Tombstone(childComplexity), true
+
+	case "DepthCount.count":
+		if e.complexity.DepthCount.Count == nil {
+			break
+		}
+
+		return e.complexity.DepthCount.Count(childComplexity), true
+
+	case "DepthCount.depth":
+		if e.complexity.DepthCount.Depth == nil {
+			break
+		}
+
+		return e.complexity.DepthCount.Depth(childComplexity), true
+
 	case "Mutation.createComment":
 		if e.complexity.Mutation.CreateComment == nil {
 			break
@@ -478,7 +858,7 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 			return 0, false
 		}
 
-		return e.complexity.Mutation.DeleteComment(childComplexity, args["id"].(string)), true
+		return e.complexity.Mutation.DeleteComment(childComplexity, args["id"].(string), args["soft"].(*bool)), true
 
 	case "Mutation.deleteCommentsBatch":
 		if e.complexity.Mutation.DeleteCommentsBatch == nil {
@@ -490,7 +870,7 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 			return 0, false
 		}
 
-		return e.complexity.Mutation.DeleteCommentsBatch(childComplexity, args["postID"].(string), args["commentIDs"].([]string)), true
+		return e.complexity.Mutation.DeleteCommentsBatch(childComplexity, args["postID"].(string), args["commentIDs"].([]string), args["atomic"].(*bool)), true
 
 	case "Mutation.deleteCommentsTree":
 		if e.complexity.Mutation.DeleteCommentsTree == nil {
@@ -639,6 +1019,13 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.Post.ID(childComplexity), true
 
+	case "Post.language":
+		if e.complexity.Post.Language == nil {
+			break
+		}
+
+		return e.complexity.Post.Language(childComplexity), true
+
 	case "Post.title":
 		if e.complexity.Post.Title == nil {
 			break
@@ -653,6 +1040,62 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.Post.UpdatedAt(childComplexity), true
 
+	case "PostAnalytics.authorID":
+		if e.complexity.PostAnalytics.AuthorID == nil {
+			break
+		}
+
+		return e.complexity.PostAnalytics.AuthorID(childComplexity), true
+
+	case "PostAnalytics.createdAt":
+		if e.complexity.PostAnalytics.CreatedAt == nil {
+			break
+		}
+
+		return e.complexity.PostAnalytics.CreatedAt(childComplexity), true
+
+	case "PostAnalytics.lastCommentAt":
+		if e.complexity.PostAnalytics.LastCommentAt == nil {
+			break
+		}
+
+		return e.complexity.PostAnalytics.LastCommentAt(childComplexity), true
+
+	case "PostAnalytics.maxCommentDepth":
+		if e.complexity.PostAnalytics.MaxCommentDepth == nil {
+			break
+		}
+
+		return e.complexity.PostAnalytics.MaxCommentDepth(childComplexity), true
+
+	case "PostAnalytics.postID":
+		if e.complexity.PostAnalytics.PostID == nil {
+			break
+		}
+
+		return e.complexity.PostAnalytics.PostID(childComplexity), true
+
+	case "PostAnalytics.rootComments":
+		if e.complexity.PostAnalytics.RootComments == nil {
+			break
+		}
+
+		return e.complexity.PostAnalytics.RootComments(childComplexity), true
+
+	case "PostAnalytics.title":
+		if e.complexity.PostAnalytics.Title == nil {
+			break
+		}
+
+		return e.complexity.PostAnalytics.Title(childComplexity), true
+
+	case "PostAnalytics.totalComments":
+		if e.complexity.PostAnalytics.TotalComments == nil {
+			break
+		}
+
+		return e.complexity.PostAnalytics.TotalComments(childComplexity), true
+
 	case "PostConnection.edges":
 		if e.complexity.PostConnection.Edges == nil {
 			break
@@ -688,6 +1131,27 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.PostEdge.Node(childComplexity), true
 
+	case "PostEvent.post":
+		if e.complexity.PostEvent.Post == nil {
+			break
+		}
+
+		return e.complexity.PostEvent.Post(childComplexity), true
+
+	case "PostEvent.postID":
+		if e.complexity.PostEvent.PostID == nil {
+			break
+		}
+
+		return e.complexity.PostEvent.PostID(childComplexity), true
+
+	case "PostEvent.type":
+		if e.complexity.PostEvent.Type == nil {
+			break
+		}
+
+		return e.complexity.PostEvent.Type(childComplexity), true
+
 	case "PostResult.error":
 		if e.complexity.PostResult.Error == nil {
 			break
@@ -709,40 +1173,108 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.PostResult.Success(childComplexity), true
 
-	case "PostStats.commentsEnabled":
-		if e.complexity.PostStats.CommentsEnabled == nil {
+	case "PostSearchConnection.edges":
+		if e.complexity.PostSearchConnection.Edges == nil {
 			break
 		}
 
-		return e.complexity.PostStats.CommentsEnabled(childComplexity), true
+		return e.complexity.PostSearchConnection.Edges(childComplexity), true
 
-	case "PostStats.lastCommentAt":
-		if e.complexity.PostStats.LastCommentAt == nil {
+	case "PostSearchConnection.pageInfo":
+		if e.complexity.PostSearchConnection.PageInfo == nil {
 			break
 		}
 
-		return e.complexity.PostStats.LastCommentAt(childComplexity), true
+		return e.complexity.PostSearchConnection.PageInfo(childComplexity), true
 
-	case "PostStats.totalComments":
-		if e.complexity.PostStats.TotalComments == nil {
+	case "PostSearchEdge.cursor":
+		if e.complexity.PostSearchEdge.Cursor == nil {
 			break
 		}
 
-		return e.complexity.PostStats.TotalComments(childComplexity), true
+		return e.complexity.PostSearchEdge.Cursor(childComplexity), true
 
-	case "Query.comment":
-		if e.complexity.Query.Comment == nil {
+	case "PostSearchEdge.node":
+		if e.complexity.PostSearchEdge.Node == nil {
 			break
 		}
 
-		args, err := ec.field_Query_comment_args(ctx, rawArgs)
-		if err != nil {
-			return 0, false
+		return e.complexity.PostSearchEdge.Node(childComplexity), true
+
+	case "PostSearchEdge.rank":
+		if e.complexity.PostSearchEdge.Rank == nil {
+			break
 		}
 
-		return e.complexity.Query.Comment(childComplexity, args["id"].(string)), true
+		return e.complexity.PostSearchEdge.Rank(childComplexity), true
 
-	case "Query.commentStats":
+	case "PostSearchEdge.snippet":
+		if e.complexity.PostSearchEdge.Snippet == nil {
+			break
+		}
+
+		return e.complexity.PostSearchEdge.Snippet(childComplexity), true
+
+	case "PostStats.commentsEnabled":
+		if e.complexity.PostStats.CommentsEnabled == nil {
+			break
+		}
+
+		return e.complexity.PostStats.CommentsEnabled(childComplexity), true
+
+	case "PostStats.lastCommentAt":
+		if e.complexity.PostStats.LastCommentAt == nil {
+			break
+		}
+
+		return e.complexity.PostStats.LastCommentAt(childComplexity), true
+
+	case "PostStats.replies":
+		if e.complexity.PostStats.Replies == nil {
+			break
+		}
+
+		return e.complexity.PostStats.Replies(childComplexity), true
+
+	case "PostStats.rootComments":
+		if e.complexity.PostStats.RootComments == nil {
+			break
+		}
+
+		return e.complexity.PostStats.RootComments(childComplexity), true
+
+	case "PostStats.totalComments":
+		if e.complexity.PostStats.TotalComments == nil {
+			break
+		}
+
+		return e.complexity.PostStats.TotalComments(childComplexity), true
+
+	case "Query.comment":
+		if e.complexity.Query.Comment == nil {
+			break
+		}
+
+		args, err := ec.field_Query_comment_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Comment(childComplexity, args["id"].(string)), true
+
+	case "Query.commentContext":
+		if e.complexity.Query.CommentContext == nil {
+			break
+		}
+
+		args, err := ec.field_Query_commentContext_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.CommentContext(childComplexity, args["id"].(string), args["ancestors"].(*int), args["replies"].(*int)), true
+
+	case "Query.commentStats":
 		if e.complexity.Query.CommentStats == nil {
 			break
 		}
@@ -764,7 +1296,7 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 			return 0, false
 		}
 
-		return e.complexity.Query.CommentTree(childComplexity, args["postID"].(string), args["maxDepth"].(*int), args["filter"].(*CommentFilter)), true
+		return e.complexity.Query.CommentTree(childComplexity, args["postID"].(string), args["parentID"].(*string), args["maxDepth"].(*int), args["filter"].(*CommentFilter), args["orderBy"].(*CommentOrder), args["first"].(*int), args["after"].(*string), args["repliesFirst"].(*int)), true
 
 	case "Query.comments":
 		if e.complexity.Query.Comments == nil {
@@ -776,7 +1308,7 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 			return 0, false
 		}
 
-		return e.complexity.Query.Comments(childComplexity, args["postID"].(string), args["first"].(*int), args["after"].(*string), args["last"].(*int), args["before"].(*string), args["filter"].(*CommentFilter)), true
+		return e.complexity.Query.Comments(childComplexity, args["postID"].(string), args["first"].(*int), args["after"].(*string), args["last"].(*int), args["before"].(*string), args["filter"].(*CommentFilter), args["orderBy"].(*CommentOrder)), true
 
 	case "Query.post":
 		if e.complexity.Query.Post == nil {
@@ -790,6 +1322,18 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.Query.Post(childComplexity, args["id"].(string)), true
 
+	case "Query.postAnalytics":
+		if e.complexity.Query.PostAnalytics == nil {
+			break
+		}
+
+		args, err := ec.field_Query_postAnalytics_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.PostAnalytics(childComplexity, args["filter"].(*PostAnalyticsFilter), args["orderBy"].(*PostAnalyticsOrder), args["first"].(*int)), true
+
 	case "Query.postStats":
 		if e.complexity.Query.PostStats == nil {
 			break
@@ -812,7 +1356,7 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 			return 0, false
 		}
 
-		return e.complexity.Query.Posts(childComplexity, args["first"].(*int), args["after"].(*string), args["last"].(*int), args["before"].(*string), args["filter"].(*PostFilter)), true
+		return e.complexity.Query.Posts(childComplexity, args["first"].(*int), args["after"].(*string), args["last"].(*int), args["before"].(*string), args["filter"].(*PostFilter), args["orderBy"].(*PostOrder)), true
 
 	case "Query.searchComments":
 		if e.complexity.Query.SearchComments == nil {
@@ -824,7 +1368,7 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 			return 0, false
 		}
 
-		return e.complexity.Query.SearchComments(childComplexity, args["postID"].(string), args["query"].(string), args["first"].(*int), args["after"].(*string)), true
+		return e.complexity.Query.SearchComments(childComplexity, args["postID"].(string), args["query"].(string), args["language"].(*Language), args["first"].(*int), args["after"].(*string)), true
 
 	case "Query.searchPosts":
 		if e.complexity.Query.SearchPosts == nil {
@@ -836,14 +1380,19 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 			return 0, false
 		}
 
-		return e.complexity.Query.SearchPosts(childComplexity, args["query"].(string), args["first"].(*int), args["after"].(*string)), true
+		return e.complexity.Query.SearchPosts(childComplexity, args["query"].(string), args["language"].(*Language), args["first"].(*int), args["after"].(*string)), true
 
 	case "Subscription.allCommentEvents":
 		if e.complexity.Subscription.AllCommentEvents == nil {
 			break
 		}
 
-		return e.complexity.Subscription.AllCommentEvents(childComplexity), true
+		args, err := ec.field_Subscription_allCommentEvents_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Subscription.AllCommentEvents(childComplexity, args["slowConsumer"].(*SlowConsumerPolicy)), true
 
 	case "Subscription.commentEvents":
 		if e.complexity.Subscription.CommentEvents == nil {
@@ -855,14 +1404,19 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 			return 0, false
 		}
 
-		return e.complexity.Subscription.CommentEvents(childComplexity, args["postID"].(string)), true
+		return e.complexity.Subscription.CommentEvents(childComplexity, args["postID"].(string), args["afterEventID"].(*string), args["slowConsumer"].(*SlowConsumerPolicy)), true
 
 	case "Subscription.newPosts":
 		if e.complexity.Subscription.NewPosts == nil {
 			break
 		}
 
-		return e.complexity.Subscription.NewPosts(childComplexity), true
+		args, err := ec.field_Subscription_newPosts_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Subscription.NewPosts(childComplexity, args["slowConsumer"].(*SlowConsumerPolicy)), true
 
 	case "Subscription.postStatsUpdates":
 		if e.complexity.Subscription.PostStatsUpdates == nil {
@@ -874,7 +1428,7 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 			return 0, false
 		}
 
-		return e.complexity.Subscription.PostStatsUpdates(childComplexity, args["postID"].(string)), true
+		return e.complexity.Subscription.PostStatsUpdates(childComplexity, args["postID"].(string), args["slowConsumer"].(*SlowConsumerPolicy)), true
 
 	case "Subscription.postUpdates":
 		if e.complexity.Subscription.PostUpdates == nil {
@@ -886,7 +1440,7 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 			return 0, false
 		}
 
-		return e.complexity.Subscription.PostUpdates(childComplexity, args["postID"].(string)), true
+		return e.complexity.Subscription.PostUpdates(childComplexity, args["postID"].(string), args["slowConsumer"].(*SlowConsumerPolicy)), true
 
 	}
 	return 0, false
@@ -899,6 +1453,7 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 		ec.unmarshalInputCommentFilter,
 		ec.unmarshalInputCommentInput,
 		ec.unmarshalInputCommentUpdateInput,
+		ec.unmarshalInputPostAnalyticsFilter,
 		ec.unmarshalInputPostFilter,
 		ec.unmarshalInputPostInput,
 		ec.unmarshalInputPostUpdateInput,
@@ -1018,7 +1573,7 @@ func (ec *executionContext) introspectType(name string) (*introspection.Type, er
 var sources = []*ast.Source{
 	{Name: "../schema/mutation.graphql", Input: `type Mutation {
   # Операции с постами
-  createPost(input: PostInput!): PostResult!
+  createPost(input: PostInput!): PostResult! @rateLimit(max: 10, window: "1m")
   updatePost(id: ID!, input: PostUpdateInput!): PostResult!
   deletePost(id: ID!): DeleteResult!
 
@@ -1027,12 +1582,19 @@ var sources = []*ast.Source{
   disableComments(postID: ID!): PostResult!
 
   # Операции с комментариями
-  createComment(input: CommentInput!): CommentResult!
+  createComment(input: CommentInput!): CommentResult! @rateLimit(max: 30, window: "1m")
   updateComment(id: ID!, input: CommentUpdateInput!): CommentResult!
-  deleteComment(id: ID!): DeleteResult!
+  # soft: комментарий с ответами становится надгробием и сохраняет ветку ответов,
+  # комментарий без ответов удаляется. Без soft удаляется вся ветка ответов
+  deleteComment(id: ID!, soft: Boolean = false): DeleteResult!
 
   # Массовые операции
-  deleteCommentsBatch(postID: ID!, commentIDs: [ID!]!): BatchDeleteResult!
+  # Удаляет комментарии поста вместе с ответами; удалять может автор комментария или
+  # модератор. atomic: удалить все или ничего в одной транзакции, иначе удаляются все
+  # комментарии, прошедшие проверку. results содержит итог каждого запрошенного ID
+  deleteCommentsBatch(postID: ID!, commentIDs: [ID!]!, atomic: Boolean = false): CommentBatchDeleteResult!
+  # Удаляет комментарий со всеми вложенными ответами атомарно;
+  # deletedIDs содержит ID всех удаленных комментариев, начиная с корня
   deleteCommentsTree(commentID: ID!): BatchDeleteResult!
 }
 
@@ -1043,6 +1605,42 @@ type BatchDeleteResult {
   deletedIDs: [ID!]!
   errors: [String!]!
 }
+
+# Результат массового удаления комментариев поста
+type CommentBatchDeleteResult {
+  # true, если удалены все запрошенные комментарии
+  success: Boolean!
+  deletedCount: Int!
+  # Все удаленные комментарии, включая ответы
+  deletedIDs: [ID!]!
+  # Итог каждого запрошенного ID в порядке запроса (повторы ID объединяются)
+  results: [CommentDeleteItemResult!]!
+  # Ошибка всей операции: нет аутентификации, пост не найден, некорректный размер пакета
+  error: String
+}
+
+# Итог удаления одного комментария
+type CommentDeleteItemResult {
+  id: ID!
+  status: CommentDeleteStatus!
+  message: String
+}
+
+enum CommentDeleteStatus {
+  # Удален вместе с ответами (в том числе как ответ другого комментария пакета)
+  DELETED
+  # ID не является UUID
+  INVALID_ID
+  NOT_FOUND
+  # Комментарий принадлежит другому посту
+  WRONG_POST
+  # Пользователь не автор комментария и не модератор
+  FORBIDDEN
+  # Системная ошибка при удалении
+  FAILED
+  # Не удален: атомарное удаление отменено из-за другого комментария
+  SKIPPED
+}
 `, BuiltIn: false},
 	{Name: "../schema/query.graphql", Input: `type Query {
   # Посты
@@ -1052,6 +1650,9 @@ type BatchDeleteResult {
     last: Int
     before: String
     filter: PostFilter
+    # Порядок сортировки, по умолчанию NEWEST. Курсоры действительны только для того порядка,
+    # в котором получены
+    orderBy: PostOrder
   ): PostConnection!
 
   post(id: ID!): Post
@@ -1064,47 +1665,129 @@ type BatchDeleteResult {
     last: Int
     before: String
     filter: CommentFilter
+    # Порядок сортировки, по умолчанию OLDEST
+    orderBy: CommentOrder
   ): CommentConnection!
 
   comment(id: ID!): Comment
 
-  # Иерархические комментарии
+  # Комментарий в контексте обсуждения для постоянных ссылок: ближайшие предки и первая
+  # страница прямых ответов без загрузки всего дерева поста
+  commentContext(
+    id: ID!
+    # Количество предков, по умолчанию 5, не больше 50
+    ancestors: Int
+    # Количество прямых ответов, по умолчанию 10, не больше 50
+    replies: Int
+  ): CommentContext!
+
+  # Иерархические комментарии: ветка дерева с ограничением глубины.
+  # Верхний уровень - корневые комментарии поста или ответы на parentID
   commentTree(
     postID: ID!
+    parentID: ID
+    # Количество уровней ответов ниже верхнего уровня, по умолчанию 3, не больше 10
     maxDepth: Int
+    # Фильтр применяется на каждом уровне: ответы на отфильтрованный комментарий не загружаются
     filter: CommentFilter
-  ): [Comment!]!
+    # Порядок комментариев на каждом уровне дерева, по умолчанию OLDEST
+    orderBy: CommentOrder
+    # Страница верхнего уровня, по умолчанию 10 комментариев, не больше 50
+    first: Int
+    after: String
+    # Количество загружаемых ответов на каждый комментарий, по умолчанию 3, не больше 20
+    repliesFirst: Int
+  ): CommentTree!
 
   # Статистика
   postStats(id: ID!): PostStats
   commentStats(postID: ID!): CommentStats
 
-  # Поиск
+  # Аналитика обсуждения постов для редакционной панели. Данные обновляются фоново
+  # (ANALYTICS_REFRESH_INTERVAL) и могут отставать от постов и комментариев.
+  # first - количество постов, по умолчанию 20, не больше 100
+  postAnalytics(
+    filter: PostAnalyticsFilter
+    # Порядок сортировки, по умолчанию MOST_COMMENTED
+    orderBy: PostAnalyticsOrder
+    first: Int
+  ): [PostAnalytics!]!
+
+  # Полнотекстовый поиск. Запрос поддерживает "фразы", OR и -исключения;
+  # результаты упорядочены по убыванию релевантности.
+  # language ограничивает поиск документами одного языка, по умолчанию - все языки
   searchPosts(
     query: String!
+    language: Language
     first: Int
     after: String
-  ): PostConnection!
+  ): PostSearchConnection!
 
   searchComments(
     postID: ID!
     query: String!
+    language: Language
     first: Int
     after: String
-  ): CommentConnection!
+  ): CommentSearchConnection!
 }
 
 # Статистика
+# Надгробия удаленных комментариев учитываются во всех счетчиках, кроме uniqueCommenters
 type PostStats {
   totalComments: Int!
+  rootComments: Int!
+  replies: Int!
   commentsEnabled: Boolean!
   lastCommentAt: Time
 }
 
 type CommentStats {
   totalComments: Int!
+  rootComments: Int!
+  replies: Int!
   maxDepth: Int!
   averageDepth: Float!
+  uniqueCommenters: Int!
+  lastCommentAt: Time
+  # Количество комментариев на каждом непустом уровне по возрастанию глубины
+  depthHistogram: [DepthCount!]!
+}
+
+type DepthCount {
+  depth: Int!
+  count: Int!
+}
+
+type PostAnalytics {
+  postID: ID!
+  title: String!
+  authorID: String!
+  createdAt: Time!
+  totalComments: Int!
+  rootComments: Int!
+  maxCommentDepth: Int!
+  lastCommentAt: Time
+}
+
+input PostAnalyticsFilter {
+  authorID: String
+  # Время создания поста в интервале [createdAfter, createdBefore)
+  createdAfter: Time
+  createdBefore: Time
+  minComments: Int
+}
+
+# Порядок сортировки аналитики постов
+enum PostAnalyticsOrder {
+  # Сначала посты с наибольшим количеством комментариев
+  MOST_COMMENTED
+  # Сначала недавно прокомментированные, посты без комментариев в конце
+  RECENTLY_COMMENTED
+  # Сначала посты с самыми глубокими ветками
+  DEEPEST
+  # Сначала новые посты
+  NEWEST
 }
 `, BuiltIn: false},
 	{Name: "../schema/schema.graphql", Input: `# GraphQL Schema для Habbr API
@@ -1120,21 +1803,27 @@ schema {
   subscription: Subscription
 }
 `, BuiltIn: false},
-	{Name: "../schema/subscription.graphql", Input: `type Subscription {
-  # Подписка на события комментариев для конкретного поста
-  commentEvents(postID: ID!): CommentEvent!
+	{Name: "../schema/subscription.graphql", Input: `# Аргумент slowConsumer выбирает поведение, когда клиент не успевает читать события;
+# без него используется политика из конфигурации сервера (SUBSCRIPTION_SLOW_CONSUMER_POLICY).
+type Subscription {
+  # Подписка на события комментариев для конкретного поста.
+  # С afterEventID сначала приходят пропущенные события с eventID больше указанного.
+  # Если часть из них уже вытеснена из журнала, подписка завершается ошибкой
+  # с extensions.code = "EVENT_CURSOR_EXPIRED".
+  commentEvents(postID: ID!, afterEventID: ID, slowConsumer: SlowConsumerPolicy): CommentEvent!
 
   # Подписка на все события комментариев (для админов)
-  allCommentEvents: CommentEvent!
+  allCommentEvents(slowConsumer: SlowConsumerPolicy): CommentEvent! @auth(role: "admin")
 
   # Подписка на события создания новых постов
-  newPosts: Post!
+  newPosts(slowConsumer: SlowConsumerPolicy): Post!
 
-  # Подписка на изменения конкретного поста
-  postUpdates(postID: ID!): Post!
+  # Подписка на изменения конкретного поста.
+  # Событие DELETED последнее: после него подписка завершается.
+  postUpdates(postID: ID!, slowConsumer: SlowConsumerPolicy): PostEvent!
 
   # Подписка на статистику поста в реальном времени
-  postStatsUpdates(postID: ID!): PostStats!
+  postStatsUpdates(postID: ID!, slowConsumer: SlowConsumerPolicy): PostStats!
 }
 `, BuiltIn: false},
 	{Name: "../schema/types.graphql", Input: `scalar Time
@@ -1146,6 +1835,8 @@ type Post {
   content: String!
   authorID: String!
   commentsEnabled: Boolean!
+  # Язык поста, по которому выполняется полнотекстовый поиск
+  language: Language!
   createdAt: Time!
   updatedAt: Time!
   comments(
@@ -1164,8 +1855,14 @@ type Comment {
   content: String!
   authorID: String!
   depth: Int!
+  # Язык комментария, определяется автоматически по тексту
+  language: Language!
   createdAt: Time!
   updatedAt: Time!
+  # Надгробие мягко удаленного комментария с ответами: содержимое заменено
+  # заглушкой, authorID пуст, ответы сохранены
+  isDeleted: Boolean!
+  deletedAt: Time
   children(
     first: Int
     after: String
@@ -1197,6 +1894,75 @@ type CommentEdge {
   cursor: String!
 }
 
+# Ветка дерева комментариев
+type CommentTree {
+  # Узлы в порядке обхода в глубину: за каждым комментарием следуют его загруженные
+  # ответы, дерево строится по comment.parentID
+  nodes: [CommentTreeNode!]!
+  # Пагинация комментариев верхнего уровня
+  pageInfo: PageInfo!
+}
+
+type CommentTreeNode {
+  comment: Comment!
+  # Позиция комментария среди ответов его родителя
+  cursor: String!
+  # Количество прямых ответов, удовлетворяющих фильтру
+  replyCount: Int!
+  # Количество загруженных в nodes прямых ответов
+  loadedReplyCount: Int!
+  # Курсор для догрузки ответов, если загружены не все:
+  # commentTree(postID, parentID: comment.id, after: moreRepliesCursor)
+  moreRepliesCursor: String
+  # У комментария есть ответы, но они ниже maxDepth и не загружены ("продолжить ветку"):
+  # commentTree(postID, parentID: comment.id)
+  continueThread: Boolean!
+}
+
+# Комментарий в контексте обсуждения
+type CommentContext {
+  comment: Comment!
+  # Ближайшие предки от самого дальнего загруженного к родителю комментария
+  ancestors: [Comment!]!
+  # Выше первого загруженного предка есть еще комментарии
+  hasMoreAncestors: Boolean!
+  # Прямые ответы; следующая страница:
+  # commentTree(postID, parentID: comment.id, after: replies.pageInfo.endCursor)
+  replies: CommentTree!
+  # Количество ответов во всем поддереве комментария, на всех уровнях
+  totalReplies: Int!
+}
+
+# Результаты поиска. Общее число совпадений не возвращается: его подсчет
+# требует ранжирования всех документов; наличие следующей страницы
+# сообщает pageInfo.hasNextPage
+type PostSearchConnection {
+  edges: [PostSearchEdge!]!
+  pageInfo: PageInfo!
+}
+
+type PostSearchEdge {
+  node: Post!
+  cursor: String!
+  # Релевантность результата запросу, больше - релевантнее
+  rank: Float!
+  # Фрагмент содержимого, совпадения обрамлены <mark> и </mark>.
+  # Остальной текст не экранирован и должен экранироваться клиентом
+  snippet: String!
+}
+
+type CommentSearchConnection {
+  edges: [CommentSearchEdge!]!
+  pageInfo: PageInfo!
+}
+
+type CommentSearchEdge {
+  node: Comment!
+  cursor: String!
+  rank: Float!
+  snippet: String!
+}
+
 type PageInfo {
   hasNextPage: Boolean!
   hasPreviousPage: Boolean!
@@ -1210,12 +1976,15 @@ input PostInput {
   content: String!
   authorID: String!
   commentsEnabled: Boolean! = true
+  # Если не указан, язык определяется по заголовку и содержимому
+  language: Language
 }
 
 input PostUpdateInput {
   title: String
   content: String
   commentsEnabled: Boolean
+  language: Language
 }
 
 input CommentInput {
@@ -1261,6 +2030,8 @@ type DeleteResult {
   success: Boolean!
   deletedID: ID
   error: String
+  # Надгробие, если мягко удаленный комментарий имел ответы
+  tombstone: Comment
 }
 
 # События для подписок
@@ -1268,12 +2039,75 @@ type CommentEvent {
   type: CommentEventType!
   comment: Comment!
   postID: ID!
+  # Возрастающий идентификатор события; передается в afterEventID при переподключении.
+  # Отсутствует, если событие не удалось записать в журнал.
+  eventID: ID
+}
+
+# Язык текста: определяет стемминг и стоп-слова полнотекстового поиска
+enum Language {
+  RUSSIAN
+  ENGLISH
+  # Без стемминга, для остальных языков
+  SIMPLE
+}
+
+# Порядок сортировки постов
+enum PostOrder {
+  # Сначала новые
+  NEWEST
+  # Сначала старые
+  OLDEST
+  # Сначала недавно измененные
+  RECENTLY_UPDATED
+  # Сначала посты с наибольшим количеством комментариев
+  MOST_COMMENTED
+}
+
+# Порядок сортировки комментариев
+enum CommentOrder {
+  # Сначала старые
+  OLDEST
+  # Сначала новые
+  NEWEST
+  # Сначала недавно измененные
+  RECENTLY_UPDATED
+  # Сначала комментарии с наибольшим количеством прямых ответов
+  MOST_REPLIES
+}
+
+# Поведение подписки, если клиент не успевает читать события
+enum SlowConsumerPolicy {
+  # Новое событие отбрасывается
+  DROP_NEWEST
+  # Самое старое непрочитанное событие вытесняется новым
+  DROP_OLDEST
+  # Отправка ждет освобождения буфера ограниченное время, затем событие отбрасывается
+  BLOCK
+  # Подписка завершается с ошибкой SLOW_CONSUMER
+  DISCONNECT
 }
 
 enum CommentEventType {
   CREATED
   UPDATED
   DELETED
+  # Комментарий с ответами мягко удален и остался в дереве надгробием
+  TOMBSTONED
+}
+
+# Событие жизненного цикла поста
+type PostEvent {
+  type: PostEventType!
+  post: Post!
+  postID: ID!
+}
+
+enum PostEventType {
+  CREATED
+  UPDATED
+  DELETED
+  COMMENTS_TOGGLED
 }
 `, BuiltIn: false},
 }
@@ -1523,6 +2357,11 @@ func (ec *executionContext) field_Mutation_deleteComment_args(ctx context.Contex
 		return nil, err
 	}
 	args["id"] = arg0
+	arg1, err := ec.field_Mutation_deleteComment_argsSoft(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["soft"] = arg1
 	return args, nil
 }
 func (ec *executionContext) field_Mutation_deleteComment_argsID(
@@ -1543,6 +2382,24 @@ func (ec *executionContext) field_Mutation_deleteComment_argsID(
 	return zeroVal, nil
 }
 
+func (ec *executionContext) field_Mutation_deleteComment_argsSoft(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*bool, error) {
+	if _, ok := rawArgs["soft"]; !ok {
+		var zeroVal *bool
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("soft"))
+	if tmp, ok := rawArgs["soft"]; ok {
+		return ec.unmarshalOBoolean2ᚖbool(ctx, tmp)
+	}
+
+	var zeroVal *bool
+	return zeroVal, nil
+}
+
 func (ec *executionContext) field_Mutation_deleteCommentsBatch_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -1556,6 +2413,11 @@ func (ec *executionContext) field_Mutation_deleteCommentsBatch_args(ctx context.
 		return nil, err
 	}
 	args["commentIDs"] = arg1
+	arg2, err := ec.field_Mutation_deleteCommentsBatch_argsAtomic(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["atomic"] = arg2
 	return args, nil
 }
 func (ec *executionContext) field_Mutation_deleteCommentsBatch_argsPostID(
@@ -1594,6 +2456,24 @@ func (ec *executionContext) field_Mutation_deleteCommentsBatch_argsCommentIDs(
 	return zeroVal, nil
 }
 
+func (ec *executionContext) field_Mutation_deleteCommentsBatch_argsAtomic(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*bool, error) {
+	if _, ok := rawArgs["atomic"]; !ok {
+		var zeroVal *bool
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("atomic"))
+	if tmp, ok := rawArgs["atomic"]; ok {
+		return ec.unmarshalOBoolean2ᚖbool(ctx, tmp)
+	}
+
+	var zeroVal *bool
+	return zeroVal, nil
+}
+
 func (ec *executionContext) field_Mutation_deleteCommentsTree_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -1956,65 +2836,37 @@ func (ec *executionContext) field_Query___type_argsName(
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_commentStats_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
-	var err error
-	args := map[string]any{}
-	arg0, err := ec.field_Query_commentStats_argsPostID(ctx, rawArgs)
-	if err != nil {
-		return nil, err
-	}
-	args["postID"] = arg0
-	return args, nil
-}
-func (ec *executionContext) field_Query_commentStats_argsPostID(
-	ctx context.Context,
-	rawArgs map[string]any,
-) (string, error) {
-	if _, ok := rawArgs["postID"]; !ok {
-		var zeroVal string
-		return zeroVal, nil
-	}
-
-	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("postID"))
-	if tmp, ok := rawArgs["postID"]; ok {
-		return ec.unmarshalNID2string(ctx, tmp)
-	}
-
-	var zeroVal string
-	return zeroVal, nil
-}
-
-func (ec *executionContext) field_Query_commentTree_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+func (ec *executionContext) field_Query_commentContext_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
-	arg0, err := ec.field_Query_commentTree_argsPostID(ctx, rawArgs)
+	arg0, err := ec.field_Query_commentContext_argsID(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
-	args["postID"] = arg0
-	arg1, err := ec.field_Query_commentTree_argsMaxDepth(ctx, rawArgs)
+	args["id"] = arg0
+	arg1, err := ec.field_Query_commentContext_argsAncestors(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
-	args["maxDepth"] = arg1
-	arg2, err := ec.field_Query_commentTree_argsFilter(ctx, rawArgs)
+	args["ancestors"] = arg1
+	arg2, err := ec.field_Query_commentContext_argsReplies(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
-	args["filter"] = arg2
+	args["replies"] = arg2
 	return args, nil
 }
-func (ec *executionContext) field_Query_commentTree_argsPostID(
+func (ec *executionContext) field_Query_commentContext_argsID(
 	ctx context.Context,
 	rawArgs map[string]any,
 ) (string, error) {
-	if _, ok := rawArgs["postID"]; !ok {
+	if _, ok := rawArgs["id"]; !ok {
 		var zeroVal string
 		return zeroVal, nil
 	}
 
-	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("postID"))
-	if tmp, ok := rawArgs["postID"]; ok {
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+	if tmp, ok := rawArgs["id"]; ok {
 		return ec.unmarshalNID2string(ctx, tmp)
 	}
 
@@ -2022,17 +2874,17 @@ func (ec *executionContext) field_Query_commentTree_argsPostID(
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_commentTree_argsMaxDepth(
+func (ec *executionContext) field_Query_commentContext_argsAncestors(
 	ctx context.Context,
 	rawArgs map[string]any,
 ) (*int, error) {
-	if _, ok := rawArgs["maxDepth"]; !ok {
+	if _, ok := rawArgs["ancestors"]; !ok {
 		var zeroVal *int
 		return zeroVal, nil
 	}
 
-	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("maxDepth"))
-	if tmp, ok := rawArgs["maxDepth"]; ok {
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("ancestors"))
+	if tmp, ok := rawArgs["ancestors"]; ok {
 		return ec.unmarshalOInt2ᚖint(ctx, tmp)
 	}
 
@@ -2040,45 +2892,45 @@ func (ec *executionContext) field_Query_commentTree_argsMaxDepth(
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_commentTree_argsFilter(
+func (ec *executionContext) field_Query_commentContext_argsReplies(
 	ctx context.Context,
 	rawArgs map[string]any,
-) (*CommentFilter, error) {
-	if _, ok := rawArgs["filter"]; !ok {
-		var zeroVal *CommentFilter
+) (*int, error) {
+	if _, ok := rawArgs["replies"]; !ok {
+		var zeroVal *int
 		return zeroVal, nil
 	}
 
-	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("filter"))
-	if tmp, ok := rawArgs["filter"]; ok {
-		return ec.unmarshalOCommentFilter2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentFilter(ctx, tmp)
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("replies"))
+	if tmp, ok := rawArgs["replies"]; ok {
+		return ec.unmarshalOInt2ᚖint(ctx, tmp)
 	}
 
-	var zeroVal *CommentFilter
+	var zeroVal *int
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_comment_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+func (ec *executionContext) field_Query_commentStats_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
-	arg0, err := ec.field_Query_comment_argsID(ctx, rawArgs)
+	arg0, err := ec.field_Query_commentStats_argsPostID(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
-	args["id"] = arg0
+	args["postID"] = arg0
 	return args, nil
 }
-func (ec *executionContext) field_Query_comment_argsID(
+func (ec *executionContext) field_Query_commentStats_argsPostID(
 	ctx context.Context,
 	rawArgs map[string]any,
 ) (string, error) {
-	if _, ok := rawArgs["id"]; !ok {
+	if _, ok := rawArgs["postID"]; !ok {
 		var zeroVal string
 		return zeroVal, nil
 	}
 
-	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
-	if tmp, ok := rawArgs["id"]; ok {
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("postID"))
+	if tmp, ok := rawArgs["postID"]; ok {
 		return ec.unmarshalNID2string(ctx, tmp)
 	}
 
@@ -2086,42 +2938,52 @@ func (ec *executionContext) field_Query_comment_argsID(
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_comments_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+func (ec *executionContext) field_Query_commentTree_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
-	arg0, err := ec.field_Query_comments_argsPostID(ctx, rawArgs)
+	arg0, err := ec.field_Query_commentTree_argsPostID(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
 	args["postID"] = arg0
-	arg1, err := ec.field_Query_comments_argsFirst(ctx, rawArgs)
+	arg1, err := ec.field_Query_commentTree_argsParentID(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
-	args["first"] = arg1
-	arg2, err := ec.field_Query_comments_argsAfter(ctx, rawArgs)
+	args["parentID"] = arg1
+	arg2, err := ec.field_Query_commentTree_argsMaxDepth(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
-	args["after"] = arg2
-	arg3, err := ec.field_Query_comments_argsLast(ctx, rawArgs)
+	args["maxDepth"] = arg2
+	arg3, err := ec.field_Query_commentTree_argsFilter(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
-	args["last"] = arg3
-	arg4, err := ec.field_Query_comments_argsBefore(ctx, rawArgs)
+	args["filter"] = arg3
+	arg4, err := ec.field_Query_commentTree_argsOrderBy(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
-	args["before"] = arg4
-	arg5, err := ec.field_Query_comments_argsFilter(ctx, rawArgs)
+	args["orderBy"] = arg4
+	arg5, err := ec.field_Query_commentTree_argsFirst(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
-	args["filter"] = arg5
+	args["first"] = arg5
+	arg6, err := ec.field_Query_commentTree_argsAfter(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["after"] = arg6
+	arg7, err := ec.field_Query_commentTree_argsRepliesFirst(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["repliesFirst"] = arg7
 	return args, nil
 }
-func (ec *executionContext) field_Query_comments_argsPostID(
+func (ec *executionContext) field_Query_commentTree_argsPostID(
 	ctx context.Context,
 	rawArgs map[string]any,
 ) (string, error) {
@@ -2139,17 +3001,35 @@ func (ec *executionContext) field_Query_comments_argsPostID(
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_comments_argsFirst(
+func (ec *executionContext) field_Query_commentTree_argsParentID(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*string, error) {
+	if _, ok := rawArgs["parentID"]; !ok {
+		var zeroVal *string
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("parentID"))
+	if tmp, ok := rawArgs["parentID"]; ok {
+		return ec.unmarshalOID2ᚖstring(ctx, tmp)
+	}
+
+	var zeroVal *string
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Query_commentTree_argsMaxDepth(
 	ctx context.Context,
 	rawArgs map[string]any,
 ) (*int, error) {
-	if _, ok := rawArgs["first"]; !ok {
+	if _, ok := rawArgs["maxDepth"]; !ok {
 		var zeroVal *int
 		return zeroVal, nil
 	}
 
-	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("first"))
-	if tmp, ok := rawArgs["first"]; ok {
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("maxDepth"))
+	if tmp, ok := rawArgs["maxDepth"]; ok {
 		return ec.unmarshalOInt2ᚖint(ctx, tmp)
 	}
 
@@ -2157,35 +3037,53 @@ func (ec *executionContext) field_Query_comments_argsFirst(
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_comments_argsAfter(
+func (ec *executionContext) field_Query_commentTree_argsFilter(
 	ctx context.Context,
 	rawArgs map[string]any,
-) (*string, error) {
-	if _, ok := rawArgs["after"]; !ok {
-		var zeroVal *string
+) (*CommentFilter, error) {
+	if _, ok := rawArgs["filter"]; !ok {
+		var zeroVal *CommentFilter
 		return zeroVal, nil
 	}
 
-	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("after"))
-	if tmp, ok := rawArgs["after"]; ok {
-		return ec.unmarshalOString2ᚖstring(ctx, tmp)
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("filter"))
+	if tmp, ok := rawArgs["filter"]; ok {
+		return ec.unmarshalOCommentFilter2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentFilter(ctx, tmp)
 	}
 
-	var zeroVal *string
+	var zeroVal *CommentFilter
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_comments_argsLast(
+func (ec *executionContext) field_Query_commentTree_argsOrderBy(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*CommentOrder, error) {
+	if _, ok := rawArgs["orderBy"]; !ok {
+		var zeroVal *CommentOrder
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("orderBy"))
+	if tmp, ok := rawArgs["orderBy"]; ok {
+		return ec.unmarshalOCommentOrder2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentOrder(ctx, tmp)
+	}
+
+	var zeroVal *CommentOrder
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Query_commentTree_argsFirst(
 	ctx context.Context,
 	rawArgs map[string]any,
 ) (*int, error) {
-	if _, ok := rawArgs["last"]; !ok {
+	if _, ok := rawArgs["first"]; !ok {
 		var zeroVal *int
 		return zeroVal, nil
 	}
 
-	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("last"))
-	if tmp, ok := rawArgs["last"]; ok {
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("first"))
+	if tmp, ok := rawArgs["first"]; ok {
 		return ec.unmarshalOInt2ᚖint(ctx, tmp)
 	}
 
@@ -2193,17 +3091,17 @@ func (ec *executionContext) field_Query_comments_argsLast(
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_comments_argsBefore(
+func (ec *executionContext) field_Query_commentTree_argsAfter(
 	ctx context.Context,
 	rawArgs map[string]any,
 ) (*string, error) {
-	if _, ok := rawArgs["before"]; !ok {
+	if _, ok := rawArgs["after"]; !ok {
 		var zeroVal *string
 		return zeroVal, nil
 	}
 
-	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("before"))
-	if tmp, ok := rawArgs["before"]; ok {
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("after"))
+	if tmp, ok := rawArgs["after"]; ok {
 		return ec.unmarshalOString2ᚖstring(ctx, tmp)
 	}
 
@@ -2211,35 +3109,35 @@ func (ec *executionContext) field_Query_comments_argsBefore(
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_comments_argsFilter(
+func (ec *executionContext) field_Query_commentTree_argsRepliesFirst(
 	ctx context.Context,
 	rawArgs map[string]any,
-) (*CommentFilter, error) {
-	if _, ok := rawArgs["filter"]; !ok {
-		var zeroVal *CommentFilter
+) (*int, error) {
+	if _, ok := rawArgs["repliesFirst"]; !ok {
+		var zeroVal *int
 		return zeroVal, nil
 	}
 
-	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("filter"))
-	if tmp, ok := rawArgs["filter"]; ok {
-		return ec.unmarshalOCommentFilter2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentFilter(ctx, tmp)
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("repliesFirst"))
+	if tmp, ok := rawArgs["repliesFirst"]; ok {
+		return ec.unmarshalOInt2ᚖint(ctx, tmp)
 	}
 
-	var zeroVal *CommentFilter
+	var zeroVal *int
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_postStats_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+func (ec *executionContext) field_Query_comment_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
-	arg0, err := ec.field_Query_postStats_argsID(ctx, rawArgs)
+	arg0, err := ec.field_Query_comment_argsID(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
 	args["id"] = arg0
 	return args, nil
 }
-func (ec *executionContext) field_Query_postStats_argsID(
+func (ec *executionContext) field_Query_comment_argsID(
 	ctx context.Context,
 	rawArgs map[string]any,
 ) (string, error) {
@@ -2257,65 +3155,65 @@ func (ec *executionContext) field_Query_postStats_argsID(
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_post_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+func (ec *executionContext) field_Query_comments_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
-	arg0, err := ec.field_Query_post_argsID(ctx, rawArgs)
+	arg0, err := ec.field_Query_comments_argsPostID(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
-	args["id"] = arg0
-	return args, nil
-}
-func (ec *executionContext) field_Query_post_argsID(
-	ctx context.Context,
-	rawArgs map[string]any,
-) (string, error) {
-	if _, ok := rawArgs["id"]; !ok {
-		var zeroVal string
-		return zeroVal, nil
-	}
-
-	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
-	if tmp, ok := rawArgs["id"]; ok {
-		return ec.unmarshalNID2string(ctx, tmp)
+	args["postID"] = arg0
+	arg1, err := ec.field_Query_comments_argsFirst(ctx, rawArgs)
+	if err != nil {
+		return nil, err
 	}
-
-	var zeroVal string
-	return zeroVal, nil
-}
-
-func (ec *executionContext) field_Query_posts_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
-	var err error
-	args := map[string]any{}
-	arg0, err := ec.field_Query_posts_argsFirst(ctx, rawArgs)
+	args["first"] = arg1
+	arg2, err := ec.field_Query_comments_argsAfter(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
-	args["first"] = arg0
-	arg1, err := ec.field_Query_posts_argsAfter(ctx, rawArgs)
+	args["after"] = arg2
+	arg3, err := ec.field_Query_comments_argsLast(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
-	args["after"] = arg1
-	arg2, err := ec.field_Query_posts_argsLast(ctx, rawArgs)
+	args["last"] = arg3
+	arg4, err := ec.field_Query_comments_argsBefore(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
-	args["last"] = arg2
-	arg3, err := ec.field_Query_posts_argsBefore(ctx, rawArgs)
+	args["before"] = arg4
+	arg5, err := ec.field_Query_comments_argsFilter(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
-	args["before"] = arg3
-	arg4, err := ec.field_Query_posts_argsFilter(ctx, rawArgs)
+	args["filter"] = arg5
+	arg6, err := ec.field_Query_comments_argsOrderBy(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
-	args["filter"] = arg4
+	args["orderBy"] = arg6
 	return args, nil
 }
-func (ec *executionContext) field_Query_posts_argsFirst(
+func (ec *executionContext) field_Query_comments_argsPostID(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (string, error) {
+	if _, ok := rawArgs["postID"]; !ok {
+		var zeroVal string
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("postID"))
+	if tmp, ok := rawArgs["postID"]; ok {
+		return ec.unmarshalNID2string(ctx, tmp)
+	}
+
+	var zeroVal string
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Query_comments_argsFirst(
 	ctx context.Context,
 	rawArgs map[string]any,
 ) (*int, error) {
@@ -2333,7 +3231,7 @@ func (ec *executionContext) field_Query_posts_argsFirst(
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_posts_argsAfter(
+func (ec *executionContext) field_Query_comments_argsAfter(
 	ctx context.Context,
 	rawArgs map[string]any,
 ) (*string, error) {
@@ -2351,7 +3249,7 @@ func (ec *executionContext) field_Query_posts_argsAfter(
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_posts_argsLast(
+func (ec *executionContext) field_Query_comments_argsLast(
 	ctx context.Context,
 	rawArgs map[string]any,
 ) (*int, error) {
@@ -2369,7 +3267,7 @@ func (ec *executionContext) field_Query_posts_argsLast(
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_posts_argsBefore(
+func (ec *executionContext) field_Query_comments_argsBefore(
 	ctx context.Context,
 	rawArgs map[string]any,
 ) (*string, error) {
@@ -2387,86 +3285,99 @@ func (ec *executionContext) field_Query_posts_argsBefore(
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_posts_argsFilter(
+func (ec *executionContext) field_Query_comments_argsFilter(
 	ctx context.Context,
 	rawArgs map[string]any,
-) (*PostFilter, error) {
+) (*CommentFilter, error) {
 	if _, ok := rawArgs["filter"]; !ok {
-		var zeroVal *PostFilter
+		var zeroVal *CommentFilter
 		return zeroVal, nil
 	}
 
 	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("filter"))
 	if tmp, ok := rawArgs["filter"]; ok {
-		return ec.unmarshalOPostFilter2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostFilter(ctx, tmp)
+		return ec.unmarshalOCommentFilter2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentFilter(ctx, tmp)
 	}
 
-	var zeroVal *PostFilter
+	var zeroVal *CommentFilter
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_searchComments_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+func (ec *executionContext) field_Query_comments_argsOrderBy(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*CommentOrder, error) {
+	if _, ok := rawArgs["orderBy"]; !ok {
+		var zeroVal *CommentOrder
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("orderBy"))
+	if tmp, ok := rawArgs["orderBy"]; ok {
+		return ec.unmarshalOCommentOrder2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentOrder(ctx, tmp)
+	}
+
+	var zeroVal *CommentOrder
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Query_postAnalytics_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
-	arg0, err := ec.field_Query_searchComments_argsPostID(ctx, rawArgs)
+	arg0, err := ec.field_Query_postAnalytics_argsFilter(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
-	args["postID"] = arg0
-	arg1, err := ec.field_Query_searchComments_argsQuery(ctx, rawArgs)
+	args["filter"] = arg0
+	arg1, err := ec.field_Query_postAnalytics_argsOrderBy(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
-	args["query"] = arg1
-	arg2, err := ec.field_Query_searchComments_argsFirst(ctx, rawArgs)
+	args["orderBy"] = arg1
+	arg2, err := ec.field_Query_postAnalytics_argsFirst(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
 	args["first"] = arg2
-	arg3, err := ec.field_Query_searchComments_argsAfter(ctx, rawArgs)
-	if err != nil {
-		return nil, err
-	}
-	args["after"] = arg3
 	return args, nil
 }
-func (ec *executionContext) field_Query_searchComments_argsPostID(
+func (ec *executionContext) field_Query_postAnalytics_argsFilter(
 	ctx context.Context,
 	rawArgs map[string]any,
-) (string, error) {
-	if _, ok := rawArgs["postID"]; !ok {
-		var zeroVal string
+) (*PostAnalyticsFilter, error) {
+	if _, ok := rawArgs["filter"]; !ok {
+		var zeroVal *PostAnalyticsFilter
 		return zeroVal, nil
 	}
 
-	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("postID"))
-	if tmp, ok := rawArgs["postID"]; ok {
-		return ec.unmarshalNID2string(ctx, tmp)
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("filter"))
+	if tmp, ok := rawArgs["filter"]; ok {
+		return ec.unmarshalOPostAnalyticsFilter2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostAnalyticsFilter(ctx, tmp)
 	}
 
-	var zeroVal string
+	var zeroVal *PostAnalyticsFilter
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_searchComments_argsQuery(
+func (ec *executionContext) field_Query_postAnalytics_argsOrderBy(
 	ctx context.Context,
 	rawArgs map[string]any,
-) (string, error) {
-	if _, ok := rawArgs["query"]; !ok {
-		var zeroVal string
+) (*PostAnalyticsOrder, error) {
+	if _, ok := rawArgs["orderBy"]; !ok {
+		var zeroVal *PostAnalyticsOrder
 		return zeroVal, nil
 	}
 
-	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("query"))
-	if tmp, ok := rawArgs["query"]; ok {
-		return ec.unmarshalNString2string(ctx, tmp)
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("orderBy"))
+	if tmp, ok := rawArgs["orderBy"]; ok {
+		return ec.unmarshalOPostAnalyticsOrder2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostAnalyticsOrder(ctx, tmp)
 	}
 
-	var zeroVal string
+	var zeroVal *PostAnalyticsOrder
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_searchComments_argsFirst(
+func (ec *executionContext) field_Query_postAnalytics_argsFirst(
 	ctx context.Context,
 	rawArgs map[string]any,
 ) (*int, error) {
@@ -2484,64 +3395,99 @@ func (ec *executionContext) field_Query_searchComments_argsFirst(
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_searchComments_argsAfter(
+func (ec *executionContext) field_Query_postStats_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := ec.field_Query_postStats_argsID(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["id"] = arg0
+	return args, nil
+}
+func (ec *executionContext) field_Query_postStats_argsID(
 	ctx context.Context,
 	rawArgs map[string]any,
-) (*string, error) {
-	if _, ok := rawArgs["after"]; !ok {
-		var zeroVal *string
+) (string, error) {
+	if _, ok := rawArgs["id"]; !ok {
+		var zeroVal string
 		return zeroVal, nil
 	}
 
-	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("after"))
-	if tmp, ok := rawArgs["after"]; ok {
-		return ec.unmarshalOString2ᚖstring(ctx, tmp)
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+	if tmp, ok := rawArgs["id"]; ok {
+		return ec.unmarshalNID2string(ctx, tmp)
 	}
 
-	var zeroVal *string
+	var zeroVal string
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_searchPosts_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+func (ec *executionContext) field_Query_post_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
-	arg0, err := ec.field_Query_searchPosts_argsQuery(ctx, rawArgs)
-	if err != nil {
-		return nil, err
-	}
-	args["query"] = arg0
-	arg1, err := ec.field_Query_searchPosts_argsFirst(ctx, rawArgs)
-	if err != nil {
-		return nil, err
-	}
-	args["first"] = arg1
-	arg2, err := ec.field_Query_searchPosts_argsAfter(ctx, rawArgs)
+	arg0, err := ec.field_Query_post_argsID(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
-	args["after"] = arg2
+	args["id"] = arg0
 	return args, nil
 }
-func (ec *executionContext) field_Query_searchPosts_argsQuery(
+func (ec *executionContext) field_Query_post_argsID(
 	ctx context.Context,
 	rawArgs map[string]any,
 ) (string, error) {
-	if _, ok := rawArgs["query"]; !ok {
+	if _, ok := rawArgs["id"]; !ok {
 		var zeroVal string
 		return zeroVal, nil
 	}
 
-	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("query"))
-	if tmp, ok := rawArgs["query"]; ok {
-		return ec.unmarshalNString2string(ctx, tmp)
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+	if tmp, ok := rawArgs["id"]; ok {
+		return ec.unmarshalNID2string(ctx, tmp)
 	}
 
 	var zeroVal string
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_searchPosts_argsFirst(
-	ctx context.Context,
+func (ec *executionContext) field_Query_posts_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := ec.field_Query_posts_argsFirst(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["first"] = arg0
+	arg1, err := ec.field_Query_posts_argsAfter(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["after"] = arg1
+	arg2, err := ec.field_Query_posts_argsLast(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["last"] = arg2
+	arg3, err := ec.field_Query_posts_argsBefore(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["before"] = arg3
+	arg4, err := ec.field_Query_posts_argsFilter(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["filter"] = arg4
+	arg5, err := ec.field_Query_posts_argsOrderBy(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["orderBy"] = arg5
+	return args, nil
+}
+func (ec *executionContext) field_Query_posts_argsFirst(
+	ctx context.Context,
 	rawArgs map[string]any,
 ) (*int, error) {
 	if _, ok := rawArgs["first"]; !ok {
@@ -2558,7 +3504,7 @@ func (ec *executionContext) field_Query_searchPosts_argsFirst(
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Query_searchPosts_argsAfter(
+func (ec *executionContext) field_Query_posts_argsAfter(
 	ctx context.Context,
 	rawArgs map[string]any,
 ) (*string, error) {
@@ -2576,73 +3522,109 @@ func (ec *executionContext) field_Query_searchPosts_argsAfter(
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Subscription_commentEvents_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
-	var err error
-	args := map[string]any{}
-	arg0, err := ec.field_Subscription_commentEvents_argsPostID(ctx, rawArgs)
-	if err != nil {
-		return nil, err
+func (ec *executionContext) field_Query_posts_argsLast(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*int, error) {
+	if _, ok := rawArgs["last"]; !ok {
+		var zeroVal *int
+		return zeroVal, nil
 	}
-	args["postID"] = arg0
-	return args, nil
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("last"))
+	if tmp, ok := rawArgs["last"]; ok {
+		return ec.unmarshalOInt2ᚖint(ctx, tmp)
+	}
+
+	var zeroVal *int
+	return zeroVal, nil
 }
-func (ec *executionContext) field_Subscription_commentEvents_argsPostID(
+
+func (ec *executionContext) field_Query_posts_argsBefore(
 	ctx context.Context,
 	rawArgs map[string]any,
-) (string, error) {
-	if _, ok := rawArgs["postID"]; !ok {
-		var zeroVal string
+) (*string, error) {
+	if _, ok := rawArgs["before"]; !ok {
+		var zeroVal *string
 		return zeroVal, nil
 	}
 
-	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("postID"))
-	if tmp, ok := rawArgs["postID"]; ok {
-		return ec.unmarshalNID2string(ctx, tmp)
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("before"))
+	if tmp, ok := rawArgs["before"]; ok {
+		return ec.unmarshalOString2ᚖstring(ctx, tmp)
 	}
 
-	var zeroVal string
+	var zeroVal *string
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Subscription_postStatsUpdates_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
-	var err error
-	args := map[string]any{}
-	arg0, err := ec.field_Subscription_postStatsUpdates_argsPostID(ctx, rawArgs)
-	if err != nil {
-		return nil, err
+func (ec *executionContext) field_Query_posts_argsFilter(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*PostFilter, error) {
+	if _, ok := rawArgs["filter"]; !ok {
+		var zeroVal *PostFilter
+		return zeroVal, nil
 	}
-	args["postID"] = arg0
-	return args, nil
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("filter"))
+	if tmp, ok := rawArgs["filter"]; ok {
+		return ec.unmarshalOPostFilter2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostFilter(ctx, tmp)
+	}
+
+	var zeroVal *PostFilter
+	return zeroVal, nil
 }
-func (ec *executionContext) field_Subscription_postStatsUpdates_argsPostID(
+
+func (ec *executionContext) field_Query_posts_argsOrderBy(
 	ctx context.Context,
 	rawArgs map[string]any,
-) (string, error) {
-	if _, ok := rawArgs["postID"]; !ok {
-		var zeroVal string
+) (*PostOrder, error) {
+	if _, ok := rawArgs["orderBy"]; !ok {
+		var zeroVal *PostOrder
 		return zeroVal, nil
 	}
 
-	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("postID"))
-	if tmp, ok := rawArgs["postID"]; ok {
-		return ec.unmarshalNID2string(ctx, tmp)
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("orderBy"))
+	if tmp, ok := rawArgs["orderBy"]; ok {
+		return ec.unmarshalOPostOrder2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostOrder(ctx, tmp)
 	}
 
-	var zeroVal string
+	var zeroVal *PostOrder
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field_Subscription_postUpdates_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+func (ec *executionContext) field_Query_searchComments_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
-	arg0, err := ec.field_Subscription_postUpdates_argsPostID(ctx, rawArgs)
+	arg0, err := ec.field_Query_searchComments_argsPostID(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
 	args["postID"] = arg0
+	arg1, err := ec.field_Query_searchComments_argsQuery(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["query"] = arg1
+	arg2, err := ec.field_Query_searchComments_argsLanguage(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["language"] = arg2
+	arg3, err := ec.field_Query_searchComments_argsFirst(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["first"] = arg3
+	arg4, err := ec.field_Query_searchComments_argsAfter(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["after"] = arg4
 	return args, nil
 }
-func (ec *executionContext) field_Subscription_postUpdates_argsPostID(
+func (ec *executionContext) field_Query_searchComments_argsPostID(
 	ctx context.Context,
 	rawArgs map[string]any,
 ) (string, error) {
@@ -2660,128 +3642,3338 @@ func (ec *executionContext) field_Subscription_postUpdates_argsPostID(
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field___Directive_args_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
-	var err error
-	args := map[string]any{}
-	arg0, err := ec.field___Directive_args_argsIncludeDeprecated(ctx, rawArgs)
-	if err != nil {
-		return nil, err
+func (ec *executionContext) field_Query_searchComments_argsQuery(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (string, error) {
+	if _, ok := rawArgs["query"]; !ok {
+		var zeroVal string
+		return zeroVal, nil
 	}
-	args["includeDeprecated"] = arg0
-	return args, nil
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("query"))
+	if tmp, ok := rawArgs["query"]; ok {
+		return ec.unmarshalNString2string(ctx, tmp)
+	}
+
+	var zeroVal string
+	return zeroVal, nil
 }
-func (ec *executionContext) field___Directive_args_argsIncludeDeprecated(
+
+func (ec *executionContext) field_Query_searchComments_argsLanguage(
 	ctx context.Context,
 	rawArgs map[string]any,
-) (*bool, error) {
-	if _, ok := rawArgs["includeDeprecated"]; !ok {
-		var zeroVal *bool
+) (*Language, error) {
+	if _, ok := rawArgs["language"]; !ok {
+		var zeroVal *Language
 		return zeroVal, nil
 	}
 
-	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
-	if tmp, ok := rawArgs["includeDeprecated"]; ok {
-		return ec.unmarshalOBoolean2ᚖbool(ctx, tmp)
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("language"))
+	if tmp, ok := rawArgs["language"]; ok {
+		return ec.unmarshalOLanguage2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐLanguage(ctx, tmp)
 	}
 
-	var zeroVal *bool
+	var zeroVal *Language
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field___Field_args_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
-	var err error
-	args := map[string]any{}
-	arg0, err := ec.field___Field_args_argsIncludeDeprecated(ctx, rawArgs)
-	if err != nil {
-		return nil, err
+func (ec *executionContext) field_Query_searchComments_argsFirst(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*int, error) {
+	if _, ok := rawArgs["first"]; !ok {
+		var zeroVal *int
+		return zeroVal, nil
 	}
-	args["includeDeprecated"] = arg0
-	return args, nil
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("first"))
+	if tmp, ok := rawArgs["first"]; ok {
+		return ec.unmarshalOInt2ᚖint(ctx, tmp)
+	}
+
+	var zeroVal *int
+	return zeroVal, nil
 }
-func (ec *executionContext) field___Field_args_argsIncludeDeprecated(
+
+func (ec *executionContext) field_Query_searchComments_argsAfter(
 	ctx context.Context,
 	rawArgs map[string]any,
-) (*bool, error) {
-	if _, ok := rawArgs["includeDeprecated"]; !ok {
-		var zeroVal *bool
+) (*string, error) {
+	if _, ok := rawArgs["after"]; !ok {
+		var zeroVal *string
 		return zeroVal, nil
 	}
 
-	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
-	if tmp, ok := rawArgs["includeDeprecated"]; ok {
-		return ec.unmarshalOBoolean2ᚖbool(ctx, tmp)
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("after"))
+	if tmp, ok := rawArgs["after"]; ok {
+		return ec.unmarshalOString2ᚖstring(ctx, tmp)
 	}
 
-	var zeroVal *bool
+	var zeroVal *string
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field___Type_enumValues_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+func (ec *executionContext) field_Query_searchPosts_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
-	arg0, err := ec.field___Type_enumValues_argsIncludeDeprecated(ctx, rawArgs)
+	arg0, err := ec.field_Query_searchPosts_argsQuery(ctx, rawArgs)
 	if err != nil {
 		return nil, err
 	}
-	args["includeDeprecated"] = arg0
+	args["query"] = arg0
+	arg1, err := ec.field_Query_searchPosts_argsLanguage(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["language"] = arg1
+	arg2, err := ec.field_Query_searchPosts_argsFirst(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["first"] = arg2
+	arg3, err := ec.field_Query_searchPosts_argsAfter(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["after"] = arg3
 	return args, nil
 }
-func (ec *executionContext) field___Type_enumValues_argsIncludeDeprecated(
+func (ec *executionContext) field_Query_searchPosts_argsQuery(
 	ctx context.Context,
 	rawArgs map[string]any,
-) (bool, error) {
-	if _, ok := rawArgs["includeDeprecated"]; !ok {
-		var zeroVal bool
+) (string, error) {
+	if _, ok := rawArgs["query"]; !ok {
+		var zeroVal string
 		return zeroVal, nil
 	}
 
-	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
-	if tmp, ok := rawArgs["includeDeprecated"]; ok {
-		return ec.unmarshalOBoolean2bool(ctx, tmp)
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("query"))
+	if tmp, ok := rawArgs["query"]; ok {
+		return ec.unmarshalNString2string(ctx, tmp)
 	}
 
-	var zeroVal bool
+	var zeroVal string
 	return zeroVal, nil
 }
 
-func (ec *executionContext) field___Type_fields_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
-	var err error
-	args := map[string]any{}
-	arg0, err := ec.field___Type_fields_argsIncludeDeprecated(ctx, rawArgs)
-	if err != nil {
-		return nil, err
-	}
-	args["includeDeprecated"] = arg0
-	return args, nil
-}
-func (ec *executionContext) field___Type_fields_argsIncludeDeprecated(
+func (ec *executionContext) field_Query_searchPosts_argsLanguage(
 	ctx context.Context,
 	rawArgs map[string]any,
-) (bool, error) {
-	if _, ok := rawArgs["includeDeprecated"]; !ok {
-		var zeroVal bool
+) (*Language, error) {
+	if _, ok := rawArgs["language"]; !ok {
+		var zeroVal *Language
 		return zeroVal, nil
 	}
 
-	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
-	if tmp, ok := rawArgs["includeDeprecated"]; ok {
-		return ec.unmarshalOBoolean2bool(ctx, tmp)
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("language"))
+	if tmp, ok := rawArgs["language"]; ok {
+		return ec.unmarshalOLanguage2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐLanguage(ctx, tmp)
+	}
+
+	var zeroVal *Language
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Query_searchPosts_argsFirst(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*int, error) {
+	if _, ok := rawArgs["first"]; !ok {
+		var zeroVal *int
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("first"))
+	if tmp, ok := rawArgs["first"]; ok {
+		return ec.unmarshalOInt2ᚖint(ctx, tmp)
+	}
+
+	var zeroVal *int
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Query_searchPosts_argsAfter(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*string, error) {
+	if _, ok := rawArgs["after"]; !ok {
+		var zeroVal *string
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("after"))
+	if tmp, ok := rawArgs["after"]; ok {
+		return ec.unmarshalOString2ᚖstring(ctx, tmp)
+	}
+
+	var zeroVal *string
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Subscription_allCommentEvents_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := ec.field_Subscription_allCommentEvents_argsSlowConsumer(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["slowConsumer"] = arg0
+	return args, nil
+}
+func (ec *executionContext) field_Subscription_allCommentEvents_argsSlowConsumer(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*SlowConsumerPolicy, error) {
+	if _, ok := rawArgs["slowConsumer"]; !ok {
+		var zeroVal *SlowConsumerPolicy
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("slowConsumer"))
+	if tmp, ok := rawArgs["slowConsumer"]; ok {
+		return ec.unmarshalOSlowConsumerPolicy2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐSlowConsumerPolicy(ctx, tmp)
+	}
+
+	var zeroVal *SlowConsumerPolicy
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Subscription_commentEvents_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := ec.field_Subscription_commentEvents_argsPostID(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["postID"] = arg0
+	arg1, err := ec.field_Subscription_commentEvents_argsAfterEventID(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["afterEventID"] = arg1
+	arg2, err := ec.field_Subscription_commentEvents_argsSlowConsumer(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["slowConsumer"] = arg2
+	return args, nil
+}
+func (ec *executionContext) field_Subscription_commentEvents_argsPostID(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (string, error) {
+	if _, ok := rawArgs["postID"]; !ok {
+		var zeroVal string
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("postID"))
+	if tmp, ok := rawArgs["postID"]; ok {
+		return ec.unmarshalNID2string(ctx, tmp)
+	}
+
+	var zeroVal string
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Subscription_commentEvents_argsAfterEventID(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*string, error) {
+	if _, ok := rawArgs["afterEventID"]; !ok {
+		var zeroVal *string
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("afterEventID"))
+	if tmp, ok := rawArgs["afterEventID"]; ok {
+		return ec.unmarshalOID2ᚖstring(ctx, tmp)
+	}
+
+	var zeroVal *string
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Subscription_commentEvents_argsSlowConsumer(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*SlowConsumerPolicy, error) {
+	if _, ok := rawArgs["slowConsumer"]; !ok {
+		var zeroVal *SlowConsumerPolicy
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("slowConsumer"))
+	if tmp, ok := rawArgs["slowConsumer"]; ok {
+		return ec.unmarshalOSlowConsumerPolicy2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐSlowConsumerPolicy(ctx, tmp)
+	}
+
+	var zeroVal *SlowConsumerPolicy
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Subscription_newPosts_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := ec.field_Subscription_newPosts_argsSlowConsumer(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["slowConsumer"] = arg0
+	return args, nil
+}
+func (ec *executionContext) field_Subscription_newPosts_argsSlowConsumer(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*SlowConsumerPolicy, error) {
+	if _, ok := rawArgs["slowConsumer"]; !ok {
+		var zeroVal *SlowConsumerPolicy
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("slowConsumer"))
+	if tmp, ok := rawArgs["slowConsumer"]; ok {
+		return ec.unmarshalOSlowConsumerPolicy2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐSlowConsumerPolicy(ctx, tmp)
+	}
+
+	var zeroVal *SlowConsumerPolicy
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Subscription_postStatsUpdates_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := ec.field_Subscription_postStatsUpdates_argsPostID(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["postID"] = arg0
+	arg1, err := ec.field_Subscription_postStatsUpdates_argsSlowConsumer(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["slowConsumer"] = arg1
+	return args, nil
+}
+func (ec *executionContext) field_Subscription_postStatsUpdates_argsPostID(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (string, error) {
+	if _, ok := rawArgs["postID"]; !ok {
+		var zeroVal string
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("postID"))
+	if tmp, ok := rawArgs["postID"]; ok {
+		return ec.unmarshalNID2string(ctx, tmp)
+	}
+
+	var zeroVal string
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Subscription_postStatsUpdates_argsSlowConsumer(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*SlowConsumerPolicy, error) {
+	if _, ok := rawArgs["slowConsumer"]; !ok {
+		var zeroVal *SlowConsumerPolicy
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("slowConsumer"))
+	if tmp, ok := rawArgs["slowConsumer"]; ok {
+		return ec.unmarshalOSlowConsumerPolicy2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐSlowConsumerPolicy(ctx, tmp)
+	}
+
+	var zeroVal *SlowConsumerPolicy
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Subscription_postUpdates_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := ec.field_Subscription_postUpdates_argsPostID(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["postID"] = arg0
+	arg1, err := ec.field_Subscription_postUpdates_argsSlowConsumer(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["slowConsumer"] = arg1
+	return args, nil
+}
+func (ec *executionContext) field_Subscription_postUpdates_argsPostID(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (string, error) {
+	if _, ok := rawArgs["postID"]; !ok {
+		var zeroVal string
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("postID"))
+	if tmp, ok := rawArgs["postID"]; ok {
+		return ec.unmarshalNID2string(ctx, tmp)
+	}
+
+	var zeroVal string
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field_Subscription_postUpdates_argsSlowConsumer(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*SlowConsumerPolicy, error) {
+	if _, ok := rawArgs["slowConsumer"]; !ok {
+		var zeroVal *SlowConsumerPolicy
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("slowConsumer"))
+	if tmp, ok := rawArgs["slowConsumer"]; ok {
+		return ec.unmarshalOSlowConsumerPolicy2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐSlowConsumerPolicy(ctx, tmp)
+	}
+
+	var zeroVal *SlowConsumerPolicy
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field___Directive_args_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := ec.field___Directive_args_argsIncludeDeprecated(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["includeDeprecated"] = arg0
+	return args, nil
+}
+func (ec *executionContext) field___Directive_args_argsIncludeDeprecated(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*bool, error) {
+	if _, ok := rawArgs["includeDeprecated"]; !ok {
+		var zeroVal *bool
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
+	if tmp, ok := rawArgs["includeDeprecated"]; ok {
+		return ec.unmarshalOBoolean2ᚖbool(ctx, tmp)
+	}
+
+	var zeroVal *bool
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field___Field_args_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := ec.field___Field_args_argsIncludeDeprecated(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["includeDeprecated"] = arg0
+	return args, nil
+}
+func (ec *executionContext) field___Field_args_argsIncludeDeprecated(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (*bool, error) {
+	if _, ok := rawArgs["includeDeprecated"]; !ok {
+		var zeroVal *bool
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
+	if tmp, ok := rawArgs["includeDeprecated"]; ok {
+		return ec.unmarshalOBoolean2ᚖbool(ctx, tmp)
+	}
+
+	var zeroVal *bool
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field___Type_enumValues_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := ec.field___Type_enumValues_argsIncludeDeprecated(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["includeDeprecated"] = arg0
+	return args, nil
+}
+func (ec *executionContext) field___Type_enumValues_argsIncludeDeprecated(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (bool, error) {
+	if _, ok := rawArgs["includeDeprecated"]; !ok {
+		var zeroVal bool
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
+	if tmp, ok := rawArgs["includeDeprecated"]; ok {
+		return ec.unmarshalOBoolean2bool(ctx, tmp)
+	}
+
+	var zeroVal bool
+	return zeroVal, nil
+}
+
+func (ec *executionContext) field___Type_fields_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := ec.field___Type_fields_argsIncludeDeprecated(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["includeDeprecated"] = arg0
+	return args, nil
+}
+func (ec *executionContext) field___Type_fields_argsIncludeDeprecated(
+	ctx context.Context,
+	rawArgs map[string]any,
+) (bool, error) {
+	if _, ok := rawArgs["includeDeprecated"]; !ok {
+		var zeroVal bool
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
+	if tmp, ok := rawArgs["includeDeprecated"]; ok {
+		return ec.unmarshalOBoolean2bool(ctx, tmp)
+	}
+
+	var zeroVal bool
+	return zeroVal, nil
+}
+
+// endregion ***************************** args.gotpl *****************************
+
+// region    ************************** directives.gotpl **************************
+
+// endregion ************************** directives.gotpl **************************
+
+// region    **************************** field.gotpl *****************************
+
+func (ec *executionContext) _BatchDeleteResult_success(ctx context.Context, field graphql.CollectedField, obj *BatchDeleteResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_BatchDeleteResult_success(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Success, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_BatchDeleteResult_success(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "BatchDeleteResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _BatchDeleteResult_deletedCount(ctx context.Context, field graphql.CollectedField, obj *BatchDeleteResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_BatchDeleteResult_deletedCount(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.DeletedCount, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_BatchDeleteResult_deletedCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "BatchDeleteResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _BatchDeleteResult_deletedIDs(ctx context.Context, field graphql.CollectedField, obj *BatchDeleteResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_BatchDeleteResult_deletedIDs(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.DeletedIDs, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]string)
+	fc.Result = res
+	return ec.marshalNID2ᚕstringᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_BatchDeleteResult_deletedIDs(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "BatchDeleteResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _BatchDeleteResult_errors(ctx context.Context, field graphql.CollectedField, obj *BatchDeleteResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_BatchDeleteResult_errors(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Errors, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]string)
+	fc.Result = res
+	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_BatchDeleteResult_errors(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "BatchDeleteResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Comment_id(ctx context.Context, field graphql.CollectedField, obj *Comment) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Comment_id(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Comment_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Comment",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Comment_postID(ctx context.Context, field graphql.CollectedField, obj *Comment) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Comment_postID(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.PostID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Comment_postID(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Comment",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Comment_parentID(ctx context.Context, field graphql.CollectedField, obj *Comment) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Comment_parentID(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ParentID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOID2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Comment_parentID(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Comment",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Comment_content(ctx context.Context, field graphql.CollectedField, obj *Comment) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Comment_content(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Content, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Comment_content(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Comment",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Comment_authorID(ctx context.Context, field graphql.CollectedField, obj *Comment) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Comment_authorID(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.AuthorID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Comment_authorID(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Comment",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Comment_depth(ctx context.Context, field graphql.CollectedField, obj *Comment) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Comment_depth(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Depth, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Comment_depth(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Comment",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Comment_language(ctx context.Context, field graphql.CollectedField, obj *Comment) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Comment_language(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Language, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(Language)
+	fc.Result = res
+	return ec.marshalNLanguage2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐLanguage(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Comment_language(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Comment",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Language does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Comment_createdAt(ctx context.Context, field graphql.CollectedField, obj *Comment) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Comment_createdAt(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.CreatedAt, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	fc.Result = res
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Comment_createdAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Comment",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Comment_updatedAt(ctx context.Context, field graphql.CollectedField, obj *Comment) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Comment_updatedAt(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.UpdatedAt, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	fc.Result = res
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Comment_updatedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Comment",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Comment_isDeleted(ctx context.Context, field graphql.CollectedField, obj *Comment) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Comment_isDeleted(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.IsDeleted, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Comment_isDeleted(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Comment",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Comment_deletedAt(ctx context.Context, field graphql.CollectedField, obj *Comment) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Comment_deletedAt(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.DeletedAt, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*time.Time)
+	fc.Result = res
+	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Comment_deletedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Comment",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Comment_children(ctx context.Context, field graphql.CollectedField, obj *Comment) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Comment_children(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Comment().Children(rctx, obj, fc.Args["first"].(*int), fc.Args["after"].(*string), fc.Args["last"].(*int), fc.Args["before"].(*string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*CommentConnection)
+	fc.Result = res
+	return ec.marshalNCommentConnection2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentConnection(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Comment_children(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Comment",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "edges":
+				return ec.fieldContext_CommentConnection_edges(ctx, field)
+			case "pageInfo":
+				return ec.fieldContext_CommentConnection_pageInfo(ctx, field)
+			case "totalCount":
+				return ec.fieldContext_CommentConnection_totalCount(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type CommentConnection", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Comment_children_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentBatchDeleteResult_success(ctx context.Context, field graphql.CollectedField, obj *CommentBatchDeleteResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentBatchDeleteResult_success(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Success, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentBatchDeleteResult_success(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentBatchDeleteResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentBatchDeleteResult_deletedCount(ctx context.Context, field graphql.CollectedField, obj *CommentBatchDeleteResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentBatchDeleteResult_deletedCount(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.DeletedCount, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentBatchDeleteResult_deletedCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentBatchDeleteResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentBatchDeleteResult_deletedIDs(ctx context.Context, field graphql.CollectedField, obj *CommentBatchDeleteResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentBatchDeleteResult_deletedIDs(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.DeletedIDs, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]string)
+	fc.Result = res
+	return ec.marshalNID2ᚕstringᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentBatchDeleteResult_deletedIDs(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentBatchDeleteResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentBatchDeleteResult_results(ctx context.Context, field graphql.CollectedField, obj *CommentBatchDeleteResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentBatchDeleteResult_results(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Results, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*CommentDeleteItemResult)
+	fc.Result = res
+	return ec.marshalNCommentDeleteItemResult2ᚕᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentDeleteItemResultᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentBatchDeleteResult_results(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentBatchDeleteResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_CommentDeleteItemResult_id(ctx, field)
+			case "status":
+				return ec.fieldContext_CommentDeleteItemResult_status(ctx, field)
+			case "message":
+				return ec.fieldContext_CommentDeleteItemResult_message(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type CommentDeleteItemResult", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentBatchDeleteResult_error(ctx context.Context, field graphql.CollectedField, obj *CommentBatchDeleteResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentBatchDeleteResult_error(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Error, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentBatchDeleteResult_error(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentBatchDeleteResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentConnection_edges(ctx context.Context, field graphql.CollectedField, obj *CommentConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentConnection_edges(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Edges, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*CommentEdge)
+	fc.Result = res
+	return ec.marshalNCommentEdge2ᚕᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentEdgeᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentConnection_edges(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentConnection",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "node":
+				return ec.fieldContext_CommentEdge_node(ctx, field)
+			case "cursor":
+				return ec.fieldContext_CommentEdge_cursor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type CommentEdge", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentConnection_pageInfo(ctx context.Context, field graphql.CollectedField, obj *CommentConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentConnection_pageInfo(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.PageInfo, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*PageInfo)
+	fc.Result = res
+	return ec.marshalNPageInfo2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPageInfo(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentConnection_pageInfo(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentConnection",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "hasNextPage":
+				return ec.fieldContext_PageInfo_hasNextPage(ctx, field)
+			case "hasPreviousPage":
+				return ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
+			case "startCursor":
+				return ec.fieldContext_PageInfo_startCursor(ctx, field)
+			case "endCursor":
+				return ec.fieldContext_PageInfo_endCursor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PageInfo", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentConnection_totalCount(ctx context.Context, field graphql.CollectedField, obj *CommentConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentConnection_totalCount(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TotalCount, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentConnection_totalCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentConnection",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentContext_comment(ctx context.Context, field graphql.CollectedField, obj *CommentContext) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentContext_comment(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Comment, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*Comment)
+	fc.Result = res
+	return ec.marshalNComment2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐComment(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentContext_comment(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentContext",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Comment_id(ctx, field)
+			case "postID":
+				return ec.fieldContext_Comment_postID(ctx, field)
+			case "parentID":
+				return ec.fieldContext_Comment_parentID(ctx, field)
+			case "content":
+				return ec.fieldContext_Comment_content(ctx, field)
+			case "authorID":
+				return ec.fieldContext_Comment_authorID(ctx, field)
+			case "depth":
+				return ec.fieldContext_Comment_depth(ctx, field)
+			case "language":
+				return ec.fieldContext_Comment_language(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Comment_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Comment_updatedAt(ctx, field)
+			case "isDeleted":
+				return ec.fieldContext_Comment_isDeleted(ctx, field)
+			case "deletedAt":
+				return ec.fieldContext_Comment_deletedAt(ctx, field)
+			case "children":
+				return ec.fieldContext_Comment_children(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Comment", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentContext_ancestors(ctx context.Context, field graphql.CollectedField, obj *CommentContext) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentContext_ancestors(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Ancestors, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*Comment)
+	fc.Result = res
+	return ec.marshalNComment2ᚕᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentContext_ancestors(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentContext",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Comment_id(ctx, field)
+			case "postID":
+				return ec.fieldContext_Comment_postID(ctx, field)
+			case "parentID":
+				return ec.fieldContext_Comment_parentID(ctx, field)
+			case "content":
+				return ec.fieldContext_Comment_content(ctx, field)
+			case "authorID":
+				return ec.fieldContext_Comment_authorID(ctx, field)
+			case "depth":
+				return ec.fieldContext_Comment_depth(ctx, field)
+			case "language":
+				return ec.fieldContext_Comment_language(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Comment_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Comment_updatedAt(ctx, field)
+			case "isDeleted":
+				return ec.fieldContext_Comment_isDeleted(ctx, field)
+			case "deletedAt":
+				return ec.fieldContext_Comment_deletedAt(ctx, field)
+			case "children":
+				return ec.fieldContext_Comment_children(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Comment", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentContext_hasMoreAncestors(ctx context.Context, field graphql.CollectedField, obj *CommentContext) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentContext_hasMoreAncestors(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.HasMoreAncestors, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentContext_hasMoreAncestors(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentContext",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentContext_replies(ctx context.Context, field graphql.CollectedField, obj *CommentContext) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentContext_replies(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Replies, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*CommentTree)
+	fc.Result = res
+	return ec.marshalNCommentTree2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentTree(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentContext_replies(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentContext",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "nodes":
+				return ec.fieldContext_CommentTree_nodes(ctx, field)
+			case "pageInfo":
+				return ec.fieldContext_CommentTree_pageInfo(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type CommentTree", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentContext_totalReplies(ctx context.Context, field graphql.CollectedField, obj *CommentContext) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentContext_totalReplies(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TotalReplies, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentContext_totalReplies(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentContext",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentDeleteItemResult_id(ctx context.Context, field graphql.CollectedField, obj *CommentDeleteItemResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentDeleteItemResult_id(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentDeleteItemResult_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentDeleteItemResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentDeleteItemResult_status(ctx context.Context, field graphql.CollectedField, obj *CommentDeleteItemResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentDeleteItemResult_status(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Status, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(CommentDeleteStatus)
+	fc.Result = res
+	return ec.marshalNCommentDeleteStatus2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentDeleteStatus(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentDeleteItemResult_status(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentDeleteItemResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type CommentDeleteStatus does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentDeleteItemResult_message(ctx context.Context, field graphql.CollectedField, obj *CommentDeleteItemResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentDeleteItemResult_message(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Message, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentDeleteItemResult_message(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentDeleteItemResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentEdge_node(ctx context.Context, field graphql.CollectedField, obj *CommentEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentEdge_node(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Node, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*Comment)
+	fc.Result = res
+	return ec.marshalNComment2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐComment(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentEdge_node(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentEdge",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Comment_id(ctx, field)
+			case "postID":
+				return ec.fieldContext_Comment_postID(ctx, field)
+			case "parentID":
+				return ec.fieldContext_Comment_parentID(ctx, field)
+			case "content":
+				return ec.fieldContext_Comment_content(ctx, field)
+			case "authorID":
+				return ec.fieldContext_Comment_authorID(ctx, field)
+			case "depth":
+				return ec.fieldContext_Comment_depth(ctx, field)
+			case "language":
+				return ec.fieldContext_Comment_language(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Comment_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Comment_updatedAt(ctx, field)
+			case "isDeleted":
+				return ec.fieldContext_Comment_isDeleted(ctx, field)
+			case "deletedAt":
+				return ec.fieldContext_Comment_deletedAt(ctx, field)
+			case "children":
+				return ec.fieldContext_Comment_children(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Comment", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentEdge_cursor(ctx context.Context, field graphql.CollectedField, obj *CommentEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentEdge_cursor(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Cursor, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentEdge_cursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentEdge",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentEvent_type(ctx context.Context, field graphql.CollectedField, obj *CommentEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentEvent_type(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Type, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(CommentEventType)
+	fc.Result = res
+	return ec.marshalNCommentEventType2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentEventType(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentEvent_type(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type CommentEventType does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentEvent_comment(ctx context.Context, field graphql.CollectedField, obj *CommentEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentEvent_comment(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Comment, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*Comment)
+	fc.Result = res
+	return ec.marshalNComment2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐComment(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentEvent_comment(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Comment_id(ctx, field)
+			case "postID":
+				return ec.fieldContext_Comment_postID(ctx, field)
+			case "parentID":
+				return ec.fieldContext_Comment_parentID(ctx, field)
+			case "content":
+				return ec.fieldContext_Comment_content(ctx, field)
+			case "authorID":
+				return ec.fieldContext_Comment_authorID(ctx, field)
+			case "depth":
+				return ec.fieldContext_Comment_depth(ctx, field)
+			case "language":
+				return ec.fieldContext_Comment_language(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Comment_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Comment_updatedAt(ctx, field)
+			case "isDeleted":
+				return ec.fieldContext_Comment_isDeleted(ctx, field)
+			case "deletedAt":
+				return ec.fieldContext_Comment_deletedAt(ctx, field)
+			case "children":
+				return ec.fieldContext_Comment_children(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Comment", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentEvent_postID(ctx context.Context, field graphql.CollectedField, obj *CommentEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentEvent_postID(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.PostID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentEvent_postID(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentEvent_eventID(ctx context.Context, field graphql.CollectedField, obj *CommentEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentEvent_eventID(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.EventID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOID2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentEvent_eventID(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentEvent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentResult_success(ctx context.Context, field graphql.CollectedField, obj *CommentResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentResult_success(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Success, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentResult_success(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentResult_comment(ctx context.Context, field graphql.CollectedField, obj *CommentResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentResult_comment(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Comment, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*Comment)
+	fc.Result = res
+	return ec.marshalOComment2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐComment(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentResult_comment(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Comment_id(ctx, field)
+			case "postID":
+				return ec.fieldContext_Comment_postID(ctx, field)
+			case "parentID":
+				return ec.fieldContext_Comment_parentID(ctx, field)
+			case "content":
+				return ec.fieldContext_Comment_content(ctx, field)
+			case "authorID":
+				return ec.fieldContext_Comment_authorID(ctx, field)
+			case "depth":
+				return ec.fieldContext_Comment_depth(ctx, field)
+			case "language":
+				return ec.fieldContext_Comment_language(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Comment_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Comment_updatedAt(ctx, field)
+			case "isDeleted":
+				return ec.fieldContext_Comment_isDeleted(ctx, field)
+			case "deletedAt":
+				return ec.fieldContext_Comment_deletedAt(ctx, field)
+			case "children":
+				return ec.fieldContext_Comment_children(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Comment", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentResult_error(ctx context.Context, field graphql.CollectedField, obj *CommentResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentResult_error(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Error, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentResult_error(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentSearchConnection_edges(ctx context.Context, field graphql.CollectedField, obj *CommentSearchConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentSearchConnection_edges(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Edges, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*CommentSearchEdge)
+	fc.Result = res
+	return ec.marshalNCommentSearchEdge2ᚕᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentSearchEdgeᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentSearchConnection_edges(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentSearchConnection",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "node":
+				return ec.fieldContext_CommentSearchEdge_node(ctx, field)
+			case "cursor":
+				return ec.fieldContext_CommentSearchEdge_cursor(ctx, field)
+			case "rank":
+				return ec.fieldContext_CommentSearchEdge_rank(ctx, field)
+			case "snippet":
+				return ec.fieldContext_CommentSearchEdge_snippet(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type CommentSearchEdge", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentSearchConnection_pageInfo(ctx context.Context, field graphql.CollectedField, obj *CommentSearchConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentSearchConnection_pageInfo(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.PageInfo, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*PageInfo)
+	fc.Result = res
+	return ec.marshalNPageInfo2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPageInfo(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentSearchConnection_pageInfo(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentSearchConnection",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "hasNextPage":
+				return ec.fieldContext_PageInfo_hasNextPage(ctx, field)
+			case "hasPreviousPage":
+				return ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
+			case "startCursor":
+				return ec.fieldContext_PageInfo_startCursor(ctx, field)
+			case "endCursor":
+				return ec.fieldContext_PageInfo_endCursor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PageInfo", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentSearchEdge_node(ctx context.Context, field graphql.CollectedField, obj *CommentSearchEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentSearchEdge_node(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Node, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*Comment)
+	fc.Result = res
+	return ec.marshalNComment2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐComment(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentSearchEdge_node(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentSearchEdge",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Comment_id(ctx, field)
+			case "postID":
+				return ec.fieldContext_Comment_postID(ctx, field)
+			case "parentID":
+				return ec.fieldContext_Comment_parentID(ctx, field)
+			case "content":
+				return ec.fieldContext_Comment_content(ctx, field)
+			case "authorID":
+				return ec.fieldContext_Comment_authorID(ctx, field)
+			case "depth":
+				return ec.fieldContext_Comment_depth(ctx, field)
+			case "language":
+				return ec.fieldContext_Comment_language(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Comment_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Comment_updatedAt(ctx, field)
+			case "isDeleted":
+				return ec.fieldContext_Comment_isDeleted(ctx, field)
+			case "deletedAt":
+				return ec.fieldContext_Comment_deletedAt(ctx, field)
+			case "children":
+				return ec.fieldContext_Comment_children(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Comment", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentSearchEdge_cursor(ctx context.Context, field graphql.CollectedField, obj *CommentSearchEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentSearchEdge_cursor(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Cursor, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentSearchEdge_cursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentSearchEdge",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentSearchEdge_rank(ctx context.Context, field graphql.CollectedField, obj *CommentSearchEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentSearchEdge_rank(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Rank, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	fc.Result = res
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentSearchEdge_rank(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentSearchEdge",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentSearchEdge_snippet(ctx context.Context, field graphql.CollectedField, obj *CommentSearchEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentSearchEdge_snippet(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Snippet, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentSearchEdge_snippet(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentSearchEdge",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentStats_totalComments(ctx context.Context, field graphql.CollectedField, obj *CommentStats) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentStats_totalComments(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TotalComments, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentStats_totalComments(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentStats",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentStats_rootComments(ctx context.Context, field graphql.CollectedField, obj *CommentStats) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentStats_rootComments(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.RootComments, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentStats_rootComments(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentStats",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentStats_replies(ctx context.Context, field graphql.CollectedField, obj *CommentStats) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentStats_replies(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Replies, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentStats_replies(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentStats",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentStats_maxDepth(ctx context.Context, field graphql.CollectedField, obj *CommentStats) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentStats_maxDepth(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.MaxDepth, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentStats_maxDepth(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentStats",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentStats_averageDepth(ctx context.Context, field graphql.CollectedField, obj *CommentStats) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentStats_averageDepth(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.AverageDepth, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	fc.Result = res
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentStats_averageDepth(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentStats",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentStats_uniqueCommenters(ctx context.Context, field graphql.CollectedField, obj *CommentStats) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentStats_uniqueCommenters(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.UniqueCommenters, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentStats_uniqueCommenters(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentStats",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentStats_lastCommentAt(ctx context.Context, field graphql.CollectedField, obj *CommentStats) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentStats_lastCommentAt(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.LastCommentAt, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*time.Time)
+	fc.Result = res
+	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentStats_lastCommentAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentStats",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentStats_depthHistogram(ctx context.Context, field graphql.CollectedField, obj *CommentStats) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentStats_depthHistogram(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.DepthHistogram, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*DepthCount)
+	fc.Result = res
+	return ec.marshalNDepthCount2ᚕᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐDepthCountᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentStats_depthHistogram(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentStats",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "depth":
+				return ec.fieldContext_DepthCount_depth(ctx, field)
+			case "count":
+				return ec.fieldContext_DepthCount_count(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type DepthCount", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentTree_nodes(ctx context.Context, field graphql.CollectedField, obj *CommentTree) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentTree_nodes(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Nodes, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*CommentTreeNode)
+	fc.Result = res
+	return ec.marshalNCommentTreeNode2ᚕᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentTreeNodeᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentTree_nodes(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentTree",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "comment":
+				return ec.fieldContext_CommentTreeNode_comment(ctx, field)
+			case "cursor":
+				return ec.fieldContext_CommentTreeNode_cursor(ctx, field)
+			case "replyCount":
+				return ec.fieldContext_CommentTreeNode_replyCount(ctx, field)
+			case "loadedReplyCount":
+				return ec.fieldContext_CommentTreeNode_loadedReplyCount(ctx, field)
+			case "moreRepliesCursor":
+				return ec.fieldContext_CommentTreeNode_moreRepliesCursor(ctx, field)
+			case "continueThread":
+				return ec.fieldContext_CommentTreeNode_continueThread(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type CommentTreeNode", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentTree_pageInfo(ctx context.Context, field graphql.CollectedField, obj *CommentTree) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentTree_pageInfo(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.PageInfo, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*PageInfo)
+	fc.Result = res
+	return ec.marshalNPageInfo2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPageInfo(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentTree_pageInfo(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentTree",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "hasNextPage":
+				return ec.fieldContext_PageInfo_hasNextPage(ctx, field)
+			case "hasPreviousPage":
+				return ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
+			case "startCursor":
+				return ec.fieldContext_PageInfo_startCursor(ctx, field)
+			case "endCursor":
+				return ec.fieldContext_PageInfo_endCursor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PageInfo", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CommentTreeNode_comment(ctx context.Context, field graphql.CollectedField, obj *CommentTreeNode) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentTreeNode_comment(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Comment, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*Comment)
+	fc.Result = res
+	return ec.marshalNComment2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐComment(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CommentTreeNode_comment(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CommentTreeNode",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Comment_id(ctx, field)
+			case "postID":
+				return ec.fieldContext_Comment_postID(ctx, field)
+			case "parentID":
+				return ec.fieldContext_Comment_parentID(ctx, field)
+			case "content":
+				return ec.fieldContext_Comment_content(ctx, field)
+			case "authorID":
+				return ec.fieldContext_Comment_authorID(ctx, field)
+			case "depth":
+				return ec.fieldContext_Comment_depth(ctx, field)
+			case "language":
+				return ec.fieldContext_Comment_language(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Comment_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Comment_updatedAt(ctx, field)
+			case "isDeleted":
+				return ec.fieldContext_Comment_isDeleted(ctx, field)
+			case "deletedAt":
+				return ec.fieldContext_Comment_deletedAt(ctx, field)
+			case "children":
+				return ec.fieldContext_Comment_children(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Comment", field.Name)
+		},
 	}
-
-	var zeroVal bool
-	return zeroVal, nil
+	return fc, nil
 }
 
-// endregion ***************************** args.gotpl *****************************
-
-// region    ************************** directives.gotpl **************************
-
-// endregion ************************** directives.gotpl **************************
-
-// region    **************************** field.gotpl *****************************
-
-func (ec *executionContext) _BatchDeleteResult_success(ctx context.Context, field graphql.CollectedField, obj *BatchDeleteResult) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_BatchDeleteResult_success(ctx, field)
+func (ec *executionContext) _CommentTreeNode_cursor(ctx context.Context, field graphql.CollectedField, obj *CommentTreeNode) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentTreeNode_cursor(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2794,7 +6986,7 @@ func (ec *executionContext) _BatchDeleteResult_success(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Success, nil
+		return obj.Cursor, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2806,26 +6998,26 @@ func (ec *executionContext) _BatchDeleteResult_success(ctx context.Context, fiel
 		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_BatchDeleteResult_success(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_CommentTreeNode_cursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "BatchDeleteResult",
+		Object:     "CommentTreeNode",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _BatchDeleteResult_deletedCount(ctx context.Context, field graphql.CollectedField, obj *BatchDeleteResult) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_BatchDeleteResult_deletedCount(ctx, field)
+func (ec *executionContext) _CommentTreeNode_replyCount(ctx context.Context, field graphql.CollectedField, obj *CommentTreeNode) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentTreeNode_replyCount(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2838,7 +7030,7 @@ func (ec *executionContext) _BatchDeleteResult_deletedCount(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.DeletedCount, nil
+		return obj.ReplyCount, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2855,9 +7047,9 @@ func (ec *executionContext) _BatchDeleteResult_deletedCount(ctx context.Context,
 	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_BatchDeleteResult_deletedCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_CommentTreeNode_replyCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "BatchDeleteResult",
+		Object:     "CommentTreeNode",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -2868,8 +7060,8 @@ func (ec *executionContext) fieldContext_BatchDeleteResult_deletedCount(_ contex
 	return fc, nil
 }
 
-func (ec *executionContext) _BatchDeleteResult_deletedIDs(ctx context.Context, field graphql.CollectedField, obj *BatchDeleteResult) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_BatchDeleteResult_deletedIDs(ctx, field)
+func (ec *executionContext) _CommentTreeNode_loadedReplyCount(ctx context.Context, field graphql.CollectedField, obj *CommentTreeNode) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentTreeNode_loadedReplyCount(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2882,7 +7074,7 @@ func (ec *executionContext) _BatchDeleteResult_deletedIDs(ctx context.Context, f
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.DeletedIDs, nil
+		return obj.LoadedReplyCount, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2894,26 +7086,26 @@ func (ec *executionContext) _BatchDeleteResult_deletedIDs(ctx context.Context, f
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNID2ᚕstringᚄ(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_BatchDeleteResult_deletedIDs(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_CommentTreeNode_loadedReplyCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "BatchDeleteResult",
+		Object:     "CommentTreeNode",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ID does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _BatchDeleteResult_errors(ctx context.Context, field graphql.CollectedField, obj *BatchDeleteResult) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_BatchDeleteResult_errors(ctx, field)
+func (ec *executionContext) _CommentTreeNode_moreRepliesCursor(ctx context.Context, field graphql.CollectedField, obj *CommentTreeNode) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentTreeNode_moreRepliesCursor(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2926,26 +7118,23 @@ func (ec *executionContext) _BatchDeleteResult_errors(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Errors, nil
+		return obj.MoreRepliesCursor, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]string)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_BatchDeleteResult_errors(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_CommentTreeNode_moreRepliesCursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "BatchDeleteResult",
+		Object:     "CommentTreeNode",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -2956,8 +7145,8 @@ func (ec *executionContext) fieldContext_BatchDeleteResult_errors(_ context.Cont
 	return fc, nil
 }
 
-func (ec *executionContext) _Comment_id(ctx context.Context, field graphql.CollectedField, obj *Comment) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Comment_id(ctx, field)
+func (ec *executionContext) _CommentTreeNode_continueThread(ctx context.Context, field graphql.CollectedField, obj *CommentTreeNode) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CommentTreeNode_continueThread(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2970,7 +7159,7 @@ func (ec *executionContext) _Comment_id(ctx context.Context, field graphql.Colle
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.ContinueThread, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2982,26 +7171,26 @@ func (ec *executionContext) _Comment_id(ctx context.Context, field graphql.Colle
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Comment_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_CommentTreeNode_continueThread(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Comment",
+		Object:     "CommentTreeNode",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ID does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Comment_postID(ctx context.Context, field graphql.CollectedField, obj *Comment) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Comment_postID(ctx, field)
+func (ec *executionContext) _DeleteResult_success(ctx context.Context, field graphql.CollectedField, obj *DeleteResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DeleteResult_success(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3014,7 +7203,7 @@ func (ec *executionContext) _Comment_postID(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PostID, nil
+		return obj.Success, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3026,26 +7215,26 @@ func (ec *executionContext) _Comment_postID(ctx context.Context, field graphql.C
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Comment_postID(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DeleteResult_success(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Comment",
+		Object:     "DeleteResult",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ID does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Comment_parentID(ctx context.Context, field graphql.CollectedField, obj *Comment) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Comment_parentID(ctx, field)
+func (ec *executionContext) _DeleteResult_deletedID(ctx context.Context, field graphql.CollectedField, obj *DeleteResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DeleteResult_deletedID(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3058,7 +7247,7 @@ func (ec *executionContext) _Comment_parentID(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ParentID, nil
+		return obj.DeletedID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3072,9 +7261,9 @@ func (ec *executionContext) _Comment_parentID(ctx context.Context, field graphql
 	return ec.marshalOID2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Comment_parentID(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DeleteResult_deletedID(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Comment",
+		Object:     "DeleteResult",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -3085,8 +7274,8 @@ func (ec *executionContext) fieldContext_Comment_parentID(_ context.Context, fie
 	return fc, nil
 }
 
-func (ec *executionContext) _Comment_content(ctx context.Context, field graphql.CollectedField, obj *Comment) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Comment_content(ctx, field)
+func (ec *executionContext) _DeleteResult_error(ctx context.Context, field graphql.CollectedField, obj *DeleteResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DeleteResult_error(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3099,26 +7288,23 @@ func (ec *executionContext) _Comment_content(ctx context.Context, field graphql.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Content, nil
+		return obj.Error, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Comment_content(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DeleteResult_error(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Comment",
+		Object:     "DeleteResult",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -3129,8 +7315,8 @@ func (ec *executionContext) fieldContext_Comment_content(_ context.Context, fiel
 	return fc, nil
 }
 
-func (ec *executionContext) _Comment_authorID(ctx context.Context, field graphql.CollectedField, obj *Comment) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Comment_authorID(ctx, field)
+func (ec *executionContext) _DeleteResult_tombstone(ctx context.Context, field graphql.CollectedField, obj *DeleteResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DeleteResult_tombstone(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3143,38 +7329,61 @@ func (ec *executionContext) _Comment_authorID(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.AuthorID, nil
+		return obj.Tombstone, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*Comment)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOComment2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐComment(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Comment_authorID(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DeleteResult_tombstone(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Comment",
+		Object:     "DeleteResult",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Comment_id(ctx, field)
+			case "postID":
+				return ec.fieldContext_Comment_postID(ctx, field)
+			case "parentID":
+				return ec.fieldContext_Comment_parentID(ctx, field)
+			case "content":
+				return ec.fieldContext_Comment_content(ctx, field)
+			case "authorID":
+				return ec.fieldContext_Comment_authorID(ctx, field)
+			case "depth":
+				return ec.fieldContext_Comment_depth(ctx, field)
+			case "language":
+				return ec.fieldContext_Comment_language(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Comment_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Comment_updatedAt(ctx, field)
+			case "isDeleted":
+				return ec.fieldContext_Comment_isDeleted(ctx, field)
+			case "deletedAt":
+				return ec.fieldContext_Comment_deletedAt(ctx, field)
+			case "children":
+				return ec.fieldContext_Comment_children(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Comment", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Comment_depth(ctx context.Context, field graphql.CollectedField, obj *Comment) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Comment_depth(ctx, field)
+func (ec *executionContext) _DepthCount_depth(ctx context.Context, field graphql.CollectedField, obj *DepthCount) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DepthCount_depth(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3204,9 +7413,9 @@ func (ec *executionContext) _Comment_depth(ctx context.Context, field graphql.Co
 	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Comment_depth(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DepthCount_depth(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Comment",
+		Object:     "DepthCount",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -3217,8 +7426,8 @@ func (ec *executionContext) fieldContext_Comment_depth(_ context.Context, field
 	return fc, nil
 }
 
-func (ec *executionContext) _Comment_createdAt(ctx context.Context, field graphql.CollectedField, obj *Comment) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Comment_createdAt(ctx, field)
+func (ec *executionContext) _DepthCount_count(ctx context.Context, field graphql.CollectedField, obj *DepthCount) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DepthCount_count(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3231,7 +7440,7 @@ func (ec *executionContext) _Comment_createdAt(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CreatedAt, nil
+		return obj.Count, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3243,26 +7452,26 @@ func (ec *executionContext) _Comment_createdAt(ctx context.Context, field graphq
 		}
 		return graphql.Null
 	}
-	res := resTmp.(time.Time)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Comment_createdAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DepthCount_count(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Comment",
+		Object:     "DepthCount",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Time does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Comment_updatedAt(ctx context.Context, field graphql.CollectedField, obj *Comment) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Comment_updatedAt(ctx, field)
+func (ec *executionContext) _Mutation_createPost(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_createPost(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3274,8 +7483,40 @@ func (ec *executionContext) _Comment_updatedAt(ctx context.Context, field graphq
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
-		ctx = rctx // use context from middleware stack in children
-		return obj.UpdatedAt, nil
+		directive0 := func(rctx context.Context) (any, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().CreatePost(rctx, fc.Args["input"].(PostInput))
+		}
+
+		directive1 := func(ctx context.Context) (any, error) {
+			max, err := ec.unmarshalNInt2int(ctx, 10)
+			if err != nil {
+				var zeroVal *PostResult
+				return zeroVal, err
+			}
+			window, err := ec.unmarshalNString2string(ctx, "1m")
+			if err != nil {
+				var zeroVal *PostResult
+				return zeroVal, err
+			}
+			if ec.directives.RateLimit == nil {
+				var zeroVal *PostResult
+				return zeroVal, errors.New("directive rateLimit is not implemented")
+			}
+			return ec.directives.RateLimit(ctx, nil, directive0, max, window)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*PostResult); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/NarthurN/habbr/internal/api/graphql/generated.PostResult`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3287,26 +7528,45 @@ func (ec *executionContext) _Comment_updatedAt(ctx context.Context, field graphq
 		}
 		return graphql.Null
 	}
-	res := resTmp.(time.Time)
+	res := resTmp.(*PostResult)
 	fc.Result = res
-	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
+	return ec.marshalNPostResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Comment_updatedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_createPost(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Comment",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Time does not have child fields")
+			switch field.Name {
+			case "success":
+				return ec.fieldContext_PostResult_success(ctx, field)
+			case "post":
+				return ec.fieldContext_PostResult_post(ctx, field)
+			case "error":
+				return ec.fieldContext_PostResult_error(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PostResult", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_createPost_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Comment_children(ctx context.Context, field graphql.CollectedField, obj *Comment) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Comment_children(ctx, field)
+func (ec *executionContext) _Mutation_updatePost(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_updatePost(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3319,7 +7579,7 @@ func (ec *executionContext) _Comment_children(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Children, nil
+		return ec.resolvers.Mutation().UpdatePost(rctx, fc.Args["id"].(string), fc.Args["input"].(PostUpdateInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3331,27 +7591,27 @@ func (ec *executionContext) _Comment_children(ctx context.Context, field graphql
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*CommentConnection)
+	res := resTmp.(*PostResult)
 	fc.Result = res
-	return ec.marshalNCommentConnection2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentConnection(ctx, field.Selections, res)
+	return ec.marshalNPostResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Comment_children(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_updatePost(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Comment",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "edges":
-				return ec.fieldContext_CommentConnection_edges(ctx, field)
-			case "pageInfo":
-				return ec.fieldContext_CommentConnection_pageInfo(ctx, field)
-			case "totalCount":
-				return ec.fieldContext_CommentConnection_totalCount(ctx, field)
+			case "success":
+				return ec.fieldContext_PostResult_success(ctx, field)
+			case "post":
+				return ec.fieldContext_PostResult_post(ctx, field)
+			case "error":
+				return ec.fieldContext_PostResult_error(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type CommentConnection", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type PostResult", field.Name)
 		},
 	}
 	defer func() {
@@ -3361,15 +7621,15 @@ func (ec *executionContext) fieldContext_Comment_children(ctx context.Context, f
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Comment_children_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_updatePost_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return fc, err
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _CommentConnection_edges(ctx context.Context, field graphql.CollectedField, obj *CommentConnection) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CommentConnection_edges(ctx, field)
+func (ec *executionContext) _Mutation_deletePost(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_deletePost(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3382,7 +7642,7 @@ func (ec *executionContext) _CommentConnection_edges(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Edges, nil
+		return ec.resolvers.Mutation().DeletePost(rctx, fc.Args["id"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3394,32 +7654,47 @@ func (ec *executionContext) _CommentConnection_edges(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*CommentEdge)
+	res := resTmp.(*DeleteResult)
 	fc.Result = res
-	return ec.marshalNCommentEdge2ᚕᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentEdgeᚄ(ctx, field.Selections, res)
+	return ec.marshalNDeleteResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐDeleteResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CommentConnection_edges(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_deletePost(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "CommentConnection",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "node":
-				return ec.fieldContext_CommentEdge_node(ctx, field)
-			case "cursor":
-				return ec.fieldContext_CommentEdge_cursor(ctx, field)
+			case "success":
+				return ec.fieldContext_DeleteResult_success(ctx, field)
+			case "deletedID":
+				return ec.fieldContext_DeleteResult_deletedID(ctx, field)
+			case "error":
+				return ec.fieldContext_DeleteResult_error(ctx, field)
+			case "tombstone":
+				return ec.fieldContext_DeleteResult_tombstone(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type CommentEdge", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type DeleteResult", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_deletePost_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _CommentConnection_pageInfo(ctx context.Context, field graphql.CollectedField, obj *CommentConnection) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CommentConnection_pageInfo(ctx, field)
+func (ec *executionContext) _Mutation_enableComments(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_enableComments(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3432,7 +7707,7 @@ func (ec *executionContext) _CommentConnection_pageInfo(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PageInfo, nil
+		return ec.resolvers.Mutation().EnableComments(rctx, fc.Args["postID"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3444,36 +7719,45 @@ func (ec *executionContext) _CommentConnection_pageInfo(ctx context.Context, fie
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*PageInfo)
+	res := resTmp.(*PostResult)
 	fc.Result = res
-	return ec.marshalNPageInfo2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPageInfo(ctx, field.Selections, res)
+	return ec.marshalNPostResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CommentConnection_pageInfo(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_enableComments(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "CommentConnection",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "hasNextPage":
-				return ec.fieldContext_PageInfo_hasNextPage(ctx, field)
-			case "hasPreviousPage":
-				return ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
-			case "startCursor":
-				return ec.fieldContext_PageInfo_startCursor(ctx, field)
-			case "endCursor":
-				return ec.fieldContext_PageInfo_endCursor(ctx, field)
+			case "success":
+				return ec.fieldContext_PostResult_success(ctx, field)
+			case "post":
+				return ec.fieldContext_PostResult_post(ctx, field)
+			case "error":
+				return ec.fieldContext_PostResult_error(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type PageInfo", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type PostResult", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_enableComments_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _CommentConnection_totalCount(ctx context.Context, field graphql.CollectedField, obj *CommentConnection) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CommentConnection_totalCount(ctx, field)
+func (ec *executionContext) _Mutation_disableComments(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_disableComments(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3486,7 +7770,7 @@ func (ec *executionContext) _CommentConnection_totalCount(ctx context.Context, f
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TotalCount, nil
+		return ec.resolvers.Mutation().DisableComments(rctx, fc.Args["postID"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3498,26 +7782,45 @@ func (ec *executionContext) _CommentConnection_totalCount(ctx context.Context, f
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*PostResult)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNPostResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CommentConnection_totalCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_disableComments(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "CommentConnection",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			switch field.Name {
+			case "success":
+				return ec.fieldContext_PostResult_success(ctx, field)
+			case "post":
+				return ec.fieldContext_PostResult_post(ctx, field)
+			case "error":
+				return ec.fieldContext_PostResult_error(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PostResult", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_disableComments_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _CommentEdge_node(ctx context.Context, field graphql.CollectedField, obj *CommentEdge) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CommentEdge_node(ctx, field)
+func (ec *executionContext) _Mutation_createComment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_createComment(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3529,8 +7832,40 @@ func (ec *executionContext) _CommentEdge_node(ctx context.Context, field graphql
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
-		ctx = rctx // use context from middleware stack in children
-		return obj.Node, nil
+		directive0 := func(rctx context.Context) (any, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().CreateComment(rctx, fc.Args["input"].(CommentInput))
+		}
+
+		directive1 := func(ctx context.Context) (any, error) {
+			max, err := ec.unmarshalNInt2int(ctx, 30)
+			if err != nil {
+				var zeroVal *CommentResult
+				return zeroVal, err
+			}
+			window, err := ec.unmarshalNString2string(ctx, "1m")
+			if err != nil {
+				var zeroVal *CommentResult
+				return zeroVal, err
+			}
+			if ec.directives.RateLimit == nil {
+				var zeroVal *CommentResult
+				return zeroVal, errors.New("directive rateLimit is not implemented")
+			}
+			return ec.directives.RateLimit(ctx, nil, directive0, max, window)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*CommentResult); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/NarthurN/habbr/internal/api/graphql/generated.CommentResult`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3542,46 +7877,45 @@ func (ec *executionContext) _CommentEdge_node(ctx context.Context, field graphql
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*Comment)
+	res := resTmp.(*CommentResult)
 	fc.Result = res
-	return ec.marshalNComment2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐComment(ctx, field.Selections, res)
+	return ec.marshalNCommentResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CommentEdge_node(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_createComment(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "CommentEdge",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "id":
-				return ec.fieldContext_Comment_id(ctx, field)
-			case "postID":
-				return ec.fieldContext_Comment_postID(ctx, field)
-			case "parentID":
-				return ec.fieldContext_Comment_parentID(ctx, field)
-			case "content":
-				return ec.fieldContext_Comment_content(ctx, field)
-			case "authorID":
-				return ec.fieldContext_Comment_authorID(ctx, field)
-			case "depth":
-				return ec.fieldContext_Comment_depth(ctx, field)
-			case "createdAt":
-				return ec.fieldContext_Comment_createdAt(ctx, field)
-			case "updatedAt":
-				return ec.fieldContext_Comment_updatedAt(ctx, field)
-			case "children":
-				return ec.fieldContext_Comment_children(ctx, field)
+			case "success":
+				return ec.fieldContext_CommentResult_success(ctx, field)
+			case "comment":
+				return ec.fieldContext_CommentResult_comment(ctx, field)
+			case "error":
+				return ec.fieldContext_CommentResult_error(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Comment", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type CommentResult", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_createComment_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _CommentEdge_cursor(ctx context.Context, field graphql.CollectedField, obj *CommentEdge) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CommentEdge_cursor(ctx, field)
+func (ec *executionContext) _Mutation_updateComment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_updateComment(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3594,7 +7928,7 @@ func (ec *executionContext) _CommentEdge_cursor(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Cursor, nil
+		return ec.resolvers.Mutation().UpdateComment(rctx, fc.Args["id"].(string), fc.Args["input"].(CommentUpdateInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3606,26 +7940,45 @@ func (ec *executionContext) _CommentEdge_cursor(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*CommentResult)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNCommentResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CommentEdge_cursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_updateComment(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "CommentEdge",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "success":
+				return ec.fieldContext_CommentResult_success(ctx, field)
+			case "comment":
+				return ec.fieldContext_CommentResult_comment(ctx, field)
+			case "error":
+				return ec.fieldContext_CommentResult_error(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type CommentResult", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_updateComment_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _CommentEvent_type(ctx context.Context, field graphql.CollectedField, obj *CommentEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CommentEvent_type(ctx, field)
+func (ec *executionContext) _Mutation_deleteComment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_deleteComment(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3638,7 +7991,7 @@ func (ec *executionContext) _CommentEvent_type(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Type, nil
+		return ec.resolvers.Mutation().DeleteComment(rctx, fc.Args["id"].(string), fc.Args["soft"].(*bool))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3650,26 +8003,47 @@ func (ec *executionContext) _CommentEvent_type(ctx context.Context, field graphq
 		}
 		return graphql.Null
 	}
-	res := resTmp.(CommentEventType)
+	res := resTmp.(*DeleteResult)
 	fc.Result = res
-	return ec.marshalNCommentEventType2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentEventType(ctx, field.Selections, res)
+	return ec.marshalNDeleteResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐDeleteResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CommentEvent_type(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_deleteComment(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "CommentEvent",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type CommentEventType does not have child fields")
+			switch field.Name {
+			case "success":
+				return ec.fieldContext_DeleteResult_success(ctx, field)
+			case "deletedID":
+				return ec.fieldContext_DeleteResult_deletedID(ctx, field)
+			case "error":
+				return ec.fieldContext_DeleteResult_error(ctx, field)
+			case "tombstone":
+				return ec.fieldContext_DeleteResult_tombstone(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type DeleteResult", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_deleteComment_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _CommentEvent_comment(ctx context.Context, field graphql.CollectedField, obj *CommentEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CommentEvent_comment(ctx, field)
+func (ec *executionContext) _Mutation_deleteCommentsBatch(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_deleteCommentsBatch(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3682,7 +8056,7 @@ func (ec *executionContext) _CommentEvent_comment(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Comment, nil
+		return ec.resolvers.Mutation().DeleteCommentsBatch(rctx, fc.Args["postID"].(string), fc.Args["commentIDs"].([]string), fc.Args["atomic"].(*bool))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3694,46 +8068,49 @@ func (ec *executionContext) _CommentEvent_comment(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*Comment)
+	res := resTmp.(*CommentBatchDeleteResult)
 	fc.Result = res
-	return ec.marshalNComment2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐComment(ctx, field.Selections, res)
+	return ec.marshalNCommentBatchDeleteResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentBatchDeleteResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CommentEvent_comment(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_deleteCommentsBatch(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "CommentEvent",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "id":
-				return ec.fieldContext_Comment_id(ctx, field)
-			case "postID":
-				return ec.fieldContext_Comment_postID(ctx, field)
-			case "parentID":
-				return ec.fieldContext_Comment_parentID(ctx, field)
-			case "content":
-				return ec.fieldContext_Comment_content(ctx, field)
-			case "authorID":
-				return ec.fieldContext_Comment_authorID(ctx, field)
-			case "depth":
-				return ec.fieldContext_Comment_depth(ctx, field)
-			case "createdAt":
-				return ec.fieldContext_Comment_createdAt(ctx, field)
-			case "updatedAt":
-				return ec.fieldContext_Comment_updatedAt(ctx, field)
-			case "children":
-				return ec.fieldContext_Comment_children(ctx, field)
+			case "success":
+				return ec.fieldContext_CommentBatchDeleteResult_success(ctx, field)
+			case "deletedCount":
+				return ec.fieldContext_CommentBatchDeleteResult_deletedCount(ctx, field)
+			case "deletedIDs":
+				return ec.fieldContext_CommentBatchDeleteResult_deletedIDs(ctx, field)
+			case "results":
+				return ec.fieldContext_CommentBatchDeleteResult_results(ctx, field)
+			case "error":
+				return ec.fieldContext_CommentBatchDeleteResult_error(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Comment", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type CommentBatchDeleteResult", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_deleteCommentsBatch_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _CommentEvent_postID(ctx context.Context, field graphql.CollectedField, obj *CommentEvent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CommentEvent_postID(ctx, field)
+func (ec *executionContext) _Mutation_deleteCommentsTree(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_deleteCommentsTree(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3746,7 +8123,7 @@ func (ec *executionContext) _CommentEvent_postID(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PostID, nil
+		return ec.resolvers.Mutation().DeleteCommentsTree(rctx, fc.Args["commentID"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3758,26 +8135,47 @@ func (ec *executionContext) _CommentEvent_postID(ctx context.Context, field grap
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*BatchDeleteResult)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalNBatchDeleteResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐBatchDeleteResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CommentEvent_postID(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_deleteCommentsTree(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "CommentEvent",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ID does not have child fields")
+			switch field.Name {
+			case "success":
+				return ec.fieldContext_BatchDeleteResult_success(ctx, field)
+			case "deletedCount":
+				return ec.fieldContext_BatchDeleteResult_deletedCount(ctx, field)
+			case "deletedIDs":
+				return ec.fieldContext_BatchDeleteResult_deletedIDs(ctx, field)
+			case "errors":
+				return ec.fieldContext_BatchDeleteResult_errors(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type BatchDeleteResult", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_deleteCommentsTree_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _CommentResult_success(ctx context.Context, field graphql.CollectedField, obj *CommentResult) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CommentResult_success(ctx, field)
+func (ec *executionContext) _PageInfo_hasNextPage(ctx context.Context, field graphql.CollectedField, obj *PageInfo) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PageInfo_hasNextPage(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3790,7 +8188,7 @@ func (ec *executionContext) _CommentResult_success(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Success, nil
+		return obj.HasNextPage, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3807,9 +8205,9 @@ func (ec *executionContext) _CommentResult_success(ctx context.Context, field gr
 	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CommentResult_success(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PageInfo_hasNextPage(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "CommentResult",
+		Object:     "PageInfo",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -3820,8 +8218,8 @@ func (ec *executionContext) fieldContext_CommentResult_success(_ context.Context
 	return fc, nil
 }
 
-func (ec *executionContext) _CommentResult_comment(ctx context.Context, field graphql.CollectedField, obj *CommentResult) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CommentResult_comment(ctx, field)
+func (ec *executionContext) _PageInfo_hasPreviousPage(ctx context.Context, field graphql.CollectedField, obj *PageInfo) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3834,55 +8232,38 @@ func (ec *executionContext) _CommentResult_comment(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Comment, nil
+		return obj.HasPreviousPage, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*Comment)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOComment2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐComment(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CommentResult_comment(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PageInfo_hasPreviousPage(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "CommentResult",
+		Object:     "PageInfo",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Comment_id(ctx, field)
-			case "postID":
-				return ec.fieldContext_Comment_postID(ctx, field)
-			case "parentID":
-				return ec.fieldContext_Comment_parentID(ctx, field)
-			case "content":
-				return ec.fieldContext_Comment_content(ctx, field)
-			case "authorID":
-				return ec.fieldContext_Comment_authorID(ctx, field)
-			case "depth":
-				return ec.fieldContext_Comment_depth(ctx, field)
-			case "createdAt":
-				return ec.fieldContext_Comment_createdAt(ctx, field)
-			case "updatedAt":
-				return ec.fieldContext_Comment_updatedAt(ctx, field)
-			case "children":
-				return ec.fieldContext_Comment_children(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Comment", field.Name)
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _CommentResult_error(ctx context.Context, field graphql.CollectedField, obj *CommentResult) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CommentResult_error(ctx, field)
+func (ec *executionContext) _PageInfo_startCursor(ctx context.Context, field graphql.CollectedField, obj *PageInfo) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PageInfo_startCursor(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3895,7 +8276,7 @@ func (ec *executionContext) _CommentResult_error(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Error, nil
+		return obj.StartCursor, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3909,9 +8290,9 @@ func (ec *executionContext) _CommentResult_error(ctx context.Context, field grap
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CommentResult_error(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PageInfo_startCursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "CommentResult",
+		Object:     "PageInfo",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -3922,8 +8303,8 @@ func (ec *executionContext) fieldContext_CommentResult_error(_ context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _CommentStats_totalComments(ctx context.Context, field graphql.CollectedField, obj *CommentStats) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CommentStats_totalComments(ctx, field)
+func (ec *executionContext) _PageInfo_endCursor(ctx context.Context, field graphql.CollectedField, obj *PageInfo) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PageInfo_endCursor(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3936,38 +8317,35 @@ func (ec *executionContext) _CommentStats_totalComments(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TotalComments, nil
+		return obj.EndCursor, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CommentStats_totalComments(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PageInfo_endCursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "CommentStats",
+		Object:     "PageInfo",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _CommentStats_maxDepth(ctx context.Context, field graphql.CollectedField, obj *CommentStats) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CommentStats_maxDepth(ctx, field)
+func (ec *executionContext) _Post_id(ctx context.Context, field graphql.CollectedField, obj *Post) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Post_id(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3980,7 +8358,7 @@ func (ec *executionContext) _CommentStats_maxDepth(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.MaxDepth, nil
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3992,26 +8370,26 @@ func (ec *executionContext) _CommentStats_maxDepth(ctx context.Context, field gr
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CommentStats_maxDepth(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Post_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "CommentStats",
+		Object:     "Post",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			return nil, errors.New("field of type ID does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _CommentStats_averageDepth(ctx context.Context, field graphql.CollectedField, obj *CommentStats) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CommentStats_averageDepth(ctx, field)
+func (ec *executionContext) _Post_title(ctx context.Context, field graphql.CollectedField, obj *Post) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Post_title(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4024,7 +8402,7 @@ func (ec *executionContext) _CommentStats_averageDepth(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.AverageDepth, nil
+		return obj.Title, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4036,26 +8414,26 @@ func (ec *executionContext) _CommentStats_averageDepth(ctx context.Context, fiel
 		}
 		return graphql.Null
 	}
-	res := resTmp.(float64)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CommentStats_averageDepth(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Post_title(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "CommentStats",
+		Object:     "Post",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Float does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DeleteResult_success(ctx context.Context, field graphql.CollectedField, obj *DeleteResult) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DeleteResult_success(ctx, field)
+func (ec *executionContext) _Post_content(ctx context.Context, field graphql.CollectedField, obj *Post) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Post_content(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4068,7 +8446,7 @@ func (ec *executionContext) _DeleteResult_success(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Success, nil
+		return obj.Content, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4080,26 +8458,26 @@ func (ec *executionContext) _DeleteResult_success(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DeleteResult_success(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Post_content(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DeleteResult",
+		Object:     "Post",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DeleteResult_deletedID(ctx context.Context, field graphql.CollectedField, obj *DeleteResult) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DeleteResult_deletedID(ctx, field)
+func (ec *executionContext) _Post_authorID(ctx context.Context, field graphql.CollectedField, obj *Post) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Post_authorID(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4112,35 +8490,38 @@ func (ec *executionContext) _DeleteResult_deletedID(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.DeletedID, nil
+		return obj.AuthorID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOID2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DeleteResult_deletedID(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Post_authorID(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DeleteResult",
+		Object:     "Post",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ID does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DeleteResult_error(ctx context.Context, field graphql.CollectedField, obj *DeleteResult) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DeleteResult_error(ctx, field)
+func (ec *executionContext) _Post_commentsEnabled(ctx context.Context, field graphql.CollectedField, obj *Post) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Post_commentsEnabled(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4153,35 +8534,38 @@ func (ec *executionContext) _DeleteResult_error(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Error, nil
+		return obj.CommentsEnabled, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DeleteResult_error(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Post_commentsEnabled(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DeleteResult",
+		Object:     "Post",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_createPost(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_createPost(ctx, field)
+func (ec *executionContext) _Post_language(ctx context.Context, field graphql.CollectedField, obj *Post) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Post_language(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4194,7 +8578,7 @@ func (ec *executionContext) _Mutation_createPost(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().CreatePost(rctx, fc.Args["input"].(PostInput))
+		return obj.Language, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4206,45 +8590,26 @@ func (ec *executionContext) _Mutation_createPost(ctx context.Context, field grap
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*PostResult)
+	res := resTmp.(Language)
 	fc.Result = res
-	return ec.marshalNPostResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostResult(ctx, field.Selections, res)
+	return ec.marshalNLanguage2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐLanguage(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_createPost(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Post_language(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "Post",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "success":
-				return ec.fieldContext_PostResult_success(ctx, field)
-			case "post":
-				return ec.fieldContext_PostResult_post(ctx, field)
-			case "error":
-				return ec.fieldContext_PostResult_error(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type PostResult", field.Name)
+			return nil, errors.New("field of type Language does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_createPost_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_updatePost(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_updatePost(ctx, field)
+func (ec *executionContext) _Post_createdAt(ctx context.Context, field graphql.CollectedField, obj *Post) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Post_createdAt(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4257,7 +8622,7 @@ func (ec *executionContext) _Mutation_updatePost(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().UpdatePost(rctx, fc.Args["id"].(string), fc.Args["input"].(PostUpdateInput))
+		return obj.CreatedAt, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4269,45 +8634,26 @@ func (ec *executionContext) _Mutation_updatePost(ctx context.Context, field grap
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*PostResult)
+	res := resTmp.(time.Time)
 	fc.Result = res
-	return ec.marshalNPostResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostResult(ctx, field.Selections, res)
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_updatePost(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Post_createdAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "Post",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "success":
-				return ec.fieldContext_PostResult_success(ctx, field)
-			case "post":
-				return ec.fieldContext_PostResult_post(ctx, field)
-			case "error":
-				return ec.fieldContext_PostResult_error(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type PostResult", field.Name)
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_updatePost_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_deletePost(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_deletePost(ctx, field)
+func (ec *executionContext) _Post_updatedAt(ctx context.Context, field graphql.CollectedField, obj *Post) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Post_updatedAt(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4320,7 +8666,7 @@ func (ec *executionContext) _Mutation_deletePost(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().DeletePost(rctx, fc.Args["id"].(string))
+		return obj.UpdatedAt, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4332,45 +8678,26 @@ func (ec *executionContext) _Mutation_deletePost(ctx context.Context, field grap
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*DeleteResult)
+	res := resTmp.(time.Time)
 	fc.Result = res
-	return ec.marshalNDeleteResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐDeleteResult(ctx, field.Selections, res)
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_deletePost(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Post_updatedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "Post",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "success":
-				return ec.fieldContext_DeleteResult_success(ctx, field)
-			case "deletedID":
-				return ec.fieldContext_DeleteResult_deletedID(ctx, field)
-			case "error":
-				return ec.fieldContext_DeleteResult_error(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type DeleteResult", field.Name)
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_deletePost_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_enableComments(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_enableComments(ctx, field)
+func (ec *executionContext) _Post_comments(ctx context.Context, field graphql.CollectedField, obj *Post) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Post_comments(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4383,7 +8710,7 @@ func (ec *executionContext) _Mutation_enableComments(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().EnableComments(rctx, fc.Args["postID"].(string))
+		return ec.resolvers.Post().Comments(rctx, obj, fc.Args["first"].(*int), fc.Args["after"].(*string), fc.Args["last"].(*int), fc.Args["before"].(*string), fc.Args["filter"].(*CommentFilter))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4395,27 +8722,27 @@ func (ec *executionContext) _Mutation_enableComments(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*PostResult)
+	res := resTmp.(*CommentConnection)
 	fc.Result = res
-	return ec.marshalNPostResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostResult(ctx, field.Selections, res)
+	return ec.marshalNCommentConnection2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentConnection(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_enableComments(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Post_comments(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "Post",
 		Field:      field,
 		IsMethod:   true,
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "success":
-				return ec.fieldContext_PostResult_success(ctx, field)
-			case "post":
-				return ec.fieldContext_PostResult_post(ctx, field)
-			case "error":
-				return ec.fieldContext_PostResult_error(ctx, field)
+			case "edges":
+				return ec.fieldContext_CommentConnection_edges(ctx, field)
+			case "pageInfo":
+				return ec.fieldContext_CommentConnection_pageInfo(ctx, field)
+			case "totalCount":
+				return ec.fieldContext_CommentConnection_totalCount(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type PostResult", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type CommentConnection", field.Name)
 		},
 	}
 	defer func() {
@@ -4425,15 +8752,15 @@ func (ec *executionContext) fieldContext_Mutation_enableComments(ctx context.Con
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_enableComments_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Post_comments_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return fc, err
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_disableComments(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_disableComments(ctx, field)
+func (ec *executionContext) _PostAnalytics_postID(ctx context.Context, field graphql.CollectedField, obj *PostAnalytics) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostAnalytics_postID(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4446,7 +8773,7 @@ func (ec *executionContext) _Mutation_disableComments(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().DisableComments(rctx, fc.Args["postID"].(string))
+		return obj.PostID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4458,45 +8785,26 @@ func (ec *executionContext) _Mutation_disableComments(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*PostResult)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNPostResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostResult(ctx, field.Selections, res)
+	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_disableComments(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostAnalytics_postID(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "PostAnalytics",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "success":
-				return ec.fieldContext_PostResult_success(ctx, field)
-			case "post":
-				return ec.fieldContext_PostResult_post(ctx, field)
-			case "error":
-				return ec.fieldContext_PostResult_error(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type PostResult", field.Name)
+			return nil, errors.New("field of type ID does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_disableComments_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_createComment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_createComment(ctx, field)
+func (ec *executionContext) _PostAnalytics_title(ctx context.Context, field graphql.CollectedField, obj *PostAnalytics) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostAnalytics_title(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4509,7 +8817,7 @@ func (ec *executionContext) _Mutation_createComment(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().CreateComment(rctx, fc.Args["input"].(CommentInput))
+		return obj.Title, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4521,45 +8829,26 @@ func (ec *executionContext) _Mutation_createComment(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*CommentResult)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNCommentResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentResult(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_createComment(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostAnalytics_title(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "PostAnalytics",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "success":
-				return ec.fieldContext_CommentResult_success(ctx, field)
-			case "comment":
-				return ec.fieldContext_CommentResult_comment(ctx, field)
-			case "error":
-				return ec.fieldContext_CommentResult_error(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type CommentResult", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_createComment_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_updateComment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_updateComment(ctx, field)
+func (ec *executionContext) _PostAnalytics_authorID(ctx context.Context, field graphql.CollectedField, obj *PostAnalytics) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostAnalytics_authorID(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4572,7 +8861,7 @@ func (ec *executionContext) _Mutation_updateComment(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().UpdateComment(rctx, fc.Args["id"].(string), fc.Args["input"].(CommentUpdateInput))
+		return obj.AuthorID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4584,45 +8873,26 @@ func (ec *executionContext) _Mutation_updateComment(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*CommentResult)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNCommentResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentResult(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_updateComment(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostAnalytics_authorID(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
-		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
-		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "success":
-				return ec.fieldContext_CommentResult_success(ctx, field)
-			case "comment":
-				return ec.fieldContext_CommentResult_comment(ctx, field)
-			case "error":
-				return ec.fieldContext_CommentResult_error(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type CommentResult", field.Name)
-		},
-	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_updateComment_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
+		Object:     "PostAnalytics",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_deleteComment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_deleteComment(ctx, field)
+func (ec *executionContext) _PostAnalytics_createdAt(ctx context.Context, field graphql.CollectedField, obj *PostAnalytics) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostAnalytics_createdAt(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4635,7 +8905,7 @@ func (ec *executionContext) _Mutation_deleteComment(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().DeleteComment(rctx, fc.Args["id"].(string))
+		return obj.CreatedAt, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4647,45 +8917,26 @@ func (ec *executionContext) _Mutation_deleteComment(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*DeleteResult)
+	res := resTmp.(time.Time)
 	fc.Result = res
-	return ec.marshalNDeleteResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐDeleteResult(ctx, field.Selections, res)
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_deleteComment(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostAnalytics_createdAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "PostAnalytics",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "success":
-				return ec.fieldContext_DeleteResult_success(ctx, field)
-			case "deletedID":
-				return ec.fieldContext_DeleteResult_deletedID(ctx, field)
-			case "error":
-				return ec.fieldContext_DeleteResult_error(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type DeleteResult", field.Name)
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_deleteComment_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_deleteCommentsBatch(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_deleteCommentsBatch(ctx, field)
+func (ec *executionContext) _PostAnalytics_totalComments(ctx context.Context, field graphql.CollectedField, obj *PostAnalytics) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostAnalytics_totalComments(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4698,7 +8949,7 @@ func (ec *executionContext) _Mutation_deleteCommentsBatch(ctx context.Context, f
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().DeleteCommentsBatch(rctx, fc.Args["postID"].(string), fc.Args["commentIDs"].([]string))
+		return obj.TotalComments, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4710,47 +8961,26 @@ func (ec *executionContext) _Mutation_deleteCommentsBatch(ctx context.Context, f
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*BatchDeleteResult)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNBatchDeleteResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐBatchDeleteResult(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_deleteCommentsBatch(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostAnalytics_totalComments(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "PostAnalytics",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "success":
-				return ec.fieldContext_BatchDeleteResult_success(ctx, field)
-			case "deletedCount":
-				return ec.fieldContext_BatchDeleteResult_deletedCount(ctx, field)
-			case "deletedIDs":
-				return ec.fieldContext_BatchDeleteResult_deletedIDs(ctx, field)
-			case "errors":
-				return ec.fieldContext_BatchDeleteResult_errors(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type BatchDeleteResult", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_deleteCommentsBatch_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_deleteCommentsTree(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_deleteCommentsTree(ctx, field)
+func (ec *executionContext) _PostAnalytics_rootComments(ctx context.Context, field graphql.CollectedField, obj *PostAnalytics) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostAnalytics_rootComments(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4763,7 +8993,7 @@ func (ec *executionContext) _Mutation_deleteCommentsTree(ctx context.Context, fi
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().DeleteCommentsTree(rctx, fc.Args["commentID"].(string))
+		return obj.RootComments, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4775,47 +9005,26 @@ func (ec *executionContext) _Mutation_deleteCommentsTree(ctx context.Context, fi
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*BatchDeleteResult)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNBatchDeleteResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐBatchDeleteResult(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_deleteCommentsTree(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostAnalytics_rootComments(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "PostAnalytics",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "success":
-				return ec.fieldContext_BatchDeleteResult_success(ctx, field)
-			case "deletedCount":
-				return ec.fieldContext_BatchDeleteResult_deletedCount(ctx, field)
-			case "deletedIDs":
-				return ec.fieldContext_BatchDeleteResult_deletedIDs(ctx, field)
-			case "errors":
-				return ec.fieldContext_BatchDeleteResult_errors(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type BatchDeleteResult", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_deleteCommentsTree_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PageInfo_hasNextPage(ctx context.Context, field graphql.CollectedField, obj *PageInfo) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PageInfo_hasNextPage(ctx, field)
+func (ec *executionContext) _PostAnalytics_maxCommentDepth(ctx context.Context, field graphql.CollectedField, obj *PostAnalytics) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostAnalytics_maxCommentDepth(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4828,7 +9037,7 @@ func (ec *executionContext) _PageInfo_hasNextPage(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.HasNextPage, nil
+		return obj.MaxCommentDepth, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4840,26 +9049,26 @@ func (ec *executionContext) _PageInfo_hasNextPage(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PageInfo_hasNextPage(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostAnalytics_maxCommentDepth(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PageInfo",
+		Object:     "PostAnalytics",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PageInfo_hasPreviousPage(ctx context.Context, field graphql.CollectedField, obj *PageInfo) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
+func (ec *executionContext) _PostAnalytics_lastCommentAt(ctx context.Context, field graphql.CollectedField, obj *PostAnalytics) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostAnalytics_lastCommentAt(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4872,38 +9081,35 @@ func (ec *executionContext) _PageInfo_hasPreviousPage(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.HasPreviousPage, nil
+		return obj.LastCommentAt, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*time.Time)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PageInfo_hasPreviousPage(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostAnalytics_lastCommentAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PageInfo",
+		Object:     "PostAnalytics",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PageInfo_startCursor(ctx context.Context, field graphql.CollectedField, obj *PageInfo) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PageInfo_startCursor(ctx, field)
+func (ec *executionContext) _PostConnection_edges(ctx context.Context, field graphql.CollectedField, obj *PostConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostConnection_edges(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4916,35 +9122,44 @@ func (ec *executionContext) _PageInfo_startCursor(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.StartCursor, nil
+		return obj.Edges, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.([]*PostEdge)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNPostEdge2ᚕᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostEdgeᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PageInfo_startCursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostConnection_edges(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PageInfo",
+		Object:     "PostConnection",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "node":
+				return ec.fieldContext_PostEdge_node(ctx, field)
+			case "cursor":
+				return ec.fieldContext_PostEdge_cursor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PostEdge", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PageInfo_endCursor(ctx context.Context, field graphql.CollectedField, obj *PageInfo) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PageInfo_endCursor(ctx, field)
+func (ec *executionContext) _PostConnection_pageInfo(ctx context.Context, field graphql.CollectedField, obj *PostConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostConnection_pageInfo(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4957,35 +9172,48 @@ func (ec *executionContext) _PageInfo_endCursor(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.EndCursor, nil
+		return obj.PageInfo, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*PageInfo)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNPageInfo2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPageInfo(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PageInfo_endCursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostConnection_pageInfo(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PageInfo",
+		Object:     "PostConnection",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "hasNextPage":
+				return ec.fieldContext_PageInfo_hasNextPage(ctx, field)
+			case "hasPreviousPage":
+				return ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
+			case "startCursor":
+				return ec.fieldContext_PageInfo_startCursor(ctx, field)
+			case "endCursor":
+				return ec.fieldContext_PageInfo_endCursor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PageInfo", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Post_id(ctx context.Context, field graphql.CollectedField, obj *Post) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Post_id(ctx, field)
+func (ec *executionContext) _PostConnection_totalCount(ctx context.Context, field graphql.CollectedField, obj *PostConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostConnection_totalCount(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4998,7 +9226,7 @@ func (ec *executionContext) _Post_id(ctx context.Context, field graphql.Collecte
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.TotalCount, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5010,26 +9238,26 @@ func (ec *executionContext) _Post_id(ctx context.Context, field graphql.Collecte
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Post_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostConnection_totalCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Post",
+		Object:     "PostConnection",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ID does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Post_title(ctx context.Context, field graphql.CollectedField, obj *Post) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Post_title(ctx, field)
+func (ec *executionContext) _PostEdge_node(ctx context.Context, field graphql.CollectedField, obj *PostEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostEdge_node(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5042,7 +9270,7 @@ func (ec *executionContext) _Post_title(ctx context.Context, field graphql.Colle
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Title, nil
+		return obj.Node, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5054,26 +9282,46 @@ func (ec *executionContext) _Post_title(ctx context.Context, field graphql.Colle
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*Post)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNPost2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPost(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Post_title(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostEdge_node(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Post",
+		Object:     "PostEdge",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Post_id(ctx, field)
+			case "title":
+				return ec.fieldContext_Post_title(ctx, field)
+			case "content":
+				return ec.fieldContext_Post_content(ctx, field)
+			case "authorID":
+				return ec.fieldContext_Post_authorID(ctx, field)
+			case "commentsEnabled":
+				return ec.fieldContext_Post_commentsEnabled(ctx, field)
+			case "language":
+				return ec.fieldContext_Post_language(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Post_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Post_updatedAt(ctx, field)
+			case "comments":
+				return ec.fieldContext_Post_comments(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Post", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Post_content(ctx context.Context, field graphql.CollectedField, obj *Post) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Post_content(ctx, field)
+func (ec *executionContext) _PostEdge_cursor(ctx context.Context, field graphql.CollectedField, obj *PostEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostEdge_cursor(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5086,7 +9334,7 @@ func (ec *executionContext) _Post_content(ctx context.Context, field graphql.Col
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Content, nil
+		return obj.Cursor, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5103,9 +9351,9 @@ func (ec *executionContext) _Post_content(ctx context.Context, field graphql.Col
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Post_content(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostEdge_cursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Post",
+		Object:     "PostEdge",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -5116,8 +9364,8 @@ func (ec *executionContext) fieldContext_Post_content(_ context.Context, field g
 	return fc, nil
 }
 
-func (ec *executionContext) _Post_authorID(ctx context.Context, field graphql.CollectedField, obj *Post) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Post_authorID(ctx, field)
+func (ec *executionContext) _PostEvent_type(ctx context.Context, field graphql.CollectedField, obj *PostEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostEvent_type(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5130,7 +9378,7 @@ func (ec *executionContext) _Post_authorID(ctx context.Context, field graphql.Co
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.AuthorID, nil
+		return obj.Type, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5142,26 +9390,26 @@ func (ec *executionContext) _Post_authorID(ctx context.Context, field graphql.Co
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(PostEventType)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNPostEventType2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostEventType(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Post_authorID(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostEvent_type(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Post",
+		Object:     "PostEvent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type PostEventType does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Post_commentsEnabled(ctx context.Context, field graphql.CollectedField, obj *Post) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Post_commentsEnabled(ctx, field)
+func (ec *executionContext) _PostEvent_post(ctx context.Context, field graphql.CollectedField, obj *PostEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostEvent_post(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5174,7 +9422,7 @@ func (ec *executionContext) _Post_commentsEnabled(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CommentsEnabled, nil
+		return obj.Post, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5186,26 +9434,46 @@ func (ec *executionContext) _Post_commentsEnabled(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*Post)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNPost2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPost(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Post_commentsEnabled(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostEvent_post(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Post",
+		Object:     "PostEvent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Post_id(ctx, field)
+			case "title":
+				return ec.fieldContext_Post_title(ctx, field)
+			case "content":
+				return ec.fieldContext_Post_content(ctx, field)
+			case "authorID":
+				return ec.fieldContext_Post_authorID(ctx, field)
+			case "commentsEnabled":
+				return ec.fieldContext_Post_commentsEnabled(ctx, field)
+			case "language":
+				return ec.fieldContext_Post_language(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Post_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Post_updatedAt(ctx, field)
+			case "comments":
+				return ec.fieldContext_Post_comments(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Post", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Post_createdAt(ctx context.Context, field graphql.CollectedField, obj *Post) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Post_createdAt(ctx, field)
+func (ec *executionContext) _PostEvent_postID(ctx context.Context, field graphql.CollectedField, obj *PostEvent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostEvent_postID(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5218,7 +9486,7 @@ func (ec *executionContext) _Post_createdAt(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CreatedAt, nil
+		return obj.PostID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5230,26 +9498,26 @@ func (ec *executionContext) _Post_createdAt(ctx context.Context, field graphql.C
 		}
 		return graphql.Null
 	}
-	res := resTmp.(time.Time)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
+	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Post_createdAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostEvent_postID(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Post",
+		Object:     "PostEvent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Time does not have child fields")
+			return nil, errors.New("field of type ID does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Post_updatedAt(ctx context.Context, field graphql.CollectedField, obj *Post) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Post_updatedAt(ctx, field)
+func (ec *executionContext) _PostResult_success(ctx context.Context, field graphql.CollectedField, obj *PostResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostResult_success(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5262,7 +9530,7 @@ func (ec *executionContext) _Post_updatedAt(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.UpdatedAt, nil
+		return obj.Success, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5274,26 +9542,26 @@ func (ec *executionContext) _Post_updatedAt(ctx context.Context, field graphql.C
 		}
 		return graphql.Null
 	}
-	res := resTmp.(time.Time)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Post_updatedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostResult_success(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Post",
+		Object:     "PostResult",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Time does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Post_comments(ctx context.Context, field graphql.CollectedField, obj *Post) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Post_comments(ctx, field)
+func (ec *executionContext) _PostResult_post(ctx context.Context, field graphql.CollectedField, obj *PostResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostResult_post(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5306,57 +9574,55 @@ func (ec *executionContext) _Post_comments(ctx context.Context, field graphql.Co
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Comments, nil
+		return obj.Post, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*CommentConnection)
+	res := resTmp.(*Post)
 	fc.Result = res
-	return ec.marshalNCommentConnection2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentConnection(ctx, field.Selections, res)
+	return ec.marshalOPost2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPost(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Post_comments(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostResult_post(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Post",
+		Object:     "PostResult",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "edges":
-				return ec.fieldContext_CommentConnection_edges(ctx, field)
-			case "pageInfo":
-				return ec.fieldContext_CommentConnection_pageInfo(ctx, field)
-			case "totalCount":
-				return ec.fieldContext_CommentConnection_totalCount(ctx, field)
+			case "id":
+				return ec.fieldContext_Post_id(ctx, field)
+			case "title":
+				return ec.fieldContext_Post_title(ctx, field)
+			case "content":
+				return ec.fieldContext_Post_content(ctx, field)
+			case "authorID":
+				return ec.fieldContext_Post_authorID(ctx, field)
+			case "commentsEnabled":
+				return ec.fieldContext_Post_commentsEnabled(ctx, field)
+			case "language":
+				return ec.fieldContext_Post_language(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Post_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Post_updatedAt(ctx, field)
+			case "comments":
+				return ec.fieldContext_Post_comments(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type CommentConnection", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Post", field.Name)
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Post_comments_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PostConnection_edges(ctx context.Context, field graphql.CollectedField, obj *PostConnection) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PostConnection_edges(ctx, field)
+func (ec *executionContext) _PostResult_error(ctx context.Context, field graphql.CollectedField, obj *PostResult) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostResult_error(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5369,44 +9635,35 @@ func (ec *executionContext) _PostConnection_edges(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Edges, nil
+		return obj.Error, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]*PostEdge)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNPostEdge2ᚕᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostEdgeᚄ(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PostConnection_edges(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostResult_error(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PostConnection",
+		Object:     "PostResult",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "node":
-				return ec.fieldContext_PostEdge_node(ctx, field)
-			case "cursor":
-				return ec.fieldContext_PostEdge_cursor(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type PostEdge", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PostConnection_pageInfo(ctx context.Context, field graphql.CollectedField, obj *PostConnection) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PostConnection_pageInfo(ctx, field)
+func (ec *executionContext) _PostSearchConnection_edges(ctx context.Context, field graphql.CollectedField, obj *PostSearchConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostSearchConnection_edges(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5419,7 +9676,7 @@ func (ec *executionContext) _PostConnection_pageInfo(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PageInfo, nil
+		return obj.Edges, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5431,36 +9688,36 @@ func (ec *executionContext) _PostConnection_pageInfo(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*PageInfo)
+	res := resTmp.([]*PostSearchEdge)
 	fc.Result = res
-	return ec.marshalNPageInfo2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPageInfo(ctx, field.Selections, res)
+	return ec.marshalNPostSearchEdge2ᚕᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostSearchEdgeᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PostConnection_pageInfo(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostSearchConnection_edges(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PostConnection",
+		Object:     "PostSearchConnection",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "hasNextPage":
-				return ec.fieldContext_PageInfo_hasNextPage(ctx, field)
-			case "hasPreviousPage":
-				return ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
-			case "startCursor":
-				return ec.fieldContext_PageInfo_startCursor(ctx, field)
-			case "endCursor":
-				return ec.fieldContext_PageInfo_endCursor(ctx, field)
+			case "node":
+				return ec.fieldContext_PostSearchEdge_node(ctx, field)
+			case "cursor":
+				return ec.fieldContext_PostSearchEdge_cursor(ctx, field)
+			case "rank":
+				return ec.fieldContext_PostSearchEdge_rank(ctx, field)
+			case "snippet":
+				return ec.fieldContext_PostSearchEdge_snippet(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type PageInfo", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type PostSearchEdge", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PostConnection_totalCount(ctx context.Context, field graphql.CollectedField, obj *PostConnection) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PostConnection_totalCount(ctx, field)
+func (ec *executionContext) _PostSearchConnection_pageInfo(ctx context.Context, field graphql.CollectedField, obj *PostSearchConnection) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostSearchConnection_pageInfo(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5473,7 +9730,7 @@ func (ec *executionContext) _PostConnection_totalCount(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TotalCount, nil
+		return obj.PageInfo, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5485,26 +9742,36 @@ func (ec *executionContext) _PostConnection_totalCount(ctx context.Context, fiel
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*PageInfo)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNPageInfo2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPageInfo(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PostConnection_totalCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostSearchConnection_pageInfo(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PostConnection",
+		Object:     "PostSearchConnection",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			switch field.Name {
+			case "hasNextPage":
+				return ec.fieldContext_PageInfo_hasNextPage(ctx, field)
+			case "hasPreviousPage":
+				return ec.fieldContext_PageInfo_hasPreviousPage(ctx, field)
+			case "startCursor":
+				return ec.fieldContext_PageInfo_startCursor(ctx, field)
+			case "endCursor":
+				return ec.fieldContext_PageInfo_endCursor(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PageInfo", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PostEdge_node(ctx context.Context, field graphql.CollectedField, obj *PostEdge) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PostEdge_node(ctx, field)
+func (ec *executionContext) _PostSearchEdge_node(ctx context.Context, field graphql.CollectedField, obj *PostSearchEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostSearchEdge_node(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5534,9 +9801,9 @@ func (ec *executionContext) _PostEdge_node(ctx context.Context, field graphql.Co
 	return ec.marshalNPost2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPost(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PostEdge_node(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostSearchEdge_node(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PostEdge",
+		Object:     "PostSearchEdge",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -5552,6 +9819,8 @@ func (ec *executionContext) fieldContext_PostEdge_node(_ context.Context, field
 				return ec.fieldContext_Post_authorID(ctx, field)
 			case "commentsEnabled":
 				return ec.fieldContext_Post_commentsEnabled(ctx, field)
+			case "language":
+				return ec.fieldContext_Post_language(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_Post_createdAt(ctx, field)
 			case "updatedAt":
@@ -5565,8 +9834,8 @@ func (ec *executionContext) fieldContext_PostEdge_node(_ context.Context, field
 	return fc, nil
 }
 
-func (ec *executionContext) _PostEdge_cursor(ctx context.Context, field graphql.CollectedField, obj *PostEdge) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PostEdge_cursor(ctx, field)
+func (ec *executionContext) _PostSearchEdge_cursor(ctx context.Context, field graphql.CollectedField, obj *PostSearchEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostSearchEdge_cursor(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5596,9 +9865,9 @@ func (ec *executionContext) _PostEdge_cursor(ctx context.Context, field graphql.
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PostEdge_cursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostSearchEdge_cursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PostEdge",
+		Object:     "PostSearchEdge",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -5609,8 +9878,8 @@ func (ec *executionContext) fieldContext_PostEdge_cursor(_ context.Context, fiel
 	return fc, nil
 }
 
-func (ec *executionContext) _PostResult_success(ctx context.Context, field graphql.CollectedField, obj *PostResult) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PostResult_success(ctx, field)
+func (ec *executionContext) _PostSearchEdge_rank(ctx context.Context, field graphql.CollectedField, obj *PostSearchEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostSearchEdge_rank(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5623,7 +9892,7 @@ func (ec *executionContext) _PostResult_success(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Success, nil
+		return obj.Rank, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5635,26 +9904,26 @@ func (ec *executionContext) _PostResult_success(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(float64)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PostResult_success(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostSearchEdge_rank(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PostResult",
+		Object:     "PostSearchEdge",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PostResult_post(ctx context.Context, field graphql.CollectedField, obj *PostResult) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PostResult_post(ctx, field)
+func (ec *executionContext) _PostSearchEdge_snippet(ctx context.Context, field graphql.CollectedField, obj *PostSearchEdge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostSearchEdge_snippet(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5667,53 +9936,82 @@ func (ec *executionContext) _PostResult_post(ctx context.Context, field graphql.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Post, nil
+		return obj.Snippet, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*Post)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOPost2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPost(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PostResult_post(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostSearchEdge_snippet(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PostResult",
+		Object:     "PostSearchEdge",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Post_id(ctx, field)
-			case "title":
-				return ec.fieldContext_Post_title(ctx, field)
-			case "content":
-				return ec.fieldContext_Post_content(ctx, field)
-			case "authorID":
-				return ec.fieldContext_Post_authorID(ctx, field)
-			case "commentsEnabled":
-				return ec.fieldContext_Post_commentsEnabled(ctx, field)
-			case "createdAt":
-				return ec.fieldContext_Post_createdAt(ctx, field)
-			case "updatedAt":
-				return ec.fieldContext_Post_updatedAt(ctx, field)
-			case "comments":
-				return ec.fieldContext_Post_comments(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Post", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _PostStats_totalComments(ctx context.Context, field graphql.CollectedField, obj *PostStats) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostStats_totalComments(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TotalComments, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_PostStats_totalComments(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PostStats",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PostResult_error(ctx context.Context, field graphql.CollectedField, obj *PostResult) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PostResult_error(ctx, field)
+func (ec *executionContext) _PostStats_rootComments(ctx context.Context, field graphql.CollectedField, obj *PostStats) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostStats_rootComments(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5726,35 +10024,38 @@ func (ec *executionContext) _PostResult_error(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Error, nil
+		return obj.RootComments, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PostResult_error(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostStats_rootComments(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PostResult",
+		Object:     "PostStats",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PostStats_totalComments(ctx context.Context, field graphql.CollectedField, obj *PostStats) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PostStats_totalComments(ctx, field)
+func (ec *executionContext) _PostStats_replies(ctx context.Context, field graphql.CollectedField, obj *PostStats) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PostStats_replies(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5767,7 +10068,7 @@ func (ec *executionContext) _PostStats_totalComments(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TotalComments, nil
+		return obj.Replies, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5784,7 +10085,7 @@ func (ec *executionContext) _PostStats_totalComments(ctx context.Context, field
 	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PostStats_totalComments(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PostStats_replies(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "PostStats",
 		Field:      field,
@@ -5896,7 +10197,7 @@ func (ec *executionContext) _Query_posts(ctx context.Context, field graphql.Coll
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Posts(rctx, fc.Args["first"].(*int), fc.Args["after"].(*string), fc.Args["last"].(*int), fc.Args["before"].(*string), fc.Args["filter"].(*PostFilter))
+		return ec.resolvers.Query().Posts(rctx, fc.Args["first"].(*int), fc.Args["after"].(*string), fc.Args["last"].(*int), fc.Args["before"].(*string), fc.Args["filter"].(*PostFilter), fc.Args["orderBy"].(*PostOrder))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5991,6 +10292,8 @@ func (ec *executionContext) fieldContext_Query_post(ctx context.Context, field g
 				return ec.fieldContext_Post_authorID(ctx, field)
 			case "commentsEnabled":
 				return ec.fieldContext_Post_commentsEnabled(ctx, field)
+			case "language":
+				return ec.fieldContext_Post_language(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_Post_createdAt(ctx, field)
 			case "updatedAt":
@@ -6029,7 +10332,7 @@ func (ec *executionContext) _Query_comments(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Comments(rctx, fc.Args["postID"].(string), fc.Args["first"].(*int), fc.Args["after"].(*string), fc.Args["last"].(*int), fc.Args["before"].(*string), fc.Args["filter"].(*CommentFilter))
+		return ec.resolvers.Query().Comments(rctx, fc.Args["postID"].(string), fc.Args["first"].(*int), fc.Args["after"].(*string), fc.Args["last"].(*int), fc.Args["before"].(*string), fc.Args["filter"].(*CommentFilter), fc.Args["orderBy"].(*CommentOrder))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6126,10 +10429,16 @@ func (ec *executionContext) fieldContext_Query_comment(ctx context.Context, fiel
 				return ec.fieldContext_Comment_authorID(ctx, field)
 			case "depth":
 				return ec.fieldContext_Comment_depth(ctx, field)
+			case "language":
+				return ec.fieldContext_Comment_language(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_Comment_createdAt(ctx, field)
 			case "updatedAt":
 				return ec.fieldContext_Comment_updatedAt(ctx, field)
+			case "isDeleted":
+				return ec.fieldContext_Comment_isDeleted(ctx, field)
+			case "deletedAt":
+				return ec.fieldContext_Comment_deletedAt(ctx, field)
 			case "children":
 				return ec.fieldContext_Comment_children(ctx, field)
 			}
@@ -6150,6 +10459,73 @@ func (ec *executionContext) fieldContext_Query_comment(ctx context.Context, fiel
 	return fc, nil
 }
 
+func (ec *executionContext) _Query_commentContext(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_commentContext(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().CommentContext(rctx, fc.Args["id"].(string), fc.Args["ancestors"].(*int), fc.Args["replies"].(*int))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*CommentContext)
+	fc.Result = res
+	return ec.marshalNCommentContext2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentContext(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Query_commentContext(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "comment":
+				return ec.fieldContext_CommentContext_comment(ctx, field)
+			case "ancestors":
+				return ec.fieldContext_CommentContext_ancestors(ctx, field)
+			case "hasMoreAncestors":
+				return ec.fieldContext_CommentContext_hasMoreAncestors(ctx, field)
+			case "replies":
+				return ec.fieldContext_CommentContext_replies(ctx, field)
+			case "totalReplies":
+				return ec.fieldContext_CommentContext_totalReplies(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type CommentContext", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_commentContext_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Query_commentTree(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Query_commentTree(ctx, field)
 	if err != nil {
@@ -6164,7 +10540,7 @@ func (ec *executionContext) _Query_commentTree(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().CommentTree(rctx, fc.Args["postID"].(string), fc.Args["maxDepth"].(*int), fc.Args["filter"].(*CommentFilter))
+		return ec.resolvers.Query().CommentTree(rctx, fc.Args["postID"].(string), fc.Args["parentID"].(*string), fc.Args["maxDepth"].(*int), fc.Args["filter"].(*CommentFilter), fc.Args["orderBy"].(*CommentOrder), fc.Args["first"].(*int), fc.Args["after"].(*string), fc.Args["repliesFirst"].(*int))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6176,9 +10552,9 @@ func (ec *executionContext) _Query_commentTree(ctx context.Context, field graphq
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*Comment)
+	res := resTmp.(*CommentTree)
 	fc.Result = res
-	return ec.marshalNComment2ᚕᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentᚄ(ctx, field.Selections, res)
+	return ec.marshalNCommentTree2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentTree(ctx, field.Selections, res)
 }
 
 func (ec *executionContext) fieldContext_Query_commentTree(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
@@ -6189,26 +10565,12 @@ func (ec *executionContext) fieldContext_Query_commentTree(ctx context.Context,
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "id":
-				return ec.fieldContext_Comment_id(ctx, field)
-			case "postID":
-				return ec.fieldContext_Comment_postID(ctx, field)
-			case "parentID":
-				return ec.fieldContext_Comment_parentID(ctx, field)
-			case "content":
-				return ec.fieldContext_Comment_content(ctx, field)
-			case "authorID":
-				return ec.fieldContext_Comment_authorID(ctx, field)
-			case "depth":
-				return ec.fieldContext_Comment_depth(ctx, field)
-			case "createdAt":
-				return ec.fieldContext_Comment_createdAt(ctx, field)
-			case "updatedAt":
-				return ec.fieldContext_Comment_updatedAt(ctx, field)
-			case "children":
-				return ec.fieldContext_Comment_children(ctx, field)
+			case "nodes":
+				return ec.fieldContext_CommentTree_nodes(ctx, field)
+			case "pageInfo":
+				return ec.fieldContext_CommentTree_pageInfo(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Comment", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type CommentTree", field.Name)
 		},
 	}
 	defer func() {
@@ -6263,6 +10625,10 @@ func (ec *executionContext) fieldContext_Query_postStats(ctx context.Context, fi
 			switch field.Name {
 			case "totalComments":
 				return ec.fieldContext_PostStats_totalComments(ctx, field)
+			case "rootComments":
+				return ec.fieldContext_PostStats_rootComments(ctx, field)
+			case "replies":
+				return ec.fieldContext_PostStats_replies(ctx, field)
 			case "commentsEnabled":
 				return ec.fieldContext_PostStats_commentsEnabled(ctx, field)
 			case "lastCommentAt":
@@ -6323,10 +10689,20 @@ func (ec *executionContext) fieldContext_Query_commentStats(ctx context.Context,
 			switch field.Name {
 			case "totalComments":
 				return ec.fieldContext_CommentStats_totalComments(ctx, field)
+			case "rootComments":
+				return ec.fieldContext_CommentStats_rootComments(ctx, field)
+			case "replies":
+				return ec.fieldContext_CommentStats_replies(ctx, field)
 			case "maxDepth":
 				return ec.fieldContext_CommentStats_maxDepth(ctx, field)
 			case "averageDepth":
 				return ec.fieldContext_CommentStats_averageDepth(ctx, field)
+			case "uniqueCommenters":
+				return ec.fieldContext_CommentStats_uniqueCommenters(ctx, field)
+			case "lastCommentAt":
+				return ec.fieldContext_CommentStats_lastCommentAt(ctx, field)
+			case "depthHistogram":
+				return ec.fieldContext_CommentStats_depthHistogram(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type CommentStats", field.Name)
 		},
@@ -6345,6 +10721,79 @@ func (ec *executionContext) fieldContext_Query_commentStats(ctx context.Context,
 	return fc, nil
 }
 
+func (ec *executionContext) _Query_postAnalytics(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_postAnalytics(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().PostAnalytics(rctx, fc.Args["filter"].(*PostAnalyticsFilter), fc.Args["orderBy"].(*PostAnalyticsOrder), fc.Args["first"].(*int))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*PostAnalytics)
+	fc.Result = res
+	return ec.marshalNPostAnalytics2ᚕᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostAnalyticsᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Query_postAnalytics(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "postID":
+				return ec.fieldContext_PostAnalytics_postID(ctx, field)
+			case "title":
+				return ec.fieldContext_PostAnalytics_title(ctx, field)
+			case "authorID":
+				return ec.fieldContext_PostAnalytics_authorID(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_PostAnalytics_createdAt(ctx, field)
+			case "totalComments":
+				return ec.fieldContext_PostAnalytics_totalComments(ctx, field)
+			case "rootComments":
+				return ec.fieldContext_PostAnalytics_rootComments(ctx, field)
+			case "maxCommentDepth":
+				return ec.fieldContext_PostAnalytics_maxCommentDepth(ctx, field)
+			case "lastCommentAt":
+				return ec.fieldContext_PostAnalytics_lastCommentAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PostAnalytics", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_postAnalytics_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Query_searchPosts(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Query_searchPosts(ctx, field)
 	if err != nil {
@@ -6359,7 +10808,7 @@ func (ec *executionContext) _Query_searchPosts(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().SearchPosts(rctx, fc.Args["query"].(string), fc.Args["first"].(*int), fc.Args["after"].(*string))
+		return ec.resolvers.Query().SearchPosts(rctx, fc.Args["query"].(string), fc.Args["language"].(*Language), fc.Args["first"].(*int), fc.Args["after"].(*string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6371,9 +10820,9 @@ func (ec *executionContext) _Query_searchPosts(ctx context.Context, field graphq
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*PostConnection)
+	res := resTmp.(*PostSearchConnection)
 	fc.Result = res
-	return ec.marshalNPostConnection2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostConnection(ctx, field.Selections, res)
+	return ec.marshalNPostSearchConnection2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostSearchConnection(ctx, field.Selections, res)
 }
 
 func (ec *executionContext) fieldContext_Query_searchPosts(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
@@ -6385,13 +10834,11 @@ func (ec *executionContext) fieldContext_Query_searchPosts(ctx context.Context,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
 			case "edges":
-				return ec.fieldContext_PostConnection_edges(ctx, field)
+				return ec.fieldContext_PostSearchConnection_edges(ctx, field)
 			case "pageInfo":
-				return ec.fieldContext_PostConnection_pageInfo(ctx, field)
-			case "totalCount":
-				return ec.fieldContext_PostConnection_totalCount(ctx, field)
+				return ec.fieldContext_PostSearchConnection_pageInfo(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type PostConnection", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type PostSearchConnection", field.Name)
 		},
 	}
 	defer func() {
@@ -6422,7 +10869,7 @@ func (ec *executionContext) _Query_searchComments(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().SearchComments(rctx, fc.Args["postID"].(string), fc.Args["query"].(string), fc.Args["first"].(*int), fc.Args["after"].(*string))
+		return ec.resolvers.Query().SearchComments(rctx, fc.Args["postID"].(string), fc.Args["query"].(string), fc.Args["language"].(*Language), fc.Args["first"].(*int), fc.Args["after"].(*string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6434,9 +10881,9 @@ func (ec *executionContext) _Query_searchComments(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*CommentConnection)
+	res := resTmp.(*CommentSearchConnection)
 	fc.Result = res
-	return ec.marshalNCommentConnection2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentConnection(ctx, field.Selections, res)
+	return ec.marshalNCommentSearchConnection2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentSearchConnection(ctx, field.Selections, res)
 }
 
 func (ec *executionContext) fieldContext_Query_searchComments(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
@@ -6448,13 +10895,11 @@ func (ec *executionContext) fieldContext_Query_searchComments(ctx context.Contex
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
 			case "edges":
-				return ec.fieldContext_CommentConnection_edges(ctx, field)
+				return ec.fieldContext_CommentSearchConnection_edges(ctx, field)
 			case "pageInfo":
-				return ec.fieldContext_CommentConnection_pageInfo(ctx, field)
-			case "totalCount":
-				return ec.fieldContext_CommentConnection_totalCount(ctx, field)
+				return ec.fieldContext_CommentSearchConnection_pageInfo(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type CommentConnection", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type CommentSearchConnection", field.Name)
 		},
 	}
 	defer func() {
@@ -6616,7 +11061,7 @@ func (ec *executionContext) _Subscription_commentEvents(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Subscription().CommentEvents(rctx, fc.Args["postID"].(string))
+		return ec.resolvers.Subscription().CommentEvents(rctx, fc.Args["postID"].(string), fc.Args["afterEventID"].(*string), fc.Args["slowConsumer"].(*SlowConsumerPolicy))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6661,6 +11106,8 @@ func (ec *executionContext) fieldContext_Subscription_commentEvents(ctx context.
 				return ec.fieldContext_CommentEvent_comment(ctx, field)
 			case "postID":
 				return ec.fieldContext_CommentEvent_postID(ctx, field)
+			case "eventID":
+				return ec.fieldContext_CommentEvent_eventID(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type CommentEvent", field.Name)
 		},
@@ -6692,8 +11139,35 @@ func (ec *executionContext) _Subscription_allCommentEvents(ctx context.Context,
 		}
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Subscription().AllCommentEvents(rctx)
+		directive0 := func(rctx context.Context) (any, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Subscription().AllCommentEvents(rctx, fc.Args["slowConsumer"].(*SlowConsumerPolicy))
+		}
+
+		directive1 := func(ctx context.Context) (any, error) {
+			role, err := ec.unmarshalOString2ᚖstring(ctx, "admin")
+			if err != nil {
+				var zeroVal *CommentEvent
+				return zeroVal, err
+			}
+			if ec.directives.Auth == nil {
+				var zeroVal *CommentEvent
+				return zeroVal, errors.New("directive auth is not implemented")
+			}
+			return ec.directives.Auth(ctx, nil, directive0, role)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(<-chan *CommentEvent); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be <-chan *github.com/NarthurN/habbr/internal/api/graphql/generated.CommentEvent`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6724,7 +11198,7 @@ func (ec *executionContext) _Subscription_allCommentEvents(ctx context.Context,
 	}
 }
 
-func (ec *executionContext) fieldContext_Subscription_allCommentEvents(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Subscription_allCommentEvents(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Subscription",
 		Field:      field,
@@ -6738,10 +11212,23 @@ func (ec *executionContext) fieldContext_Subscription_allCommentEvents(_ context
 				return ec.fieldContext_CommentEvent_comment(ctx, field)
 			case "postID":
 				return ec.fieldContext_CommentEvent_postID(ctx, field)
+			case "eventID":
+				return ec.fieldContext_CommentEvent_eventID(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type CommentEvent", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Subscription_allCommentEvents_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
@@ -6759,7 +11246,7 @@ func (ec *executionContext) _Subscription_newPosts(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Subscription().NewPosts(rctx)
+		return ec.resolvers.Subscription().NewPosts(rctx, fc.Args["slowConsumer"].(*SlowConsumerPolicy))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6790,7 +11277,7 @@ func (ec *executionContext) _Subscription_newPosts(ctx context.Context, field gr
 	}
 }
 
-func (ec *executionContext) fieldContext_Subscription_newPosts(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Subscription_newPosts(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Subscription",
 		Field:      field,
@@ -6808,6 +11295,8 @@ func (ec *executionContext) fieldContext_Subscription_newPosts(_ context.Context
 				return ec.fieldContext_Post_authorID(ctx, field)
 			case "commentsEnabled":
 				return ec.fieldContext_Post_commentsEnabled(ctx, field)
+			case "language":
+				return ec.fieldContext_Post_language(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_Post_createdAt(ctx, field)
 			case "updatedAt":
@@ -6818,6 +11307,17 @@ func (ec *executionContext) fieldContext_Subscription_newPosts(_ context.Context
 			return nil, fmt.Errorf("no field named %q was found under type Post", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Subscription_newPosts_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
@@ -6835,7 +11335,7 @@ func (ec *executionContext) _Subscription_postUpdates(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Subscription().PostUpdates(rctx, fc.Args["postID"].(string))
+		return ec.resolvers.Subscription().PostUpdates(rctx, fc.Args["postID"].(string), fc.Args["slowConsumer"].(*SlowConsumerPolicy))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6849,7 +11349,7 @@ func (ec *executionContext) _Subscription_postUpdates(ctx context.Context, field
 	}
 	return func(ctx context.Context) graphql.Marshaler {
 		select {
-		case res, ok := <-resTmp.(<-chan *Post):
+		case res, ok := <-resTmp.(<-chan *PostEvent):
 			if !ok {
 				return nil
 			}
@@ -6857,7 +11357,7 @@ func (ec *executionContext) _Subscription_postUpdates(ctx context.Context, field
 				w.Write([]byte{'{'})
 				graphql.MarshalString(field.Alias).MarshalGQL(w)
 				w.Write([]byte{':'})
-				ec.marshalNPost2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPost(ctx, field.Selections, res).MarshalGQL(w)
+				ec.marshalNPostEvent2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostEvent(ctx, field.Selections, res).MarshalGQL(w)
 				w.Write([]byte{'}'})
 			})
 		case <-ctx.Done():
@@ -6874,24 +11374,14 @@ func (ec *executionContext) fieldContext_Subscription_postUpdates(ctx context.Co
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "id":
-				return ec.fieldContext_Post_id(ctx, field)
-			case "title":
-				return ec.fieldContext_Post_title(ctx, field)
-			case "content":
-				return ec.fieldContext_Post_content(ctx, field)
-			case "authorID":
-				return ec.fieldContext_Post_authorID(ctx, field)
-			case "commentsEnabled":
-				return ec.fieldContext_Post_commentsEnabled(ctx, field)
-			case "createdAt":
-				return ec.fieldContext_Post_createdAt(ctx, field)
-			case "updatedAt":
-				return ec.fieldContext_Post_updatedAt(ctx, field)
-			case "comments":
-				return ec.fieldContext_Post_comments(ctx, field)
+			case "type":
+				return ec.fieldContext_PostEvent_type(ctx, field)
+			case "post":
+				return ec.fieldContext_PostEvent_post(ctx, field)
+			case "postID":
+				return ec.fieldContext_PostEvent_postID(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Post", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type PostEvent", field.Name)
 		},
 	}
 	defer func() {
@@ -6922,7 +11412,7 @@ func (ec *executionContext) _Subscription_postStatsUpdates(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Subscription().PostStatsUpdates(rctx, fc.Args["postID"].(string))
+		return ec.resolvers.Subscription().PostStatsUpdates(rctx, fc.Args["postID"].(string), fc.Args["slowConsumer"].(*SlowConsumerPolicy))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6963,6 +11453,10 @@ func (ec *executionContext) fieldContext_Subscription_postStatsUpdates(ctx conte
 			switch field.Name {
 			case "totalComments":
 				return ec.fieldContext_PostStats_totalComments(ctx, field)
+			case "rootComments":
+				return ec.fieldContext_PostStats_rootComments(ctx, field)
+			case "replies":
+				return ec.fieldContext_PostStats_replies(ctx, field)
 			case "commentsEnabled":
 				return ec.fieldContext_PostStats_commentsEnabled(ctx, field)
 			case "lastCommentAt":
@@ -9052,7 +13546,55 @@ func (ec *executionContext) unmarshalInputCommentUpdateInput(ctx context.Context
 			if err != nil {
 				return it, err
 			}
-			it.Content = data
+			it.Content = data
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputPostAnalyticsFilter(ctx context.Context, obj any) (PostAnalyticsFilter, error) {
+	var it PostAnalyticsFilter
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
+		asMap[k] = v
+	}
+
+	fieldsInOrder := [...]string{"authorID", "createdAfter", "createdBefore", "minComments"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "authorID":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("authorID"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.AuthorID = data
+		case "createdAfter":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("createdAfter"))
+			data, err := ec.unmarshalOTime2ᚖtimeᚐTime(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.CreatedAfter = data
+		case "createdBefore":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("createdBefore"))
+			data, err := ec.unmarshalOTime2ᚖtimeᚐTime(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.CreatedBefore = data
+		case "minComments":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("minComments"))
+			data, err := ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.MinComments = data
 		}
 	}
 
@@ -9118,7 +13660,7 @@ func (ec *executionContext) unmarshalInputPostInput(ctx context.Context, obj any
 		asMap["commentsEnabled"] = true
 	}
 
-	fieldsInOrder := [...]string{"title", "content", "authorID", "commentsEnabled"}
+	fieldsInOrder := [...]string{"title", "content", "authorID", "commentsEnabled", "language"}
 	for _, k := range fieldsInOrder {
 		v, ok := asMap[k]
 		if !ok {
@@ -9153,6 +13695,13 @@ func (ec *executionContext) unmarshalInputPostInput(ctx context.Context, obj any
 				return it, err
 			}
 			it.CommentsEnabled = data
+		case "language":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("language"))
+			data, err := ec.unmarshalOLanguage2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐLanguage(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Language = data
 		}
 	}
 
@@ -9166,7 +13715,7 @@ func (ec *executionContext) unmarshalInputPostUpdateInput(ctx context.Context, o
 		asMap[k] = v
 	}
 
-	fieldsInOrder := [...]string{"title", "content", "commentsEnabled"}
+	fieldsInOrder := [...]string{"title", "content", "commentsEnabled", "language"}
 	for _, k := range fieldsInOrder {
 		v, ok := asMap[k]
 		if !ok {
@@ -9194,6 +13743,13 @@ func (ec *executionContext) unmarshalInputPostUpdateInput(ctx context.Context, o
 				return it, err
 			}
 			it.CommentsEnabled = data
+		case "language":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("language"))
+			data, err := ec.unmarshalOLanguage2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐLanguage(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Language = data
 		}
 	}
 
@@ -9210,32 +13766,315 @@ func (ec *executionContext) unmarshalInputPostUpdateInput(ctx context.Context, o
 
 var batchDeleteResultImplementors = []string{"BatchDeleteResult"}
 
-func (ec *executionContext) _BatchDeleteResult(ctx context.Context, sel ast.SelectionSet, obj *BatchDeleteResult) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, batchDeleteResultImplementors)
+func (ec *executionContext) _BatchDeleteResult(ctx context.Context, sel ast.SelectionSet, obj *BatchDeleteResult) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, batchDeleteResultImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("BatchDeleteResult")
+		case "success":
+			out.Values[i] = ec._BatchDeleteResult_success(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "deletedCount":
+			out.Values[i] = ec._BatchDeleteResult_deletedCount(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "deletedIDs":
+			out.Values[i] = ec._BatchDeleteResult_deletedIDs(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "errors":
+			out.Values[i] = ec._BatchDeleteResult_errors(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var commentImplementors = []string{"Comment"}
+
+func (ec *executionContext) _Comment(ctx context.Context, sel ast.SelectionSet, obj *Comment) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, commentImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Comment")
+		case "id":
+			out.Values[i] = ec._Comment_id(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "postID":
+			out.Values[i] = ec._Comment_postID(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "parentID":
+			out.Values[i] = ec._Comment_parentID(ctx, field, obj)
+		case "content":
+			out.Values[i] = ec._Comment_content(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "authorID":
+			out.Values[i] = ec._Comment_authorID(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "depth":
+			out.Values[i] = ec._Comment_depth(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "language":
+			out.Values[i] = ec._Comment_language(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "createdAt":
+			out.Values[i] = ec._Comment_createdAt(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "updatedAt":
+			out.Values[i] = ec._Comment_updatedAt(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "isDeleted":
+			out.Values[i] = ec._Comment_isDeleted(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "deletedAt":
+			out.Values[i] = ec._Comment_deletedAt(ctx, field, obj)
+		case "children":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Comment_children(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var commentBatchDeleteResultImplementors = []string{"CommentBatchDeleteResult"}
+
+func (ec *executionContext) _CommentBatchDeleteResult(ctx context.Context, sel ast.SelectionSet, obj *CommentBatchDeleteResult) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, commentBatchDeleteResultImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("CommentBatchDeleteResult")
+		case "success":
+			out.Values[i] = ec._CommentBatchDeleteResult_success(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "deletedCount":
+			out.Values[i] = ec._CommentBatchDeleteResult_deletedCount(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "deletedIDs":
+			out.Values[i] = ec._CommentBatchDeleteResult_deletedIDs(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "results":
+			out.Values[i] = ec._CommentBatchDeleteResult_results(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "error":
+			out.Values[i] = ec._CommentBatchDeleteResult_error(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var commentConnectionImplementors = []string{"CommentConnection"}
+
+func (ec *executionContext) _CommentConnection(ctx context.Context, sel ast.SelectionSet, obj *CommentConnection) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, commentConnectionImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("CommentConnection")
+		case "edges":
+			out.Values[i] = ec._CommentConnection_edges(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "pageInfo":
+			out.Values[i] = ec._CommentConnection_pageInfo(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "totalCount":
+			out.Values[i] = ec._CommentConnection_totalCount(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var commentContextImplementors = []string{"CommentContext"}
+
+func (ec *executionContext) _CommentContext(ctx context.Context, sel ast.SelectionSet, obj *CommentContext) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, commentContextImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	deferred := make(map[string]*graphql.FieldSet)
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("BatchDeleteResult")
-		case "success":
-			out.Values[i] = ec._BatchDeleteResult_success(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("CommentContext")
+		case "comment":
+			out.Values[i] = ec._CommentContext_comment(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "deletedCount":
-			out.Values[i] = ec._BatchDeleteResult_deletedCount(ctx, field, obj)
+		case "ancestors":
+			out.Values[i] = ec._CommentContext_ancestors(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "deletedIDs":
-			out.Values[i] = ec._BatchDeleteResult_deletedIDs(ctx, field, obj)
+		case "hasMoreAncestors":
+			out.Values[i] = ec._CommentContext_hasMoreAncestors(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "errors":
-			out.Values[i] = ec._BatchDeleteResult_errors(ctx, field, obj)
+		case "replies":
+			out.Values[i] = ec._CommentContext_replies(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "totalReplies":
+			out.Values[i] = ec._CommentContext_totalReplies(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
@@ -9262,59 +14101,167 @@ func (ec *executionContext) _BatchDeleteResult(ctx context.Context, sel ast.Sele
 	return out
 }
 
-var commentImplementors = []string{"Comment"}
+var commentDeleteItemResultImplementors = []string{"CommentDeleteItemResult"}
 
-func (ec *executionContext) _Comment(ctx context.Context, sel ast.SelectionSet, obj *Comment) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, commentImplementors)
+func (ec *executionContext) _CommentDeleteItemResult(ctx context.Context, sel ast.SelectionSet, obj *CommentDeleteItemResult) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, commentDeleteItemResultImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	deferred := make(map[string]*graphql.FieldSet)
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("Comment")
+			out.Values[i] = graphql.MarshalString("CommentDeleteItemResult")
 		case "id":
-			out.Values[i] = ec._Comment_id(ctx, field, obj)
+			out.Values[i] = ec._CommentDeleteItemResult_id(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "postID":
-			out.Values[i] = ec._Comment_postID(ctx, field, obj)
+		case "status":
+			out.Values[i] = ec._CommentDeleteItemResult_status(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "parentID":
-			out.Values[i] = ec._Comment_parentID(ctx, field, obj)
-		case "content":
-			out.Values[i] = ec._Comment_content(ctx, field, obj)
+		case "message":
+			out.Values[i] = ec._CommentDeleteItemResult_message(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var commentEdgeImplementors = []string{"CommentEdge"}
+
+func (ec *executionContext) _CommentEdge(ctx context.Context, sel ast.SelectionSet, obj *CommentEdge) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, commentEdgeImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("CommentEdge")
+		case "node":
+			out.Values[i] = ec._CommentEdge_node(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "authorID":
-			out.Values[i] = ec._Comment_authorID(ctx, field, obj)
+		case "cursor":
+			out.Values[i] = ec._CommentEdge_cursor(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "depth":
-			out.Values[i] = ec._Comment_depth(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var commentEventImplementors = []string{"CommentEvent"}
+
+func (ec *executionContext) _CommentEvent(ctx context.Context, sel ast.SelectionSet, obj *CommentEvent) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, commentEventImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("CommentEvent")
+		case "type":
+			out.Values[i] = ec._CommentEvent_type(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "createdAt":
-			out.Values[i] = ec._Comment_createdAt(ctx, field, obj)
+		case "comment":
+			out.Values[i] = ec._CommentEvent_comment(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "updatedAt":
-			out.Values[i] = ec._Comment_updatedAt(ctx, field, obj)
+		case "postID":
+			out.Values[i] = ec._CommentEvent_postID(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "children":
-			out.Values[i] = ec._Comment_children(ctx, field, obj)
+		case "eventID":
+			out.Values[i] = ec._CommentEvent_eventID(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var commentResultImplementors = []string{"CommentResult"}
+
+func (ec *executionContext) _CommentResult(ctx context.Context, sel ast.SelectionSet, obj *CommentResult) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, commentResultImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("CommentResult")
+		case "success":
+			out.Values[i] = ec._CommentResult_success(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "comment":
+			out.Values[i] = ec._CommentResult_comment(ctx, field, obj)
+		case "error":
+			out.Values[i] = ec._CommentResult_error(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -9338,29 +14285,24 @@ func (ec *executionContext) _Comment(ctx context.Context, sel ast.SelectionSet,
 	return out
 }
 
-var commentConnectionImplementors = []string{"CommentConnection"}
+var commentSearchConnectionImplementors = []string{"CommentSearchConnection"}
 
-func (ec *executionContext) _CommentConnection(ctx context.Context, sel ast.SelectionSet, obj *CommentConnection) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, commentConnectionImplementors)
+func (ec *executionContext) _CommentSearchConnection(ctx context.Context, sel ast.SelectionSet, obj *CommentSearchConnection) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, commentSearchConnectionImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	deferred := make(map[string]*graphql.FieldSet)
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("CommentConnection")
+			out.Values[i] = graphql.MarshalString("CommentSearchConnection")
 		case "edges":
-			out.Values[i] = ec._CommentConnection_edges(ctx, field, obj)
+			out.Values[i] = ec._CommentSearchConnection_edges(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
 		case "pageInfo":
-			out.Values[i] = ec._CommentConnection_pageInfo(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				out.Invalids++
-			}
-		case "totalCount":
-			out.Values[i] = ec._CommentConnection_totalCount(ctx, field, obj)
+			out.Values[i] = ec._CommentSearchConnection_pageInfo(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
@@ -9387,24 +14329,34 @@ func (ec *executionContext) _CommentConnection(ctx context.Context, sel ast.Sele
 	return out
 }
 
-var commentEdgeImplementors = []string{"CommentEdge"}
+var commentSearchEdgeImplementors = []string{"CommentSearchEdge"}
 
-func (ec *executionContext) _CommentEdge(ctx context.Context, sel ast.SelectionSet, obj *CommentEdge) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, commentEdgeImplementors)
+func (ec *executionContext) _CommentSearchEdge(ctx context.Context, sel ast.SelectionSet, obj *CommentSearchEdge) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, commentSearchEdgeImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	deferred := make(map[string]*graphql.FieldSet)
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("CommentEdge")
+			out.Values[i] = graphql.MarshalString("CommentSearchEdge")
 		case "node":
-			out.Values[i] = ec._CommentEdge_node(ctx, field, obj)
+			out.Values[i] = ec._CommentSearchEdge_node(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
 		case "cursor":
-			out.Values[i] = ec._CommentEdge_cursor(ctx, field, obj)
+			out.Values[i] = ec._CommentSearchEdge_cursor(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "rank":
+			out.Values[i] = ec._CommentSearchEdge_rank(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "snippet":
+			out.Values[i] = ec._CommentSearchEdge_snippet(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
@@ -9431,29 +14383,51 @@ func (ec *executionContext) _CommentEdge(ctx context.Context, sel ast.SelectionS
 	return out
 }
 
-var commentEventImplementors = []string{"CommentEvent"}
+var commentStatsImplementors = []string{"CommentStats"}
 
-func (ec *executionContext) _CommentEvent(ctx context.Context, sel ast.SelectionSet, obj *CommentEvent) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, commentEventImplementors)
+func (ec *executionContext) _CommentStats(ctx context.Context, sel ast.SelectionSet, obj *CommentStats) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, commentStatsImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	deferred := make(map[string]*graphql.FieldSet)
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("CommentEvent")
-		case "type":
-			out.Values[i] = ec._CommentEvent_type(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("CommentStats")
+		case "totalComments":
+			out.Values[i] = ec._CommentStats_totalComments(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "comment":
-			out.Values[i] = ec._CommentEvent_comment(ctx, field, obj)
+		case "rootComments":
+			out.Values[i] = ec._CommentStats_rootComments(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "postID":
-			out.Values[i] = ec._CommentEvent_postID(ctx, field, obj)
+		case "replies":
+			out.Values[i] = ec._CommentStats_replies(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "maxDepth":
+			out.Values[i] = ec._CommentStats_maxDepth(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "averageDepth":
+			out.Values[i] = ec._CommentStats_averageDepth(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "uniqueCommenters":
+			out.Values[i] = ec._CommentStats_uniqueCommenters(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "lastCommentAt":
+			out.Values[i] = ec._CommentStats_lastCommentAt(ctx, field, obj)
+		case "depthHistogram":
+			out.Values[i] = ec._CommentStats_depthHistogram(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
@@ -9480,26 +14454,88 @@ func (ec *executionContext) _CommentEvent(ctx context.Context, sel ast.Selection
 	return out
 }
 
-var commentResultImplementors = []string{"CommentResult"}
+var commentTreeImplementors = []string{"CommentTree"}
 
-func (ec *executionContext) _CommentResult(ctx context.Context, sel ast.SelectionSet, obj *CommentResult) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, commentResultImplementors)
+func (ec *executionContext) _CommentTree(ctx context.Context, sel ast.SelectionSet, obj *CommentTree) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, commentTreeImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	deferred := make(map[string]*graphql.FieldSet)
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("CommentResult")
-		case "success":
-			out.Values[i] = ec._CommentResult_success(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("CommentTree")
+		case "nodes":
+			out.Values[i] = ec._CommentTree_nodes(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "pageInfo":
+			out.Values[i] = ec._CommentTree_pageInfo(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var commentTreeNodeImplementors = []string{"CommentTreeNode"}
+
+func (ec *executionContext) _CommentTreeNode(ctx context.Context, sel ast.SelectionSet, obj *CommentTreeNode) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, commentTreeNodeImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("CommentTreeNode")
+		case "comment":
+			out.Values[i] = ec._CommentTreeNode_comment(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "cursor":
+			out.Values[i] = ec._CommentTreeNode_cursor(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "replyCount":
+			out.Values[i] = ec._CommentTreeNode_replyCount(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "loadedReplyCount":
+			out.Values[i] = ec._CommentTreeNode_loadedReplyCount(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "moreRepliesCursor":
+			out.Values[i] = ec._CommentTreeNode_moreRepliesCursor(ctx, field, obj)
+		case "continueThread":
+			out.Values[i] = ec._CommentTreeNode_continueThread(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "comment":
-			out.Values[i] = ec._CommentResult_comment(ctx, field, obj)
-		case "error":
-			out.Values[i] = ec._CommentResult_error(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -9523,32 +14559,28 @@ func (ec *executionContext) _CommentResult(ctx context.Context, sel ast.Selectio
 	return out
 }
 
-var commentStatsImplementors = []string{"CommentStats"}
+var deleteResultImplementors = []string{"DeleteResult"}
 
-func (ec *executionContext) _CommentStats(ctx context.Context, sel ast.SelectionSet, obj *CommentStats) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, commentStatsImplementors)
+func (ec *executionContext) _DeleteResult(ctx context.Context, sel ast.SelectionSet, obj *DeleteResult) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, deleteResultImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	deferred := make(map[string]*graphql.FieldSet)
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("CommentStats")
-		case "totalComments":
-			out.Values[i] = ec._CommentStats_totalComments(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				out.Invalids++
-			}
-		case "maxDepth":
-			out.Values[i] = ec._CommentStats_maxDepth(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				out.Invalids++
-			}
-		case "averageDepth":
-			out.Values[i] = ec._CommentStats_averageDepth(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("DeleteResult")
+		case "success":
+			out.Values[i] = ec._DeleteResult_success(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "deletedID":
+			out.Values[i] = ec._DeleteResult_deletedID(ctx, field, obj)
+		case "error":
+			out.Values[i] = ec._DeleteResult_error(ctx, field, obj)
+		case "tombstone":
+			out.Values[i] = ec._DeleteResult_tombstone(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -9572,26 +14604,27 @@ func (ec *executionContext) _CommentStats(ctx context.Context, sel ast.Selection
 	return out
 }
 
-var deleteResultImplementors = []string{"DeleteResult"}
+var depthCountImplementors = []string{"DepthCount"}
 
-func (ec *executionContext) _DeleteResult(ctx context.Context, sel ast.SelectionSet, obj *DeleteResult) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, deleteResultImplementors)
+func (ec *executionContext) _DepthCount(ctx context.Context, sel ast.SelectionSet, obj *DepthCount) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, depthCountImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	deferred := make(map[string]*graphql.FieldSet)
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("DeleteResult")
-		case "success":
-			out.Values[i] = ec._DeleteResult_success(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("DepthCount")
+		case "depth":
+			out.Values[i] = ec._DepthCount_depth(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "count":
+			out.Values[i] = ec._DepthCount_count(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "deletedID":
-			out.Values[i] = ec._DeleteResult_deletedID(ctx, field, obj)
-		case "error":
-			out.Values[i] = ec._DeleteResult_error(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -9789,43 +14822,150 @@ func (ec *executionContext) _Post(ctx context.Context, sel ast.SelectionSet, obj
 		case "id":
 			out.Values[i] = ec._Post_id(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "title":
 			out.Values[i] = ec._Post_title(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "content":
 			out.Values[i] = ec._Post_content(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "authorID":
 			out.Values[i] = ec._Post_authorID(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "commentsEnabled":
 			out.Values[i] = ec._Post_commentsEnabled(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "language":
+			out.Values[i] = ec._Post_language(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "createdAt":
 			out.Values[i] = ec._Post_createdAt(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "updatedAt":
 			out.Values[i] = ec._Post_updatedAt(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "comments":
-			out.Values[i] = ec._Post_comments(ctx, field, obj)
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Post_comments(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var postAnalyticsImplementors = []string{"PostAnalytics"}
+
+func (ec *executionContext) _PostAnalytics(ctx context.Context, sel ast.SelectionSet, obj *PostAnalytics) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, postAnalyticsImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("PostAnalytics")
+		case "postID":
+			out.Values[i] = ec._PostAnalytics_postID(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "title":
+			out.Values[i] = ec._PostAnalytics_title(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "authorID":
+			out.Values[i] = ec._PostAnalytics_authorID(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "createdAt":
+			out.Values[i] = ec._PostAnalytics_createdAt(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "totalComments":
+			out.Values[i] = ec._PostAnalytics_totalComments(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "rootComments":
+			out.Values[i] = ec._PostAnalytics_rootComments(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "maxCommentDepth":
+			out.Values[i] = ec._PostAnalytics_maxCommentDepth(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "lastCommentAt":
+			out.Values[i] = ec._PostAnalytics_lastCommentAt(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -9942,6 +15082,55 @@ func (ec *executionContext) _PostEdge(ctx context.Context, sel ast.SelectionSet,
 	return out
 }
 
+var postEventImplementors = []string{"PostEvent"}
+
+func (ec *executionContext) _PostEvent(ctx context.Context, sel ast.SelectionSet, obj *PostEvent) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, postEventImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("PostEvent")
+		case "type":
+			out.Values[i] = ec._PostEvent_type(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "post":
+			out.Values[i] = ec._PostEvent_post(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "postID":
+			out.Values[i] = ec._PostEvent_postID(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
 var postResultImplementors = []string{"PostResult"}
 
 func (ec *executionContext) _PostResult(ctx context.Context, sel ast.SelectionSet, obj *PostResult) graphql.Marshaler {
@@ -9958,10 +15147,108 @@ func (ec *executionContext) _PostResult(ctx context.Context, sel ast.SelectionSe
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "post":
-			out.Values[i] = ec._PostResult_post(ctx, field, obj)
-		case "error":
-			out.Values[i] = ec._PostResult_error(ctx, field, obj)
+		case "post":
+			out.Values[i] = ec._PostResult_post(ctx, field, obj)
+		case "error":
+			out.Values[i] = ec._PostResult_error(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var postSearchConnectionImplementors = []string{"PostSearchConnection"}
+
+func (ec *executionContext) _PostSearchConnection(ctx context.Context, sel ast.SelectionSet, obj *PostSearchConnection) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, postSearchConnectionImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("PostSearchConnection")
+		case "edges":
+			out.Values[i] = ec._PostSearchConnection_edges(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "pageInfo":
+			out.Values[i] = ec._PostSearchConnection_pageInfo(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var postSearchEdgeImplementors = []string{"PostSearchEdge"}
+
+func (ec *executionContext) _PostSearchEdge(ctx context.Context, sel ast.SelectionSet, obj *PostSearchEdge) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, postSearchEdgeImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("PostSearchEdge")
+		case "node":
+			out.Values[i] = ec._PostSearchEdge_node(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "cursor":
+			out.Values[i] = ec._PostSearchEdge_cursor(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "rank":
+			out.Values[i] = ec._PostSearchEdge_rank(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "snippet":
+			out.Values[i] = ec._PostSearchEdge_snippet(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -10001,6 +15288,16 @@ func (ec *executionContext) _PostStats(ctx context.Context, sel ast.SelectionSet
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "rootComments":
+			out.Values[i] = ec._PostStats_rootComments(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "replies":
+			out.Values[i] = ec._PostStats_replies(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		case "commentsEnabled":
 			out.Values[i] = ec._PostStats_commentsEnabled(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
@@ -10131,6 +15428,28 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 			}
 
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "commentContext":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_commentContext(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
 		case "commentTree":
 			field := field
@@ -10191,6 +15510,28 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 			}
 
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "postAnalytics":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_postAnalytics(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
 		case "searchPosts":
 			field := field
@@ -10714,6 +16055,20 @@ func (ec *executionContext) marshalNComment2ᚖgithubᚗcomᚋNarthurNᚋhabbr
 	return ec._Comment(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNCommentBatchDeleteResult2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentBatchDeleteResult(ctx context.Context, sel ast.SelectionSet, v CommentBatchDeleteResult) graphql.Marshaler {
+	return ec._CommentBatchDeleteResult(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNCommentBatchDeleteResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentBatchDeleteResult(ctx context.Context, sel ast.SelectionSet, v *CommentBatchDeleteResult) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._CommentBatchDeleteResult(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalNCommentConnection2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentConnection(ctx context.Context, sel ast.SelectionSet, v CommentConnection) graphql.Marshaler {
 	return ec._CommentConnection(ctx, sel, &v)
 }
@@ -10728,6 +16083,84 @@ func (ec *executionContext) marshalNCommentConnection2ᚖgithubᚗcomᚋNarthurN
 	return ec._CommentConnection(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNCommentContext2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentContext(ctx context.Context, sel ast.SelectionSet, v CommentContext) graphql.Marshaler {
+	return ec._CommentContext(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNCommentContext2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentContext(ctx context.Context, sel ast.SelectionSet, v *CommentContext) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._CommentContext(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNCommentDeleteItemResult2ᚕᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentDeleteItemResultᚄ(ctx context.Context, sel ast.SelectionSet, v []*CommentDeleteItemResult) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNCommentDeleteItemResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentDeleteItemResult(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNCommentDeleteItemResult2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentDeleteItemResult(ctx context.Context, sel ast.SelectionSet, v *CommentDeleteItemResult) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._CommentDeleteItemResult(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalNCommentDeleteStatus2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentDeleteStatus(ctx context.Context, v any) (CommentDeleteStatus, error) {
+	var res CommentDeleteStatus
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNCommentDeleteStatus2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentDeleteStatus(ctx context.Context, sel ast.SelectionSet, v CommentDeleteStatus) graphql.Marshaler {
+	return v
+}
+
 func (ec *executionContext) marshalNCommentEdge2ᚕᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentEdgeᚄ(ctx context.Context, sel ast.SelectionSet, v []*CommentEdge) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
@@ -10822,7 +16255,143 @@ func (ec *executionContext) marshalNCommentResult2ᚖgithubᚗcomᚋNarthurNᚋh
 		}
 		return graphql.Null
 	}
-	return ec._CommentResult(ctx, sel, v)
+	return ec._CommentResult(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNCommentSearchConnection2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentSearchConnection(ctx context.Context, sel ast.SelectionSet, v CommentSearchConnection) graphql.Marshaler {
+	return ec._CommentSearchConnection(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNCommentSearchConnection2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentSearchConnection(ctx context.Context, sel ast.SelectionSet, v *CommentSearchConnection) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._CommentSearchConnection(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNCommentSearchEdge2ᚕᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentSearchEdgeᚄ(ctx context.Context, sel ast.SelectionSet, v []*CommentSearchEdge) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNCommentSearchEdge2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentSearchEdge(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNCommentSearchEdge2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentSearchEdge(ctx context.Context, sel ast.SelectionSet, v *CommentSearchEdge) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._CommentSearchEdge(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNCommentTree2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentTree(ctx context.Context, sel ast.SelectionSet, v CommentTree) graphql.Marshaler {
+	return ec._CommentTree(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNCommentTree2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentTree(ctx context.Context, sel ast.SelectionSet, v *CommentTree) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._CommentTree(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNCommentTreeNode2ᚕᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentTreeNodeᚄ(ctx context.Context, sel ast.SelectionSet, v []*CommentTreeNode) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNCommentTreeNode2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentTreeNode(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNCommentTreeNode2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentTreeNode(ctx context.Context, sel ast.SelectionSet, v *CommentTreeNode) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._CommentTreeNode(ctx, sel, v)
 }
 
 func (ec *executionContext) unmarshalNCommentUpdateInput2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentUpdateInput(ctx context.Context, v any) (CommentUpdateInput, error) {
@@ -10844,6 +16413,60 @@ func (ec *executionContext) marshalNDeleteResult2ᚖgithubᚗcomᚋNarthurNᚋha
 	return ec._DeleteResult(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNDepthCount2ᚕᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐDepthCountᚄ(ctx context.Context, sel ast.SelectionSet, v []*DepthCount) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNDepthCount2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐDepthCount(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNDepthCount2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐDepthCount(ctx context.Context, sel ast.SelectionSet, v *DepthCount) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._DepthCount(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNFloat2float64(ctx context.Context, v any) (float64, error) {
 	res, err := graphql.UnmarshalFloatContext(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -10922,6 +16545,16 @@ func (ec *executionContext) marshalNInt2int(ctx context.Context, sel ast.Selecti
 	return res
 }
 
+func (ec *executionContext) unmarshalNLanguage2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐLanguage(ctx context.Context, v any) (Language, error) {
+	var res Language
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNLanguage2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐLanguage(ctx context.Context, sel ast.SelectionSet, v Language) graphql.Marshaler {
+	return v
+}
+
 func (ec *executionContext) marshalNPageInfo2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPageInfo(ctx context.Context, sel ast.SelectionSet, v *PageInfo) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
@@ -10946,6 +16579,60 @@ func (ec *executionContext) marshalNPost2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋin
 	return ec._Post(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNPostAnalytics2ᚕᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostAnalyticsᚄ(ctx context.Context, sel ast.SelectionSet, v []*PostAnalytics) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNPostAnalytics2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostAnalytics(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNPostAnalytics2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostAnalytics(ctx context.Context, sel ast.SelectionSet, v *PostAnalytics) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._PostAnalytics(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalNPostConnection2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostConnection(ctx context.Context, sel ast.SelectionSet, v PostConnection) graphql.Marshaler {
 	return ec._PostConnection(ctx, sel, &v)
 }
@@ -11014,6 +16701,30 @@ func (ec *executionContext) marshalNPostEdge2ᚖgithubᚗcomᚋNarthurNᚋhabbr
 	return ec._PostEdge(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNPostEvent2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostEvent(ctx context.Context, sel ast.SelectionSet, v PostEvent) graphql.Marshaler {
+	return ec._PostEvent(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNPostEvent2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostEvent(ctx context.Context, sel ast.SelectionSet, v *PostEvent) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._PostEvent(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalNPostEventType2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostEventType(ctx context.Context, v any) (PostEventType, error) {
+	var res PostEventType
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNPostEventType2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostEventType(ctx context.Context, sel ast.SelectionSet, v PostEventType) graphql.Marshaler {
+	return v
+}
+
 func (ec *executionContext) unmarshalNPostInput2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostInput(ctx context.Context, v any) (PostInput, error) {
 	res, err := ec.unmarshalInputPostInput(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -11033,6 +16744,74 @@ func (ec *executionContext) marshalNPostResult2ᚖgithubᚗcomᚋNarthurNᚋhabb
 	return ec._PostResult(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNPostSearchConnection2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostSearchConnection(ctx context.Context, sel ast.SelectionSet, v PostSearchConnection) graphql.Marshaler {
+	return ec._PostSearchConnection(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNPostSearchConnection2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostSearchConnection(ctx context.Context, sel ast.SelectionSet, v *PostSearchConnection) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._PostSearchConnection(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNPostSearchEdge2ᚕᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostSearchEdgeᚄ(ctx context.Context, sel ast.SelectionSet, v []*PostSearchEdge) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNPostSearchEdge2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostSearchEdge(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNPostSearchEdge2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostSearchEdge(ctx context.Context, sel ast.SelectionSet, v *PostSearchEdge) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._PostSearchEdge(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalNPostStats2githubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostStats(ctx context.Context, sel ast.SelectionSet, v PostStats) graphql.Marshaler {
 	return ec._PostStats(ctx, sel, &v)
 }
@@ -11412,6 +17191,22 @@ func (ec *executionContext) unmarshalOCommentFilter2ᚖgithubᚗcomᚋNarthurN
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) unmarshalOCommentOrder2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentOrder(ctx context.Context, v any) (*CommentOrder, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res = new(CommentOrder)
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOCommentOrder2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentOrder(ctx context.Context, sel ast.SelectionSet, v *CommentOrder) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
 func (ec *executionContext) marshalOCommentStats2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐCommentStats(ctx context.Context, sel ast.SelectionSet, v *CommentStats) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -11455,6 +17250,22 @@ func (ec *executionContext) marshalOInt2ᚖint(ctx context.Context, sel ast.Sele
 	return res
 }
 
+func (ec *executionContext) unmarshalOLanguage2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐLanguage(ctx context.Context, v any) (*Language, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res = new(Language)
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOLanguage2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐLanguage(ctx context.Context, sel ast.SelectionSet, v *Language) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
 func (ec *executionContext) marshalOPost2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPost(ctx context.Context, sel ast.SelectionSet, v *Post) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -11462,6 +17273,30 @@ func (ec *executionContext) marshalOPost2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋin
 	return ec._Post(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalOPostAnalyticsFilter2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostAnalyticsFilter(ctx context.Context, v any) (*PostAnalyticsFilter, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalInputPostAnalyticsFilter(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) unmarshalOPostAnalyticsOrder2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostAnalyticsOrder(ctx context.Context, v any) (*PostAnalyticsOrder, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res = new(PostAnalyticsOrder)
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOPostAnalyticsOrder2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostAnalyticsOrder(ctx context.Context, sel ast.SelectionSet, v *PostAnalyticsOrder) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
 func (ec *executionContext) unmarshalOPostFilter2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostFilter(ctx context.Context, v any) (*PostFilter, error) {
 	if v == nil {
 		return nil, nil
@@ -11470,6 +17305,22 @@ func (ec *executionContext) unmarshalOPostFilter2ᚖgithubᚗcomᚋNarthurNᚋha
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) unmarshalOPostOrder2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostOrder(ctx context.Context, v any) (*PostOrder, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res = new(PostOrder)
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOPostOrder2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostOrder(ctx context.Context, sel ast.SelectionSet, v *PostOrder) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
 func (ec *executionContext) marshalOPostStats2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐPostStats(ctx context.Context, sel ast.SelectionSet, v *PostStats) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -11477,6 +17328,22 @@ func (ec *executionContext) marshalOPostStats2ᚖgithubᚗcomᚋNarthurNᚋhabbr
 	return ec._PostStats(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalOSlowConsumerPolicy2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐSlowConsumerPolicy(ctx context.Context, v any) (*SlowConsumerPolicy, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res = new(SlowConsumerPolicy)
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOSlowConsumerPolicy2ᚖgithubᚗcomᚋNarthurNᚋhabbrᚋinternalᚋapiᚋgraphqlᚋgeneratedᚐSlowConsumerPolicy(ctx context.Context, sel ast.SelectionSet, v *SlowConsumerPolicy) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
 func (ec *executionContext) unmarshalOString2ᚖstring(ctx context.Context, v any) (*string, error) {
 	if v == nil {
 		return nil, nil
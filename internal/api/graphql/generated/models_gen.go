@@ -24,17 +24,42 @@ type Comment struct {
 	Content   string             `json:"content"`
 	AuthorID  string             `json:"authorID"`
 	Depth     int                `json:"depth"`
+	Language  Language           `json:"language"`
 	CreatedAt time.Time          `json:"createdAt"`
 	UpdatedAt time.Time          `json:"updatedAt"`
+	IsDeleted bool               `json:"isDeleted"`
+	DeletedAt *time.Time         `json:"deletedAt,omitempty"`
 	Children  *CommentConnection `json:"children"`
 }
 
+type CommentBatchDeleteResult struct {
+	Success      bool                       `json:"success"`
+	DeletedCount int                        `json:"deletedCount"`
+	DeletedIDs   []string                   `json:"deletedIDs"`
+	Results      []*CommentDeleteItemResult `json:"results"`
+	Error        *string                    `json:"error,omitempty"`
+}
+
 type CommentConnection struct {
 	Edges      []*CommentEdge `json:"edges"`
 	PageInfo   *PageInfo      `json:"pageInfo"`
 	TotalCount int            `json:"totalCount"`
 }
 
+type CommentContext struct {
+	Comment          *Comment     `json:"comment"`
+	Ancestors        []*Comment   `json:"ancestors"`
+	HasMoreAncestors bool         `json:"hasMoreAncestors"`
+	Replies          *CommentTree `json:"replies"`
+	TotalReplies     int          `json:"totalReplies"`
+}
+
+type CommentDeleteItemResult struct {
+	ID      string              `json:"id"`
+	Status  CommentDeleteStatus `json:"status"`
+	Message *string             `json:"message,omitempty"`
+}
+
 type CommentEdge struct {
 	Node   *Comment `json:"node"`
 	Cursor string   `json:"cursor"`
@@ -44,6 +69,7 @@ type CommentEvent struct {
 	Type    CommentEventType `json:"type"`
 	Comment *Comment         `json:"comment"`
 	PostID  string           `json:"postID"`
+	EventID *string          `json:"eventID,omitempty"`
 }
 
 type CommentFilter struct {
@@ -66,10 +92,41 @@ type CommentResult struct {
 	Error   *string  `json:"error,omitempty"`
 }
 
+type CommentSearchConnection struct {
+	Edges    []*CommentSearchEdge `json:"edges"`
+	PageInfo *PageInfo            `json:"pageInfo"`
+}
+
+type CommentSearchEdge struct {
+	Node    *Comment `json:"node"`
+	Cursor  string   `json:"cursor"`
+	Rank    float64  `json:"rank"`
+	Snippet string   `json:"snippet"`
+}
+
 type CommentStats struct {
-	TotalComments int     `json:"totalComments"`
-	MaxDepth      int     `json:"maxDepth"`
-	AverageDepth  float64 `json:"averageDepth"`
+	TotalComments    int           `json:"totalComments"`
+	RootComments     int           `json:"rootComments"`
+	Replies          int           `json:"replies"`
+	MaxDepth         int           `json:"maxDepth"`
+	AverageDepth     float64       `json:"averageDepth"`
+	UniqueCommenters int           `json:"uniqueCommenters"`
+	LastCommentAt    *time.Time    `json:"lastCommentAt,omitempty"`
+	DepthHistogram   []*DepthCount `json:"depthHistogram"`
+}
+
+type CommentTree struct {
+	Nodes    []*CommentTreeNode `json:"nodes"`
+	PageInfo *PageInfo          `json:"pageInfo"`
+}
+
+type CommentTreeNode struct {
+	Comment           *Comment `json:"comment"`
+	Cursor            string   `json:"cursor"`
+	ReplyCount        int      `json:"replyCount"`
+	LoadedReplyCount  int      `json:"loadedReplyCount"`
+	MoreRepliesCursor *string  `json:"moreRepliesCursor,omitempty"`
+	ContinueThread    bool     `json:"continueThread"`
 }
 
 type CommentUpdateInput struct {
@@ -77,9 +134,15 @@ type CommentUpdateInput struct {
 }
 
 type DeleteResult struct {
-	Success   bool    `json:"success"`
-	DeletedID *string `json:"deletedID,omitempty"`
-	Error     *string `json:"error,omitempty"`
+	Success   bool     `json:"success"`
+	DeletedID *string  `json:"deletedID,omitempty"`
+	Error     *string  `json:"error,omitempty"`
+	Tombstone *Comment `json:"tombstone,omitempty"`
+}
+
+type DepthCount struct {
+	Depth int `json:"depth"`
+	Count int `json:"count"`
 }
 
 type Mutation struct {
@@ -98,11 +161,30 @@ type Post struct {
 	Content         string             `json:"content"`
 	AuthorID        string             `json:"authorID"`
 	CommentsEnabled bool               `json:"commentsEnabled"`
+	Language        Language           `json:"language"`
 	CreatedAt       time.Time          `json:"createdAt"`
 	UpdatedAt       time.Time          `json:"updatedAt"`
 	Comments        *CommentConnection `json:"comments"`
 }
 
+type PostAnalytics struct {
+	PostID          string     `json:"postID"`
+	Title           string     `json:"title"`
+	AuthorID        string     `json:"authorID"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	TotalComments   int        `json:"totalComments"`
+	RootComments    int        `json:"rootComments"`
+	MaxCommentDepth int        `json:"maxCommentDepth"`
+	LastCommentAt   *time.Time `json:"lastCommentAt,omitempty"`
+}
+
+type PostAnalyticsFilter struct {
+	AuthorID      *string    `json:"authorID,omitempty"`
+	CreatedAfter  *time.Time `json:"createdAfter,omitempty"`
+	CreatedBefore *time.Time `json:"createdBefore,omitempty"`
+	MinComments   *int       `json:"minComments,omitempty"`
+}
+
 type PostConnection struct {
 	Edges      []*PostEdge `json:"edges"`
 	PageInfo   *PageInfo   `json:"pageInfo"`
@@ -114,6 +196,12 @@ type PostEdge struct {
 	Cursor string `json:"cursor"`
 }
 
+type PostEvent struct {
+	Type   PostEventType `json:"type"`
+	Post   *Post         `json:"post"`
+	PostID string        `json:"postID"`
+}
+
 type PostFilter struct {
 	AuthorID        *string `json:"authorID,omitempty"`
 	Title           *string `json:"title,omitempty"`
@@ -122,10 +210,11 @@ type PostFilter struct {
 }
 
 type PostInput struct {
-	Title           string `json:"title"`
-	Content         string `json:"content"`
-	AuthorID        string `json:"authorID"`
-	CommentsEnabled bool   `json:"commentsEnabled"`
+	Title           string    `json:"title"`
+	Content         string    `json:"content"`
+	AuthorID        string    `json:"authorID"`
+	CommentsEnabled bool      `json:"commentsEnabled"`
+	Language        *Language `json:"language,omitempty"`
 }
 
 type PostResult struct {
@@ -134,16 +223,31 @@ type PostResult struct {
 	Error   *string `json:"error,omitempty"`
 }
 
+type PostSearchConnection struct {
+	Edges    []*PostSearchEdge `json:"edges"`
+	PageInfo *PageInfo         `json:"pageInfo"`
+}
+
+type PostSearchEdge struct {
+	Node    *Post   `json:"node"`
+	Cursor  string  `json:"cursor"`
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
 type PostStats struct {
 	TotalComments   int        `json:"totalComments"`
+	RootComments    int        `json:"rootComments"`
+	Replies         int        `json:"replies"`
 	CommentsEnabled bool       `json:"commentsEnabled"`
 	LastCommentAt   *time.Time `json:"lastCommentAt,omitempty"`
 }
 
 type PostUpdateInput struct {
-	Title           *string `json:"title,omitempty"`
-	Content         *string `json:"content,omitempty"`
-	CommentsEnabled *bool   `json:"commentsEnabled,omitempty"`
+	Title           *string   `json:"title,omitempty"`
+	Content         *string   `json:"content,omitempty"`
+	CommentsEnabled *bool     `json:"commentsEnabled,omitempty"`
+	Language        *Language `json:"language,omitempty"`
 }
 
 type Query struct {
@@ -152,23 +256,90 @@ type Query struct {
 type Subscription struct {
 }
 
+type CommentDeleteStatus string
+
+const (
+	CommentDeleteStatusDeleted   CommentDeleteStatus = "DELETED"
+	CommentDeleteStatusInvalidID CommentDeleteStatus = "INVALID_ID"
+	CommentDeleteStatusNotFound  CommentDeleteStatus = "NOT_FOUND"
+	CommentDeleteStatusWrongPost CommentDeleteStatus = "WRONG_POST"
+	CommentDeleteStatusForbidden CommentDeleteStatus = "FORBIDDEN"
+	CommentDeleteStatusFailed    CommentDeleteStatus = "FAILED"
+	CommentDeleteStatusSkipped   CommentDeleteStatus = "SKIPPED"
+)
+
+var AllCommentDeleteStatus = []CommentDeleteStatus{
+	CommentDeleteStatusDeleted,
+	CommentDeleteStatusInvalidID,
+	CommentDeleteStatusNotFound,
+	CommentDeleteStatusWrongPost,
+	CommentDeleteStatusForbidden,
+	CommentDeleteStatusFailed,
+	CommentDeleteStatusSkipped,
+}
+
+func (e CommentDeleteStatus) IsValid() bool {
+	switch e {
+	case CommentDeleteStatusDeleted, CommentDeleteStatusInvalidID, CommentDeleteStatusNotFound, CommentDeleteStatusWrongPost, CommentDeleteStatusForbidden, CommentDeleteStatusFailed, CommentDeleteStatusSkipped:
+		return true
+	}
+	return false
+}
+
+func (e CommentDeleteStatus) String() string {
+	return string(e)
+}
+
+func (e *CommentDeleteStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CommentDeleteStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CommentDeleteStatus", str)
+	}
+	return nil
+}
+
+func (e CommentDeleteStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *CommentDeleteStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e CommentDeleteStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type CommentEventType string
 
 const (
-	CommentEventTypeCreated CommentEventType = "CREATED"
-	CommentEventTypeUpdated CommentEventType = "UPDATED"
-	CommentEventTypeDeleted CommentEventType = "DELETED"
+	CommentEventTypeCreated    CommentEventType = "CREATED"
+	CommentEventTypeUpdated    CommentEventType = "UPDATED"
+	CommentEventTypeDeleted    CommentEventType = "DELETED"
+	CommentEventTypeTombstoned CommentEventType = "TOMBSTONED"
 )
 
 var AllCommentEventType = []CommentEventType{
 	CommentEventTypeCreated,
 	CommentEventTypeUpdated,
 	CommentEventTypeDeleted,
+	CommentEventTypeTombstoned,
 }
 
 func (e CommentEventType) IsValid() bool {
 	switch e {
-	case CommentEventTypeCreated, CommentEventTypeUpdated, CommentEventTypeDeleted:
+	case CommentEventTypeCreated, CommentEventTypeUpdated, CommentEventTypeDeleted, CommentEventTypeTombstoned:
 		return true
 	}
 	return false
@@ -208,3 +379,355 @@ func (e CommentEventType) MarshalJSON() ([]byte, error) {
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
 }
+
+type CommentOrder string
+
+const (
+	CommentOrderOldest          CommentOrder = "OLDEST"
+	CommentOrderNewest          CommentOrder = "NEWEST"
+	CommentOrderRecentlyUpdated CommentOrder = "RECENTLY_UPDATED"
+	CommentOrderMostReplies     CommentOrder = "MOST_REPLIES"
+)
+
+var AllCommentOrder = []CommentOrder{
+	CommentOrderOldest,
+	CommentOrderNewest,
+	CommentOrderRecentlyUpdated,
+	CommentOrderMostReplies,
+}
+
+func (e CommentOrder) IsValid() bool {
+	switch e {
+	case CommentOrderOldest, CommentOrderNewest, CommentOrderRecentlyUpdated, CommentOrderMostReplies:
+		return true
+	}
+	return false
+}
+
+func (e CommentOrder) String() string {
+	return string(e)
+}
+
+func (e *CommentOrder) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CommentOrder(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CommentOrder", str)
+	}
+	return nil
+}
+
+func (e CommentOrder) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *CommentOrder) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e CommentOrder) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type Language string
+
+const (
+	LanguageRussian Language = "RUSSIAN"
+	LanguageEnglish Language = "ENGLISH"
+	LanguageSimple  Language = "SIMPLE"
+)
+
+var AllLanguage = []Language{
+	LanguageRussian,
+	LanguageEnglish,
+	LanguageSimple,
+}
+
+func (e Language) IsValid() bool {
+	switch e {
+	case LanguageRussian, LanguageEnglish, LanguageSimple:
+		return true
+	}
+	return false
+}
+
+func (e Language) String() string {
+	return string(e)
+}
+
+func (e *Language) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Language(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Language", str)
+	}
+	return nil
+}
+
+func (e Language) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *Language) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e Language) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PostAnalyticsOrder string
+
+const (
+	PostAnalyticsOrderMostCommented     PostAnalyticsOrder = "MOST_COMMENTED"
+	PostAnalyticsOrderRecentlyCommented PostAnalyticsOrder = "RECENTLY_COMMENTED"
+	PostAnalyticsOrderDeepest           PostAnalyticsOrder = "DEEPEST"
+	PostAnalyticsOrderNewest            PostAnalyticsOrder = "NEWEST"
+)
+
+var AllPostAnalyticsOrder = []PostAnalyticsOrder{
+	PostAnalyticsOrderMostCommented,
+	PostAnalyticsOrderRecentlyCommented,
+	PostAnalyticsOrderDeepest,
+	PostAnalyticsOrderNewest,
+}
+
+func (e PostAnalyticsOrder) IsValid() bool {
+	switch e {
+	case PostAnalyticsOrderMostCommented, PostAnalyticsOrderRecentlyCommented, PostAnalyticsOrderDeepest, PostAnalyticsOrderNewest:
+		return true
+	}
+	return false
+}
+
+func (e PostAnalyticsOrder) String() string {
+	return string(e)
+}
+
+func (e *PostAnalyticsOrder) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PostAnalyticsOrder(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PostAnalyticsOrder", str)
+	}
+	return nil
+}
+
+func (e PostAnalyticsOrder) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PostAnalyticsOrder) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PostAnalyticsOrder) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PostEventType string
+
+const (
+	PostEventTypeCreated         PostEventType = "CREATED"
+	PostEventTypeUpdated         PostEventType = "UPDATED"
+	PostEventTypeDeleted         PostEventType = "DELETED"
+	PostEventTypeCommentsToggled PostEventType = "COMMENTS_TOGGLED"
+)
+
+var AllPostEventType = []PostEventType{
+	PostEventTypeCreated,
+	PostEventTypeUpdated,
+	PostEventTypeDeleted,
+	PostEventTypeCommentsToggled,
+}
+
+func (e PostEventType) IsValid() bool {
+	switch e {
+	case PostEventTypeCreated, PostEventTypeUpdated, PostEventTypeDeleted, PostEventTypeCommentsToggled:
+		return true
+	}
+	return false
+}
+
+func (e PostEventType) String() string {
+	return string(e)
+}
+
+func (e *PostEventType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PostEventType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PostEventType", str)
+	}
+	return nil
+}
+
+func (e PostEventType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PostEventType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PostEventType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PostOrder string
+
+const (
+	PostOrderNewest          PostOrder = "NEWEST"
+	PostOrderOldest          PostOrder = "OLDEST"
+	PostOrderRecentlyUpdated PostOrder = "RECENTLY_UPDATED"
+	PostOrderMostCommented   PostOrder = "MOST_COMMENTED"
+)
+
+var AllPostOrder = []PostOrder{
+	PostOrderNewest,
+	PostOrderOldest,
+	PostOrderRecentlyUpdated,
+	PostOrderMostCommented,
+}
+
+func (e PostOrder) IsValid() bool {
+	switch e {
+	case PostOrderNewest, PostOrderOldest, PostOrderRecentlyUpdated, PostOrderMostCommented:
+		return true
+	}
+	return false
+}
+
+func (e PostOrder) String() string {
+	return string(e)
+}
+
+func (e *PostOrder) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PostOrder(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PostOrder", str)
+	}
+	return nil
+}
+
+func (e PostOrder) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PostOrder) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PostOrder) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type SlowConsumerPolicy string
+
+const (
+	SlowConsumerPolicyDropNewest SlowConsumerPolicy = "DROP_NEWEST"
+	SlowConsumerPolicyDropOldest SlowConsumerPolicy = "DROP_OLDEST"
+	SlowConsumerPolicyBlock      SlowConsumerPolicy = "BLOCK"
+	SlowConsumerPolicyDisconnect SlowConsumerPolicy = "DISCONNECT"
+)
+
+var AllSlowConsumerPolicy = []SlowConsumerPolicy{
+	SlowConsumerPolicyDropNewest,
+	SlowConsumerPolicyDropOldest,
+	SlowConsumerPolicyBlock,
+	SlowConsumerPolicyDisconnect,
+}
+
+func (e SlowConsumerPolicy) IsValid() bool {
+	switch e {
+	case SlowConsumerPolicyDropNewest, SlowConsumerPolicyDropOldest, SlowConsumerPolicyBlock, SlowConsumerPolicyDisconnect:
+		return true
+	}
+	return false
+}
+
+func (e SlowConsumerPolicy) String() string {
+	return string(e)
+}
+
+func (e *SlowConsumerPolicy) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SlowConsumerPolicy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SlowConsumerPolicy", str)
+	}
+	return nil
+}
+
+func (e SlowConsumerPolicy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SlowConsumerPolicy) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SlowConsumerPolicy) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
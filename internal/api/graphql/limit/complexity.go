@@ -0,0 +1,127 @@
+package limit
+
+import (
+	"math"
+
+	"github.com/NarthurN/habbr/internal/api/graphql/generated"
+	"github.com/NarthurN/habbr/internal/model"
+)
+
+// Размеры страниц по умолчанию, если клиент не передал first/last.
+// Совпадают с размерами страниц сервисов, чтобы стоимость отражала реальную выборку.
+const (
+	defaultPostPageSize    = 20
+	defaultCommentPageSize = 50
+	defaultSearchPageSize  = 20
+)
+
+// Weights задает веса полей в модели стоимости запроса
+type Weights struct {
+	// Stats - стоимость вычисления статистики postStats и commentStats
+	Stats int
+}
+
+// Complexity создает функции стоимости полей схемы.
+//
+// Стоимость поля по умолчанию равна 1 плюс стоимость вложенных полей.
+// Для полей-соединений стоимость вложенных полей умножается на размер
+// страницы (first или last, иначе размер страницы по умолчанию), поэтому
+// вложенные списки, например Post.comments внутри posts, перемножаются.
+// Так же оценивается список postAnalytics.
+// Для commentTree стоимость узла умножается на наибольший размер ветки
+// при заданных first, repliesFirst и maxDepth, для commentContext - на общее
+// количество комментария, его предков и ответов.
+//
+// Пример использования:
+//
+//	generated.NewExecutableSchema(generated.Config{
+//	    Resolvers:  resolverImpl,
+//	    Complexity: limit.Complexity(limit.Weights{Stats: 10}),
+//	})
+func Complexity(weights Weights) generated.ComplexityRoot {
+	var root generated.ComplexityRoot
+
+	root.Query.Posts = func(childComplexity int, first *int, after *string, last *int, before *string, filter *generated.PostFilter, orderBy *generated.PostOrder) int {
+		return connectionCost(childComplexity, first, last, defaultPostPageSize)
+	}
+	root.Query.Comments = func(childComplexity int, postID string, first *int, after *string, last *int, before *string, filter *generated.CommentFilter, orderBy *generated.CommentOrder) int {
+		return connectionCost(childComplexity, first, last, defaultCommentPageSize)
+	}
+	root.Query.SearchPosts = func(childComplexity int, query string, language *generated.Language, first *int, after *string) int {
+		return connectionCost(childComplexity, first, nil, defaultSearchPageSize)
+	}
+	root.Query.SearchComments = func(childComplexity int, postID string, query string, language *generated.Language, first *int, after *string) int {
+		return connectionCost(childComplexity, first, nil, defaultSearchPageSize)
+	}
+	root.Post.Comments = func(childComplexity int, first *int, after *string, last *int, before *string, filter *generated.CommentFilter) int {
+		return connectionCost(childComplexity, first, last, defaultCommentPageSize)
+	}
+	root.Comment.Children = func(childComplexity int, first *int, after *string, last *int, before *string) int {
+		return connectionCost(childComplexity, first, last, defaultCommentPageSize)
+	}
+
+	root.Query.CommentTree = func(childComplexity int, postID string, parentID *string, maxDepth *int, filter *generated.CommentFilter, orderBy *generated.CommentOrder, first *int, after *string, repliesFirst *int) int {
+		size := model.CommentTreeSize(
+			valueOr(first, model.DefaultCommentTreePageSize),
+			valueOr(repliesFirst, model.DefaultCommentTreeReplies),
+			valueOr(maxDepth, model.DefaultCommentTreeDepth),
+		)
+		return saturatingAdd(1, saturatingMul(childComplexity, max(size, 1)))
+	}
+	root.Query.CommentContext = func(childComplexity int, id string, ancestors *int, replies *int) int {
+		size := saturatingAdd(
+			saturatingAdd(1, max(valueOr(ancestors, model.DefaultCommentContextAncestors), 0)),
+			max(valueOr(replies, model.DefaultCommentTreePageSize), 0),
+		)
+		return saturatingAdd(1, saturatingMul(childComplexity, size))
+	}
+	root.Query.PostStats = func(childComplexity int, id string) int {
+		return saturatingAdd(max(weights.Stats, 1), childComplexity)
+	}
+	root.Query.CommentStats = func(childComplexity int, postID string) int {
+		return saturatingAdd(max(weights.Stats, 1), childComplexity)
+	}
+	root.Query.PostAnalytics = func(childComplexity int, filter *generated.PostAnalyticsFilter, orderBy *generated.PostAnalyticsOrder, first *int) int {
+		return connectionCost(childComplexity, first, nil, model.DefaultPostAnalyticsLimit)
+	}
+
+	return root
+}
+
+// connectionCost вычисляет стоимость поля-соединения с размером страницы first или last
+func connectionCost(childComplexity int, first, last *int, defaultSize int) int {
+	size := defaultSize
+	switch {
+	case first != nil:
+		size = *first
+	case last != nil:
+		size = *last
+	}
+
+	return saturatingAdd(1, saturatingMul(childComplexity, max(size, 1)))
+}
+
+// valueOr возвращает значение аргумента или defaultValue, если аргумент не задан
+func valueOr(value *int, defaultValue int) int {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
+
+// saturatingAdd складывает неотрицательные стоимости, ограничивая результат math.MaxInt
+func saturatingAdd(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
+
+// saturatingMul умножает неотрицательные стоимости, ограничивая результат math.MaxInt,
+// чтобы переполнение не позволило обойти лимит
+func saturatingMul(a, b int) int {
+	if a != 0 && b > math.MaxInt/a {
+		return math.MaxInt
+	}
+	return a * b
+}
@@ -0,0 +1,151 @@
+// Package limit ограничивает глубину и стоимость GraphQL запросов.
+//
+// Комментарии вкладываются до 50 уровней, а поле Comment.children рекурсивно,
+// поэтому один запрос может развернуться в огромную выборку. Extension вычисляет
+// глубину и стоимость операции после валидации и отклоняет запрос до выполнения
+// резолверов.
+package limit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/99designs/gqlgen/complexity"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.uber.org/zap"
+)
+
+// Коды ошибок отклоненных запросов
+const (
+	// CodeQueryTooDeep - глубина запроса превышает лимит
+	CodeQueryTooDeep = "QUERY_TOO_DEEP"
+
+	// CodeQueryTooComplex - стоимость запроса превышает лимит
+	CodeQueryTooComplex = "QUERY_TOO_COMPLEX"
+)
+
+// Extension проверяет глубину и стоимость операции перед выполнением.
+//
+// При превышении лимита возвращается GraphQL ошибка с extensions:
+//   - code: "QUERY_TOO_DEEP" и depth - вычисленная глубина
+//   - code: "QUERY_TOO_COMPLEX" и cost - вычисленная стоимость
+//   - limit: нарушенный лимит
+//
+// Нулевой лимит отключает соответствующую проверку.
+//
+// Пример использования:
+//
+//	srv.Use(limit.New(12, 10000, logger))
+type Extension struct {
+	maxDepth      int
+	maxComplexity int
+	logger        *zap.Logger
+
+	schema graphql.ExecutableSchema
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = &Extension{}
+
+// New создает расширение с лимитами глубины maxDepth и стоимости maxComplexity
+func New(maxDepth, maxComplexity int, logger *zap.Logger) *Extension {
+	return &Extension{
+		maxDepth:      maxDepth,
+		maxComplexity: maxComplexity,
+		logger:        logger,
+	}
+}
+
+// ExtensionName возвращает имя расширения
+func (e *Extension) ExtensionName() string {
+	return "QueryLimit"
+}
+
+// Validate сохраняет схему, по функциям стоимости которой вычисляется стоимость запроса
+func (e *Extension) Validate(schema graphql.ExecutableSchema) error {
+	if schema == nil {
+		return errors.New("query limit requires executable schema")
+	}
+	e.schema = schema
+	return nil
+}
+
+// MutateOperationContext отклоняет операцию, превышающую лимиты
+func (e *Extension) MutateOperationContext(ctx context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	op := opCtx.Doc.Operations.ForName(opCtx.OperationName)
+	if op == nil {
+		return nil
+	}
+
+	// Глубина проверяется первой: она дешевле и ограничивает рекурсию при подсчете стоимости
+	if e.maxDepth > 0 {
+		if depth := selectionSetDepth(op.SelectionSet); depth > e.maxDepth {
+			e.logger.Debug("Query rejected by depth limit",
+				zap.String("operation", opCtx.OperationName),
+				zap.Int("depth", depth),
+				zap.Int("limit", e.maxDepth),
+			)
+			return newError(CodeQueryTooDeep,
+				fmt.Sprintf("query depth %d exceeds the limit of %d", depth, e.maxDepth),
+				map[string]interface{}{"depth": depth, "limit": e.maxDepth},
+			)
+		}
+	}
+
+	if e.maxComplexity > 0 {
+		if cost := complexity.Calculate(ctx, e.schema, op, opCtx.Variables); cost > e.maxComplexity {
+			e.logger.Debug("Query rejected by complexity limit",
+				zap.String("operation", opCtx.OperationName),
+				zap.Int("cost", cost),
+				zap.Int("limit", e.maxComplexity),
+			)
+			return newError(CodeQueryTooComplex,
+				fmt.Sprintf("query cost %d exceeds the limit of %d", cost, e.maxComplexity),
+				map[string]interface{}{"cost": cost, "limit": e.maxComplexity},
+			)
+		}
+	}
+
+	return nil
+}
+
+// selectionSetDepth возвращает глубину вложенности полей.
+//
+// Фрагменты не добавляют уровней, служебные поля интроспекции (__schema, __type)
+// не учитываются.
+func selectionSetDepth(selectionSet ast.SelectionSet) int {
+	depth := 0
+	for _, selection := range selectionSet {
+		var selectionDepth int
+		switch s := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name, "__") {
+				continue
+			}
+			selectionDepth = 1 + selectionSetDepth(s.SelectionSet)
+		case *ast.FragmentSpread:
+			if s.Definition != nil {
+				selectionDepth = selectionSetDepth(s.Definition.SelectionSet)
+			}
+		case *ast.InlineFragment:
+			selectionDepth = selectionSetDepth(s.SelectionSet)
+		}
+		depth = max(depth, selectionDepth)
+	}
+	return depth
+}
+
+// newError создает GraphQL ошибку с кодом в extensions
+func newError(code, message string, extensions map[string]interface{}) *gqlerror.Error {
+	extensions["code"] = code
+	return &gqlerror.Error{
+		Message:    message,
+		Extensions: extensions,
+	}
+}
@@ -0,0 +1,146 @@
+package limit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.uber.org/zap"
+
+	"github.com/NarthurN/habbr/internal/api/graphql/generated"
+)
+
+// check применяет лимиты к запросу и возвращает ошибку расширения
+func check(t *testing.T, maxDepth, maxComplexity int, query string, variables map[string]interface{}) *gqlerror.Error {
+	t.Helper()
+
+	schema := generated.NewExecutableSchema(generated.Config{
+		Complexity: Complexity(Weights{Stats: 10}),
+	})
+
+	doc, errs := gqlparser.LoadQuery(schema.Schema(), query)
+	require.Empty(t, errs)
+
+	ext := New(maxDepth, maxComplexity, zap.NewNop())
+	require.NoError(t, ext.Validate(schema))
+
+	return ext.MutateOperationContext(context.Background(), &graphql.OperationContext{
+		Doc:       doc,
+		Variables: variables,
+	})
+}
+
+func TestDepthLimit(t *testing.T) {
+	query := `{
+		posts(first: 1) { edges { node { comments(first: 1) { edges { node {
+			children(first: 1) { edges { node { id } } }
+		} } } } } }
+	}`
+
+	require.Nil(t, check(t, 10, 0, query, nil))
+
+	err := check(t, 9, 0, query, nil)
+	require.NotNil(t, err)
+	assert.Equal(t, "query depth 10 exceeds the limit of 9", err.Message)
+	assert.Equal(t, CodeQueryTooDeep, err.Extensions["code"])
+	assert.Equal(t, 10, err.Extensions["depth"])
+	assert.Equal(t, 9, err.Extensions["limit"])
+}
+
+func TestDepthLimitFragments(t *testing.T) {
+	query := `
+		query { post(id: "1") { ...PostFields } }
+		fragment PostFields on Post { comments { edges { node { id } } } }
+	`
+
+	err := check(t, 4, 0, query, nil)
+	require.NotNil(t, err)
+	assert.Equal(t, 5, err.Extensions["depth"])
+}
+
+func TestComplexityLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		wantCost  int
+	}{
+		{
+			// edges(1 + node(1 + id)) = 3 на пост, 10 постов
+			name:     "connection multiplied by first",
+			query:    `{ posts(first: 10) { edges { node { id } } } }`,
+			wantCost: 1 + 3*10,
+		},
+		{
+			name:      "first from variables",
+			query:     `query($n: Int) { posts(first: $n) { edges { node { id } } } }`,
+			variables: map[string]interface{}{"n": 5},
+			wantCost:  1 + 3*5,
+		},
+		{
+			name:     "default page size",
+			query:    `{ posts { edges { node { id } } } }`,
+			wantCost: 1 + 3*defaultPostPageSize,
+		},
+		{
+			// comments: 1 + 3*2 = 7; node: 1 + 7 = 8; edges: 9; posts: 1 + 9*3
+			name:     "nested connections multiply",
+			query:    `{ posts(first: 3) { edges { node { comments(first: 2) { edges { node { id } } } } } } }`,
+			wantCost: 1 + 9*3,
+		},
+		{
+			// nodes(1 + comment(1 + id)) = 3 на узел, ветка 10 * (1 + 3 + 9 + 27) узлов
+			name:     "comment tree default size",
+			query:    `{ commentTree(postID: "1") { nodes { comment { id } } } }`,
+			wantCost: 1 + 3*400,
+		},
+		{
+			name:     "comment tree size from arguments",
+			query:    `{ commentTree(postID: "1", first: 2, repliesFirst: 2, maxDepth: 1) { nodes { comment { id } } } }`,
+			wantCost: 1 + 3*6,
+		},
+		{
+			// comment(1 + id) = 2, комментарий + 2 предка + 3 ответа = 6
+			name:     "comment context size from arguments",
+			query:    `{ commentContext(id: "1", ancestors: 2, replies: 3) { comment { id } } }`,
+			wantCost: 1 + 2*6,
+		},
+		{
+			name:     "stats weight",
+			query:    `{ postStats(id: "1") { totalComments } }`,
+			wantCost: 10 + 1,
+		},
+		{
+			// postID + totalComments = 2 на пост
+			name:     "post analytics list multiplied by first",
+			query:    `{ postAnalytics(first: 5) { postID totalComments } }`,
+			wantCost: 1 + 2*5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Nil(t, check(t, 0, tt.wantCost, tt.query, tt.variables))
+
+			err := check(t, 0, tt.wantCost-1, tt.query, tt.variables)
+			require.NotNil(t, err)
+			assert.Equal(t, CodeQueryTooComplex, err.Extensions["code"])
+			assert.Equal(t, tt.wantCost, err.Extensions["cost"])
+			assert.Equal(t, tt.wantCost-1, err.Extensions["limit"])
+		})
+	}
+}
+
+func TestComplexityDoesNotOverflow(t *testing.T) {
+	query := `{ posts(first: 2147483647) { edges { node { comments(first: 2147483647) {
+		edges { node { children(first: 2147483647) { edges { node { id } } } } }
+	} } } } }`
+
+	err := check(t, 0, 10000, query, nil)
+	require.NotNil(t, err)
+	assert.Equal(t, CodeQueryTooComplex, err.Extensions["code"])
+}
@@ -2,46 +2,154 @@ package resolver
 
 import (
 	"context"
-	"time"
+	"errors"
 
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/NarthurN/habbr/internal/api/graphql/converter"
 	"github.com/NarthurN/habbr/internal/api/graphql/generated"
+	"github.com/NarthurN/habbr/internal/auth"
+	"github.com/NarthurN/habbr/internal/dataloader"
+	"github.com/NarthurN/habbr/internal/model"
 	"github.com/NarthurN/habbr/internal/service"
+	"github.com/NarthurN/habbr/internal/service/subscription"
 	"github.com/google/uuid"
+	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
-// getCurrentPostStats получает текущую статистику поста
-func getCurrentPostStats(ctx context.Context, services *service.Services, postID uuid.UUID) (*generated.PostStats, error) {
+// loadPostStats загружает текущую статистику поста из хранилища
+func loadPostStats(ctx context.Context, services *service.Services, postID uuid.UUID) (*model.PostStats, error) {
 	// Получаем пост для проверки существования
 	post, err := services.Post.GetPost(ctx, postID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Получаем количество комментариев
-	filter := generated.CommentFilter{}
-	domainFilter, _ := converter.CommentFilterFromGraphQL(&filter)
-	domainFilter.PostID = &postID
+	// Статистика комментариев вычисляется в хранилище
+	stats, err := services.Comment.GetCommentStats(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PostStats{
+		PostID:          postID,
+		TotalComments:   stats.TotalComments,
+		RootComments:    stats.RootComments,
+		Replies:         stats.Replies,
+		CommentsEnabled: post.CommentsEnabled,
+		LastCommentAt:   stats.LastCommentAt,
+	}, nil
+}
 
-	pagination := converter.PaginationFromGraphQL(nil, nil, nil, nil)
-	comments, err := services.Comment.ListComments(ctx, *domainFilter, *pagination)
+// loadPostComments загружает комментарии поста для поля Post.comments.
+//
+// Использует загрузчики запроса, чтобы комментарии всех постов списка были получены
+// одним запросом к хранилищу. Без загрузчиков в контексте (middleware не подключен)
+// выполняет пакетный запрос для одного поста.
+func loadPostComments(ctx context.Context, services *service.Services, postID uuid.UUID, filter model.CommentFilter, pagination model.PaginationInput) (*model.CommentConnection, error) {
+	if loaders, ok := dataloader.FromContext(ctx); ok {
+		return loaders.PostComments(ctx, postID, filter, pagination)
+	}
+
+	connections, err := services.Comment.ListCommentsByPostIDs(ctx, []uuid.UUID{postID}, filter, pagination)
 	if err != nil {
 		return nil, err
 	}
+	return connections[postID], nil
+}
+
+// loadReplies загружает прямые ответы на комментарий для поля Comment.children,
+// аналогично loadPostComments
+func loadReplies(ctx context.Context, services *service.Services, parentID uuid.UUID, filter model.CommentFilter, pagination model.PaginationInput) (*model.CommentConnection, error) {
+	if loaders, ok := dataloader.FromContext(ctx); ok {
+		return loaders.Replies(ctx, parentID, filter, pagination)
+	}
 
-	// Находим последний комментарий
-	var lastCommentAt *time.Time
-	if len(comments.Edges) > 0 {
-		for _, edge := range comments.Edges {
-			if lastCommentAt == nil || edge.Node.CreatedAt.After(*lastCommentAt) {
-				lastCommentAt = &edge.Node.CreatedAt
-			}
-		}
+	connections, err := services.Comment.ListRepliesByParentIDs(ctx, []uuid.UUID{parentID}, filter, pagination)
+	if err != nil {
+		return nil, err
 	}
+	return connections[parentID], nil
+}
 
-	return &generated.PostStats{
-		TotalComments:   len(comments.Edges),
-		CommentsEnabled: post.CommentsEnabled,
-		LastCommentAt:   lastCommentAt,
-	}, nil
+// currentUserID возвращает идентификатор аутентифицированного пользователя из контекста запроса.
+//
+// Возвращает ошибку UNAUTHORIZED для анонимных запросов.
+func currentUserID(ctx context.Context) (uuid.UUID, error) {
+	principal, err := currentPrincipal(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return principal.UserID, nil
+}
+
+// currentPrincipal возвращает аутентифицированного пользователя из контекста запроса
+// или ошибку UNAUTHORIZED для анонимных запросов
+func currentPrincipal(ctx context.Context) (*model.Principal, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, model.NewUnauthorizedError()
+	}
+	return principal, nil
+}
+
+// resolveInputAuthor определяет автора создаваемой сущности.
+//
+// Автором всегда становится аутентифицированный пользователь. Если клиент
+// передал во входных данных другой authorID, запрос отклоняется с ошибкой FORBIDDEN,
+// чтобы нельзя было публиковать контент от чужого имени.
+func resolveInputAuthor(ctx context.Context, inputAuthorID uuid.UUID) (uuid.UUID, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if inputAuthorID != uuid.Nil && inputAuthorID != userID {
+		return uuid.Nil, model.NewForbiddenError("act on behalf of another author")
+	}
+
+	return userID, nil
+}
+
+// subscriptionContext подготавливает контекст подписки с политикой медленного подписчика,
+// выбранной клиентом (nil - политика из конфигурации)
+func subscriptionContext(ctx context.Context, slowConsumer *generated.SlowConsumerPolicy) context.Context {
+	return subscription.WithSlowConsumerPolicy(ctx, converter.SlowConsumerPolicyFromGraphQL(slowConsumer))
+}
+
+// subscribeError возвращает ошибку создания подписки; для доменной ошибки ее тип
+// передается клиенту кодом в extensions (например, EVENT_CURSOR_EXPIRED)
+func subscribeError(ctx context.Context, err error) error {
+	var domainErr *model.DomainError
+	if !errors.As(err, &domainErr) {
+		return err
+	}
+
+	return &gqlerror.Error{
+		Message: domainErr.Message,
+		Path:    graphql.GetPath(ctx),
+		Extensions: map[string]interface{}{
+			"code": domainErr.Type,
+		},
+	}
+}
+
+// reportSubscriptionError передает клиенту ошибку, с которой сервис подписок завершил подписку.
+//
+// Вызывается после закрытия канала сервиса и до закрытия канала резолвера: тогда websocket
+// транспорт завершает подписку сообщением error с кодом в extensions вместо complete.
+// Подписки обслуживаются только websocket транспортом.
+func reportSubscriptionError(ctx context.Context) {
+	var domainErr *model.DomainError
+	if !errors.As(subscription.Err(ctx), &domainErr) {
+		return
+	}
+
+	transport.AddSubscriptionError(ctx, &gqlerror.Error{
+		Message: domainErr.Message,
+		Path:    graphql.GetPath(ctx),
+		Extensions: map[string]interface{}{
+			"code": domainErr.Type,
+		},
+	})
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/NarthurN/habbr/internal/api/graphql/converter"
 	"github.com/NarthurN/habbr/internal/api/graphql/generated"
+	"github.com/NarthurN/habbr/internal/model"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
@@ -24,6 +25,13 @@ func (r *mutationResolver) CreatePost(ctx context.Context, input generated.PostI
 		return converter.PostResultToGraphQL(nil, err), nil
 	}
 
+	// Автором поста всегда является аутентифицированный пользователь
+	domainInput.AuthorID, err = resolveInputAuthor(ctx, domainInput.AuthorID)
+	if err != nil {
+		r.logger.Warn("Post author does not match authenticated user", zap.Error(err))
+		return converter.PostResultToGraphQL(nil, err), nil
+	}
+
 	// Создаем пост через сервис
 	post, err := r.services.Post.CreatePost(ctx, *domainInput)
 	if err != nil {
@@ -53,9 +61,11 @@ func (r *mutationResolver) UpdatePost(ctx context.Context, id string, input gene
 		return converter.PostResultToGraphQL(nil, err), nil
 	}
 
-	// TODO: Получить AuthorID из контекста аутентификации
-	// Пока используем фиктивный ID
-	authorID := uuid.New()
+	// Действие выполняется от имени аутентифицированного пользователя
+	authorID, err := currentUserID(ctx)
+	if err != nil {
+		return converter.PostResultToGraphQL(nil, err), nil
+	}
 
 	// Обновляем пост через сервис
 	post, err := r.services.Post.UpdatePost(ctx, postID, *domainInput, authorID)
@@ -79,9 +89,11 @@ func (r *mutationResolver) DeletePost(ctx context.Context, id string) (*generate
 		return converter.DeleteResultToGraphQL(uuid.Nil, err), nil
 	}
 
-	// TODO: Получить AuthorID из контекста аутентификации
-	// Пока используем фиктивный ID
-	authorID := uuid.New()
+	// Действие выполняется от имени аутентифицированного пользователя
+	authorID, err := currentUserID(ctx)
+	if err != nil {
+		return converter.DeleteResultToGraphQL(uuid.Nil, err), nil
+	}
 
 	// Удаляем пост через сервис
 	err = r.services.Post.DeletePost(ctx, postID, authorID)
@@ -105,9 +117,11 @@ func (r *mutationResolver) EnableComments(ctx context.Context, postID string) (*
 		return converter.PostResultToGraphQL(nil, err), nil
 	}
 
-	// TODO: Получить AuthorID из контекста аутентификации
-	// Пока используем фиктивный ID
-	authorID := uuid.New()
+	// Действие выполняется от имени аутентифицированного пользователя
+	authorID, err := currentUserID(ctx)
+	if err != nil {
+		return converter.PostResultToGraphQL(nil, err), nil
+	}
 
 	// Включаем комментарии через сервис
 	post, err := r.services.Post.ToggleComments(ctx, parsedPostID, authorID, true)
@@ -131,9 +145,11 @@ func (r *mutationResolver) DisableComments(ctx context.Context, postID string) (
 		return converter.PostResultToGraphQL(nil, err), nil
 	}
 
-	// TODO: Получить AuthorID из контекста аутентификации
-	// Пока используем фиктивный ID
-	authorID := uuid.New()
+	// Действие выполняется от имени аутентифицированного пользователя
+	authorID, err := currentUserID(ctx)
+	if err != nil {
+		return converter.PostResultToGraphQL(nil, err), nil
+	}
 
 	// Отключаем комментарии через сервис
 	post, err := r.services.Post.ToggleComments(ctx, parsedPostID, authorID, false)
@@ -157,6 +173,13 @@ func (r *mutationResolver) CreateComment(ctx context.Context, input generated.Co
 		return converter.CommentResultToGraphQL(nil, err), nil
 	}
 
+	// Автором комментария всегда является аутентифицированный пользователь
+	domainInput.AuthorID, err = resolveInputAuthor(ctx, domainInput.AuthorID)
+	if err != nil {
+		r.logger.Warn("Comment author does not match authenticated user", zap.Error(err))
+		return converter.CommentResultToGraphQL(nil, err), nil
+	}
+
 	// Создаем комментарий через сервис
 	comment, err := r.services.Comment.CreateComment(ctx, *domainInput)
 	if err != nil {
@@ -186,9 +209,11 @@ func (r *mutationResolver) UpdateComment(ctx context.Context, id string, input g
 		return converter.CommentResultToGraphQL(nil, err), nil
 	}
 
-	// TODO: Получить AuthorID из контекста аутентификации
-	// Пока используем фиктивный ID
-	authorID := uuid.New()
+	// Действие выполняется от имени аутентифицированного пользователя
+	authorID, err := currentUserID(ctx)
+	if err != nil {
+		return converter.CommentResultToGraphQL(nil, err), nil
+	}
 
 	// Обновляем комментарий через сервис
 	comment, err := r.services.Comment.UpdateComment(ctx, commentID, *domainInput, authorID)
@@ -202,7 +227,7 @@ func (r *mutationResolver) UpdateComment(ctx context.Context, id string, input g
 }
 
 // DeleteComment is the resolver for the deleteComment field.
-func (r *mutationResolver) DeleteComment(ctx context.Context, id string) (*generated.DeleteResult, error) {
+func (r *mutationResolver) DeleteComment(ctx context.Context, id string, soft *bool) (*generated.DeleteResult, error) {
 	r.logger.Debug("DeleteComment mutation", zap.String("id", id))
 
 	// Парсим ID
@@ -212,9 +237,28 @@ func (r *mutationResolver) DeleteComment(ctx context.Context, id string) (*gener
 		return converter.DeleteResultToGraphQL(uuid.Nil, err), nil
 	}
 
-	// TODO: Получить AuthorID из контекста аутентификации
-	// Пока используем фиктивный ID
-	authorID := uuid.New()
+	// Действие выполняется от имени аутентифицированного пользователя
+	authorID, err := currentUserID(ctx)
+	if err != nil {
+		return converter.DeleteResultToGraphQL(uuid.Nil, err), nil
+	}
+
+	// Мягкое удаление сохраняет ветку ответов под надгробием
+	if soft != nil && *soft {
+		comment, tombstone, err := r.services.Comment.SoftDeleteComment(ctx, commentID, authorID)
+		if err != nil {
+			r.logger.Error("Failed to soft delete comment", zap.String("id", id), zap.Error(err))
+			return converter.DeleteResultToGraphQL(uuid.Nil, err), nil
+		}
+
+		result := converter.DeleteResultToGraphQL(commentID, nil)
+		if tombstone {
+			result.Tombstone = converter.CommentToGraphQL(comment)
+		}
+
+		r.logger.Info("Comment soft deleted successfully", zap.String("id", id), zap.Bool("tombstone", tombstone))
+		return result, nil
+	}
 
 	// Удаляем комментарий через сервис
 	err = r.services.Comment.DeleteComment(ctx, commentID, authorID)
@@ -228,39 +272,59 @@ func (r *mutationResolver) DeleteComment(ctx context.Context, id string) (*gener
 }
 
 // DeleteCommentsBatch is the resolver for the deleteCommentsBatch field.
-func (r *mutationResolver) DeleteCommentsBatch(ctx context.Context, postID string, commentIDs []string) (*generated.BatchDeleteResult, error) {
+func (r *mutationResolver) DeleteCommentsBatch(ctx context.Context, postID string, commentIDs []string, atomic *bool) (*generated.CommentBatchDeleteResult, error) {
 	r.logger.Debug("DeleteCommentsBatch mutation", zap.String("postID", postID), zap.Int("count", len(commentIDs)))
 
-	var deletedIDs []uuid.UUID
-	var errors []error
+	// Парсим ID поста
+	parsedPostID, err := converter.ParseID(postID)
+	if err != nil {
+		r.logger.Error("Invalid post ID", zap.String("postID", postID), zap.Error(err))
+		return converter.CommentBatchDeleteResultToGraphQL(commentIDs, nil, err), nil
+	}
 
-	// TODO: Получить AuthorID из контекста аутентификации
-	// Пока используем фиктивный ID
-	authorID := uuid.New()
+	// Действие выполняется от имени аутентифицированного пользователя
+	principal, err := currentPrincipal(ctx)
+	if err != nil {
+		return converter.CommentBatchDeleteResultToGraphQL(commentIDs, nil, err), nil
+	}
 
-	// Удаляем комментарии по одному
+	// Некорректные ID не передаются в сервис и получают итог INVALID_ID
+	ids := make([]uuid.UUID, 0, len(commentIDs))
 	for _, idStr := range commentIDs {
-		commentID, err := converter.ParseID(idStr)
-		if err != nil {
-			r.logger.Error("Invalid comment ID in batch", zap.String("id", idStr), zap.Error(err))
-			errors = append(errors, err)
-			continue
+		if commentID, err := converter.ParseID(idStr); err == nil {
+			ids = append(ids, commentID)
 		}
+	}
+	atomicMode := atomic != nil && *atomic
 
-		err = r.services.Comment.DeleteComment(ctx, commentID, authorID)
+	result := &model.CommentBatchDeleteResult{}
+	switch {
+	case len(ids) == len(commentIDs) || (!atomicMode && len(ids) > 0):
+		result, err = r.services.Comment.DeleteCommentsBatch(ctx, parsedPostID, ids, principal, atomicMode)
 		if err != nil {
-			r.logger.Error("Failed to delete comment in batch", zap.String("id", idStr), zap.Error(err))
-			errors = append(errors, err)
-		} else {
-			deletedIDs = append(deletedIDs, commentID)
+			r.logger.Error("Failed to delete comments batch", zap.String("postID", postID), zap.Error(err))
+			return converter.CommentBatchDeleteResultToGraphQL(commentIDs, nil, err), nil
+		}
+	case atomicMode:
+		// Атомарное удаление с некорректным ID не удаляет ни одного комментария
+		for _, commentID := range ids {
+			result.Items = append(result.Items, &model.CommentDeleteItem{
+				ID:      commentID,
+				Status:  model.CommentDeleteStatusSkipped,
+				Message: "batch deletion aborted by another comment",
+			})
 		}
 	}
 
+	gqlResult := converter.CommentBatchDeleteResultToGraphQL(commentIDs, result, nil)
 	r.logger.Info("Batch comment deletion completed",
-		zap.Int("deleted", len(deletedIDs)),
-		zap.Int("errors", len(errors)))
+		zap.String("postID", postID),
+		zap.Bool("atomic", atomicMode),
+		zap.Bool("success", gqlResult.Success),
+		zap.Int("deleted", gqlResult.DeletedCount),
+	)
 
-	return converter.BatchDeleteResultToGraphQL(deletedIDs, errors), nil
+	return gqlResult, nil
 }
 
 // DeleteCommentsTree is the resolver for the deleteCommentsTree field.
@@ -274,20 +338,24 @@ func (r *mutationResolver) DeleteCommentsTree(ctx context.Context, commentID str
 		return converter.BatchDeleteResultToGraphQL(nil, []error{err}), nil
 	}
 
-	// TODO: Получить AuthorID из контекста аутентификации
-	// Пока используем фиктивный ID
-	authorID := uuid.New()
+	// Действие выполняется от имени аутентифицированного пользователя
+	authorID, err := currentUserID(ctx)
+	if err != nil {
+		return converter.BatchDeleteResultToGraphQL(nil, []error{err}), nil
+	}
 
-	// Для удаления дерева комментариев нужно сначала получить все дочерние комментарии
-	// Пока просто удаляем один комментарий
-	err = r.services.Comment.DeleteComment(ctx, parsedCommentID, authorID)
+	// Удаляем комментарий со всем поддеревом ответов
+	deletedIDs, err := r.services.Comment.DeleteCommentsTree(ctx, parsedCommentID, authorID)
 	if err != nil {
 		r.logger.Error("Failed to delete comment tree", zap.String("commentID", commentID), zap.Error(err))
 		return converter.BatchDeleteResultToGraphQL(nil, []error{err}), nil
 	}
 
-	r.logger.Info("Comment tree deleted successfully", zap.String("commentID", commentID))
-	return converter.BatchDeleteResultToGraphQL([]uuid.UUID{parsedCommentID}, nil), nil
+	r.logger.Info("Comment tree deleted successfully",
+		zap.String("commentID", commentID),
+		zap.Int("deletedCount", len(deletedIDs)),
+	)
+	return converter.BatchDeleteResultToGraphQL(deletedIDs, nil), nil
 }
 
 // Mutation returns generated.MutationResolver implementation.
@@ -6,15 +6,15 @@ package resolver
 
 import (
 	"context"
-	"time"
 
 	"github.com/NarthurN/habbr/internal/api/graphql/converter"
 	"github.com/NarthurN/habbr/internal/api/graphql/generated"
+	"github.com/NarthurN/habbr/internal/model"
 	"go.uber.org/zap"
 )
 
 // Posts is the resolver for the posts field.
-func (r *queryResolver) Posts(ctx context.Context, first *int, after *string, last *int, before *string, filter *generated.PostFilter) (*generated.PostConnection, error) {
+func (r *queryResolver) Posts(ctx context.Context, first *int, after *string, last *int, before *string, filter *generated.PostFilter, orderBy *generated.PostOrder) (*generated.PostConnection, error) {
 	r.logger.Debug("Posts query", zap.Any("filter", filter))
 
 	// Конвертируем фильтр
@@ -23,6 +23,7 @@ func (r *queryResolver) Posts(ctx context.Context, first *int, after *string, la
 		r.logger.Error("Failed to convert post filter", zap.Error(err))
 		return nil, err
 	}
+	domainFilter.Order = converter.PostOrderFromGraphQL(orderBy)
 
 	// Конвертируем пагинацию
 	pagination := converter.PaginationFromGraphQL(first, last, after, before)
@@ -59,7 +60,7 @@ func (r *queryResolver) Post(ctx context.Context, id string) (*generated.Post, e
 }
 
 // Comments is the resolver for the comments field.
-func (r *queryResolver) Comments(ctx context.Context, postID string, first *int, after *string, last *int, before *string, filter *generated.CommentFilter) (*generated.CommentConnection, error) {
+func (r *queryResolver) Comments(ctx context.Context, postID string, first *int, after *string, last *int, before *string, filter *generated.CommentFilter, orderBy *generated.CommentOrder) (*generated.CommentConnection, error) {
 	r.logger.Debug("Comments query", zap.String("postID", postID))
 
 	// Парсим ID поста
@@ -76,8 +77,9 @@ func (r *queryResolver) Comments(ctx context.Context, postID string, first *int,
 		return nil, err
 	}
 
-	// Устанавливаем PostID в фильтре
+	// Устанавливаем PostID и порядок сортировки в фильтре
 	domainFilter.PostID = &parsedPostID
+	domainFilter.Order = converter.CommentOrderFromGraphQL(orderBy)
 
 	// Конвертируем пагинацию
 	pagination := converter.PaginationFromGraphQL(first, last, after, before)
@@ -113,9 +115,37 @@ func (r *queryResolver) Comment(ctx context.Context, id string) (*generated.Comm
 	return converter.CommentToGraphQL(comment), nil
 }
 
+// CommentContext is the resolver for the commentContext field.
+func (r *queryResolver) CommentContext(ctx context.Context, id string, ancestors *int, replies *int) (*generated.CommentContext, error) {
+	r.logger.Debug("CommentContext query",
+		zap.String("id", id),
+		zap.Any("ancestors", ancestors),
+		zap.Any("replies", replies),
+	)
+
+	// Парсим ID
+	commentID, err := converter.ParseID(id)
+	if err != nil {
+		r.logger.Error("Invalid comment ID", zap.String("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	commentCtx, err := r.services.Comment.GetCommentContext(ctx, commentID, ancestors, replies)
+	if err != nil {
+		r.logger.Error("Failed to get comment context", zap.String("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return converter.CommentContextToGraphQL(commentCtx), nil
+}
+
 // CommentTree is the resolver for the commentTree field.
-func (r *queryResolver) CommentTree(ctx context.Context, postID string, maxDepth *int, filter *generated.CommentFilter) ([]*generated.Comment, error) {
-	r.logger.Debug("CommentTree query", zap.String("postID", postID), zap.Any("maxDepth", maxDepth))
+func (r *queryResolver) CommentTree(ctx context.Context, postID string, parentID *string, maxDepth *int, filter *generated.CommentFilter, orderBy *generated.CommentOrder, first *int, after *string, repliesFirst *int) (*generated.CommentTree, error) {
+	r.logger.Debug("CommentTree query",
+		zap.String("postID", postID),
+		zap.Stringp("parentID", parentID),
+		zap.Any("maxDepth", maxDepth),
+	)
 
 	// Парсим ID поста
 	parsedPostID, err := converter.ParseID(postID)
@@ -124,20 +154,41 @@ func (r *queryResolver) CommentTree(ctx context.Context, postID string, maxDepth
 		return nil, err
 	}
 
-	// Получаем дерево комментариев через сервис
-	tree, err := r.services.Comment.GetCommentsTree(ctx, parsedPostID)
+	// Конвертируем фильтр
+	domainFilter, err := converter.CommentFilterFromGraphQL(filter)
 	if err != nil {
-		r.logger.Error("Failed to get comment tree", zap.String("postID", postID), zap.Error(err))
+		r.logger.Error("Failed to convert comment filter", zap.Error(err))
 		return nil, err
 	}
+	domainFilter.Order = converter.CommentOrderFromGraphQL(orderBy)
+
+	query := model.CommentTreeQuery{
+		PostID:       parsedPostID,
+		Filter:       *domainFilter,
+		Depth:        maxDepth,
+		First:        first,
+		After:        after,
+		RepliesFirst: repliesFirst,
+	}
+
+	// Парсим ID родителя ветки
+	if parentID != nil {
+		parsedParentID, err := converter.ParseID(*parentID)
+		if err != nil {
+			r.logger.Error("Invalid parent ID", zap.String("parentID", *parentID), zap.Error(err))
+			return nil, err
+		}
+		query.ParentID = &parsedParentID
+	}
 
-	// Конвертируем в GraphQL формат
-	result := make([]*generated.Comment, len(tree))
-	for i, comment := range tree {
-		result[i] = converter.CommentToGraphQL(comment)
+	// Получаем ветку дерева комментариев через сервис
+	tree, err := r.services.Comment.GetCommentTree(ctx, query)
+	if err != nil {
+		r.logger.Error("Failed to get comment tree", zap.String("postID", postID), zap.Error(err))
+		return nil, err
 	}
 
-	return result, nil
+	return converter.CommentTreeToGraphQL(tree), nil
 }
 
 // PostStats is the resolver for the postStats field.
@@ -151,41 +202,13 @@ func (r *queryResolver) PostStats(ctx context.Context, id string) (*generated.Po
 		return nil, err
 	}
 
-	// Получаем пост для проверки существования
-	post, err := r.services.Post.GetPost(ctx, postID)
-	if err != nil {
-		r.logger.Error("Failed to get post for stats", zap.String("id", id), zap.Error(err))
-		return nil, err
-	}
-
-	// Получаем количество комментариев
-	filter := generated.CommentFilter{}
-	domainFilter, _ := converter.CommentFilterFromGraphQL(&filter)
-	domainFilter.PostID = &postID
-
-	pagination := converter.PaginationFromGraphQL(nil, nil, nil, nil)
-	comments, err := r.services.Comment.ListComments(ctx, *domainFilter, *pagination)
+	stats, err := loadPostStats(ctx, r.services, postID)
 	if err != nil {
-		r.logger.Error("Failed to get comments for stats", zap.String("postID", id), zap.Error(err))
+		r.logger.Error("Failed to get post stats", zap.String("id", id), zap.Error(err))
 		return nil, err
 	}
 
-	// Находим последний комментарий
-	var lastCommentAt *time.Time
-	if len(comments.Edges) > 0 {
-		// Сортируем по дате создания и берем последний
-		for _, edge := range comments.Edges {
-			if lastCommentAt == nil || edge.Node.CreatedAt.After(*lastCommentAt) {
-				lastCommentAt = &edge.Node.CreatedAt
-			}
-		}
-	}
-
-	return &generated.PostStats{
-		TotalComments:   len(comments.Edges),
-		CommentsEnabled: post.CommentsEnabled,
-		LastCommentAt:   lastCommentAt,
-	}, nil
+	return converter.PostStatsToGraphQL(stats), nil
 }
 
 // CommentStats is the resolver for the commentStats field.
@@ -199,73 +222,53 @@ func (r *queryResolver) CommentStats(ctx context.Context, postID string) (*gener
 		return nil, err
 	}
 
-	// Получаем все комментарии поста
-	filter := generated.CommentFilter{}
-	domainFilter, _ := converter.CommentFilterFromGraphQL(&filter)
-	domainFilter.PostID = &parsedPostID
-
-	pagination := converter.PaginationFromGraphQL(nil, nil, nil, nil)
-	comments, err := r.services.Comment.ListComments(ctx, *domainFilter, *pagination)
+	stats, err := r.services.Comment.GetCommentStats(ctx, parsedPostID)
 	if err != nil {
-		r.logger.Error("Failed to get comments for stats", zap.String("postID", postID), zap.Error(err))
+		r.logger.Error("Failed to get comment stats", zap.String("postID", postID), zap.Error(err))
 		return nil, err
 	}
 
-	totalComments := len(comments.Edges)
-	maxDepth := 0
-	totalDepth := 0
+	return converter.CommentStatsToGraphQL(stats), nil
+}
 
-	// Вычисляем статистику глубины
-	for _, edge := range comments.Edges {
-		depth := edge.Node.Depth
-		if depth > maxDepth {
-			maxDepth = depth
-		}
-		totalDepth += depth
+// PostAnalytics is the resolver for the postAnalytics field.
+func (r *queryResolver) PostAnalytics(ctx context.Context, filter *generated.PostAnalyticsFilter, orderBy *generated.PostAnalyticsOrder, first *int) ([]*generated.PostAnalytics, error) {
+	r.logger.Debug("PostAnalytics query", zap.Any("filter", filter), zap.Any("orderBy", orderBy))
+
+	domainFilter, err := converter.PostAnalyticsFilterFromGraphQL(filter, orderBy)
+	if err != nil {
+		r.logger.Error("Failed to convert post analytics filter", zap.Error(err))
+		return nil, err
 	}
 
-	var averageDepth float64 = 0
-	if totalComments > 0 {
-		averageDepth = float64(totalDepth) / float64(totalComments)
+	items, err := r.services.Analytics.ListPostAnalytics(ctx, domainFilter, first)
+	if err != nil {
+		r.logger.Error("Failed to list post analytics", zap.Error(err))
+		return nil, err
 	}
 
-	return &generated.CommentStats{
-		TotalComments: totalComments,
-		MaxDepth:      maxDepth,
-		AverageDepth:  averageDepth,
-	}, nil
+	return converter.PostAnalyticsToGraphQL(items), nil
 }
 
 // SearchPosts is the resolver for the searchPosts field.
-func (r *queryResolver) SearchPosts(ctx context.Context, query string, first *int, after *string) (*generated.PostConnection, error) {
-	r.logger.Debug("SearchPosts query", zap.String("query", query))
-
-	// Создаем фильтр для поиска
-	filter := generated.PostFilter{
-		Title:   &query,
-		Content: &query,
-	}
-	domainFilter, err := converter.PostFilterFromGraphQL(&filter)
-	if err != nil {
-		r.logger.Error("Failed to convert search filter", zap.Error(err))
-		return nil, err
-	}
+func (r *queryResolver) SearchPosts(ctx context.Context, query string, language *generated.Language, first *int, after *string) (*generated.PostSearchConnection, error) {
+	r.logger.Debug("SearchPosts query", zap.String("query", query), zap.Any("language", language))
 
 	// Конвертируем пагинацию
 	pagination := converter.PaginationFromGraphQL(first, nil, after, nil)
 
 	// Выполняем поиск через сервис
-	connection, err := r.services.Post.ListPosts(ctx, *domainFilter, *pagination)
+	connection, err := r.services.Search.SearchPosts(ctx, query, converter.LanguageFromGraphQL(language), *pagination)
 	if err != nil {
 		r.logger.Error("Failed to search posts", zap.String("query", query), zap.Error(err))
 		return nil, err
 	}
 
-	return converter.PostConnectionToGraphQL(connection), nil
+	return converter.PostSearchConnectionToGraphQL(connection), nil
 }
 
 // SearchComments is the resolver for the searchComments field.
-func (r *queryResolver) SearchComments(ctx context.Context, postID string, query string, first *int, after *string) (*generated.CommentConnection, error) {
+func (r *queryResolver) SearchComments(ctx context.Context, postID string, query string, language *generated.Language, first *int, after *string) (*generated.CommentSearchConnection, error) {
 	r.logger.Debug("SearchComments query", zap.String("postID", postID), zap.String("query", query))
 
 	// Парсим ID поста
@@ -275,27 +278,17 @@ func (r *queryResolver) SearchComments(ctx context.Context, postID string, query
 		return nil, err
 	}
 
-	// Создаем фильтр для поиска (здесь можно расширить логику поиска)
-	filter := generated.CommentFilter{}
-	domainFilter, err := converter.CommentFilterFromGraphQL(&filter)
-	if err != nil {
-		r.logger.Error("Failed to convert search filter", zap.Error(err))
-		return nil, err
-	}
-
-	domainFilter.PostID = &parsedPostID
-
 	// Конвертируем пагинацию
 	pagination := converter.PaginationFromGraphQL(first, nil, after, nil)
 
 	// Выполняем поиск через сервис
-	connection, err := r.services.Comment.ListComments(ctx, *domainFilter, *pagination)
+	connection, err := r.services.Search.SearchComments(ctx, parsedPostID, query, converter.LanguageFromGraphQL(language), *pagination)
 	if err != nil {
 		r.logger.Error("Failed to search comments", zap.String("postID", postID), zap.String("query", query), zap.Error(err))
 		return nil, err
 	}
 
-	return converter.CommentConnectionToGraphQL(connection), nil
+	return converter.CommentSearchConnectionToGraphQL(connection), nil
 }
 
 // Query returns generated.QueryResolver implementation.
@@ -9,11 +9,12 @@ import (
 
 	"github.com/NarthurN/habbr/internal/api/graphql/converter"
 	"github.com/NarthurN/habbr/internal/api/graphql/generated"
+	"github.com/NarthurN/habbr/internal/model"
 	"go.uber.org/zap"
 )
 
 // CommentEvents is the resolver for the commentEvents field.
-func (r *subscriptionResolver) CommentEvents(ctx context.Context, postID string) (<-chan *generated.CommentEvent, error) {
+func (r *subscriptionResolver) CommentEvents(ctx context.Context, postID string, afterEventID *string, slowConsumer *generated.SlowConsumerPolicy) (<-chan *generated.CommentEvent, error) {
 	r.logger.Debug("CommentEvents subscription", zap.String("postID", postID))
 
 	// Парсим ID поста
@@ -23,11 +24,22 @@ func (r *subscriptionResolver) CommentEvents(ctx context.Context, postID string)
 		return nil, err
 	}
 
-	// Подписываемся на события комментариев через сервис подписок
-	domainCh, err := r.services.Subscription.Subscribe(ctx, parsedPostID)
+	// Парсим ID последнего полученного события, если клиент переподключается
+	var parsedAfterEventID int64
+	if afterEventID != nil {
+		parsedAfterEventID, err = converter.ParseEventID(*afterEventID)
+		if err != nil {
+			r.logger.Error("Invalid event ID for subscription", zap.String("afterEventID", *afterEventID), zap.Error(err))
+			return nil, err
+		}
+	}
+
+	// Подписываемся на события комментариев через сервис подписок с повторной доставкой пропущенных
+	ctx = subscriptionContext(ctx, slowConsumer)
+	domainCh, err := r.services.Subscription.SubscribeFrom(ctx, parsedPostID, parsedAfterEventID)
 	if err != nil {
 		r.logger.Error("Failed to subscribe to comment events", zap.String("postID", postID), zap.Error(err))
-		return nil, err
+		return nil, subscribeError(ctx, err)
 	}
 
 	// Создаем канал для GraphQL событий
@@ -46,11 +58,12 @@ func (r *subscriptionResolver) CommentEvents(ctx context.Context, postID string)
 			case payload, ok := <-domainCh:
 				if !ok {
 					r.logger.Debug("Domain channel closed", zap.String("postID", postID))
+					reportSubscriptionError(ctx)
 					return
 				}
 
 				// Конвертируем в GraphQL событие
-				gqlEvent, err := converter.CommentEventToGraphQL(payload.ActionType, payload.Comment)
+				gqlEvent, err := converter.CommentPayloadToGraphQL(payload)
 				if err != nil {
 					r.logger.Error("Failed to convert comment event", zap.Error(err))
 					continue
@@ -74,65 +87,169 @@ func (r *subscriptionResolver) CommentEvents(ctx context.Context, postID string)
 }
 
 // AllCommentEvents is the resolver for the allCommentEvents field.
-func (r *subscriptionResolver) AllCommentEvents(ctx context.Context) (<-chan *generated.CommentEvent, error) {
+func (r *subscriptionResolver) AllCommentEvents(ctx context.Context, slowConsumer *generated.SlowConsumerPolicy) (<-chan *generated.CommentEvent, error) {
 	r.logger.Debug("AllCommentEvents subscription")
 
-	// TODO: Реализовать подписку на все события комментариев
-	// Пока возвращаем заглушку
-	eventCh := make(chan *generated.CommentEvent)
+	// Подписываемся на события комментариев всех постов
+	ctx = subscriptionContext(ctx, slowConsumer)
+	domainCh, err := r.services.Subscription.SubscribeAll(ctx)
+	if err != nil {
+		r.logger.Error("Failed to subscribe to all comment events", zap.Error(err))
+		return nil, err
+	}
 
+	// Создаем канал для GraphQL событий
+	eventCh := make(chan *generated.CommentEvent, 10)
+
+	// Горутина для конвертации domain событий в GraphQL события
 	go func() {
 		defer close(eventCh)
-		<-ctx.Done()
-		r.logger.Debug("AllCommentEvents subscription cancelled")
+		defer r.logger.Debug("AllCommentEvents subscription closed")
+
+		for {
+			select {
+			case <-ctx.Done():
+				r.logger.Debug("AllCommentEvents subscription cancelled")
+				return
+			case payload, ok := <-domainCh:
+				if !ok {
+					r.logger.Debug("Firehose channel closed")
+					reportSubscriptionError(ctx)
+					return
+				}
+
+				// Конвертируем в GraphQL событие
+				gqlEvent, err := converter.CommentPayloadToGraphQL(payload)
+				if err != nil {
+					r.logger.Error("Failed to convert comment event", zap.Error(err))
+					continue
+				}
+
+				select {
+				case eventCh <- gqlEvent:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
 	}()
 
+	r.logger.Info("AllCommentEvents subscription established")
 	return eventCh, nil
 }
 
 // NewPosts is the resolver for the newPosts field.
-func (r *subscriptionResolver) NewPosts(ctx context.Context) (<-chan *generated.Post, error) {
+func (r *subscriptionResolver) NewPosts(ctx context.Context, slowConsumer *generated.SlowConsumerPolicy) (<-chan *generated.Post, error) {
 	r.logger.Debug("NewPosts subscription")
 
-	// TODO: Реализовать подписку на новые посты
-	// Пока возвращаем заглушку
-	postCh := make(chan *generated.Post)
+	// Подписываемся на события всех постов, из которых берем только создание
+	ctx = subscriptionContext(ctx, slowConsumer)
+	domainCh, err := r.services.Subscription.SubscribeToAllPosts(ctx)
+	if err != nil {
+		r.logger.Error("Failed to subscribe to post events", zap.Error(err))
+		return nil, err
+	}
+
+	postCh := make(chan *generated.Post, 10)
 
 	go func() {
 		defer close(postCh)
-		<-ctx.Done()
-		r.logger.Debug("NewPosts subscription cancelled")
+		defer r.logger.Debug("NewPosts subscription closed")
+
+		for {
+			select {
+			case <-ctx.Done():
+				r.logger.Debug("NewPosts subscription cancelled")
+				return
+			case payload, ok := <-domainCh:
+				if !ok {
+					r.logger.Debug("Post events channel closed")
+					reportSubscriptionError(ctx)
+					return
+				}
+
+				if payload.ActionType != model.PostEventCreated {
+					continue
+				}
+
+				select {
+				case postCh <- converter.PostToGraphQL(payload.Post):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
 	}()
 
+	r.logger.Info("NewPosts subscription established")
 	return postCh, nil
 }
 
 // PostUpdates is the resolver for the postUpdates field.
-func (r *subscriptionResolver) PostUpdates(ctx context.Context, postID string) (<-chan *generated.Post, error) {
+func (r *subscriptionResolver) PostUpdates(ctx context.Context, postID string, slowConsumer *generated.SlowConsumerPolicy) (<-chan *generated.PostEvent, error) {
 	r.logger.Debug("PostUpdates subscription", zap.String("postID", postID))
 
 	// Парсим ID поста
-	_, err := converter.ParseID(postID)
+	parsedPostID, err := converter.ParseID(postID)
 	if err != nil {
 		r.logger.Error("Invalid post ID for subscription", zap.String("postID", postID), zap.Error(err))
 		return nil, err
 	}
 
-	// TODO: Реализовать подписку на обновления поста
-	// Пока возвращаем заглушку
-	postCh := make(chan *generated.Post)
+	// Проверяем существование поста, чтобы не ждать событий несуществующего поста
+	if _, err := r.services.Post.GetPost(ctx, parsedPostID); err != nil {
+		r.logger.Error("Post not found for subscription", zap.String("postID", postID), zap.Error(err))
+		return nil, err
+	}
+
+	// Подписываемся на события поста
+	ctx = subscriptionContext(ctx, slowConsumer)
+	domainCh, err := r.services.Subscription.SubscribeToPost(ctx, parsedPostID)
+	if err != nil {
+		r.logger.Error("Failed to subscribe to post updates", zap.String("postID", postID), zap.Error(err))
+		return nil, err
+	}
+
+	eventCh := make(chan *generated.PostEvent, 10)
 
 	go func() {
-		defer close(postCh)
-		<-ctx.Done()
-		r.logger.Debug("PostUpdates subscription cancelled", zap.String("postID", postID))
+		defer close(eventCh)
+		defer r.logger.Debug("PostUpdates subscription closed", zap.String("postID", postID))
+
+		for {
+			select {
+			case <-ctx.Done():
+				r.logger.Debug("PostUpdates subscription cancelled", zap.String("postID", postID))
+				return
+			case payload, ok := <-domainCh:
+				if !ok {
+					// Канал закрывается после события DELETED
+					r.logger.Debug("Post events channel closed", zap.String("postID", postID))
+					reportSubscriptionError(ctx)
+					return
+				}
+
+				gqlEvent, err := converter.PostEventToGraphQL(payload.ActionType, payload.Post)
+				if err != nil {
+					r.logger.Error("Failed to convert post event", zap.Error(err))
+					continue
+				}
+
+				select {
+				case eventCh <- gqlEvent:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
 	}()
 
-	return postCh, nil
+	r.logger.Info("PostUpdates subscription established", zap.String("postID", postID))
+	return eventCh, nil
 }
 
 // PostStatsUpdates is the resolver for the postStatsUpdates field.
-func (r *subscriptionResolver) PostStatsUpdates(ctx context.Context, postID string) (<-chan *generated.PostStats, error) {
+func (r *subscriptionResolver) PostStatsUpdates(ctx context.Context, postID string, slowConsumer *generated.SlowConsumerPolicy) (<-chan *generated.PostStats, error) {
 	r.logger.Debug("PostStatsUpdates subscription", zap.String("postID", postID))
 
 	// Парсим ID поста
@@ -142,50 +259,39 @@ func (r *subscriptionResolver) PostStatsUpdates(ctx context.Context, postID stri
 		return nil, err
 	}
 
-	// Подписываемся на события комментариев для получения статистики
-	domainCh, err := r.services.Subscription.Subscribe(ctx, parsedPostID)
+	// Подписываемся на статистику поста: текущее значение загружается один раз,
+	// дальше сервис подписок обновляет его по событиям комментариев и поста
+	ctx = subscriptionContext(ctx, slowConsumer)
+	domainCh, err := r.services.Subscription.SubscribeToPostStats(ctx, parsedPostID, func(ctx context.Context) (*model.PostStats, error) {
+		return loadPostStats(ctx, r.services, parsedPostID)
+	})
 	if err != nil {
-		r.logger.Error("Failed to subscribe to comment events for stats", zap.String("postID", postID), zap.Error(err))
+		r.logger.Error("Failed to subscribe to post stats", zap.String("postID", postID), zap.Error(err))
 		return nil, err
 	}
 
 	// Создаем канал для статистики
 	statsCh := make(chan *generated.PostStats, 10)
 
-	// Горутина для обновления статистики при изменении комментариев
+	// Горутина для конвертации domain статистики в GraphQL
 	go func() {
 		defer close(statsCh)
 		defer r.logger.Debug("PostStatsUpdates subscription closed", zap.String("postID", postID))
 
-		// Отправляем текущую статистику
-		if stats, err := getCurrentPostStats(ctx, r.services, parsedPostID); err == nil {
-			select {
-			case statsCh <- stats:
-			case <-ctx.Done():
-				return
-			}
-		}
-
-		// Слушаем события комментариев и обновляем статистику
 		for {
 			select {
 			case <-ctx.Done():
 				r.logger.Debug("PostStatsUpdates subscription cancelled", zap.String("postID", postID))
 				return
-			case _, ok := <-domainCh:
+			case stats, ok := <-domainCh:
 				if !ok {
+					// Канал закрывается после удаления поста
+					reportSubscriptionError(ctx)
 					return
 				}
 
-				// Получаем обновленную статистику
-				stats, err := getCurrentPostStats(ctx, r.services, parsedPostID)
-				if err != nil {
-					r.logger.Error("Failed to get updated post stats", zap.String("postID", postID), zap.Error(err))
-					continue
-				}
-
 				select {
-				case statsCh <- stats:
+				case statsCh <- converter.PostStatsToGraphQL(stats):
 					r.logger.Debug("Post stats updated", zap.String("postID", postID))
 				case <-ctx.Done():
 					return
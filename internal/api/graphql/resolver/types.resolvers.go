@@ -0,0 +1,74 @@
+package resolver
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.76
+
+import (
+	"context"
+
+	"github.com/NarthurN/habbr/internal/api/graphql/converter"
+	"github.com/NarthurN/habbr/internal/api/graphql/generated"
+	"github.com/NarthurN/habbr/internal/model"
+	"go.uber.org/zap"
+)
+
+// Children is the resolver for the children field.
+func (r *commentResolver) Children(ctx context.Context, obj *generated.Comment, first *int, after *string, last *int, before *string) (*generated.CommentConnection, error) {
+	// Парсим ID комментария
+	parentID, err := converter.ParseID(obj.ID)
+	if err != nil {
+		r.logger.Error("Invalid comment ID", zap.String("id", obj.ID), zap.Error(err))
+		return nil, err
+	}
+
+	// Конвертируем пагинацию
+	pagination := converter.PaginationFromGraphQL(first, last, after, before)
+
+	// Получаем ответы через загрузчик запроса
+	connection, err := loadReplies(ctx, r.services, parentID, model.CommentFilter{}, *pagination)
+	if err != nil {
+		r.logger.Error("Failed to get comment children", zap.String("id", obj.ID), zap.Error(err))
+		return nil, err
+	}
+
+	return converter.CommentConnectionToGraphQL(connection), nil
+}
+
+// Comments is the resolver for the comments field.
+func (r *postResolver) Comments(ctx context.Context, obj *generated.Post, first *int, after *string, last *int, before *string, filter *generated.CommentFilter) (*generated.CommentConnection, error) {
+	// Парсим ID поста
+	postID, err := converter.ParseID(obj.ID)
+	if err != nil {
+		r.logger.Error("Invalid post ID", zap.String("id", obj.ID), zap.Error(err))
+		return nil, err
+	}
+
+	// Конвертируем фильтр
+	domainFilter, err := converter.CommentFilterFromGraphQL(filter)
+	if err != nil {
+		r.logger.Error("Failed to convert comment filter", zap.Error(err))
+		return nil, err
+	}
+
+	// Конвертируем пагинацию
+	pagination := converter.PaginationFromGraphQL(first, last, after, before)
+
+	// Получаем комментарии через загрузчик запроса
+	connection, err := loadPostComments(ctx, r.services, postID, *domainFilter, *pagination)
+	if err != nil {
+		r.logger.Error("Failed to get post comments", zap.String("id", obj.ID), zap.Error(err))
+		return nil, err
+	}
+
+	return converter.CommentConnectionToGraphQL(connection), nil
+}
+
+// Comment returns generated.CommentResolver implementation.
+func (r *Resolver) Comment() generated.CommentResolver { return &commentResolver{r} }
+
+// Post returns generated.PostResolver implementation.
+func (r *Resolver) Post() generated.PostResolver { return &postResolver{r} }
+
+type commentResolver struct{ *Resolver }
+type postResolver struct{ *Resolver }
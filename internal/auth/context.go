@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/NarthurN/habbr/internal/model"
+)
+
+// contextKey является приватным типом ключей контекста, чтобы избежать коллизий с другими пакетами
+type contextKey struct {
+	name string
+}
+
+var principalContextKey = &contextKey{"principal"}
+
+// WithPrincipal возвращает копию контекста с прикрепленным аутентифицированным пользователем
+func WithPrincipal(ctx context.Context, principal *model.Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey, principal)
+}
+
+// PrincipalFromContext извлекает аутентифицированного пользователя из контекста.
+//
+// Возвращает false, если запрос анонимный (токен не передавался).
+func PrincipalFromContext(ctx context.Context) (*model.Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey).(*model.Principal)
+	if !ok || principal == nil {
+		return nil, false
+	}
+	return principal, true
+}
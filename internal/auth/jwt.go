@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/NarthurN/habbr/internal/config"
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// Ошибки проверки токенов
+var (
+	ErrInvalidToken      = errors.New("invalid token")
+	ErrAuthNotConfigured = errors.New("authentication is not configured")
+)
+
+// Claims описывает набор claims, которые сервис читает из JWT
+type Claims struct {
+	jwt.RegisteredClaims
+
+	// Role - роль пользователя, необязательный claim
+	Role string `json:"role,omitempty"`
+}
+
+// Verifier проверяет подписанные JWT токены и извлекает из них Principal.
+//
+// Поддерживает HS256 (общий секрет) и RS256 (открытые ключи из JWKS файла).
+// Алгоритм выбирается по заголовку токена, но только из списка разрешенных
+// конфигурацией, что защищает от подмены алгоритма ("alg": "none" и т.п.).
+//
+// Пример использования:
+//
+//	verifier, err := auth.NewVerifier(cfg.Auth)
+//	if err != nil {
+//	    return err
+//	}
+//	principal, err := verifier.Verify(tokenString)
+type Verifier struct {
+	hmacSecret  []byte
+	rsaKeys     map[string]*rsa.PublicKey
+	parser      *jwt.Parser
+	defaultRole model.Role
+}
+
+// NewVerifier создает проверяющего токены на основе конфигурации аутентификации.
+//
+// Возвращает ErrAuthNotConfigured, если не задан ни HMAC секрет, ни JWKS файл.
+func NewVerifier(cfg config.AuthConfig) (*Verifier, error) {
+	if !cfg.IsEnabled() {
+		return nil, ErrAuthNotConfigured
+	}
+
+	defaultRole := model.RoleAuthor
+	if cfg.DefaultRole != "" {
+		role, err := model.ParseRole(cfg.DefaultRole)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default role: %w", err)
+		}
+		defaultRole = role
+	}
+
+	verifier := &Verifier{defaultRole: defaultRole}
+	var methods []string
+
+	if cfg.HMACSecret != "" {
+		verifier.hmacSecret = []byte(cfg.HMACSecret)
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+
+	if cfg.JWKSFile != "" {
+		keys, err := LoadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load JWKS: %w", err)
+		}
+		verifier.rsaKeys = keys
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithLeeway(cfg.ClockSkew),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	}
+	if cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+
+	verifier.parser = jwt.NewParser(options...)
+
+	return verifier, nil
+}
+
+// Verify проверяет подпись и срок действия токена и возвращает аутентифицированного пользователя.
+//
+// Claim "sub" обязан содержать UUID пользователя. Claim "role" необязателен:
+// при его отсутствии пользователю назначается роль по умолчанию из конфигурации,
+// неизвестная роль делает токен невалидным.
+func (v *Verifier) Verify(tokenString string) (*model.Principal, error) {
+	claims := &Claims{}
+	if _, err := v.parser.ParseWithClaims(tokenString, claims, v.keyFunc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil || userID == uuid.Nil {
+		return nil, fmt.Errorf("%w: subject must be a user UUID", ErrInvalidToken)
+	}
+
+	role := v.defaultRole
+	if claims.Role != "" {
+		role, err = model.ParseRole(claims.Role)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+		}
+	}
+
+	return &model.Principal{
+		UserID: userID,
+		Role:   role,
+	}, nil
+}
+
+// keyFunc подбирает ключ проверки подписи в зависимости от алгоритма токена
+func (v *Verifier) keyFunc(token *jwt.Token) (interface{}, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return v.hmacSecret, nil
+	case jwt.SigningMethodRS256.Alg():
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" && len(v.rsaKeys) == 1 {
+			for _, key := range v.rsaKeys {
+				return key, nil
+			}
+		}
+		key, exists := v.rsaKeys[kid]
+		if !exists {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
+
+// ExtractBearerToken извлекает токен из значения заголовка Authorization вида "Bearer <token>"
+func ExtractBearerToken(header string) (string, bool) {
+	const prefix = "bearer "
+
+	header = strings.TrimSpace(header)
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", false
+	}
+
+	token := strings.TrimSpace(header[len(prefix):])
+	return token, token != ""
+}
@@ -0,0 +1,235 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NarthurN/habbr/internal/config"
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret-that-is-at-least-32-bytes"
+
+func signHS256(t *testing.T, secret string, claims jwt.Claims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	require.NoError(t, err)
+	return token
+}
+
+func validClaims(subject string) jwt.RegisteredClaims {
+	now := time.Now()
+	return jwt.RegisteredClaims{
+		Subject:   subject,
+		Issuer:    "habbr-test",
+		Audience:  jwt.ClaimStrings{"habbr-api"},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+	}
+}
+
+func TestVerifierHS256(t *testing.T) {
+	verifier, err := NewVerifier(config.AuthConfig{
+		HMACSecret: testSecret,
+		Issuer:     "habbr-test",
+		Audience:   "habbr-api",
+		ClockSkew:  time.Second,
+	})
+	require.NoError(t, err)
+
+	userID := uuid.New()
+
+	expired := validClaims(userID.String())
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+
+	wrongIssuer := validClaims(userID.String())
+	wrongIssuer.Issuer = "someone-else"
+
+	noExpiry := validClaims(userID.String())
+	noExpiry.ExpiresAt = nil
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:  "valid token",
+			token: signHS256(t, testSecret, validClaims(userID.String())),
+		},
+		{
+			name:    "wrong secret",
+			token:   signHS256(t, "another-secret-that-is-at-least-32-bytes", validClaims(userID.String())),
+			wantErr: true,
+		},
+		{
+			name:    "expired token",
+			token:   signHS256(t, testSecret, expired),
+			wantErr: true,
+		},
+		{
+			name:    "missing expiration",
+			token:   signHS256(t, testSecret, noExpiry),
+			wantErr: true,
+		},
+		{
+			name:    "wrong issuer",
+			token:   signHS256(t, testSecret, wrongIssuer),
+			wantErr: true,
+		},
+		{
+			name:    "subject is not uuid",
+			token:   signHS256(t, testSecret, validClaims("user-123")),
+			wantErr: true,
+		},
+		{
+			name:    "malformed token",
+			token:   "not-a-jwt",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := verifier.Verify(tt.token)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidToken)
+				assert.Nil(t, principal)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, userID, principal.UserID)
+		})
+	}
+}
+
+func TestVerifierRoleClaim(t *testing.T) {
+	verifier, err := NewVerifier(config.AuthConfig{
+		HMACSecret:  testSecret,
+		DefaultRole: "reader",
+	})
+	require.NoError(t, err)
+
+	userID := uuid.New()
+
+	tests := []struct {
+		name     string
+		role     string
+		expected model.Role
+		wantErr  bool
+	}{
+		{name: "default role", role: "", expected: model.RoleReader},
+		{name: "explicit role", role: "Admin", expected: model.RoleAdmin},
+		{name: "unknown role", role: "root", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := signHS256(t, testSecret, Claims{
+				RegisteredClaims: validClaims(userID.String()),
+				Role:             tt.role,
+			})
+
+			principal, err := verifier.Verify(token)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidToken)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, principal.Role)
+		})
+	}
+}
+
+func TestVerifierRS256(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwks, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": "key-1",
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(privateKey.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(privateKey.E)).Bytes()),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(jwksFile, jwks, 0o600))
+
+	verifier, err := NewVerifier(config.AuthConfig{JWKSFile: jwksFile})
+	require.NoError(t, err)
+
+	userID := uuid.New()
+
+	t.Run("valid token", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, validClaims(userID.String()))
+		token.Header["kid"] = "key-1"
+		signed, err := token.SignedString(privateKey)
+		require.NoError(t, err)
+
+		principal, err := verifier.Verify(signed)
+		require.NoError(t, err)
+		assert.Equal(t, userID, principal.UserID)
+	})
+
+	t.Run("unknown key id", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, validClaims(userID.String()))
+		token.Header["kid"] = "key-2"
+		signed, err := token.SignedString(privateKey)
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(signed)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("HS256 rejected when only JWKS configured", func(t *testing.T) {
+		_, err := verifier.Verify(signHS256(t, testSecret, validClaims(userID.String())))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestNewVerifierNotConfigured(t *testing.T) {
+	_, err := NewVerifier(config.AuthConfig{})
+	assert.ErrorIs(t, err, ErrAuthNotConfigured)
+}
+
+func TestExtractBearerToken(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected string
+		ok       bool
+	}{
+		{header: "Bearer abc.def.ghi", expected: "abc.def.ghi", ok: true},
+		{header: "bearer abc", expected: "abc", ok: true},
+		{header: "Basic dXNlcjpwYXNz", ok: false},
+		{header: "Bearer ", ok: false},
+		{header: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			token, ok := ExtractBearerToken(tt.header)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, token)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"go.uber.org/zap"
+)
+
+// Middleware создает HTTP middleware, аутентифицирующий запросы по заголовку Authorization.
+//
+// Поведение:
+//   - Заголовок отсутствует: запрос обрабатывается как анонимный (Principal не устанавливается)
+//   - Заголовок содержит валидный "Bearer <token>": Principal помещается в контекст запроса
+//   - Заголовок некорректен или токен не прошел проверку: ответ 401 без вызова next
+//
+// Если verifier равен nil (аутентификация не настроена), все запросы считаются анонимными.
+//
+// Параметры:
+//   - verifier: проверяющий токены, может быть nil
+//   - logger: логгер для записи отклоненных токенов
+//
+// Пример использования:
+//
+//	mux.Handle("/query", auth.Middleware(verifier, logger)(graphqlServer))
+func Middleware(verifier *Verifier, logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			if header == "" || verifier == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, err := authenticate(r.Context(), verifier, header)
+			if err != nil {
+				logger.Debug("Rejected request with invalid token", zap.Error(err))
+				writeUnauthorized(w)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// WebsocketInitFunc возвращает обработчик инициализации WebSocket соединения для подписок.
+//
+// Браузеры не позволяют задавать заголовки WebSocket запроса, поэтому токен
+// передается в payload сообщения connection_init в поле "Authorization"
+// (в формате "Bearer <token>"). Отсутствие токена допускается, невалидный токен
+// приводит к отказу в установке соединения.
+//
+// Пример использования:
+//
+//	srv.AddTransport(transport.Websocket{
+//	    InitFunc: auth.WebsocketInitFunc(verifier, logger),
+//	})
+func WebsocketInitFunc(verifier *Verifier, logger *zap.Logger) transport.WebsocketInitFunc {
+	return func(ctx context.Context, initPayload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+		header := initPayload.Authorization()
+		if header == "" || verifier == nil {
+			return ctx, &initPayload, nil
+		}
+
+		ctx, err := authenticate(ctx, verifier, header)
+		if err != nil {
+			logger.Debug("Rejected websocket connection with invalid token", zap.Error(err))
+			return ctx, nil, err
+		}
+
+		return ctx, &initPayload, nil
+	}
+}
+
+// authenticate проверяет значение заголовка Authorization и прикрепляет Principal к контексту
+func authenticate(ctx context.Context, verifier *Verifier, header string) (context.Context, error) {
+	token, ok := ExtractBearerToken(header)
+	if !ok {
+		return ctx, errors.New("authorization header must use Bearer scheme")
+	}
+
+	principal, err := verifier.Verify(token)
+	if err != nil {
+		return ctx, err
+	}
+
+	return WithPrincipal(ctx, principal), nil
+}
+
+// writeUnauthorized отправляет ответ 401 в формате GraphQL ошибки
+func writeUnauthorized(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+	w.WriteHeader(http.StatusUnauthorized)
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"errors": []map[string]interface{}{
+			{
+				"message":    "invalid or expired token",
+				"extensions": map[string]string{"code": "UNAUTHORIZED"},
+			},
+		},
+	})
+}
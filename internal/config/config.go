@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
+
+	"github.com/NarthurN/habbr/internal/model"
 )
 
 // Config представляет полную конфигурацию приложения Habbr.
@@ -17,6 +19,11 @@ import (
 // - Server: настройки HTTP сервера и GraphQL API
 // - Database: параметры подключения к базе данных
 // - Logger: настройки системы логирования
+// - Auth: параметры проверки JWT токенов
+// - RateLimit: ограничение частоты запросов
+// - Subscription: настройки real-time подписок
+// - QueryLimit: ограничения глубины и стоимости GraphQL запросов
+// - Analytics: обновление аналитики постов
 //
 // Пример использования:
 //   cfg, err := config.Load()
@@ -37,6 +44,21 @@ type Config struct {
 
 	// Logger содержит настройки системы логирования
 	Logger LoggerConfig `envconfig:"LOGGER"`
+
+	// Auth содержит параметры аутентификации по JWT
+	Auth AuthConfig `envconfig:"AUTH"`
+
+	// RateLimit содержит настройки ограничения частоты запросов
+	RateLimit RateLimitConfig `envconfig:"RATE_LIMIT"`
+
+	// Subscription содержит настройки real-time подписок
+	Subscription SubscriptionConfig `envconfig:"SUBSCRIPTION"`
+
+	// QueryLimit содержит ограничения глубины и стоимости GraphQL запросов
+	QueryLimit QueryLimitConfig `envconfig:"QUERY_LIMIT"`
+
+	// Analytics содержит настройки обновления аналитики постов
+	Analytics AnalyticsConfig `envconfig:"ANALYTICS"`
 }
 
 // ServerConfig содержит настройки HTTP сервера и GraphQL API.
@@ -200,6 +222,175 @@ type LoggerConfig struct {
 	EnableCaller bool `envconfig:"ENABLE_CALLER" default:"true"`
 }
 
+// AuthConfig содержит настройки аутентификации по подписанным JWT токенам.
+//
+// Поддерживаются два алгоритма подписи:
+// - HS256: общий секрет, задается через HMACSecret
+// - RS256: открытые ключи RSA из локального JWKS файла
+//
+// Можно указать оба источника ключей одновременно, алгоритм выбирается по заголовку токена.
+// Если не задан ни один источник, все запросы считаются анонимными и мутации,
+// требующие автора, отклоняются.
+//
+// Переменные окружения имеют префикс AUTH_, например:
+//   AUTH_HMAC_SECRET=very-long-random-secret-of-32-bytes
+//   AUTH_JWKS_FILE=/etc/habbr/jwks.json
+//   AUTH_ISSUER=https://auth.habbr.local
+//   AUTH_AUDIENCE=habbr-api
+//   AUTH_DEFAULT_ROLE=author
+//
+// Пример использования:
+//   if cfg.Auth.IsEnabled() {
+//       verifier, err := auth.NewVerifier(cfg.Auth)
+//   }
+type AuthConfig struct {
+	// HMACSecret - общий секрет для проверки токенов HS256
+	// Значение по умолчанию: "" (HS256 отключен)
+	// Минимальная длина: 32 байта
+	HMACSecret string `envconfig:"HMAC_SECRET"`
+
+	// JWKSFile - путь к локальному JWKS файлу с открытыми ключами для RS256
+	// Значение по умолчанию: "" (RS256 отключен)
+	JWKSFile string `envconfig:"JWKS_FILE"`
+
+	// Issuer - ожидаемое значение claim "iss"
+	// Значение по умолчанию: "" (не проверяется)
+	Issuer string `envconfig:"ISSUER"`
+
+	// Audience - ожидаемое значение claim "aud"
+	// Значение по умолчанию: "" (не проверяется)
+	Audience string `envconfig:"AUDIENCE"`
+
+	// ClockSkew - допустимое расхождение часов при проверке exp/nbf/iat
+	// Значение по умолчанию: 30s
+	ClockSkew time.Duration `envconfig:"CLOCK_SKEW" default:"30s"`
+
+	// DefaultRole - роль пользователя, если токен не содержит claim "role"
+	// Значение по умолчанию: author
+	// Допустимые значения: reader, author, moderator, admin (без учета регистра)
+	DefaultRole string `envconfig:"DEFAULT_ROLE" default:"author"`
+}
+
+// IsEnabled проверяет, настроен ли хотя бы один источник ключей для проверки токенов.
+func (c AuthConfig) IsEnabled() bool {
+	return c.HMACSecret != "" || c.JWKSFile != ""
+}
+
+// RateLimitConfig содержит настройки ограничения частоты запросов.
+//
+// Сами лимиты задаются в схеме директивой @rateLimit(max:, window:) для каждого поля,
+// здесь настраивается только поведение лимитера в целом.
+//
+// Переменные окружения имеют префикс RATE_LIMIT_, например:
+//   RATE_LIMIT_ENABLED=true
+//   RATE_LIMIT_TRUST_FORWARDED_FOR=true
+//   RATE_LIMIT_IDLE_TTL=10m
+type RateLimitConfig struct {
+	// Enabled - включить проверку директивы @rateLimit
+	// Значение по умолчанию: true
+	Enabled bool `envconfig:"ENABLED" default:"true"`
+
+	// TrustForwardedFor - использовать заголовок X-Forwarded-For для определения IP клиента
+	// Значение по умолчанию: false
+	// Включайте только если сервис работает за доверенным reverse proxy
+	TrustForwardedFor bool `envconfig:"TRUST_FORWARDED_FOR" default:"false"`
+
+	// IdleTTL - время, после которого неактивные корзины токенов удаляются из памяти
+	// Значение по умолчанию: 10m
+	IdleTTL time.Duration `envconfig:"IDLE_TTL" default:"10m"`
+}
+
+// SubscriptionConfig содержит настройки real-time подписок.
+//
+// Брокер сообщений определяет, как события доходят до подписчиков:
+// - "memory": только внутри процесса (один экземпляр сервиса)
+// - "postgres": PostgreSQL LISTEN/NOTIFY, требует DATABASE_TYPE=postgres
+// - "redis": Redis pub/sub
+//
+// Переменные окружения имеют префикс SUBSCRIPTION_, например:
+//   SUBSCRIPTION_STATS_INTERVAL=2s
+//   SUBSCRIPTION_BROKER=redis
+//   SUBSCRIPTION_REDIS_ADDR=redis:6379
+//   SUBSCRIPTION_SLOW_CONSUMER_POLICY=drop-oldest
+type SubscriptionConfig struct {
+	// StatsInterval - минимальный интервал между обновлениями статистики одного поста
+	// Значение по умолчанию: 1s
+	// Изменения за интервал объединяются в одно обновление, 0 - отправлять каждое изменение сразу
+	StatsInterval time.Duration `envconfig:"STATS_INTERVAL" default:"1s"`
+
+	// Broker - брокер сообщений для доставки событий между экземплярами сервиса
+	// Значения: "memory", "postgres", "redis"
+	// Значение по умолчанию: "memory"
+	Broker string `envconfig:"BROKER" default:"memory"`
+
+	// ChannelPrefix - префикс имен каналов брокера
+	// Значение по умолчанию: "habbr"
+	// Позволяет нескольким окружениям использовать один PostgreSQL или Redis
+	ChannelPrefix string `envconfig:"CHANNEL_PREFIX" default:"habbr"`
+
+	// RedisAddr - адрес Redis в формате host:port
+	// Значение по умолчанию: "localhost:6379"
+	// Используется только для брокера "redis"
+	RedisAddr string `envconfig:"REDIS_ADDR" default:"localhost:6379"`
+
+	// RedisPassword - пароль Redis
+	// Значение по умолчанию: "" (без пароля)
+	RedisPassword string `envconfig:"REDIS_PASSWORD"`
+
+	// RedisDB - номер базы данных Redis
+	// Значение по умолчанию: 0
+	RedisDB int `envconfig:"REDIS_DB" default:"0"`
+
+	// SlowConsumerPolicy - поведение при заполненном буфере подписчика, если клиент не выбрал свое
+	// Значения: "drop-newest", "drop-oldest", "block", "disconnect"
+	// Значение по умолчанию: "drop-newest"
+	SlowConsumerPolicy string `envconfig:"SLOW_CONSUMER_POLICY" default:"drop-newest"`
+
+	// SlowConsumerTimeout - максимальное ожидание места в буфере для политики "block"
+	// Значение по умолчанию: 100ms
+	SlowConsumerTimeout time.Duration `envconfig:"SLOW_CONSUMER_TIMEOUT" default:"100ms"`
+}
+
+// QueryLimitConfig содержит ограничения глубины и стоимости GraphQL запросов.
+//
+// Стоимость поля равна 1 плюс стоимость вложенных полей; для соединений
+// стоимость вложенных полей умножается на first/last (или размер страницы
+// по умолчанию). Запросы сверх лимитов отклоняются до выполнения.
+//
+// Переменные окружения имеют префикс QUERY_LIMIT_, например:
+//   QUERY_LIMIT_MAX_DEPTH=12
+//   QUERY_LIMIT_MAX_COMPLEXITY=10000
+type QueryLimitConfig struct {
+	// MaxDepth - максимальная глубина вложенности полей запроса
+	// Значение по умолчанию: 12
+	// 0 - без ограничения
+	MaxDepth int `envconfig:"MAX_DEPTH" default:"12"`
+
+	// MaxComplexity - максимальная стоимость запроса
+	// Значение по умолчанию: 10000
+	// 0 - без ограничения
+	MaxComplexity int `envconfig:"MAX_COMPLEXITY" default:"10000"`
+
+	// StatsWeight - стоимость полей статистики postStats и commentStats
+	// Значение по умолчанию: 10
+	StatsWeight int `envconfig:"STATS_WEIGHT" default:"10"`
+}
+
+// AnalyticsConfig содержит настройки обновления аналитики постов.
+//
+// В PostgreSQL аналитика читается из материализованного представления post_analytics,
+// которое фоново пересчитывается с заданным интервалом. In-memory хранилище вычисляет
+// аналитику при каждом запросе, и интервал не используется.
+//
+// Переменные окружения имеют префикс ANALYTICS_, например:
+//   ANALYTICS_REFRESH_INTERVAL=1m
+type AnalyticsConfig struct {
+	// RefreshInterval - интервал обновления представления post_analytics
+	// Значение по умолчанию: 5m
+	// 0 - не обновлять фоново
+	RefreshInterval time.Duration `envconfig:"REFRESH_INTERVAL" default:"5m"`
+}
+
 // Load загружает конфигурацию из переменных окружения с валидацией.
 //
 // Функция использует библиотеку envconfig для автоматического сканирования
@@ -252,6 +443,7 @@ func Load() (*Config, error) {
 // - Наличие обязательных параметров для PostgreSQL
 // - Валидный уровень логирования
 // - Валидный формат логирования
+// - Достаточная длина HMAC секрета аутентификации
 //
 // Возвращает:
 //   - nil: если вся конфигурация корректна
@@ -298,6 +490,66 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid logger format: %s", c.Logger.Format)
 	}
 
+	if c.Auth.HMACSecret != "" && len(c.Auth.HMACSecret) < 32 {
+		return fmt.Errorf("auth hmac secret must be at least 32 bytes")
+	}
+
+	if c.Auth.ClockSkew < 0 {
+		return fmt.Errorf("invalid auth clock skew: %s", c.Auth.ClockSkew)
+	}
+
+	if c.RateLimit.IdleTTL < 0 {
+		return fmt.Errorf("invalid rate limit idle ttl: %s", c.RateLimit.IdleTTL)
+	}
+
+	if c.Subscription.StatsInterval < 0 {
+		return fmt.Errorf("invalid subscription stats interval: %s", c.Subscription.StatsInterval)
+	}
+
+	switch c.Subscription.Broker {
+	case "memory", "redis":
+	case "postgres":
+		if c.Database.Type != "postgres" {
+			return fmt.Errorf("subscription broker postgres requires database type postgres")
+		}
+	default:
+		return fmt.Errorf("invalid subscription broker: %s (must be 'memory', 'postgres' or 'redis')", c.Subscription.Broker)
+	}
+
+	if c.Subscription.Broker == "redis" && c.Subscription.RedisAddr == "" {
+		return fmt.Errorf("redis address is required for subscription broker redis")
+	}
+
+	switch c.Subscription.SlowConsumerPolicy {
+	case "drop-newest", "drop-oldest", "block", "disconnect":
+	default:
+		return fmt.Errorf("invalid subscription slow consumer policy: %s (must be 'drop-newest', 'drop-oldest', 'block' or 'disconnect')", c.Subscription.SlowConsumerPolicy)
+	}
+
+	if c.Subscription.SlowConsumerTimeout < 0 {
+		return fmt.Errorf("invalid subscription slow consumer timeout: %s", c.Subscription.SlowConsumerTimeout)
+	}
+
+	if c.QueryLimit.MaxDepth < 0 {
+		return fmt.Errorf("invalid query limit max depth: %d", c.QueryLimit.MaxDepth)
+	}
+
+	if c.QueryLimit.MaxComplexity < 0 {
+		return fmt.Errorf("invalid query limit max complexity: %d", c.QueryLimit.MaxComplexity)
+	}
+
+	if c.QueryLimit.StatsWeight < 1 {
+		return fmt.Errorf("query limit stats weight must be positive")
+	}
+
+	if c.Analytics.RefreshInterval < 0 {
+		return fmt.Errorf("invalid analytics refresh interval: %s", c.Analytics.RefreshInterval)
+	}
+
+	if _, err := model.ParseRole(c.Auth.DefaultRole); err != nil {
+		return fmt.Errorf("invalid auth default role: %s", c.Auth.DefaultRole)
+	}
+
 	return nil
 }
 
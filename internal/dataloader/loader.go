@@ -0,0 +1,144 @@
+// Package dataloader реализует пакетную загрузку данных для GraphQL резолверов.
+//
+// Резолверы полей списка выполняются параллельно, и каждый запрашивает данные
+// своего родителя. Loader собирает ключи, запрошенные за короткое окно ожидания,
+// и загружает их одним вызовом, избавляя от проблемы N+1 запросов.
+package dataloader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// FetchFunc загружает значения для набора ключей одним запросом.
+//
+// Отсутствующий в результате ключ получает нулевое значение V.
+type FetchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader группирует запросы значений по ключам в пакеты.
+//
+// Пакет отправляется по истечении окна wait после первого ключа или сразу при
+// достижении maxBatch ключей. Повторные ключи внутри пакета загружаются один раз.
+// Результаты не кэшируются между пакетами: загрузчик живет в течение HTTP запроса,
+// а для WebSocket соединения - все время подписки, и кэш отдавал бы устаревшие данные
+// после мутаций.
+type Loader[K comparable, V any] struct {
+	fetch    FetchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu    sync.Mutex
+	batch *batch[K, V]
+}
+
+// batch представляет пакет ключей, ожидающий загрузки.
+//
+// ctx пакета не отменяется вместе с контекстом отдельного вызова: он отменяется,
+// только когда пакет перестают ждать все вызовы Load.
+type batch[K comparable, V any] struct {
+	keys       []K
+	seen       map[K]struct{}
+	ctx        context.Context
+	cancel     context.CancelFunc
+	waiters    int
+	dispatched bool
+	done       chan struct{}
+	values     map[K]V
+	err        error
+}
+
+// NewLoader создает загрузчик с окном ожидания wait и максимальным размером пакета maxBatch
+func NewLoader[K comparable, V any](fetch FetchFunc[K, V], wait time.Duration, maxBatch int) *Loader[K, V] {
+	if maxBatch <= 0 {
+		maxBatch = 1
+	}
+	return &Loader[K, V]{
+		fetch:    fetch,
+		wait:     wait,
+		maxBatch: maxBatch,
+	}
+}
+
+// Load возвращает значение для ключа, загружая его в составе текущего пакета.
+//
+// Отмена ctx прерывает ожидание только этого вызова. Пакет загружается с контекстом
+// без отмены, унаследовавшим значения контекста вызова, открывшего пакет (все вызовы
+// загрузчика относятся к одному запросу), и отменяется, когда пакет не ждет ни один вызов.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	b := l.batch
+	if b == nil {
+		batchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		b = &batch[K, V]{seen: make(map[K]struct{}), ctx: batchCtx, cancel: cancel, done: make(chan struct{})}
+		l.batch = b
+		time.AfterFunc(l.wait, func() { l.dispatch(b) })
+	}
+	if _, ok := b.seen[key]; !ok {
+		b.seen[key] = struct{}{}
+		b.keys = append(b.keys, key)
+	}
+	b.waiters++
+	full := len(b.keys) >= l.maxBatch
+	if full {
+		// Заполненный пакет больше не принимает ключи, следующий ключ откроет новый
+		l.batch = nil
+	}
+	l.mu.Unlock()
+
+	if full {
+		go l.dispatch(b)
+	}
+
+	select {
+	case <-b.done:
+		if b.err != nil {
+			var zero V
+			return zero, b.err
+		}
+		return b.values[key], nil
+	case <-ctx.Done():
+		l.leave(b)
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// leave снимает вызов с ожидания пакета. Пакет, который больше никто не ждет,
+// отменяется и перестает принимать ключи
+func (l *Loader[K, V]) leave(b *batch[K, V]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b.waiters--
+	if b.waiters > 0 {
+		return
+	}
+	if l.batch == b {
+		l.batch = nil
+	}
+	b.cancel()
+}
+
+// dispatch загружает пакет; повторные вызовы для уже отправленного пакета ничего не делают
+func (l *Loader[K, V]) dispatch(b *batch[K, V]) {
+	l.mu.Lock()
+	if l.batch == b {
+		l.batch = nil
+	}
+	if b.dispatched {
+		l.mu.Unlock()
+		return
+	}
+	b.dispatched = true
+	abandoned := b.waiters == 0
+	l.mu.Unlock()
+
+	if abandoned {
+		b.err = context.Canceled // результат пакета никому не нужен
+	} else {
+		b.values, b.err = l.fetch(b.ctx, b.keys)
+	}
+	b.cancel()
+	close(b.done)
+}
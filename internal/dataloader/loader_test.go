@@ -0,0 +1,175 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingFetch возвращает удвоенные ключи и запоминает состав каждого пакета
+type recordingFetch struct {
+	mu      sync.Mutex
+	batches [][]int
+	err     error
+}
+
+func (f *recordingFetch) fetch(ctx context.Context, keys []int) (map[int]int, error) {
+	f.mu.Lock()
+	f.batches = append(f.batches, append([]int(nil), keys...))
+	f.mu.Unlock()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+	values := make(map[int]int, len(keys))
+	for _, key := range keys {
+		values[key] = key * 2
+	}
+	return values, nil
+}
+
+// loadAll одновременно загружает ключи и возвращает результаты в порядке ключей
+func loadAll(loader *Loader[int, int], keys []int) ([]int, []error) {
+	values := make([]int, len(keys))
+	errs := make([]error, len(keys))
+
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			values[i], errs[i] = loader.Load(context.Background(), key)
+		}()
+	}
+	wg.Wait()
+
+	return values, errs
+}
+
+func TestLoaderBatchesConcurrentLoads(t *testing.T) {
+	fetch := &recordingFetch{}
+	loader := NewLoader(fetch.fetch, 20*time.Millisecond, 100)
+
+	values, errs := loadAll(loader, []int{1, 2, 3, 2, 1})
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []int{2, 4, 6, 4, 2}, values)
+
+	// Все ключи загружены одним пакетом, повторные ключи - один раз
+	require.Len(t, fetch.batches, 1)
+	assert.ElementsMatch(t, []int{1, 2, 3}, fetch.batches[0])
+}
+
+func TestLoaderSplitsByMaxBatch(t *testing.T) {
+	fetch := &recordingFetch{}
+	loader := NewLoader(fetch.fetch, 20*time.Millisecond, 2)
+
+	values, errs := loadAll(loader, []int{1, 2, 3, 4, 5})
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []int{2, 4, 6, 8, 10}, values)
+
+	require.Len(t, fetch.batches, 3)
+	for _, batch := range fetch.batches {
+		assert.LessOrEqual(t, len(batch), 2)
+	}
+}
+
+func TestLoaderDoesNotCacheBetweenBatches(t *testing.T) {
+	fetch := &recordingFetch{}
+	loader := NewLoader(fetch.fetch, time.Millisecond, 100)
+
+	for i := 0; i < 2; i++ {
+		value, err := loader.Load(context.Background(), 7)
+		require.NoError(t, err)
+		assert.Equal(t, 14, value)
+	}
+
+	assert.Len(t, fetch.batches, 2)
+}
+
+func TestLoaderPropagatesFetchError(t *testing.T) {
+	fetch := &recordingFetch{err: errors.New("storage unavailable")}
+	loader := NewLoader(fetch.fetch, 10*time.Millisecond, 100)
+
+	_, errs := loadAll(loader, []int{1, 2})
+
+	for _, err := range errs {
+		assert.EqualError(t, err, "storage unavailable")
+	}
+}
+
+func TestLoaderMissingKeyReturnsZeroValue(t *testing.T) {
+	loader := NewLoader(func(ctx context.Context, keys []int) (map[int]int, error) {
+		return map[int]int{}, nil
+	}, time.Millisecond, 100)
+
+	value, err := loader.Load(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Zero(t, value)
+}
+
+func TestLoaderIgnoresCancellationOfSingleCaller(t *testing.T) {
+	fetched := make(chan struct{})
+	loader := NewLoader(func(ctx context.Context, keys []int) (map[int]int, error) {
+		defer close(fetched)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return map[int]int{1: 2, 2: 4}, nil
+	}, 20*time.Millisecond, 100)
+
+	// Вызов, открывший пакет, отменяется до загрузки пакета
+	firstCtx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := loader.Load(firstCtx, 1)
+		firstErr <- err
+	}()
+	time.Sleep(5 * time.Millisecond)
+
+	secondDone := make(chan struct{})
+	var value int
+	var err error
+	go func() {
+		defer close(secondDone)
+		value, err = loader.Load(context.Background(), 2)
+	}()
+	time.Sleep(5 * time.Millisecond)
+	cancel()
+
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+	<-secondDone
+	require.NoError(t, err)
+	assert.Equal(t, 4, value)
+	<-fetched
+}
+
+func TestLoaderCancelsAbandonedBatch(t *testing.T) {
+	fetch := &recordingFetch{}
+	loader := NewLoader(fetch.fetch, 20*time.Millisecond, 100)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := loader.Load(ctx, 1)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// Следующий вызов открывает новый пакет, а брошенный пакет не загружается
+	value, err := loader.Load(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, 4, value)
+
+	time.Sleep(30 * time.Millisecond)
+	fetch.mu.Lock()
+	defer fetch.mu.Unlock()
+	assert.Equal(t, [][]int{{2}}, fetch.batches)
+}
@@ -0,0 +1,98 @@
+package dataloader
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/NarthurN/habbr/internal/service"
+	"github.com/google/uuid"
+)
+
+// Параметры пакетов загрузчиков запроса
+const (
+	// DefaultWait - окно ожидания ключей пакета; резолверы полей списка
+	// запускаются одновременно, поэтому короткого окна достаточно
+	DefaultWait = 2 * time.Millisecond
+
+	// DefaultMaxBatch - максимальное количество ключей в одном запросе к хранилищу
+	DefaultMaxBatch = 100
+)
+
+// connectionLoader загружает страницы комментариев по ID родителя: поста или комментария
+type connectionLoader = Loader[uuid.UUID, *model.CommentConnection]
+
+// Loaders содержит загрузчики одного запроса.
+//
+// Поля Post.comments и Comment.children принимают аргументы фильтра и пагинации,
+// поэтому для каждого набора аргументов создается отдельный загрузчик: в один пакет
+// попадают только родители, запрошенные с одинаковыми аргументами.
+type Loaders struct {
+	comments service.CommentService
+
+	mu           sync.Mutex
+	postComments map[string]*connectionLoader
+	replies      map[string]*connectionLoader
+}
+
+// NewLoaders создает загрузчики запроса поверх сервиса комментариев
+func NewLoaders(comments service.CommentService) *Loaders {
+	return &Loaders{
+		comments:     comments,
+		postComments: make(map[string]*connectionLoader),
+		replies:      make(map[string]*connectionLoader),
+	}
+}
+
+// PostComments возвращает комментарии поста postID.
+//
+// Запросы комментариев разных постов с одинаковыми filter и pagination
+// объединяются в один вызов CommentService.ListCommentsByPostIDs.
+func (l *Loaders) PostComments(ctx context.Context, postID uuid.UUID, filter model.CommentFilter, pagination model.PaginationInput) (*model.CommentConnection, error) {
+	loader := l.loader(l.postComments, filter, pagination, l.comments.ListCommentsByPostIDs)
+	return loader.Load(ctx, postID)
+}
+
+// Replies возвращает прямые ответы на комментарий parentID.
+//
+// Запросы ответов на разные комментарии с одинаковыми filter и pagination
+// объединяются в один вызов CommentService.ListRepliesByParentIDs.
+func (l *Loaders) Replies(ctx context.Context, parentID uuid.UUID, filter model.CommentFilter, pagination model.PaginationInput) (*model.CommentConnection, error) {
+	loader := l.loader(l.replies, filter, pagination, l.comments.ListRepliesByParentIDs)
+	return loader.Load(ctx, parentID)
+}
+
+// loader возвращает загрузчик для набора аргументов, создавая его при первом обращении
+func (l *Loaders) loader(
+	loaders map[string]*connectionLoader,
+	filter model.CommentFilter,
+	pagination model.PaginationInput,
+	list func(context.Context, []uuid.UUID, model.CommentFilter, model.PaginationInput) (map[uuid.UUID]*model.CommentConnection, error),
+) *connectionLoader {
+	filter.PostID = nil
+	filter.ParentID = nil
+	key := argumentsKey(filter, pagination)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	loader, ok := loaders[key]
+	if !ok {
+		loader = NewLoader(func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*model.CommentConnection, error) {
+			return list(ctx, ids, filter, pagination)
+		}, DefaultWait, DefaultMaxBatch)
+		loaders[key] = loader
+	}
+	return loader
+}
+
+// argumentsKey возвращает ключ набора аргументов поля: значения, а не адреса указателей
+func argumentsKey(filter model.CommentFilter, pagination model.PaginationInput) string {
+	data, _ := json.Marshal(struct {
+		Filter     model.CommentFilter
+		Pagination model.PaginationInput
+	}{filter, pagination})
+	return string(data)
+}
@@ -0,0 +1,42 @@
+package dataloader
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/NarthurN/habbr/internal/service"
+)
+
+// contextKey является приватным типом ключей контекста пакета
+type contextKey struct {
+	name string
+}
+
+var loadersContextKey = &contextKey{"loaders"}
+
+// WithLoaders возвращает копию контекста с загрузчиками запроса
+func WithLoaders(ctx context.Context, loaders *Loaders) context.Context {
+	return context.WithValue(ctx, loadersContextKey, loaders)
+}
+
+// FromContext извлекает загрузчики запроса из контекста.
+//
+// Возвращает false, если middleware не подключен.
+func FromContext(ctx context.Context) (*Loaders, bool) {
+	loaders, ok := ctx.Value(loadersContextKey).(*Loaders)
+	return loaders, ok && loaders != nil
+}
+
+// Middleware создает HTTP middleware, помещающий в контекст новые загрузчики для каждого запроса.
+//
+// Пример использования:
+//
+//	mux.Handle("/query", dataloader.Middleware(services.Comment)(graphqlServer))
+func Middleware(comments service.CommentService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := WithLoaders(r.Context(), NewLoaders(comments))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Размер выборки аналитики постов
+const (
+	// DefaultPostAnalyticsLimit - количество постов в выборке, если first не указан
+	DefaultPostAnalyticsLimit = 20
+
+	// MaxPostAnalyticsLimit - максимальное количество постов в выборке
+	MaxPostAnalyticsLimit = 100
+)
+
+// PostAnalytics представляет сводку обсуждения поста для редакционной панели.
+//
+// В PostgreSQL данные читаются из материализованного представления post_analytics
+// и отстают от постов и комментариев не больше чем на интервал его обновления.
+// Надгробия мягко удаленных комментариев учитываются в счетчиках.
+type PostAnalytics struct {
+	// PostID - идентификатор поста
+	PostID uuid.UUID `json:"post_id"`
+
+	// Title - заголовок поста на момент обновления
+	Title string `json:"title"`
+
+	// AuthorID - идентификатор автора поста
+	AuthorID uuid.UUID `json:"author_id"`
+
+	// CreatedAt - время создания поста
+	CreatedAt time.Time `json:"created_at"`
+
+	// TotalComments - общее количество комментариев, включая ответы
+	TotalComments int `json:"total_comments"`
+
+	// RootComments - количество комментариев верхнего уровня
+	RootComments int `json:"root_comments"`
+
+	// MaxCommentDepth - максимальная глубина вложенности (0, если комментариев нет)
+	MaxCommentDepth int `json:"max_comment_depth"`
+
+	// LastCommentAt - время создания последнего комментария (nil, если комментариев нет)
+	LastCommentAt *time.Time `json:"last_comment_at"`
+}
+
+// PostAnalyticsFilter представляет параметры выборки аналитики постов.
+//
+// Пример использования:
+//   minComments := 10
+//   items, err := analyticsService.ListPostAnalytics(ctx, PostAnalyticsFilter{
+//       MinComments: &minComments,
+//       Order:       PostAnalyticsOrderRecentlyCommented,
+//   }, nil)
+type PostAnalyticsFilter struct {
+	// AuthorID - только посты автора
+	AuthorID *uuid.UUID `json:"author_id,omitempty"`
+
+	// CreatedAfter и CreatedBefore ограничивают время создания поста: [CreatedAfter, CreatedBefore)
+	CreatedAfter  *time.Time `json:"created_after,omitempty"`
+	CreatedBefore *time.Time `json:"created_before,omitempty"`
+
+	// MinComments - минимальное общее количество комментариев
+	MinComments *int `json:"min_comments,omitempty"`
+
+	// Order - порядок сортировки, по умолчанию PostAnalyticsOrderMostCommented
+	Order PostAnalyticsOrder `json:"order,omitempty"`
+}
+
+// PostAnalyticsOrder определяет порядок сортировки аналитики постов.
+// Посты с равным ключом упорядочиваются по ID.
+type PostAnalyticsOrder string
+
+// Порядки сортировки аналитики постов
+const (
+	// PostAnalyticsOrderMostCommented - сначала посты с наибольшим количеством комментариев (по умолчанию)
+	PostAnalyticsOrderMostCommented PostAnalyticsOrder = "MOST_COMMENTED"
+
+	// PostAnalyticsOrderRecentlyCommented - сначала недавно прокомментированные, посты без комментариев в конце
+	PostAnalyticsOrderRecentlyCommented PostAnalyticsOrder = "RECENTLY_COMMENTED"
+
+	// PostAnalyticsOrderDeepest - сначала посты с самыми глубокими ветками
+	PostAnalyticsOrderDeepest PostAnalyticsOrder = "DEEPEST"
+
+	// PostAnalyticsOrderNewest - сначала новые посты
+	PostAnalyticsOrderNewest PostAnalyticsOrder = "NEWEST"
+)
+
+// IsValid проверяет, что порядок входит в список поддерживаемых
+func (o PostAnalyticsOrder) IsValid() bool {
+	switch o {
+	case PostAnalyticsOrderMostCommented, PostAnalyticsOrderRecentlyCommented,
+		PostAnalyticsOrderDeepest, PostAnalyticsOrderNewest:
+		return true
+	default:
+		return false
+	}
+}
+
+// OrDefault возвращает порядок, подставляя PostAnalyticsOrderMostCommented вместо пустого значения
+func (o PostAnalyticsOrder) OrDefault() PostAnalyticsOrder {
+	if o == "" {
+		return PostAnalyticsOrderMostCommented
+	}
+	return o
+}
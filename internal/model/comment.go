@@ -12,6 +12,10 @@ import (
 // Ограничение помогает предотвратить злоупотребления и обеспечить разумный размер данных.
 const MaxCommentLength = 2000
 
+// DeletedCommentPlaceholder заменяет содержимое надгробия - мягко удаленного комментария,
+// у которого есть ответы
+const DeletedCommentPlaceholder = "[комментарий удален]"
+
 // Comment представляет доменную модель комментария в иерархической системе.
 //
 // Комментарии организованы в древовидную структуру с неограниченной глубиной вложенности.
@@ -49,12 +53,20 @@ type Comment struct {
 	// Depth - глубина вложенности комментария в дереве (0 для корневых)
 	Depth int `json:"depth"`
 
+	// Language - язык комментария, определяется по содержимому
+	Language Language `json:"language"`
+
 	// CreatedAt - время создания комментария
 	CreatedAt time.Time `json:"created_at"`
 
 	// UpdatedAt - время последнего обновления комментария
 	UpdatedAt time.Time `json:"updated_at"`
 
+	// DeletedAt - время мягкого удаления (nil для обычных комментариев).
+	// Надгробие сохраняет место в дереве для ответов: содержимое заменено
+	// на DeletedCommentPlaceholder, автор скрыт
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
 	// Children - массив дочерних комментариев (заполняется при построении дерева)
 	Children []*Comment `json:"children,omitempty"`
 }
@@ -145,6 +157,9 @@ type CommentFilter struct {
 
 	// MaxDepth - максимальная глубина вложенности для включения в результат
 	MaxDepth *int `json:"max_depth,omitempty"`
+
+	// Order - порядок сортировки, пустое значение означает CommentOrderOldest
+	Order CommentOrder `json:"order,omitempty"`
 }
 
 // CommentConnection представляет результат пагинированного запроса комментариев.
@@ -201,8 +216,17 @@ type CommentSubscriptionPayload struct {
 	// Comment - данные комментария (может быть nil для события удаления)
 	Comment *Comment `json:"comment"`
 
-	// ActionType - тип события: "CREATED", "UPDATED", "DELETED"
+	// ActionType - тип события: "CREATED", "UPDATED", "DELETED", "TOMBSTONED"
+	// (комментарий с ответами стал надгробием)
 	ActionType string `json:"action_type"`
+
+	// DeletedCount - количество удаленных комментариев (только для DELETED).
+	// При удалении поддерева событие отправляется для каждого комментария с DeletedCount = 1
+	DeletedCount int `json:"deleted_count,omitempty"`
+
+	// EventID - возрастающий идентификатор события в журнале событий поста.
+	// Клиент передает последний полученный EventID при переподключении, чтобы получить пропущенные события.
+	EventID int64 `json:"event_id,omitempty"`
 }
 
 // Validate проверяет валидность входных данных для создания комментария.
@@ -282,6 +306,7 @@ func (c *CommentUpdateInput) Validate() error {
 // - Генерирует новый UUID для комментария
 // - Обрезает пробелы в начале и конце содержимого
 // - Устанавливает переданную глубину вложенности
+// - Определяет язык по содержимому
 // - Устанавливает текущее время как CreatedAt и UpdatedAt
 // - Инициализирует пустой массив Children
 // - Копирует остальные поля из входных данных
@@ -314,6 +339,7 @@ func NewComment(input CommentInput, depth int) *Comment {
 		Content:   strings.TrimSpace(input.Content),
 		AuthorID:  input.AuthorID,
 		Depth:     depth,
+		Language:  DetectLanguage(input.Content),
 		CreatedAt: now,
 		UpdatedAt: now,
 		Children:  make([]*Comment, 0),
@@ -346,6 +372,7 @@ func NewComment(input CommentInput, depth int) *Comment {
 func (c *Comment) Update(input CommentUpdateInput) {
 	if input.Content != nil {
 		c.Content = strings.TrimSpace(*input.Content)
+		c.Language = DetectLanguage(c.Content)
 	}
 
 	c.UpdatedAt = time.Now()
@@ -370,11 +397,16 @@ func (c *Comment) IsRootComment() bool {
 	return c.ParentID == nil
 }
 
+// IsDeleted проверяет, является ли комментарий надгробием мягко удаленного комментария.
+func (c *Comment) IsDeleted() bool {
+	return c.DeletedAt != nil
+}
+
 // CanBeRepliedTo проверяет, можно ли создать ответ на данный комментарий.
 //
-// В текущей реализации всегда возвращает true, но метод оставлен для
-// будущих возможных ограничений (например, максимальная глубина вложенности,
-// архивирование старых комментариев, блокировка пользователей).
+// На надгробия удаленных комментариев отвечать нельзя. Метод оставлен для
+// будущих возможных ограничений (например, архивирование старых комментариев,
+// блокировка пользователей).
 //
 // Возвращает:
 //   - true если на комментарий можно ответить
@@ -388,8 +420,7 @@ func (c *Comment) IsRootComment() bool {
 //       return errors.New("ответы на этот комментарий запрещены")
 //   }
 func (c *Comment) CanBeRepliedTo() bool {
-	// Можно добавить ограничения на глубину вложенности если потребуется
-	return true
+	return !c.IsDeleted()
 }
 
 // AddChild добавляет дочерний комментарий в коллекцию Children.
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"github.com/google/uuid"
+)
+
+// MaxCommentBatchSize - максимальное количество комментариев в одном массовом удалении
+const MaxCommentBatchSize = 100
+
+// CommentDeleteStatus определяет итог удаления одного комментария в массовой операции
+type CommentDeleteStatus string
+
+// Итоги удаления комментария
+const (
+	// CommentDeleteStatusDeleted - комментарий удален вместе с ответами
+	// (в том числе как ответ другого комментария пакета)
+	CommentDeleteStatusDeleted CommentDeleteStatus = "DELETED"
+
+	// CommentDeleteStatusInvalidID - идентификатор не является UUID
+	CommentDeleteStatusInvalidID CommentDeleteStatus = "INVALID_ID"
+
+	// CommentDeleteStatusNotFound - комментарий не найден
+	CommentDeleteStatusNotFound CommentDeleteStatus = "NOT_FOUND"
+
+	// CommentDeleteStatusWrongPost - комментарий принадлежит другому посту
+	CommentDeleteStatusWrongPost CommentDeleteStatus = "WRONG_POST"
+
+	// CommentDeleteStatusForbidden - пользователь не автор комментария и не модератор
+	CommentDeleteStatusForbidden CommentDeleteStatus = "FORBIDDEN"
+
+	// CommentDeleteStatusFailed - удаление завершилось системной ошибкой
+	CommentDeleteStatusFailed CommentDeleteStatus = "FAILED"
+
+	// CommentDeleteStatusSkipped - комментарий не удален, так как атомарное удаление
+	// отменено из-за другого комментария пакета
+	CommentDeleteStatusSkipped CommentDeleteStatus = "SKIPPED"
+)
+
+// CommentDeleteItem - итог удаления одного запрошенного комментария
+type CommentDeleteItem struct {
+	ID     uuid.UUID           `json:"id"`
+	Status CommentDeleteStatus `json:"status"`
+
+	// Message - описание ошибки для статусов, отличных от DELETED
+	Message string `json:"message,omitempty"`
+}
+
+// CommentBatchDeleteResult - результат массового удаления комментариев.
+//
+// Items содержит итог для каждого уникального запрошенного ID в порядке запроса,
+// DeletedIDs - все фактически удаленные комментарии, включая ответы.
+type CommentBatchDeleteResult struct {
+	Items      []*CommentDeleteItem `json:"items"`
+	DeletedIDs []uuid.UUID          `json:"deleted_ids"`
+}
+
+// Success сообщает, что удалены все запрошенные комментарии
+func (r *CommentBatchDeleteResult) Success() bool {
+	for _, item := range r.Items {
+		if item.Status != CommentDeleteStatusDeleted {
+			return false
+		}
+	}
+	return true
+}
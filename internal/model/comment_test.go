@@ -2,6 +2,7 @@ package model
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -189,8 +190,14 @@ func TestComment_CanBeRepliedTo(t *testing.T) {
 		Depth: 5,
 	}
 
-	// В текущей реализации всегда можно ответить на комментарий
+	// На обычный комментарий можно ответить на любой глубине
 	assert.True(t, comment.CanBeRepliedTo())
+
+	// На надгробие удаленного комментария отвечать нельзя
+	deletedAt := time.Now()
+	comment.DeletedAt = &deletedAt
+	assert.True(t, comment.IsDeleted())
+	assert.False(t, comment.CanBeRepliedTo())
 }
 
 func TestComment_AddChild(t *testing.T) {
@@ -0,0 +1,156 @@
+package model
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// Ограничения загрузки ветки дерева комментариев
+const (
+	// DefaultCommentTreePageSize - количество комментариев верхнего уровня по умолчанию
+	DefaultCommentTreePageSize = 10
+	// MaxCommentTreePageSize - максимальное количество комментариев верхнего уровня
+	MaxCommentTreePageSize = 50
+
+	// DefaultCommentTreeDepth - количество уровней ответов ниже верхнего уровня по умолчанию
+	DefaultCommentTreeDepth = 3
+	// MaxCommentTreeDepth - максимальное количество уровней ответов
+	MaxCommentTreeDepth = 10
+
+	// DefaultCommentTreeReplies - количество загружаемых ответов на комментарий по умолчанию
+	DefaultCommentTreeReplies = 3
+	// MaxCommentTreeReplies - максимальное количество загружаемых ответов на комментарий
+	MaxCommentTreeReplies = 20
+
+	// MaxCommentTreeNodes - максимальное количество комментариев, которое может
+	// вернуть одна загрузка ветки (см. CommentTreeSize)
+	MaxCommentTreeNodes = 1000
+
+	// DefaultCommentContextAncestors - количество загружаемых предков комментария по умолчанию
+	DefaultCommentContextAncestors = 5
+	// MaxCommentContextAncestors - максимальное количество загружаемых предков комментария
+	MaxCommentContextAncestors = 50
+)
+
+// CommentTreeQuery описывает загрузку ветки дерева комментариев.
+//
+// Верхний уровень ветки - ответы на ParentID или корневые комментарии поста PostID,
+// если ParentID не указан; First и After задают страницу верхнего уровня. Ниже
+// загружается не более Depth уровней ответов и не более RepliesFirst ответов на
+// каждый комментарий. Фильтр (AuthorID, MaxDepth) и порядок применяются на каждом
+// уровне: ответы на отфильтрованный комментарий не загружаются.
+//
+// Пример использования:
+//   // Корневые комментарии с двумя уровнями ответов
+//   depth := 2
+//   query := CommentTreeQuery{PostID: postID, Depth: &depth}
+//
+//   // Следующие ответы свернутого узла
+//   query := CommentTreeQuery{
+//       PostID:   postID,
+//       ParentID: &node.Comment.ID,
+//       After:    node.MoreRepliesCursor,
+//   }
+type CommentTreeQuery struct {
+	// PostID - пост, обязательное поле
+	PostID uuid.UUID `json:"post_id"`
+
+	// ParentID - комментарий, ответы на который образуют верхний уровень ветки
+	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+
+	// Filter - фильтр по автору и абсолютной глубине; PostID и ParentID фильтра не учитываются
+	Filter CommentFilter `json:"filter"`
+
+	// Depth - количество уровней ответов ниже верхнего уровня, по умолчанию DefaultCommentTreeDepth
+	Depth *int `json:"depth,omitempty"`
+
+	// First и After - страница комментариев верхнего уровня
+	First *int    `json:"first,omitempty"`
+	After *string `json:"after,omitempty"`
+
+	// RepliesFirst - количество загружаемых ответов на каждый комментарий,
+	// по умолчанию DefaultCommentTreeReplies
+	RepliesFirst *int `json:"replies_first,omitempty"`
+}
+
+// CommentTreeNode представляет комментарий загруженной ветки дерева
+type CommentTreeNode struct {
+	Comment *Comment `json:"comment"`
+
+	// Cursor - позиция комментария среди ответов его родителя
+	Cursor string `json:"cursor"`
+
+	// ReplyCount - количество прямых ответов, удовлетворяющих фильтру
+	ReplyCount int `json:"reply_count"`
+
+	// LoadedReplyCount - количество загруженных прямых ответов
+	LoadedReplyCount int `json:"loaded_reply_count"`
+
+	// MoreRepliesCursor - курсор последнего загруженного ответа, если загружены не все
+	// ответы: остальные загружаются запросом ветки с ParentID = Comment.ID и After
+	MoreRepliesCursor *string `json:"more_replies_cursor,omitempty"`
+
+	// ContinueThread - у комментария есть ответы, но он находится на последнем
+	// загруженном уровне: продолжение загружается запросом ветки с ParentID = Comment.ID
+	ContinueThread bool `json:"continue_thread"`
+}
+
+// CommentTree представляет загруженную ветку дерева комментариев.
+//
+// Nodes перечислены в порядке обхода в глубину: за каждым комментарием следуют его
+// загруженные ответы, поэтому дерево строится по Comment.ParentID за один проход.
+// PageInfo относится к комментариям верхнего уровня.
+type CommentTree struct {
+	Nodes    []*CommentTreeNode `json:"nodes"`
+	PageInfo *PageInfo          `json:"page_info"`
+}
+
+// CommentTreeSize возвращает наибольшее количество комментариев в ветке из first
+// комментариев верхнего уровня с depth уровнями по repliesFirst ответов:
+// first * (1 + repliesFirst + ... + repliesFirst^depth). Результат ограничен math.MaxInt
+func CommentTreeSize(first, repliesFirst, depth int) int {
+	repliesFirst = max(repliesFirst, 0)
+
+	size, level := max(first, 0), max(first, 0)
+	for i := 0; i < depth; i++ {
+		if repliesFirst > 0 && level > math.MaxInt/repliesFirst {
+			return math.MaxInt
+		}
+		level *= repliesFirst
+
+		if size > math.MaxInt-level {
+			return math.MaxInt
+		}
+		size += level
+	}
+	return size
+}
+
+// CommentContext представляет комментарий в контексте обсуждения: цепочку ближайших
+// предков и первую страницу прямых ответов. Используется для ссылок на глубоко
+// вложенный комментарий без загрузки всего дерева поста.
+//
+// Пример использования:
+//   ctx, err := commentService.GetCommentContext(ctx, commentID, nil, nil)
+//   for _, ancestor := range ctx.Ancestors {
+//       fmt.Println(ancestor.Depth, ancestor.Content)
+//   }
+//   fmt.Println(ctx.Comment.Depth, ctx.Comment.Content)
+type CommentContext struct {
+	// Comment - запрошенный комментарий
+	Comment *Comment `json:"comment"`
+
+	// Ancestors - ближайшие предки от самого дальнего загруженного к родителю комментария
+	Ancestors []*Comment `json:"ancestors"`
+
+	// HasMoreAncestors - выше первого загруженного предка есть еще комментарии
+	HasMoreAncestors bool `json:"has_more_ancestors"`
+
+	// Replies - прямые ответы на комментарий; следующая страница загружается запросом
+	// ветки с ParentID = Comment.ID и After = Replies.PageInfo.EndCursor
+	Replies *CommentTree `json:"replies"`
+
+	// TotalReplies - количество ответов во всем поддереве комментария (на всех уровнях)
+	TotalReplies int `json:"total_replies"`
+}
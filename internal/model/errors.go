@@ -79,3 +79,24 @@ func NewInternalError(message string) *DomainError {
 		Message: message,
 	}
 }
+
+// NewEventCursorExpiredError создает ошибку подписки с курсором, после которого часть
+// событий уже удалена из журнала: клиент должен заново загрузить комментарии и
+// подписаться без курсора
+func NewEventCursorExpiredError(afterEventID int64) *DomainError {
+	return &DomainError{
+		Type:    "EVENT_CURSOR_EXPIRED",
+		Message: "events after the cursor are no longer available, reload comments and subscribe without afterEventID",
+		Details: map[string]string{
+			"after_event_id": fmt.Sprintf("%d", afterEventID),
+		},
+	}
+}
+
+// NewSlowConsumerError создает ошибку завершения подписки, не успевающей читать события
+func NewSlowConsumerError() *DomainError {
+	return &DomainError{
+		Type:    "SLOW_CONSUMER",
+		Message: "subscription closed: subscriber is not reading events fast enough",
+	}
+}
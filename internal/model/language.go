@@ -0,0 +1,69 @@
+package model
+
+import (
+	"unicode"
+)
+
+// Language определяет язык текста для полнотекстового поиска.
+//
+// Значения совпадают с именами конфигураций полнотекстового поиска PostgreSQL:
+// от языка зависят стемминг и список стоп-слов при индексации и разборе запроса.
+//
+// Пример использования:
+//   language := DetectLanguage(input.Title, input.Content)
+//   fmt.Println(language) // "russian"
+type Language string
+
+// Поддерживаемые языки поиска
+const (
+	// LanguageRussian - русский стемминг; слова латиницей обрабатываются английским стеммером
+	LanguageRussian Language = "russian"
+
+	// LanguageEnglish - английский стемминг
+	LanguageEnglish Language = "english"
+
+	// LanguageSimple - без стемминга и стоп-слов, для остальных языков
+	LanguageSimple Language = "simple"
+)
+
+// Languages возвращает все поддерживаемые языки
+func Languages() []Language {
+	return []Language{LanguageRussian, LanguageEnglish, LanguageSimple}
+}
+
+// IsValid проверяет, что язык входит в список поддерживаемых
+func (l Language) IsValid() bool {
+	switch l {
+	case LanguageRussian, LanguageEnglish, LanguageSimple:
+		return true
+	default:
+		return false
+	}
+}
+
+// DetectLanguage определяет язык текста по преобладающему алфавиту.
+//
+// Текст с преобладанием кириллицы считается русским, с преобладанием латиницы - английским,
+// текст без букв этих алфавитов - LanguageSimple.
+func DetectLanguage(texts ...string) Language {
+	cyrillic, latin := 0, 0
+	for _, text := range texts {
+		for _, r := range text {
+			switch {
+			case unicode.Is(unicode.Cyrillic, r):
+				cyrillic++
+			case unicode.Is(unicode.Latin, r):
+				latin++
+			}
+		}
+	}
+
+	switch {
+	case cyrillic > 0 && cyrillic >= latin:
+		return LanguageRussian
+	case latin > 0:
+		return LanguageEnglish
+	default:
+		return LanguageSimple
+	}
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []string
+		want  Language
+	}{
+		{name: "russian", texts: []string{"Привет, мир"}, want: LanguageRussian},
+		{name: "english", texts: []string{"Hello, world"}, want: LanguageEnglish},
+		{name: "russian with latin terms", texts: []string{"Запросы GraphQL", "Подписки работают через websocket"}, want: LanguageRussian},
+		{name: "mostly latin", texts: []string{"GraphQL subscriptions over websockets, кратко"}, want: LanguageEnglish},
+		{name: "no letters", texts: []string{"12345", "!!!"}, want: LanguageSimple},
+		{name: "empty", want: LanguageSimple},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DetectLanguage(tt.texts...))
+		})
+	}
+}
+
+func TestPostLanguage(t *testing.T) {
+	post := NewPost(PostInput{Title: "Заголовок", Content: "Содержимое", AuthorID: uuid.New()})
+	assert.Equal(t, LanguageRussian, post.Language, "language is detected when not set")
+
+	post = NewPost(PostInput{Title: "Заголовок", Content: "Содержимое", AuthorID: uuid.New(), Language: LanguageSimple})
+	assert.Equal(t, LanguageSimple, post.Language)
+
+	title := "English title"
+	post.Update(PostUpdateInput{Title: &title})
+	assert.Equal(t, LanguageSimple, post.Language, "update keeps the language unless it is set explicitly")
+
+	english := LanguageEnglish
+	post.Update(PostUpdateInput{Language: &english})
+	assert.Equal(t, LanguageEnglish, post.Language)
+
+	invalid := Language("klingon")
+	assert.Error(t, (&PostUpdateInput{Language: &invalid}).Validate())
+	assert.Error(t, (&PostInput{Title: "Title", Content: "Content", AuthorID: uuid.New(), Language: invalid}).Validate())
+}
@@ -0,0 +1,82 @@
+package model
+
+// PostOrder определяет порядок сортировки списка постов.
+//
+// Каждый порядок задает ключ сортировки и направление; записи с равным ключом
+// упорядочиваются по ID, поэтому порядок строгий и пригоден для курсорной пагинации.
+//
+// Пример использования:
+//   filter := PostFilter{Order: PostOrderMostCommented}
+//   connection, err := postService.ListPosts(ctx, filter, pagination)
+type PostOrder string
+
+// Порядки сортировки постов
+const (
+	// PostOrderNewest - сначала новые (по умолчанию)
+	PostOrderNewest PostOrder = "NEWEST"
+
+	// PostOrderOldest - сначала старые
+	PostOrderOldest PostOrder = "OLDEST"
+
+	// PostOrderRecentlyUpdated - сначала недавно измененные
+	PostOrderRecentlyUpdated PostOrder = "RECENTLY_UPDATED"
+
+	// PostOrderMostCommented - сначала посты с наибольшим количеством комментариев
+	PostOrderMostCommented PostOrder = "MOST_COMMENTED"
+)
+
+// IsValid проверяет, что порядок входит в список поддерживаемых
+func (o PostOrder) IsValid() bool {
+	switch o {
+	case PostOrderNewest, PostOrderOldest, PostOrderRecentlyUpdated, PostOrderMostCommented:
+		return true
+	default:
+		return false
+	}
+}
+
+// OrDefault возвращает порядок, подставляя PostOrderNewest вместо пустого значения
+func (o PostOrder) OrDefault() PostOrder {
+	if o == "" {
+		return PostOrderNewest
+	}
+	return o
+}
+
+// CommentOrder определяет порядок сортировки списка и дерева комментариев.
+//
+// В дереве комментариев порядок применяется к ответам на каждом уровне вложенности.
+type CommentOrder string
+
+// Порядки сортировки комментариев
+const (
+	// CommentOrderOldest - сначала старые (по умолчанию), порядок хода обсуждения
+	CommentOrderOldest CommentOrder = "OLDEST"
+
+	// CommentOrderNewest - сначала новые
+	CommentOrderNewest CommentOrder = "NEWEST"
+
+	// CommentOrderRecentlyUpdated - сначала недавно измененные
+	CommentOrderRecentlyUpdated CommentOrder = "RECENTLY_UPDATED"
+
+	// CommentOrderMostReplies - сначала комментарии с наибольшим количеством прямых ответов
+	CommentOrderMostReplies CommentOrder = "MOST_REPLIES"
+)
+
+// IsValid проверяет, что порядок входит в список поддерживаемых
+func (o CommentOrder) IsValid() bool {
+	switch o {
+	case CommentOrderOldest, CommentOrderNewest, CommentOrderRecentlyUpdated, CommentOrderMostReplies:
+		return true
+	default:
+		return false
+	}
+}
+
+// OrDefault возвращает порядок, подставляя CommentOrderOldest вместо пустого значения
+func (o CommentOrder) OrDefault() CommentOrder {
+	if o == "" {
+		return CommentOrderOldest
+	}
+	return o
+}
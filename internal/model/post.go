@@ -37,6 +37,9 @@ type Post struct {
 	// CommentsEnabled - флаг, разрешены ли комментарии к этому посту
 	CommentsEnabled bool `json:"comments_enabled"`
 
+	// Language - язык поста, определяет стемминг при полнотекстовом поиске
+	Language Language `json:"language"`
+
 	// CreatedAt - время создания поста
 	CreatedAt time.Time `json:"created_at"`
 
@@ -71,6 +74,9 @@ type PostInput struct {
 
 	// CommentsEnabled - разрешены ли комментарии, по умолчанию false
 	CommentsEnabled bool `json:"comments_enabled"`
+
+	// Language - язык поста; если не указан, определяется по заголовку и содержимому
+	Language Language `json:"language,omitempty"`
 }
 
 // PostUpdateInput представляет входные данные для обновления существующего поста.
@@ -93,6 +99,9 @@ type PostUpdateInput struct {
 
 	// CommentsEnabled - новое значение разрешения комментариев, опциональное поле
 	CommentsEnabled *bool `json:"comments_enabled,omitempty"`
+
+	// Language - новый язык поста, опциональное поле
+	Language *Language `json:"language,omitempty"`
 }
 
 // PostFilter представляет фильтры для поиска и выборки постов.
@@ -114,6 +123,9 @@ type PostFilter struct {
 	// false - только посты с отключенными комментариями
 	// nil - все посты независимо от настройки комментариев
 	WithComments *bool `json:"with_comments,omitempty"`
+
+	// Order - порядок сортировки, пустое значение означает PostOrderNewest
+	Order PostOrder `json:"order,omitempty"`
 }
 
 // PaginationInput представляет параметры пагинации для cursor-based подхода.
@@ -195,6 +207,63 @@ type PageInfo struct {
 	EndCursor *string `json:"end_cursor"`
 }
 
+// Типы событий жизненного цикла поста для real-time подписок
+const (
+	PostEventCreated         = "CREATED"
+	PostEventUpdated         = "UPDATED"
+	PostEventDeleted         = "DELETED"
+	PostEventCommentsToggled = "COMMENTS_TOGGLED"
+)
+
+// PostSubscriptionPayload представляет данные события жизненного цикла поста для real-time подписок.
+//
+// Событие DELETED является терминальным: после него подписки на конкретный пост
+// завершаются, так как новых событий по удаленному посту уже не будет.
+//
+// Пример использования:
+//   subscription := `
+//       subscription PostUpdates($postID: ID!) {
+//           postUpdates(postID: $postID) {
+//               type
+//               post { id title commentsEnabled }
+//           }
+//       }
+//   `
+type PostSubscriptionPayload struct {
+	// PostID - идентификатор поста, к которому относится событие
+	PostID uuid.UUID `json:"post_id"`
+
+	// Post - состояние поста после события (для DELETED - последнее известное состояние)
+	Post *Post `json:"post"`
+
+	// ActionType - тип события: "CREATED", "UPDATED", "DELETED", "COMMENTS_TOGGLED"
+	ActionType string `json:"action_type"`
+}
+
+// PostStats представляет статистику комментариев поста для real-time подписок.
+//
+// Значения поддерживаются инкрементально по событиям комментариев и поста;
+// после удаления последнего комментария LastCommentAt перечитывается из хранилища.
+type PostStats struct {
+	// PostID - идентификатор поста
+	PostID uuid.UUID `json:"post_id"`
+
+	// TotalComments - общее количество комментариев к посту
+	TotalComments int `json:"total_comments"`
+
+	// RootComments - количество комментариев верхнего уровня
+	RootComments int `json:"root_comments"`
+
+	// Replies - количество ответов на комментарии
+	Replies int `json:"replies"`
+
+	// CommentsEnabled - разрешены ли комментарии к посту
+	CommentsEnabled bool `json:"comments_enabled"`
+
+	// LastCommentAt - время создания последнего комментария (nil, если комментариев не было)
+	LastCommentAt *time.Time `json:"last_comment_at"`
+}
+
 // Validate проверяет валидность входных данных для создания поста.
 //
 // Выполняет следующие проверки:
@@ -233,6 +302,10 @@ func (p *PostInput) Validate() error {
 		return errors.New("author_id is required")
 	}
 
+	if p.Language != "" && !p.Language.IsValid() {
+		return errors.New("unsupported language")
+	}
+
 	return nil
 }
 
@@ -274,6 +347,10 @@ func (p *PostUpdateInput) Validate() error {
 		}
 	}
 
+	if p.Language != nil && !p.Language.IsValid() {
+		return errors.New("unsupported language")
+	}
+
 	return nil
 }
 
@@ -282,6 +359,7 @@ func (p *PostUpdateInput) Validate() error {
 // Функция выполняет следующие действия:
 // - Генерирует новый UUID для поста
 // - Обрезает пробелы в начале и конце заголовка и содержимого
+// - Определяет язык по тексту, если он не указан во входных данных
 // - Устанавливает текущее время как CreatedAt и UpdatedAt
 // - Копирует остальные поля из входных данных
 //
@@ -308,15 +386,22 @@ func (p *PostUpdateInput) Validate() error {
 //   // post.Title теперь "Заголовок с пробелами" (без пробелов по краям)
 func NewPost(input PostInput) *Post {
 	now := time.Now()
-	return &Post{
+	post := &Post{
 		ID:              uuid.New(),
 		Title:           strings.TrimSpace(input.Title),
 		Content:         strings.TrimSpace(input.Content),
 		AuthorID:        input.AuthorID,
 		CommentsEnabled: input.CommentsEnabled,
+		Language:        input.Language,
 		CreatedAt:       now,
 		UpdatedAt:       now,
 	}
+
+	if post.Language == "" {
+		post.Language = DetectLanguage(post.Title, post.Content)
+	}
+
+	return post
 }
 
 // Update обновляет существующий пост новыми данными.
@@ -324,6 +409,7 @@ func NewPost(input PostInput) *Post {
 // Метод применяет изменения только к тем полям, которые указаны в input (не равны nil).
 // Автоматически обновляет временную метку UpdatedAt.
 // Обрезает пробелы в начале и конце текстовых полей.
+// Язык поста повторно не определяется: он меняется только явным input.Language.
 //
 // Параметры:
 //   - input: данные для обновления, поля равные nil игнорируются
@@ -361,6 +447,10 @@ func (p *Post) Update(input PostUpdateInput) {
 		p.CommentsEnabled = *input.CommentsEnabled
 	}
 
+	if input.Language != nil {
+		p.Language = *input.Language
+	}
+
 	p.UpdatedAt = time.Now()
 }
 
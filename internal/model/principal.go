@@ -0,0 +1,31 @@
+package model
+
+import (
+	"github.com/google/uuid"
+)
+
+// Principal представляет аутентифицированного пользователя, выполняющего запрос.
+//
+// Principal создается слоем аутентификации после успешной проверки подписанного
+// токена и передается дальше через контекст запроса. Сервисы и резолверы используют
+// его как единственный источник информации о том, кто выполняет действие,
+// вместо идентификаторов, присланных клиентом во входных данных.
+//
+// Пример использования:
+//   principal, ok := auth.PrincipalFromContext(ctx)
+//   if !ok {
+//       return model.NewUnauthorizedError()
+//   }
+//   post, err := postService.UpdatePost(ctx, postID, input, principal.UserID)
+type Principal struct {
+	// UserID - идентификатор пользователя (claim "sub" токена в формате UUID)
+	UserID uuid.UUID `json:"user_id"`
+
+	// Role - роль пользователя (claim "role" токена), определяет доступ к защищенным полям схемы
+	Role Role `json:"role"`
+}
+
+// HasRole проверяет, обладает ли пользователь привилегиями не ниже требуемой роли
+func (p *Principal) HasRole(required Role) bool {
+	return p != nil && p.Role.AtLeast(required)
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Role определяет уровень доступа пользователя.
+//
+// Роли упорядочены по возрастанию привилегий: каждая следующая роль
+// включает все права предыдущих.
+//   - reader: чтение постов и комментариев
+//   - author: публикация собственных постов и комментариев
+//   - moderator: модерация чужого контента
+//   - admin: полный доступ, включая служебные подписки
+//
+// Пример использования:
+//
+//	if !principal.Role.AtLeast(model.RoleModerator) {
+//	    return model.NewForbiddenError("moderate comments")
+//	}
+type Role string
+
+// Поддерживаемые роли пользователей
+const (
+	RoleReader    Role = "reader"
+	RoleAuthor    Role = "author"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+// roleLevels задает порядок ролей для сравнения привилегий
+var roleLevels = map[Role]int{
+	RoleReader:    1,
+	RoleAuthor:    2,
+	RoleModerator: 3,
+	RoleAdmin:     4,
+}
+
+// ParseRole преобразует строку в роль без учета регистра.
+//
+// Возвращает ошибку валидации для неизвестных ролей.
+func ParseRole(value string) (Role, error) {
+	role := Role(strings.ToLower(strings.TrimSpace(value)))
+	if !role.IsValid() {
+		return "", NewValidationError("role", fmt.Sprintf("unknown role %q", value))
+	}
+	return role, nil
+}
+
+// IsValid проверяет, является ли роль одной из поддерживаемых
+func (r Role) IsValid() bool {
+	_, ok := roleLevels[r]
+	return ok
+}
+
+// AtLeast проверяет, что роль обладает привилегиями не ниже требуемой.
+//
+// Неизвестные роли не удовлетворяют никакому требованию.
+func (r Role) AtLeast(required Role) bool {
+	level, ok := roleLevels[r]
+	if !ok {
+		return false
+	}
+	return level >= roleLevels[required]
+}
+
+// String возвращает строковое представление роли
+func (r Role) String() string {
+	return string(r)
+}
@@ -0,0 +1,89 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxSearchQueryLength - максимальная длина поискового запроса в символах
+const MaxSearchQueryLength = 200
+
+// SearchQuery представляет поисковый запрос пользователя.
+//
+// Запрос поддерживает синтаксис веб-поиска: слова объединяются по И,
+// "фраза в кавычках" ищется целиком, OR объединяет варианты, -слово исключает результаты.
+//
+// Пример использования:
+//   query := SearchQuery(`graphql "cursor pagination" -rest`)
+//   if err := query.Validate(); err != nil {
+//       return err
+//   }
+type SearchQuery string
+
+// Validate проверяет, что запрос не пустой и не превышает MaxSearchQueryLength
+func (q SearchQuery) Validate() error {
+	text := strings.TrimSpace(string(q))
+	if text == "" {
+		return fmt.Errorf("search query cannot be empty")
+	}
+	if utf8.RuneCountInString(text) > MaxSearchQueryLength {
+		return fmt.Errorf("search query cannot exceed %d characters", MaxSearchQueryLength)
+	}
+	return nil
+}
+
+// PostSearchConnection представляет страницу результатов поиска постов.
+//
+// Результаты упорядочены по убыванию релевантности; cursor ребра указывает
+// позицию в этом порядке и передается в after для получения следующей страницы.
+type PostSearchConnection struct {
+	// Edges - найденные посты в порядке убывания релевантности
+	Edges []*PostSearchEdge `json:"edges"`
+
+	// PageInfo - информация о пагинации
+	PageInfo *PageInfo `json:"page_info"`
+}
+
+// PostSearchEdge представляет найденный пост.
+//
+// Snippet содержит фрагмент текста поста, в котором совпадения обрамлены
+// маркерами <mark> и </mark>. Остальной текст не экранируется: клиент должен
+// экранировать его перед выводом в HTML.
+type PostSearchEdge struct {
+	// Node - найденный пост
+	Node *Post `json:"node"`
+
+	// Cursor - позиция поста в результатах поиска
+	Cursor string `json:"cursor"`
+
+	// Rank - релевантность поста запросу, больше - релевантнее
+	Rank float64 `json:"rank"`
+
+	// Snippet - фрагмент содержимого с подсвеченными совпадениями
+	Snippet string `json:"snippet"`
+}
+
+// CommentSearchConnection представляет страницу результатов поиска комментариев
+type CommentSearchConnection struct {
+	// Edges - найденные комментарии в порядке убывания релевантности
+	Edges []*CommentSearchEdge `json:"edges"`
+
+	// PageInfo - информация о пагинации
+	PageInfo *PageInfo `json:"page_info"`
+}
+
+// CommentSearchEdge представляет найденный комментарий, аналогично PostSearchEdge
+type CommentSearchEdge struct {
+	// Node - найденный комментарий
+	Node *Comment `json:"node"`
+
+	// Cursor - позиция комментария в результатах поиска
+	Cursor string `json:"cursor"`
+
+	// Rank - релевантность комментария запросу, больше - релевантнее
+	Rank float64 `json:"rank"`
+
+	// Snippet - фрагмент содержимого с подсвеченными совпадениями
+	Snippet string `json:"snippet"`
+}
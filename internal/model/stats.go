@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CommentStats представляет статистику комментариев поста, вычисленную в хранилище.
+//
+// Надгробия мягко удаленных комментариев остаются в дереве и учитываются во всех
+// счетчиках, кроме UniqueCommenters: у надгробия нет автора.
+//
+// Пример использования:
+//   stats, err := commentService.GetCommentStats(ctx, postID)
+//   for _, level := range stats.DepthHistogram {
+//       fmt.Printf("depth %d: %d comments\n", level.Depth, level.Count)
+//   }
+type CommentStats struct {
+	// PostID - идентификатор поста
+	PostID uuid.UUID `json:"post_id"`
+
+	// TotalComments - общее количество комментариев, включая ответы
+	TotalComments int `json:"total_comments"`
+
+	// RootComments - количество комментариев верхнего уровня
+	RootComments int `json:"root_comments"`
+
+	// Replies - количество ответов на комментарии
+	Replies int `json:"replies"`
+
+	// MaxDepth - максимальная глубина вложенности (0, если комментариев нет)
+	MaxDepth int `json:"max_depth"`
+
+	// AverageDepth - средняя глубина вложенности (0, если комментариев нет)
+	AverageDepth float64 `json:"average_depth"`
+
+	// UniqueCommenters - количество различных авторов комментариев
+	UniqueCommenters int `json:"unique_commenters"`
+
+	// LastCommentAt - время создания последнего комментария (nil, если комментариев нет)
+	LastCommentAt *time.Time `json:"last_comment_at"`
+
+	// DepthHistogram - количество комментариев на каждом непустом уровне по возрастанию глубины
+	DepthHistogram []DepthCount `json:"depth_histogram"`
+}
+
+// DepthCount - количество комментариев на одном уровне вложенности
+type DepthCount struct {
+	Depth int `json:"depth"`
+	Count int `json:"count"`
+}
@@ -0,0 +1,40 @@
+package model
+
+// SlowConsumerPolicy определяет, что делать с событием, если буфер подписчика заполнен.
+//
+// Подписчик, который читает события медленнее, чем они публикуются, не должен
+// замедлять доставку остальным подписчикам. Политика выбирается при создании
+// подписки или берется из конфигурации сервиса подписок.
+//
+// Пример использования:
+//   policy := SlowConsumerPolicy("drop-oldest")
+//   if !policy.IsValid() {
+//       return NewValidationError("slow_consumer_policy", "unknown policy")
+//   }
+type SlowConsumerPolicy string
+
+// Политики обработки медленных подписчиков
+const (
+	// SlowConsumerDropNewest отбрасывает новое событие (поведение по умолчанию)
+	SlowConsumerDropNewest SlowConsumerPolicy = "drop-newest"
+
+	// SlowConsumerDropOldest вытесняет самое старое событие из буфера, освобождая место для нового
+	SlowConsumerDropOldest SlowConsumerPolicy = "drop-oldest"
+
+	// SlowConsumerBlock ждет освобождения буфера не дольше настроенного таймаута,
+	// затем отбрасывает событие. Пока отправка ждет, остальные подписчики получают событие с задержкой
+	SlowConsumerBlock SlowConsumerPolicy = "block"
+
+	// SlowConsumerDisconnect завершает подписку с ошибкой SLOW_CONSUMER
+	SlowConsumerDisconnect SlowConsumerPolicy = "disconnect"
+)
+
+// IsValid проверяет, что политика входит в список поддерживаемых
+func (p SlowConsumerPolicy) IsValid() bool {
+	switch p {
+	case SlowConsumerDropNewest, SlowConsumerDropOldest, SlowConsumerBlock, SlowConsumerDisconnect:
+		return true
+	default:
+		return false
+	}
+}
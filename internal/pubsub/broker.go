@@ -0,0 +1,49 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+)
+
+// Типы брокеров сообщений
+const (
+	BrokerMemory   = "memory"
+	BrokerPostgres = "postgres"
+	BrokerRedis    = "redis"
+)
+
+// ErrClosed возвращается при обращении к закрытому брокеру
+var ErrClosed = errors.New("pubsub: broker is closed")
+
+// Handler обрабатывает сообщение, полученное из канала брокера.
+//
+// Обработчик вызывается последовательно для сообщений одного канала и не должен
+// надолго блокироваться: пока он работает, следующие сообщения канала не доставляются.
+type Handler func(message []byte)
+
+// Broker доставляет сообщения всем экземплярам сервиса, подписанным на канал.
+//
+// Интерфейс отделяет сервис подписок от транспорта: с in-memory брокером события
+// доставляются только внутри процесса, с PostgreSQL LISTEN/NOTIFY или Redis pub/sub -
+// всем репликам сервиса, включая отправителя. Гарантии доставки - "не более одного раза":
+// сообщения, опубликованные во время переподключения подписчика, теряются.
+//
+// Пример использования:
+//
+//	broker := pubsub.NewMemoryBroker()
+//	defer broker.Close()
+//
+//	_ = broker.Subscribe("comments", func(message []byte) {
+//	    fmt.Printf("received %s\n", message)
+//	})
+//	_ = broker.Publish(ctx, "comments", []byte(`{"action_type":"CREATED"}`))
+type Broker interface {
+	// Publish отправляет сообщение всем подписчикам канала
+	Publish(ctx context.Context, channel string, message []byte) error
+
+	// Subscribe регистрирует обработчик сообщений канала
+	Subscribe(channel string, handler Handler) error
+
+	// Close прекращает доставку сообщений и освобождает ресурсы брокера
+	Close() error
+}
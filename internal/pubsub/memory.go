@@ -0,0 +1,69 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryBroker реализует Broker внутри одного процесса.
+//
+// Обработчики вызываются синхронно в горутине отправителя, поэтому к моменту
+// возврата из Publish сообщение уже доставлено всем подписчикам. Подходит для
+// запуска одного экземпляра сервиса и для тестов.
+type MemoryBroker struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+	closed   bool
+}
+
+// NewMemoryBroker создает in-memory брокер
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		handlers: make(map[string][]Handler),
+	}
+}
+
+// Publish синхронно передает сообщение всем обработчикам канала
+func (b *MemoryBroker) Publish(ctx context.Context, channel string, message []byte) error {
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrClosed
+	}
+	handlers := b.handlers[channel]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(message)
+	}
+
+	return nil
+}
+
+// Subscribe регистрирует обработчик сообщений канала
+func (b *MemoryBroker) Subscribe(channel string, handler Handler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+
+	// Копируем срез, чтобы не менять список, который сейчас обходит Publish
+	handlers := make([]Handler, 0, len(b.handlers[channel])+1)
+	handlers = append(handlers, b.handlers[channel]...)
+	b.handlers[channel] = append(handlers, handler)
+
+	return nil
+}
+
+// Close удаляет все обработчики
+func (b *MemoryBroker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	b.handlers = make(map[string][]Handler)
+
+	return nil
+}
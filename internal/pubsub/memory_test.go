@@ -0,0 +1,40 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryBroker(t *testing.T) {
+	ctx := context.Background()
+	broker := NewMemoryBroker()
+
+	var first, second, other []string
+	require.NoError(t, broker.Subscribe("comments", func(message []byte) { first = append(first, string(message)) }))
+	require.NoError(t, broker.Subscribe("comments", func(message []byte) { second = append(second, string(message)) }))
+	require.NoError(t, broker.Subscribe("posts", func(message []byte) { other = append(other, string(message)) }))
+
+	t.Run("delivers synchronously to every handler of the channel", func(t *testing.T) {
+		require.NoError(t, broker.Publish(ctx, "comments", []byte("a")))
+		require.NoError(t, broker.Publish(ctx, "comments", []byte("b")))
+
+		assert.Equal(t, []string{"a", "b"}, first)
+		assert.Equal(t, []string{"a", "b"}, second)
+		assert.Empty(t, other)
+	})
+
+	t.Run("channel without subscribers", func(t *testing.T) {
+		assert.NoError(t, broker.Publish(ctx, "unknown", []byte("c")))
+	})
+
+	t.Run("closed broker rejects calls", func(t *testing.T) {
+		require.NoError(t, broker.Close())
+
+		assert.ErrorIs(t, broker.Publish(ctx, "comments", []byte("d")), ErrClosed)
+		assert.ErrorIs(t, broker.Subscribe("comments", func([]byte) {}), ErrClosed)
+		assert.Len(t, first, 2)
+	})
+}
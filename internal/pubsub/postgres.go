@@ -0,0 +1,284 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+const (
+	// notifyPayloadLimit - максимальный размер payload в NOTIFY (ограничение PostgreSQL - 8000 байт)
+	notifyPayloadLimit = 7999
+
+	// inlinePrefix помечает сообщение, переданное прямо в payload уведомления
+	inlinePrefix = "="
+
+	// overflowPrefix помечает ссылку на сообщение, сохраненное в таблице pubsub_messages
+	overflowPrefix = "@"
+
+	// listenMinBackoff и listenMaxBackoff ограничивают паузу между попытками переподключения
+	listenMinBackoff = 100 * time.Millisecond
+	listenMaxBackoff = 10 * time.Second
+)
+
+// PostgresBroker реализует Broker поверх PostgreSQL LISTEN/NOTIFY.
+//
+// Для прослушивания из пула забирается одно выделенное соединение, публикация
+// выполняется через pg_notify на любом соединении пула. Сообщения, которые не помещаются
+// в payload уведомления, сохраняются в UNLOGGED таблицу pubsub_messages, а в уведомлении
+// передается только идентификатор записи; такие записи удаляются через несколько минут.
+//
+// Подписка на новый канал выполняет LISTEN на том же соединении: горутина прослушивания
+// прерывает ожидание уведомлений отменой контекста (pgx при этом выставляет deadline
+// и не закрывает соединение), поэтому уведомления уже прослушиваемых каналов не теряются.
+//
+// Сообщения должны быть текстом в кодировке UTF-8 (например, JSON).
+// При потере соединения брокер переподключается с экспоненциальной паузой;
+// уведомления, отправленные во время переподключения, не доставляются.
+type PostgresBroker struct {
+	pool      *pgxpool.Pool
+	logger    *zap.Logger
+	mu        sync.Mutex
+	handlers  map[string][]Handler
+	interrupt context.CancelFunc // прерывает ожидание уведомлений, чтобы выполнить LISTEN для новых каналов
+	closed    bool
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// NewPostgresBroker создает брокер на пуле соединений PostgreSQL и запускает прослушивание.
+//
+// Таблица pubsub_messages создается миграцией 009_pubsub_messages; без нее брокер
+// не создается. Пул принадлежит вызывающему коду и не закрывается в Close.
+func NewPostgresBroker(ctx context.Context, pool *pgxpool.Pool, logger *zap.Logger) (*PostgresBroker, error) {
+	if pool == nil {
+		return nil, fmt.Errorf("pubsub: postgres pool is required")
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	// Таблица для сообщений, превышающих лимит payload уведомления
+	var exists bool
+	if err := pool.QueryRow(ctx, "SELECT to_regclass('pubsub_messages') IS NOT NULL").Scan(&exists); err != nil {
+		return nil, fmt.Errorf("pubsub: failed to check messages table: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("pubsub: table pubsub_messages does not exist, apply database migrations first")
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	b := &PostgresBroker{
+		pool:     pool,
+		logger:   logger,
+		handlers: make(map[string][]Handler),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+
+	go b.listen(runCtx)
+
+	return b, nil
+}
+
+// Publish отправляет сообщение через pg_notify
+func (b *PostgresBroker) Publish(ctx context.Context, channel string, message []byte) error {
+	b.mu.Lock()
+	closed := b.closed
+	b.mu.Unlock()
+	if closed {
+		return ErrClosed
+	}
+
+	payload := inlinePrefix + string(message)
+	if len(payload) > notifyPayloadLimit {
+		var id int64
+		err := b.pool.QueryRow(ctx,
+			"INSERT INTO pubsub_messages (channel, payload) VALUES ($1, $2) RETURNING id",
+			channel, string(message),
+		).Scan(&id)
+		if err != nil {
+			return fmt.Errorf("pubsub: failed to store message: %w", err)
+		}
+		payload = overflowPrefix + strconv.FormatInt(id, 10)
+
+		// Получатели забирают сообщение сразу после уведомления, старые записи больше не нужны
+		if _, err := b.pool.Exec(ctx, "DELETE FROM pubsub_messages WHERE created_at < NOW() - INTERVAL '5 minutes'"); err != nil {
+			b.logger.Warn("Failed to clean up stored pubsub messages", zap.Error(err))
+		}
+	}
+
+	if _, err := b.pool.Exec(ctx, "SELECT pg_notify($1, $2)", channel, payload); err != nil {
+		return fmt.Errorf("pubsub: failed to notify: %w", err)
+	}
+
+	return nil
+}
+
+// Subscribe регистрирует обработчик и начинает прослушивание канала
+func (b *PostgresBroker) Subscribe(channel string, handler Handler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+
+	_, listening := b.handlers[channel]
+	b.handlers[channel] = append(b.handlers[channel], handler)
+
+	// LISTEN для нового канала выполняет горутина прослушивания, владеющая соединением
+	if !listening && b.interrupt != nil {
+		b.interrupt()
+	}
+
+	return nil
+}
+
+// Close останавливает прослушивание и дожидается его завершения
+func (b *PostgresBroker) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	b.mu.Unlock()
+
+	b.cancel()
+	<-b.done
+
+	return nil
+}
+
+// listen поддерживает выделенное соединение для LISTEN и переподключается при ошибках
+func (b *PostgresBroker) listen(ctx context.Context) {
+	defer close(b.done)
+
+	backoff := listenMinBackoff
+	for ctx.Err() == nil {
+		err := b.listenOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			backoff = listenMinBackoff
+			continue
+		}
+
+		b.logger.Warn("PostgreSQL listener failed, reconnecting",
+			zap.Error(err),
+			zap.Duration("backoff", backoff),
+		)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		backoff = min(backoff*2, listenMaxBackoff)
+	}
+}
+
+// listenOnce подписывается на все зарегистрированные каналы и доставляет уведомления.
+//
+// Ожидание прерывается при подписке на новый канал: LISTEN для него выполняется на том же
+// соединении, и ожидание продолжается. Возвращает ошибку при потере соединения.
+func (b *PostgresBroker) listenOnce(ctx context.Context) error {
+	pooled, err := b.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	// Соединение с LISTEN нельзя возвращать в пул: оно продолжит получать уведомления
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	listening := make(map[string]bool)
+	for {
+		waitCtx, interrupt := context.WithCancel(ctx)
+
+		// Новый interrupt публикуется вместе со списком каналов: подписка, пришедшая
+		// после этого, прервет следующее ожидание и не будет пропущена
+		b.mu.Lock()
+		var channels []string
+		for channel := range b.handlers {
+			if !listening[channel] {
+				channels = append(channels, channel)
+			}
+		}
+		b.interrupt = interrupt
+		b.mu.Unlock()
+
+		for _, channel := range channels {
+			if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+				interrupt()
+				return fmt.Errorf("failed to listen %s: %w", channel, err)
+			}
+			listening[channel] = true
+		}
+
+		err := b.wait(ctx, waitCtx, conn)
+		interrupt()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// wait доставляет уведомления, пока не отменен waitCtx.
+//
+// Возвращает nil, если ожидание прервано для подписки на новый канал.
+func (b *PostgresBroker) wait(ctx, waitCtx context.Context, conn *pgx.Conn) error {
+	for {
+		notification, err := conn.WaitForNotification(waitCtx)
+		if err != nil {
+			if waitCtx.Err() != nil && ctx.Err() == nil {
+				return nil // LISTEN для нового канала
+			}
+			return fmt.Errorf("failed to wait for notification: %w", err)
+		}
+
+		b.dispatch(ctx, notification.Channel, notification.Payload)
+	}
+}
+
+// dispatch декодирует payload уведомления и передает сообщение обработчикам канала
+func (b *PostgresBroker) dispatch(ctx context.Context, channel, payload string) {
+	var message string
+	switch {
+	case strings.HasPrefix(payload, inlinePrefix):
+		message = strings.TrimPrefix(payload, inlinePrefix)
+	case strings.HasPrefix(payload, overflowPrefix):
+		id, err := strconv.ParseInt(strings.TrimPrefix(payload, overflowPrefix), 10, 64)
+		if err != nil {
+			b.logger.Warn("Invalid stored message reference", zap.String("channel", channel), zap.String("payload", payload))
+			return
+		}
+		if err := b.pool.QueryRow(ctx, "SELECT payload FROM pubsub_messages WHERE id = $1", id).Scan(&message); err != nil {
+			b.logger.Warn("Failed to load stored message",
+				zap.String("channel", channel),
+				zap.Int64("id", id),
+				zap.Error(err),
+			)
+			return
+		}
+	default:
+		b.logger.Warn("Unknown notification payload format", zap.String("channel", channel))
+		return
+	}
+
+	b.mu.Lock()
+	handlers := b.handlers[channel]
+	b.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler([]byte(message))
+	}
+}
@@ -0,0 +1,120 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// RedisBroker реализует Broker поверх Redis pub/sub.
+//
+// Все каналы обслуживаются одним подключением PubSub, которое go-redis
+// автоматически восстанавливает после обрыва; сообщения, отправленные во время
+// переподключения, не доставляются.
+type RedisBroker struct {
+	client   *redis.Client
+	pubsub   *redis.PubSub
+	logger   *zap.Logger
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+	closed   bool
+	done     chan struct{}
+}
+
+// NewRedisBroker подключается к Redis и запускает получение сообщений
+func NewRedisBroker(ctx context.Context, options *redis.Options, logger *zap.Logger) (*RedisBroker, error) {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	client := redis.NewClient(options)
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("pubsub: failed to connect to redis: %w", err)
+	}
+
+	b := &RedisBroker{
+		client:   client,
+		pubsub:   client.Subscribe(ctx),
+		logger:   logger,
+		handlers: make(map[string][]Handler),
+		done:     make(chan struct{}),
+	}
+
+	go b.receive()
+
+	return b, nil
+}
+
+// Publish отправляет сообщение в канал Redis
+func (b *RedisBroker) Publish(ctx context.Context, channel string, message []byte) error {
+	b.mu.RLock()
+	closed := b.closed
+	b.mu.RUnlock()
+	if closed {
+		return ErrClosed
+	}
+
+	if err := b.client.Publish(ctx, channel, message).Err(); err != nil {
+		return fmt.Errorf("pubsub: failed to publish: %w", err)
+	}
+
+	return nil
+}
+
+// Subscribe регистрирует обработчик и подписывается на канал Redis
+func (b *RedisBroker) Subscribe(channel string, handler Handler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+
+	if _, subscribed := b.handlers[channel]; !subscribed {
+		if err := b.pubsub.Subscribe(context.Background(), channel); err != nil {
+			return fmt.Errorf("pubsub: failed to subscribe to %s: %w", channel, err)
+		}
+	}
+	b.handlers[channel] = append(b.handlers[channel], handler)
+
+	return nil
+}
+
+// Close закрывает подписку и соединение с Redis
+func (b *RedisBroker) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	b.mu.Unlock()
+
+	err := b.pubsub.Close()
+	<-b.done
+
+	if closeErr := b.client.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// receive доставляет сообщения обработчикам до закрытия подписки
+func (b *RedisBroker) receive() {
+	defer close(b.done)
+
+	for msg := range b.pubsub.Channel() {
+		b.mu.RLock()
+		handlers := b.handlers[msg.Channel]
+		b.mu.RUnlock()
+
+		for _, handler := range handlers {
+			handler([]byte(msg.Payload))
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+
+	"github.com/NarthurN/habbr/internal/auth"
+)
+
+// Stats содержит метрики лимитера для endpoint /metrics
+type Stats struct {
+	Allowed     int64            `json:"allowed"`
+	Rejected    int64            `json:"rejected"`
+	StoreErrors int64            `json:"store_errors"`
+	TrackedKeys int              `json:"tracked_keys"`
+	RejectedBy  map[string]int64 `json:"rejected_by_field"`
+}
+
+// Limiter применяет ограничения частоты запросов к операциям GraphQL.
+//
+// Ключ корзины строится из названия операции и идентификатора клиента:
+// для аутентифицированных запросов используется UserID из Principal,
+// для анонимных - IP адрес клиента (см. Middleware). Таким образом лимиты
+// независимы для разных полей схемы и разных пользователей.
+//
+// При ошибке хранилища запрос пропускается (fail-open): недоступность
+// хранилища лимитов не должна останавливать работу API.
+//
+// Пример использования:
+//
+//	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(5*time.Minute), logger)
+//	result, err := limiter.Allow(ctx, "Mutation.createComment", ratelimit.Limit{Max: 30, Window: time.Minute})
+type Limiter struct {
+	store  Store
+	logger *zap.Logger
+
+	allowed     atomic.Int64
+	rejected    atomic.Int64
+	storeErrors atomic.Int64
+
+	mu         sync.Mutex
+	rejectedBy map[string]int64
+}
+
+// NewLimiter создает лимитер поверх указанного хранилища
+func NewLimiter(store Store, logger *zap.Logger) *Limiter {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	return &Limiter{
+		store:      store,
+		logger:     logger,
+		rejectedBy: make(map[string]int64),
+	}
+}
+
+// Allow проверяет лимит операции operation для клиента, выполняющего запрос
+func (l *Limiter) Allow(ctx context.Context, operation string, limit Limit) (Result, error) {
+	key := operation + ":" + ClientKey(ctx)
+
+	result, err := l.store.Allow(ctx, key, limit)
+	if err != nil {
+		l.storeErrors.Add(1)
+		l.logger.Error("Rate limit store failed, allowing request",
+			zap.String("operation", operation),
+			zap.Error(err),
+		)
+		return Result{Allowed: true}, err
+	}
+
+	if result.Allowed {
+		l.allowed.Add(1)
+		return result, nil
+	}
+
+	l.rejected.Add(1)
+	l.mu.Lock()
+	l.rejectedBy[operation]++
+	l.mu.Unlock()
+
+	return result, nil
+}
+
+// Stats возвращает текущие метрики лимитера
+func (l *Limiter) Stats(ctx context.Context) Stats {
+	stats := Stats{
+		Allowed:     l.allowed.Load(),
+		Rejected:    l.rejected.Load(),
+		StoreErrors: l.storeErrors.Load(),
+		RejectedBy:  make(map[string]int64),
+	}
+
+	if size, err := l.store.Size(ctx); err == nil {
+		stats.TrackedKeys = size
+	}
+
+	l.mu.Lock()
+	for operation, count := range l.rejectedBy {
+		stats.RejectedBy[operation] = count
+	}
+	l.mu.Unlock()
+
+	return stats
+}
+
+// Close освобождает ресурсы хранилища
+func (l *Limiter) Close() error {
+	return l.store.Close()
+}
+
+// ClientKey возвращает идентификатор клиента для ключа корзины.
+//
+// Возвращает "user:<uuid>" для аутентифицированных запросов,
+// "ip:<адрес>" для анонимных и "anonymous", если адрес неизвестен.
+func ClientKey(ctx context.Context) string {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		return "user:" + principal.UserID.String()
+	}
+
+	if ip, ok := ClientIPFromContext(ctx); ok {
+		return "ip:" + ip
+	}
+
+	return "anonymous"
+}
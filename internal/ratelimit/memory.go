@@ -0,0 +1,135 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// bucket хранит состояние одной корзины токенов
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// MemoryStore реализует Store в памяти процесса.
+//
+// Подходит для одного экземпляра сервиса и для тестов. Корзины, которые
+// не использовались дольше idleTTL, периодически удаляются фоновой горутиной,
+// поэтому память не растет бесконечно при большом количестве клиентов.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	idleTTL time.Duration
+	now     func() time.Time
+	stop    chan struct{}
+	once    sync.Once
+}
+
+// NewMemoryStore создает хранилище в памяти с фоновой очисткой неактивных корзин.
+//
+// Параметры:
+//   - idleTTL: время бездействия, после которого корзина удаляется (0 - без очистки)
+func NewMemoryStore(idleTTL time.Duration) *MemoryStore {
+	store := &MemoryStore{
+		buckets: make(map[string]*bucket),
+		idleTTL: idleTTL,
+		now:     time.Now,
+		stop:    make(chan struct{}),
+	}
+
+	if idleTTL > 0 {
+		go store.cleanupRoutine()
+	}
+
+	return store
+}
+
+// Allow списывает один токен из корзины key, если он доступен
+func (s *MemoryStore) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	if limit.Max <= 0 || limit.Window <= 0 {
+		return Result{Allowed: true}, nil
+	}
+
+	capacity := float64(limit.Max)
+	ratePerSecond := capacity / limit.Window.Seconds()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	b, exists := s.buckets[key]
+	if !exists {
+		b = &bucket{tokens: capacity, lastSeen: now}
+		s.buckets[key] = b
+	} else {
+		elapsed := now.Sub(b.lastSeen).Seconds()
+		if elapsed > 0 {
+			b.tokens = math.Min(capacity, b.tokens+elapsed*ratePerSecond)
+		}
+		b.lastSeen = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return Result{
+			Allowed:   true,
+			Remaining: int(b.tokens),
+		}, nil
+	}
+
+	missing := 1 - b.tokens
+	return Result{
+		Allowed:    false,
+		Remaining:  0,
+		RetryAfter: time.Duration(missing / ratePerSecond * float64(time.Second)),
+	}, nil
+}
+
+// Size возвращает количество отслеживаемых корзин
+func (s *MemoryStore) Size(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.buckets), nil
+}
+
+// Close останавливает фоновую очистку
+func (s *MemoryStore) Close() error {
+	s.once.Do(func() {
+		close(s.stop)
+	})
+	return nil
+}
+
+// cleanupRoutine периодически удаляет неактивные корзины
+func (s *MemoryStore) cleanupRoutine() {
+	ticker := time.NewTicker(s.idleTTL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.cleanup()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// cleanup удаляет корзины, не использовавшиеся дольше idleTTL.
+//
+// Корзина, простоявшая idleTTL, как правило уже полностью восполнена,
+// поэтому ее удаление не меняет поведение лимита.
+func (s *MemoryStore) cleanup() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	threshold := s.now().Add(-s.idleTTL)
+	for key, b := range s.buckets {
+		if b.lastSeen.Before(threshold) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(now *time.Time) *MemoryStore {
+	store := NewMemoryStore(0)
+	store.now = func() time.Time { return *now }
+	return store
+}
+
+func TestMemoryStoreAllow(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := newTestStore(&now)
+	limit := Limit{Max: 3, Window: 3 * time.Second}
+
+	t.Run("burst up to max", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			result, err := store.Allow(ctx, "user:a", limit)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, 2-i, result.Remaining)
+		}
+	})
+
+	t.Run("rejected when empty", func(t *testing.T) {
+		result, err := store.Allow(ctx, "user:a", limit)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, time.Second, result.RetryAfter)
+	})
+
+	t.Run("other keys are independent", func(t *testing.T) {
+		result, err := store.Allow(ctx, "user:b", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("tokens refill over time", func(t *testing.T) {
+		now = now.Add(time.Second)
+		result, err := store.Allow(ctx, "user:a", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+
+		result, err = store.Allow(ctx, "user:a", limit)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+	})
+
+	t.Run("cleanup removes idle buckets", func(t *testing.T) {
+		store.idleTTL = time.Minute
+		now = now.Add(2 * time.Minute)
+		store.cleanup()
+
+		size, err := store.Size(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, size)
+	})
+}
@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// contextKey является приватным типом ключей контекста пакета
+type contextKey struct {
+	name string
+}
+
+var clientIPContextKey = &contextKey{"client_ip"}
+
+// WithClientIP возвращает копию контекста с IP адресом клиента
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPContextKey, ip)
+}
+
+// ClientIPFromContext извлекает IP адрес клиента из контекста
+func ClientIPFromContext(ctx context.Context) (string, bool) {
+	ip, ok := ctx.Value(clientIPContextKey).(string)
+	return ip, ok && ip != ""
+}
+
+// Middleware создает HTTP middleware, сохраняющий IP адрес клиента в контексте запроса.
+//
+// IP адрес используется как ключ лимита для анонимных запросов. Заголовок
+// X-Forwarded-For учитывается только при trustForwardedFor = true, то есть когда
+// сервис гарантированно работает за доверенным reverse proxy; иначе клиент
+// мог бы обходить лимит, подставляя произвольный адрес.
+//
+// Пример использования:
+//
+//	mux.Handle("/query", ratelimit.Middleware(cfg.RateLimit.TrustForwardedFor)(graphqlServer))
+func Middleware(trustForwardedFor bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := WithClientIP(r.Context(), clientIP(r, trustForwardedFor))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// clientIP определяет IP адрес клиента по запросу
+func clientIP(r *http.Request, trustForwardedFor bool) string {
+	if trustForwardedFor {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			first, _, _ := strings.Cut(forwarded, ",")
+			if ip := strings.TrimSpace(first); ip != "" {
+				return ip
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
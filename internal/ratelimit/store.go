@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limit описывает ограничение частоты запросов: не более Max запросов за Window.
+//
+// Ограничение реализуется алгоритмом token bucket: емкость корзины равна Max,
+// токены восполняются равномерно со скоростью Max/Window. Это допускает
+// короткие всплески до Max запросов, но в среднем удерживает частоту в пределах лимита.
+type Limit struct {
+	// Max - максимальное количество запросов за окно (емкость корзины)
+	Max int
+
+	// Window - длительность окна, за которое корзина восполняется полностью
+	Window time.Duration
+}
+
+// Result содержит результат проверки лимита для одного запроса
+type Result struct {
+	// Allowed - разрешен ли запрос
+	Allowed bool
+
+	// Remaining - количество целых токенов, оставшихся после запроса
+	Remaining int
+
+	// RetryAfter - через сколько появится следующий токен (только для отклоненных запросов)
+	RetryAfter time.Duration
+}
+
+// Store определяет хранилище состояния корзин токенов.
+//
+// Интерфейс позволяет заменить in-process хранилище на распределенное
+// (например, Redis) для согласованных лимитов между несколькими экземплярами
+// сервиса, не меняя код директивы и лимитера.
+//
+// Пример использования:
+//
+//	store := ratelimit.NewMemoryStore(5 * time.Minute)
+//	defer store.Close()
+//
+//	result, err := store.Allow(ctx, "user:123", ratelimit.Limit{Max: 10, Window: time.Minute})
+//	if err == nil && !result.Allowed {
+//	    // повторить через result.RetryAfter
+//	}
+type Store interface {
+	// Allow списывает один токен из корзины key, если он доступен
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+
+	// Size возвращает количество отслеживаемых корзин
+	Size(ctx context.Context) (int, error)
+
+	// Close освобождает ресурсы хранилища
+	Close() error
+}
@@ -0,0 +1,50 @@
+package converter
+
+import (
+	"github.com/NarthurN/habbr/internal/model"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+)
+
+// PostAnalyticsOrderToRepo возвращает ключ сортировки репозитория для порядка аналитики постов
+func PostAnalyticsOrderToRepo(order model.PostAnalyticsOrder) repomodel.PostAnalyticsSort {
+	switch order.OrDefault() {
+	case model.PostAnalyticsOrderRecentlyCommented:
+		return repomodel.PostAnalyticsSortLastComment
+	case model.PostAnalyticsOrderDeepest:
+		return repomodel.PostAnalyticsSortMaxDepth
+	case model.PostAnalyticsOrderNewest:
+		return repomodel.PostAnalyticsSortCreatedAt
+	default:
+		return repomodel.PostAnalyticsSortTotalComments
+	}
+}
+
+// PostAnalyticsFilterToRepo конвертирует доменный фильтр аналитики постов в фильтр репозитория
+func PostAnalyticsFilterToRepo(filter model.PostAnalyticsFilter, limit int) repomodel.PostAnalyticsFilter {
+	return repomodel.PostAnalyticsFilter{
+		AuthorID:      filter.AuthorID,
+		CreatedAfter:  filter.CreatedAfter,
+		CreatedBefore: filter.CreatedBefore,
+		MinComments:   filter.MinComments,
+		Sort:          PostAnalyticsOrderToRepo(filter.Order),
+		Limit:         limit,
+	}
+}
+
+// PostAnalyticsFromRepo конвертирует аналитику поста репозитория в доменную модель
+func PostAnalyticsFromRepo(analytics *repomodel.PostAnalytics) *model.PostAnalytics {
+	if analytics == nil {
+		return nil
+	}
+
+	return &model.PostAnalytics{
+		PostID:          analytics.PostID,
+		Title:           analytics.Title,
+		AuthorID:        analytics.AuthorID,
+		CreatedAt:       analytics.CreatedAt,
+		TotalComments:   analytics.TotalComments,
+		RootComments:    analytics.RootComments,
+		MaxCommentDepth: analytics.MaxCommentDepth,
+		LastCommentAt:   analytics.LastCommentAt,
+	}
+}
@@ -18,8 +18,10 @@ func CommentToRepo(comment *model.Comment) *repomodel.Comment {
 		Content:   comment.Content,
 		AuthorID:  comment.AuthorID,
 		Depth:     comment.Depth,
+		Language:  string(comment.Language),
 		CreatedAt: comment.CreatedAt,
 		UpdatedAt: comment.UpdatedAt,
+		DeletedAt: comment.DeletedAt,
 	}
 }
 
@@ -36,8 +38,10 @@ func CommentFromRepo(comment *repomodel.Comment) *model.Comment {
 		Content:   comment.Content,
 		AuthorID:  comment.AuthorID,
 		Depth:     comment.Depth,
+		Language:  model.Language(comment.Language),
 		CreatedAt: comment.CreatedAt,
 		UpdatedAt: comment.UpdatedAt,
+		DeletedAt: comment.DeletedAt,
 		Children:  make([]*model.Comment, 0), // Дочерние комментарии будут добавлены отдельно
 	}
 }
@@ -56,35 +60,33 @@ func CommentsFromRepo(comments []*repomodel.Comment) []*model.Comment {
 	return result
 }
 
-// CommentFilterToRepo конвертирует доменный фильтр комментариев в фильтр репозитория
-func CommentFilterToRepo(filter model.CommentFilter, pagination model.PaginationInput) repomodel.CommentFilter {
-	repoFilter := repomodel.CommentFilter{
+// CommentFilterToRepo конвертирует доменный фильтр комментариев и страницу keyset-пагинации в фильтр репозитория
+func CommentFilterToRepo(filter model.CommentFilter, page repomodel.Page) repomodel.CommentFilter {
+	orderBy, orderDir := CommentOrderToRepo(filter.Order)
+
+	return repomodel.CommentFilter{
 		PostID:   filter.PostID,
 		ParentID: filter.ParentID,
 		AuthorID: filter.AuthorID,
 		MaxDepth: filter.MaxDepth,
-		OrderBy:  "created_at",
-		OrderDir: "asc", // Комментарии обычно сортируются по возрастанию времени
-		Limit:    50,    // значение по умолчанию
-		Offset:   0,
-	}
-
-	// Применяем пагинацию
-	if pagination.First != nil {
-		repoFilter.Limit = *pagination.First
-		if repoFilter.Limit > 200 {
-			repoFilter.Limit = 200 // максимальный лимит для комментариев
-		}
+		Page:     page,
+		OrderBy:  orderBy,
+		OrderDir: orderDir,
 	}
+}
 
-	if pagination.Last != nil {
-		repoFilter.Limit = *pagination.Last
-		if repoFilter.Limit > 200 {
-			repoFilter.Limit = 200
-		}
+// CommentOrderToRepo конвертирует порядок сортировки комментариев в ключ и направление сортировки репозитория
+func CommentOrderToRepo(order model.CommentOrder) (repomodel.SortKey, string) {
+	switch order.OrDefault() {
+	case model.CommentOrderNewest:
+		return repomodel.SortByCreatedAt, "desc"
+	case model.CommentOrderRecentlyUpdated:
+		return repomodel.SortByUpdatedAt, "desc"
+	case model.CommentOrderMostReplies:
+		return repomodel.SortByReplyCount, "desc"
+	default:
+		return repomodel.SortByCreatedAt, "asc"
 	}
-
-	return repoFilter
 }
 
 // CommentWithChildrenFromRepo конвертирует модель репозитория с количеством дочерних комментариев
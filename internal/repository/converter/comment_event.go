@@ -0,0 +1,42 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/NarthurN/habbr/internal/model"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+)
+
+// CommentEventToRepo конвертирует событие подписки на комментарии в запись журнала событий
+func CommentEventToRepo(payload *model.CommentSubscriptionPayload) (*repomodel.CommentEvent, error) {
+	if payload == nil {
+		return nil, fmt.Errorf("payload cannot be nil")
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode comment event: %w", err)
+	}
+
+	return &repomodel.CommentEvent{
+		PostID:     payload.PostID,
+		ActionType: payload.ActionType,
+		Payload:    data,
+	}, nil
+}
+
+// CommentEventFromRepo конвертирует запись журнала в событие подписки с идентификатором записи
+func CommentEventFromRepo(event *repomodel.CommentEvent) (*model.CommentSubscriptionPayload, error) {
+	if event == nil {
+		return nil, nil
+	}
+
+	var payload model.CommentSubscriptionPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode comment event %d: %w", event.ID, err)
+	}
+	payload.EventID = event.ID
+
+	return &payload, nil
+}
@@ -17,6 +17,7 @@ func PostToRepo(post *model.Post) *repomodel.Post {
 		Content:         post.Content,
 		AuthorID:        post.AuthorID,
 		CommentsEnabled: post.CommentsEnabled,
+		Language:        string(post.Language),
 		CreatedAt:       post.CreatedAt,
 		UpdatedAt:       post.UpdatedAt,
 	}
@@ -34,6 +35,7 @@ func PostFromRepo(post *repomodel.Post) *model.Post {
 		Content:         post.Content,
 		AuthorID:        post.AuthorID,
 		CommentsEnabled: post.CommentsEnabled,
+		Language:        model.Language(post.Language),
 		CreatedAt:       post.CreatedAt,
 		UpdatedAt:       post.UpdatedAt,
 	}
@@ -53,35 +55,31 @@ func PostsFromRepo(posts []*repomodel.Post) []*model.Post {
 	return result
 }
 
-// PostFilterToRepo конвертирует доменный фильтр постов в фильтр репозитория
-func PostFilterToRepo(filter model.PostFilter, pagination model.PaginationInput) repomodel.PostFilter {
-	repoFilter := repomodel.PostFilter{
+// PostFilterToRepo конвертирует доменный фильтр постов и страницу keyset-пагинации в фильтр репозитория
+func PostFilterToRepo(filter model.PostFilter, page repomodel.Page) repomodel.PostFilter {
+	orderBy, orderDir := PostOrderToRepo(filter.Order)
+
+	return repomodel.PostFilter{
 		AuthorID:     filter.AuthorID,
 		WithComments: filter.WithComments,
-		OrderBy:      "created_at",
-		OrderDir:     "desc",
-		Limit:        20, // значение по умолчанию
-		Offset:       0,
-	}
-
-	// Применяем пагинацию
-	if pagination.First != nil {
-		repoFilter.Limit = *pagination.First
-		if repoFilter.Limit > 100 {
-			repoFilter.Limit = 100 // максимальный лимит
-		}
+		Page:         page,
+		OrderBy:      orderBy,
+		OrderDir:     orderDir,
 	}
+}
 
-	if pagination.Last != nil {
-		repoFilter.Limit = *pagination.Last
-		if repoFilter.Limit > 100 {
-			repoFilter.Limit = 100
-		}
+// PostOrderToRepo конвертирует порядок сортировки постов в ключ и направление сортировки репозитория
+func PostOrderToRepo(order model.PostOrder) (repomodel.SortKey, string) {
+	switch order.OrDefault() {
+	case model.PostOrderOldest:
+		return repomodel.SortByCreatedAt, "asc"
+	case model.PostOrderRecentlyUpdated:
+		return repomodel.SortByUpdatedAt, "desc"
+	case model.PostOrderMostCommented:
+		return repomodel.SortByCommentCount, "desc"
+	default:
+		return repomodel.SortByCreatedAt, "desc"
 	}
-
-	// Для cursor-based пагинации offset будет вычислен отдельно
-
-	return repoFilter
 }
 
 // PostWithCommentCountFromRepo конвертирует модель репозитория с количеством комментариев
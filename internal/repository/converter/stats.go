@@ -0,0 +1,31 @@
+package converter
+
+import (
+	"github.com/NarthurN/habbr/internal/model"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+	"github.com/google/uuid"
+)
+
+// CommentStatsFromRepo конвертирует статистику комментариев репозитория в доменную модель
+func CommentStatsFromRepo(postID uuid.UUID, stats *repomodel.CommentStats) *model.CommentStats {
+	if stats == nil {
+		return nil
+	}
+
+	histogram := make([]model.DepthCount, len(stats.DepthHistogram))
+	for i, level := range stats.DepthHistogram {
+		histogram[i] = model.DepthCount{Depth: level.Depth, Count: level.Count}
+	}
+
+	return &model.CommentStats{
+		PostID:           postID,
+		TotalComments:    stats.TotalComments,
+		RootComments:     stats.RootComments,
+		Replies:          stats.TotalComments - stats.RootComments,
+		MaxDepth:         stats.MaxDepth,
+		AverageDepth:     stats.AverageDepth,
+		UniqueCommenters: stats.UniqueCommenters,
+		LastCommentAt:    stats.LastCommentAt,
+		DepthHistogram:   histogram,
+	}
+}
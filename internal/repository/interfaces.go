@@ -11,6 +11,9 @@ import (
 // Общие ошибки репозиториев
 var (
 	ErrNotFound = errors.New("entity not found")
+
+	// ErrEventCursorExpired - события после курсора частично вытеснены из журнала
+	ErrEventCursorExpired = errors.New("event cursor expired")
 )
 
 //go:generate mockery --name PostRepository --output ./mocks --filename mock_post_repository.go
@@ -21,10 +24,10 @@ type PostRepository interface {
 	// Получение поста по ID
 	GetByID(ctx context.Context, id uuid.UUID) (*repomodel.Post, error)
 
-	// Получение списка постов с фильтрацией и пагинацией
-	List(ctx context.Context, filter repomodel.PostFilter) ([]*repomodel.Post, error)
+	// Получение страницы постов с фильтрацией и keyset-пагинацией по filter.Page
+	List(ctx context.Context, filter repomodel.PostFilter) (*repomodel.PostPage, error)
 
-	// Подсчет общего количества постов с фильтрацией
+	// Подсчет общего количества постов с фильтрацией (filter.Page не учитывается)
 	Count(ctx context.Context, filter repomodel.PostFilter) (int, error)
 
 	// Обновление поста
@@ -38,6 +41,9 @@ type PostRepository interface {
 
 	// Получение постов с количеством комментариев
 	ListWithCommentCounts(ctx context.Context, filter repomodel.PostFilter) ([]*repomodel.PostWithCommentCount, error)
+
+	// Полнотекстовый поиск по заголовку и содержимому с ранжированием по релевантности
+	Search(ctx context.Context, filter repomodel.SearchFilter) ([]*repomodel.PostSearchHit, error)
 }
 
 //go:generate mockery --name CommentRepository --output ./mocks --filename mock_comment_repository.go
@@ -48,22 +54,61 @@ type CommentRepository interface {
 	// Получение комментария по ID
 	GetByID(ctx context.Context, id uuid.UUID) (*repomodel.Comment, error)
 
-	// Получение списка комментариев с фильтрацией и пагинацией
-	List(ctx context.Context, filter repomodel.CommentFilter) ([]*repomodel.Comment, error)
+	// Получение страницы комментариев с фильтрацией и keyset-пагинацией по filter.Page
+	List(ctx context.Context, filter repomodel.CommentFilter) (*repomodel.CommentPage, error)
 
-	// Подсчет общего количества комментариев с фильтрацией
+	// Подсчет общего количества комментариев с фильтрацией (filter.Page не учитывается)
 	Count(ctx context.Context, filter repomodel.CommentFilter) (int, error)
 
+	// Получение страниц комментариев нескольких постов одним запросом. Фильтр, сортировка
+	// и filter.Page применяются к комментариям каждого поста отдельно, filter.PostID и
+	// filter.ParentID не учитываются. Результат содержит страницу для каждого из postIDs
+	ListByPostIDs(ctx context.Context, postIDs []uuid.UUID, filter repomodel.CommentFilter) (map[uuid.UUID]*repomodel.CommentPage, error)
+
+	// Получение страниц прямых ответов на несколько комментариев одним запросом,
+	// аналогично ListByPostIDs
+	ListByParentIDs(ctx context.Context, parentIDs []uuid.UUID, filter repomodel.CommentFilter) (map[uuid.UUID]*repomodel.CommentPage, error)
+
+	// Получение ветки дерева комментариев по filter: страницы верхнего уровня и ответов
+	// на нее до filter.Levels уровней вглубь, не более filter.RepliesLimit на комментарий
+	ListTree(ctx context.Context, filter repomodel.CommentTreeFilter) (*repomodel.CommentTreePage, error)
+
+	// Получение комментария и не более maxAncestors его ближайших предков в порядке от
+	// самого дальнего загруженного предка к комментарию. Для несуществующего комментария
+	// возвращает пустой список
+	GetCommentPath(ctx context.Context, commentID uuid.UUID, maxAncestors int) ([]*repomodel.Comment, error)
+
+	// Получение комментария со всеми вложенными ответами одним запросом по материализованному
+	// пути в порядке обхода ветки в глубину (ответы в порядке created_at, id) или ErrNotFound
+	GetSubtree(ctx context.Context, id uuid.UUID) ([]*repomodel.Comment, error)
+
+	// Подсчет комментариев поддерева, включая сам комментарий, или ErrNotFound
+	CountSubtree(ctx context.Context, id uuid.UUID) (int, error)
+
 	// Обновление комментария
 	Update(ctx context.Context, comment *repomodel.Comment) error
 
 	// Удаление комментария
 	Delete(ctx context.Context, id uuid.UUID) error
 
+	// Атомарное удаление комментария со всеми вложенными ответами. Возвращает удаленные
+	// комментарии в порядке (depth, created_at, id) или ErrNotFound
+	DeleteSubtree(ctx context.Context, id uuid.UUID) ([]*repomodel.Comment, error)
+
+	// Мягкое удаление: комментарий с ответами становится надгробием (content заменяется
+	// на placeholder, author_id обнуляется, устанавливается deleted_at), комментарий без
+	// ответов удаляется. Возвращает итоговое состояние комментария и true для надгробия
+	SoftDelete(ctx context.Context, id uuid.UUID, placeholder string) (*repomodel.Comment, bool, error)
+
+	// Удаление надгробий, оставшихся без ответов: начиная с id и вверх по ветке удаляется
+	// каждое надгробие без ответов. Вызывается после удаления ответа с родителем id.
+	// Возвращает удаленные надгробия от id к корню ветки
+	PruneTombstones(ctx context.Context, id uuid.UUID) ([]*repomodel.Comment, error)
+
 	// Проверка существования комментария
 	Exists(ctx context.Context, id uuid.UUID) (bool, error)
 
-	// Получение комментариев к посту (для построения дерева)
+	// Получение комментариев к посту в порядке обхода веток в глубину (для построения дерева)
 	GetByPostID(ctx context.Context, postID uuid.UUID) ([]*repomodel.Comment, error)
 
 	// Получение дочерних комментариев
@@ -77,16 +122,69 @@ type CommentRepository interface {
 
 	// Получение количества комментариев к посту
 	CountByPostID(ctx context.Context, postID uuid.UUID) (int, error)
+
+	// Полнотекстовый поиск по содержимому с ранжированием по релевантности
+	Search(ctx context.Context, filter repomodel.SearchFilter) ([]*repomodel.CommentSearchHit, error)
+}
+
+// CommentEventRetention - количество последних событий комментариев, хранимых для каждого поста
+const CommentEventRetention = 1000
+
+//go:generate mockery --name CommentEventRepository --output ./mocks --filename mock_comment_event_repository.go
+type CommentEventRepository interface {
+	// Добавление события в журнал; присваивает event.ID, возрастающий для каждого нового события.
+	// События одного поста становятся видимыми в порядке ID: событие с меньшим ID не может
+	// появиться в журнале после события с большим. Для каждого поста хранятся только
+	// последние CommentEventRetention событий
+	Append(ctx context.Context, event *repomodel.CommentEvent) error
+
+	// Добавление нескольких событий одной записью с теми же гарантиями, что и у Append;
+	// ID назначаются в порядке событий в срезе
+	AppendBatch(ctx context.Context, events []*repomodel.CommentEvent) error
+
+	// Получение событий поста с ID больше afterID в порядке возрастания ID. Если часть
+	// событий после afterID уже вытеснена из журнала, возвращает ErrEventCursorExpired
+	ListAfter(ctx context.Context, postID uuid.UUID, afterID int64, limit int) ([]*repomodel.CommentEvent, error)
+}
+
+//go:generate mockery --name StatsRepository --output ./mocks --filename mock_stats_repository.go
+type StatsRepository interface {
+	// Агрегированная статистика комментариев поста, вычисляемая в хранилище.
+	// Для поста без комментариев возвращает нулевую статистику
+	GetCommentStats(ctx context.Context, postID uuid.UUID) (*repomodel.CommentStats, error)
+}
+
+//go:generate mockery --name AnalyticsRepository --output ./mocks --filename mock_analytics_repository.go
+type AnalyticsRepository interface {
+	// Аналитика постов, отфильтрованная и отсортированная по filter.Sort, не больше filter.Limit записей.
+	// Данные могут отставать от постов и комментариев до следующего вызова Refresh
+	List(ctx context.Context, filter repomodel.PostAnalyticsFilter) ([]*repomodel.PostAnalytics, error)
+
+	// Обновление данных аналитики без блокировки чтения
+	Refresh(ctx context.Context) error
 }
 
 // Repositories объединяет все репозитории
 type Repositories struct {
-	Post    PostRepository
-	Comment CommentRepository
+	Post         PostRepository
+	Comment      CommentRepository
+	CommentEvent CommentEventRepository
+	Stats        StatsRepository
+	Analytics    AnalyticsRepository
+}
+
+// Transactor выполняет несколько операций над репозиториями как единое целое
+type Transactor interface {
+	// Выполнение fn в транзакции: изменения, сделанные через переданные в fn репозитории,
+	// фиксируются, если fn вернула nil, и откатываются, если fn вернула ошибку или
+	// запаниковала. Ошибка fn возвращается без изменений. Вложенные вызовы не поддерживаются
+	WithinTx(ctx context.Context, fn func(repos *Repositories) error) error
 }
 
 // RepositoryManager управляет подключениями к репозиториям
 type RepositoryManager interface {
+	Transactor
+
 	// Получение всех репозиториев
 	GetRepositories() *Repositories
 
@@ -0,0 +1,140 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/NarthurN/habbr/internal/repository"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+	"github.com/google/uuid"
+)
+
+// AnalyticsRepository представляет in-memory реализацию репозитория аналитики.
+//
+// Аналитика вычисляется при каждом запросе по данным репозиториев постов и
+// комментариев, поэтому всегда актуальна, а Refresh ничего не делает.
+type AnalyticsRepository struct {
+	posts    *PostRepository
+	comments *CommentRepository
+}
+
+// NewAnalyticsRepository создает новый in-memory репозиторий аналитики постов
+func NewAnalyticsRepository(posts *PostRepository, comments *CommentRepository) repository.AnalyticsRepository {
+	return &AnalyticsRepository{posts: posts, comments: comments}
+}
+
+// List вычисляет аналитику постов, соответствующих фильтру
+func (r *AnalyticsRepository) List(ctx context.Context, filter repomodel.PostAnalyticsFilter) ([]*repomodel.PostAnalytics, error) {
+	less, err := analyticsLess(filter.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregates := r.comments.analyticsByPost()
+
+	r.posts.mu.RLock()
+	items := make([]*repomodel.PostAnalytics, 0, len(r.posts.posts))
+	for _, post := range r.posts.posts {
+		if filter.AuthorID != nil && post.AuthorID != *filter.AuthorID {
+			continue
+		}
+		if filter.CreatedAfter != nil && post.CreatedAt.Before(*filter.CreatedAfter) {
+			continue
+		}
+		if filter.CreatedBefore != nil && !post.CreatedAt.Before(*filter.CreatedBefore) {
+			continue
+		}
+
+		item := repomodel.PostAnalytics{
+			PostID:    post.ID,
+			Title:     post.Title,
+			AuthorID:  post.AuthorID,
+			CreatedAt: post.CreatedAt,
+		}
+		if aggregate, ok := aggregates[post.ID]; ok {
+			item.TotalComments = aggregate.TotalComments
+			item.RootComments = aggregate.RootComments
+			item.MaxCommentDepth = aggregate.MaxCommentDepth
+			item.LastCommentAt = aggregate.LastCommentAt
+		}
+		if filter.MinComments != nil && item.TotalComments < *filter.MinComments {
+			continue
+		}
+		items = append(items, &item)
+	}
+	r.posts.mu.RUnlock()
+
+	slices.SortFunc(items, func(a, b *repomodel.PostAnalytics) int {
+		if c := less(a, b); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.PostID[:], b.PostID[:])
+	})
+
+	if len(items) > filter.Limit {
+		items = items[:filter.Limit]
+	}
+	return items, nil
+}
+
+// Refresh ничего не делает: in-memory аналитика вычисляется при запросе
+func (r *AnalyticsRepository) Refresh(ctx context.Context) error {
+	return nil
+}
+
+// analyticsLess возвращает сравнение аналитики постов по убыванию ключа сортировки
+func analyticsLess(sort repomodel.PostAnalyticsSort) (func(a, b *repomodel.PostAnalytics) int, error) {
+	switch sort {
+	case repomodel.PostAnalyticsSortTotalComments:
+		return func(a, b *repomodel.PostAnalytics) int { return b.TotalComments - a.TotalComments }, nil
+	case repomodel.PostAnalyticsSortMaxDepth:
+		return func(a, b *repomodel.PostAnalytics) int { return b.MaxCommentDepth - a.MaxCommentDepth }, nil
+	case repomodel.PostAnalyticsSortCreatedAt:
+		return func(a, b *repomodel.PostAnalytics) int { return b.CreatedAt.Compare(a.CreatedAt) }, nil
+	case repomodel.PostAnalyticsSortLastComment:
+		// Посты без комментариев в конце, как NULLS LAST в PostgreSQL
+		return func(a, b *repomodel.PostAnalytics) int {
+			switch {
+			case a.LastCommentAt == nil && b.LastCommentAt == nil:
+				return 0
+			case a.LastCommentAt == nil:
+				return 1
+			case b.LastCommentAt == nil:
+				return -1
+			default:
+				return b.LastCommentAt.Compare(*a.LastCommentAt)
+			}
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported post analytics sort: %q", sort)
+	}
+}
+
+// analyticsByPost возвращает агрегаты комментариев каждого поста для аналитики.
+// Надгробия учитываются, как в представлении post_analytics.
+func (r *CommentRepository) analyticsByPost() map[uuid.UUID]*repomodel.PostAnalytics {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	aggregates := make(map[uuid.UUID]*repomodel.PostAnalytics)
+	for _, comment := range r.comments {
+		aggregate, ok := aggregates[comment.PostID]
+		if !ok {
+			aggregate = &repomodel.PostAnalytics{}
+			aggregates[comment.PostID] = aggregate
+		}
+
+		aggregate.TotalComments++
+		if comment.ParentID == nil {
+			aggregate.RootComments++
+		}
+		aggregate.MaxCommentDepth = max(aggregate.MaxCommentDepth, comment.Depth)
+		if aggregate.LastCommentAt == nil || comment.CreatedAt.After(*aggregate.LastCommentAt) {
+			createdAt := comment.CreatedAt
+			aggregate.LastCommentAt = &createdAt
+		}
+	}
+	return aggregates
+}
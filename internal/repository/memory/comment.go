@@ -1,12 +1,16 @@
 package memory
 
 import (
+	"bytes"
+	"cmp"
 	"context"
 	"fmt"
-	"sort"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/NarthurN/habbr/internal/repository"
 	repomodel "github.com/NarthurN/habbr/internal/repository/model"
 	"github.com/google/uuid"
 )
@@ -15,19 +19,30 @@ import (
 type CommentRepository struct {
 	mu       sync.RWMutex
 	comments map[uuid.UUID]*repomodel.Comment
+	index    *searchIndex
+	tx       txState[repomodel.Comment]
+	gate     txGate
 }
 
 // NewCommentRepository создает новый in-memory репозиторий комментариев
 func NewCommentRepository() *CommentRepository {
 	return &CommentRepository{
 		comments: make(map[uuid.UUID]*repomodel.Comment),
+		index:    newSearchIndex(),
 	}
 }
 
-// Create создает новый комментарий
+// Create создает новый комментарий и записывает его материализованный путь в comment.Path
 func (r *CommentRepository) Create(ctx context.Context, comment *repomodel.Comment) error {
+	defer r.gate.enter()()
+	return r.createComment(ctx, comment)
+}
+
+// createComment выполняет Create без ожидания открытой транзакции Manager.WithinTx
+func (r *CommentRepository) createComment(ctx context.Context, comment *repomodel.Comment) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	r.tx.save(r.comments, r.index)
 
 	if comment == nil {
 		return fmt.Errorf("comment cannot be nil")
@@ -38,9 +53,25 @@ func (r *CommentRepository) Create(ctx context.Context, comment *repomodel.Comme
 		return fmt.Errorf("comment with ID %s already exists", comment.ID)
 	}
 
+	// Путь продолжает путь родителя, как в триггере set_comments_path
+	parentPath := ""
+	if comment.ParentID != nil {
+		parent, exists := r.comments[*comment.ParentID]
+		if !exists {
+			return fmt.Errorf("parent comment with ID %s not found", *comment.ParentID)
+		}
+		parentPath = parent.Path
+	}
+	path, err := repomodel.CommentPath(parentPath, comment.ID, comment.CreatedAt)
+	if err != nil {
+		return err
+	}
+	comment.Path = path
+
 	// Создаем копию комментария
 	commentCopy := *comment
 	r.comments[comment.ID] = &commentCopy
+	r.index.add(comment.ID, comment.Language, searchField{text: comment.Content, weight: contentWeight})
 
 	return nil
 }
@@ -52,7 +83,7 @@ func (r *CommentRepository) GetByID(ctx context.Context, id uuid.UUID) (*repomod
 
 	comment, exists := r.comments[id]
 	if !exists {
-		return nil, nil // не найден
+		return nil, repository.ErrNotFound
 	}
 
 	// Возвращаем копию
@@ -60,11 +91,16 @@ func (r *CommentRepository) GetByID(ctx context.Context, id uuid.UUID) (*repomod
 	return &commentCopy, nil
 }
 
-// List возвращает список комментариев с фильтрацией и пагинацией
-func (r *CommentRepository) List(ctx context.Context, filter repomodel.CommentFilter) ([]*repomodel.Comment, error) {
+// List возвращает страницу комментариев с фильтрацией и keyset-пагинацией
+func (r *CommentRepository) List(ctx context.Context, filter repomodel.CommentFilter) (*repomodel.CommentPage, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	cursor, err := r.commentCursor(filter.OrderBy.OrDefault())
+	if err != nil {
+		return nil, err
+	}
+
 	// Собираем все комментарии
 	allComments := make([]*repomodel.Comment, 0, len(r.comments))
 	for _, comment := range r.comments {
@@ -92,22 +128,148 @@ func (r *CommentRepository) List(ctx context.Context, filter repomodel.CommentFi
 		allComments = append(allComments, &commentCopy)
 	}
 
-	// Сортируем
-	r.sortComments(allComments, filter.OrderBy, filter.OrderDir)
+	// Сортируем и выбираем страницу
+	desc := strings.EqualFold(filter.OrderDir, "desc")
+	sortByCursor(allComments, cursor, desc)
+	comments, hasNext, hasPrevious := paginate(allComments, cursor, desc, filter.Page)
+
+	return &repomodel.CommentPage{
+		Comments:        comments,
+		Cursors:         cursorsOf(comments, cursor),
+		HasNextPage:     hasNext,
+		HasPreviousPage: hasPrevious,
+	}, nil
+}
+
+// ListByPostIDs возвращает страницы комментариев нескольких постов
+func (r *CommentRepository) ListByPostIDs(ctx context.Context, postIDs []uuid.UUID, filter repomodel.CommentFilter) (map[uuid.UUID]*repomodel.CommentPage, error) {
+	result := make(map[uuid.UUID]*repomodel.CommentPage, len(postIDs))
+	for _, postID := range postIDs {
+		filter.PostID = &postID
+		filter.ParentID = nil
+
+		page, err := r.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		result[postID] = page
+	}
+
+	return result, nil
+}
+
+// ListByParentIDs возвращает страницы прямых ответов на несколько комментариев
+func (r *CommentRepository) ListByParentIDs(ctx context.Context, parentIDs []uuid.UUID, filter repomodel.CommentFilter) (map[uuid.UUID]*repomodel.CommentPage, error) {
+	result := make(map[uuid.UUID]*repomodel.CommentPage, len(parentIDs))
+	for _, parentID := range parentIDs {
+		filter.PostID = nil
+		filter.ParentID = &parentID
+
+		page, err := r.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		result[parentID] = page
+	}
+
+	return result, nil
+}
+
+// ListTree возвращает ветку дерева комментариев: страницу верхнего уровня и ответы
+// на нее по уровням, не более filter.RepliesLimit на комментарий
+func (r *CommentRepository) ListTree(ctx context.Context, filter repomodel.CommentTreeFilter) (*repomodel.CommentTreePage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cursor, err := r.commentCursor(filter.OrderBy.OrDefault())
+	if err != nil {
+		return nil, err
+	}
+
+	// Отбираем комментарии верхнего уровня и группируем ответы по родителю
+	var top []*repomodel.Comment
+	replies := make(map[uuid.UUID][]*repomodel.Comment)
+	for _, comment := range r.comments {
+		if filter.PostID != nil && comment.PostID != *filter.PostID {
+			continue
+		}
+
+		if filter.AuthorID != nil && comment.AuthorID != *filter.AuthorID {
+			continue
+		}
+
+		if filter.MaxDepth != nil && comment.Depth > *filter.MaxDepth {
+			continue
+		}
+
+		commentCopy := *comment
+		if comment.ParentID != nil {
+			replies[*comment.ParentID] = append(replies[*comment.ParentID], &commentCopy)
+		}
+
+		if (filter.ParentID == nil && comment.ParentID == nil) ||
+			(filter.ParentID != nil && comment.ParentID != nil && *comment.ParentID == *filter.ParentID) {
+			top = append(top, &commentCopy)
+		}
+	}
+
+	desc := strings.EqualFold(filter.OrderDir, "desc")
+	sortByCursor(top, cursor, desc)
+	comments, hasNext, hasPrevious := paginate(top, cursor, desc, filter.Page)
+
+	result := &repomodel.CommentTreePage{
+		CommentPage: repomodel.CommentPage{
+			Comments:        comments,
+			Cursors:         cursorsOf(comments, cursor),
+			HasNextPage:     hasNext,
+			HasPreviousPage: hasPrevious,
+		},
+		ReplyCounts: make(map[uuid.UUID]int),
+	}
 
-	// Применяем пагинацию
-	start := filter.Offset
-	end := start + filter.Limit
+	// Загружаем ответы уровень за уровнем
+	level := comments
+	for i := 0; i < filter.Levels && len(level) > 0; i++ {
+		var next []*repomodel.Comment
+		for _, comment := range level {
+			children := replies[comment.ID]
+			sortByCursor(children, cursor, desc)
+			next = append(next, children[:min(len(children), filter.RepliesLimit)]...)
+		}
+		result.Replies = append(result.Replies, next...)
+		level = next
+	}
+	sortByCursor(result.Replies, cursor, desc)
+	result.ReplyCursors = cursorsOf(result.Replies, cursor)
 
-	if start >= len(allComments) {
-		return []*repomodel.Comment{}, nil
+	for _, comment := range append(slices.Clone(comments), result.Replies...) {
+		if count := len(replies[comment.ID]); count > 0 {
+			result.ReplyCounts[comment.ID] = count
+		}
 	}
 
-	if end > len(allComments) {
-		end = len(allComments)
+	return result, nil
+}
+
+// GetCommentPath возвращает комментарий и не более maxAncestors его ближайших предков,
+// начиная с самого дальнего
+func (r *CommentRepository) GetCommentPath(ctx context.Context, commentID uuid.UUID, maxAncestors int) ([]*repomodel.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var path []*repomodel.Comment
+	comment, exists := r.comments[commentID]
+	for exists && len(path) <= maxAncestors {
+		commentCopy := *comment
+		path = append(path, &commentCopy)
+		if comment.ParentID == nil {
+			break
+		}
+		comment, exists = r.comments[*comment.ParentID]
 	}
 
-	return allComments[start:end], nil
+	slices.Reverse(path)
+	return path, nil
 }
 
 // Count возвращает общее количество комментариев с фильтрацией
@@ -144,40 +306,181 @@ func (r *CommentRepository) Count(ctx context.Context, filter repomodel.CommentF
 
 // Update обновляет комментарий
 func (r *CommentRepository) Update(ctx context.Context, comment *repomodel.Comment) error {
+	defer r.gate.enter()()
+	return r.updateComment(ctx, comment)
+}
+
+// updateComment выполняет Update без ожидания открытой транзакции Manager.WithinTx
+func (r *CommentRepository) updateComment(ctx context.Context, comment *repomodel.Comment) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	r.tx.save(r.comments, r.index)
 
 	if comment == nil {
 		return fmt.Errorf("comment cannot be nil")
 	}
 
-	if _, exists := r.comments[comment.ID]; !exists {
+	existing, exists := r.comments[comment.ID]
+	if !exists {
 		return fmt.Errorf("comment with ID %s not found", comment.ID)
 	}
 
 	// Обновляем время изменения
 	comment.UpdatedAt = time.Now()
 
-	// Создаем копию и сохраняем
+	// Создаем копию и сохраняем; родитель, время создания и вычисленный из них путь
+	// после создания не изменяются, как и в PostgreSQL (триггер guard_comments_path)
 	commentCopy := *comment
+	commentCopy.ParentID = existing.ParentID
+	commentCopy.CreatedAt = existing.CreatedAt
+	commentCopy.Path = existing.Path
 	r.comments[comment.ID] = &commentCopy
+	r.index.add(comment.ID, comment.Language, searchField{text: comment.Content, weight: contentWeight})
 
 	return nil
 }
 
 // Delete удаляет комментарий
 func (r *CommentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	defer r.gate.enter()()
+	return r.deleteComment(ctx, id)
+}
+
+// deleteComment выполняет Delete без ожидания открытой транзакции Manager.WithinTx
+func (r *CommentRepository) deleteComment(ctx context.Context, id uuid.UUID) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	r.tx.save(r.comments, r.index)
 
 	if _, exists := r.comments[id]; !exists {
 		return fmt.Errorf("comment with ID %s not found", id)
 	}
 
 	delete(r.comments, id)
+	r.index.remove(id)
 	return nil
 }
 
+// DeleteSubtree удаляет комментарий со всеми вложенными ответами.
+//
+// Поддерево выбирается по префиксу материализованного пути под одной блокировкой
+// записи, поэтому параллельные операции не видят частично удаленного поддерева.
+func (r *CommentRepository) DeleteSubtree(ctx context.Context, id uuid.UUID) ([]*repomodel.Comment, error) {
+	defer r.gate.enter()()
+	return r.deleteSubtree(ctx, id)
+}
+
+// deleteSubtree выполняет DeleteSubtree без ожидания открытой транзакции Manager.WithinTx
+func (r *CommentRepository) deleteSubtree(ctx context.Context, id uuid.UUID) ([]*repomodel.Comment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tx.save(r.comments, r.index)
+
+	root, exists := r.comments[id]
+	if !exists {
+		return nil, repository.ErrNotFound
+	}
+
+	result := r.subtree(root)
+	for _, comment := range result {
+		delete(r.comments, comment.ID)
+		r.index.remove(comment.ID)
+	}
+
+	// Порядок совпадает с PostgreSQL реализацией
+	slices.SortFunc(result, func(a, b *repomodel.Comment) int {
+		if c := cmp.Compare(a.Depth, b.Depth); c != 0 {
+			return c
+		}
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID[:], b.ID[:])
+	})
+
+	return result, nil
+}
+
+// SoftDelete выполняет мягкое удаление комментария: надгробие для комментария с ответами,
+// удаление для комментария без ответов
+func (r *CommentRepository) SoftDelete(ctx context.Context, id uuid.UUID, placeholder string) (*repomodel.Comment, bool, error) {
+	defer r.gate.enter()()
+	return r.softDelete(ctx, id, placeholder)
+}
+
+// softDelete выполняет SoftDelete без ожидания открытой транзакции Manager.WithinTx
+func (r *CommentRepository) softDelete(ctx context.Context, id uuid.UUID, placeholder string) (*repomodel.Comment, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tx.save(r.comments, r.index)
+
+	comment, exists := r.comments[id]
+	if !exists {
+		return nil, false, repository.ErrNotFound
+	}
+
+	hasReplies := r.hasReplies(id)
+
+	// Надгробие не участвует в поиске в обоих случаях
+	r.index.remove(id)
+
+	if !hasReplies {
+		delete(r.comments, id)
+		commentCopy := *comment
+		return &commentCopy, false, nil
+	}
+
+	comment.Content = placeholder
+	comment.AuthorID = uuid.Nil
+	if comment.DeletedAt == nil {
+		deletedAt := time.Now()
+		comment.DeletedAt = &deletedAt
+	}
+
+	commentCopy := *comment
+	return &commentCopy, true, nil
+}
+
+// PruneTombstones удаляет надгробия без ответов, начиная с id и вверх по ветке
+func (r *CommentRepository) PruneTombstones(ctx context.Context, id uuid.UUID) ([]*repomodel.Comment, error) {
+	defer r.gate.enter()()
+	return r.pruneTombstones(ctx, id)
+}
+
+// pruneTombstones выполняет PruneTombstones без ожидания открытой транзакции Manager.WithinTx
+func (r *CommentRepository) pruneTombstones(ctx context.Context, id uuid.UUID) ([]*repomodel.Comment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tx.save(r.comments, r.index)
+
+	var result []*repomodel.Comment
+	for {
+		comment, exists := r.comments[id]
+		if !exists || comment.DeletedAt == nil || r.hasReplies(id) {
+			return result, nil
+		}
+
+		delete(r.comments, id)
+		commentCopy := *comment
+		result = append(result, &commentCopy)
+
+		if comment.ParentID == nil {
+			return result, nil
+		}
+		id = *comment.ParentID
+	}
+}
+
+// hasReplies сообщает, есть ли у комментария ответы. Вызывается под r.mu
+func (r *CommentRepository) hasReplies(id uuid.UUID) bool {
+	for _, reply := range r.comments {
+		if reply.ParentID != nil && *reply.ParentID == id {
+			return true
+		}
+	}
+	return false
+}
+
 // Exists проверяет существование комментария
 func (r *CommentRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
 	r.mu.RLock()
@@ -187,30 +490,65 @@ func (r *CommentRepository) Exists(ctx context.Context, id uuid.UUID) (bool, err
 	return exists, nil
 }
 
-// GetByPostID возвращает все комментарии к посту
+// GetByPostID возвращает все комментарии к посту в порядке обхода веток в глубину
 func (r *CommentRepository) GetByPostID(ctx context.Context, postID uuid.UUID) ([]*repomodel.Comment, error) {
-	filter := repomodel.CommentFilter{
-		PostID:   &postID,
-		Limit:    1000, // большой лимит для получения всех комментариев
-		Offset:   0,
-		OrderBy:  "created_at",
-		OrderDir: "asc",
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*repomodel.Comment
+	for _, comment := range r.comments {
+		if comment.PostID == postID {
+			commentCopy := *comment
+			result = append(result, &commentCopy)
+		}
 	}
 
-	return r.List(ctx, filter)
+	sortByPath(result)
+	return result, nil
+}
+
+// GetSubtree возвращает комментарий со всеми вложенными ответами в порядке обхода ветки в глубину
+func (r *CommentRepository) GetSubtree(ctx context.Context, id uuid.UUID) ([]*repomodel.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	root, exists := r.comments[id]
+	if !exists {
+		return nil, repository.ErrNotFound
+	}
+
+	result := r.subtree(root)
+	sortByPath(result)
+	return result, nil
+}
+
+// CountSubtree возвращает количество комментариев поддерева, включая сам комментарий
+func (r *CommentRepository) CountSubtree(ctx context.Context, id uuid.UUID) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	root, exists := r.comments[id]
+	if !exists {
+		return 0, repository.ErrNotFound
+	}
+
+	count := 0
+	for _, comment := range r.comments {
+		if repomodel.IsInSubtree(comment.Path, root.Path) {
+			count++
+		}
+	}
+	return count, nil
 }
 
 // GetChildren возвращает дочерние комментарии
 func (r *CommentRepository) GetChildren(ctx context.Context, parentID uuid.UUID) ([]*repomodel.Comment, error) {
-	filter := repomodel.CommentFilter{
-		ParentID: &parentID,
-		Limit:    1000,
-		Offset:   0,
-		OrderBy:  "created_at",
-		OrderDir: "asc",
+	page, err := r.List(ctx, repomodel.CommentFilter{ParentID: &parentID})
+	if err != nil {
+		return nil, err
 	}
 
-	return r.List(ctx, filter)
+	return page.Comments, nil
 }
 
 // GetMaxDepthForPost возвращает максимальную глубину комментариев к посту
@@ -230,8 +568,15 @@ func (r *CommentRepository) GetMaxDepthForPost(ctx context.Context, postID uuid.
 
 // DeleteByPostID удаляет все комментарии к посту
 func (r *CommentRepository) DeleteByPostID(ctx context.Context, postID uuid.UUID) error {
+	defer r.gate.enter()()
+	return r.deleteByPostID(ctx, postID)
+}
+
+// deleteByPostID выполняет DeleteByPostID без ожидания открытой транзакции Manager.WithinTx
+func (r *CommentRepository) deleteByPostID(ctx context.Context, postID uuid.UUID) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	r.tx.save(r.comments, r.index)
 
 	// Собираем ID комментариев для удаления
 	var idsToDelete []uuid.UUID
@@ -244,6 +589,7 @@ func (r *CommentRepository) DeleteByPostID(ctx context.Context, postID uuid.UUID
 	// Удаляем комментарии
 	for _, id := range idsToDelete {
 		delete(r.comments, id)
+		r.index.remove(id)
 	}
 
 	return nil
@@ -258,35 +604,85 @@ func (r *CommentRepository) CountByPostID(ctx context.Context, postID uuid.UUID)
 	return r.Count(ctx, filter)
 }
 
-// sortComments сортирует комментарии по указанному полю и направлению
-func (r *CommentRepository) sortComments(comments []*repomodel.Comment, orderBy, orderDir string) {
-	if orderBy == "" {
-		orderBy = "created_at"
+// beginTx открывает транзакцию Manager.WithinTx
+func (r *CommentRepository) beginTx() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tx.begin()
+}
+
+// endTx закрывает транзакцию, при откате восстанавливая состояние до ее первого изменения
+func (r *CommentRepository) endTx(commit bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if backup := r.tx.end(); backup != nil && !commit {
+		r.comments = backup.items
+		r.index = backup.index
 	}
+}
 
-	if orderDir == "" {
-		orderDir = "asc"
+// subtree возвращает копии комментариев поддерева root по префиксу пути.
+// Вызывается под блокировкой r.mu.
+func (r *CommentRepository) subtree(root *repomodel.Comment) []*repomodel.Comment {
+	var result []*repomodel.Comment
+	for _, comment := range r.comments {
+		if comment.PostID == root.PostID && repomodel.IsInSubtree(comment.Path, root.Path) {
+			commentCopy := *comment
+			result = append(result, &commentCopy)
+		}
 	}
+	return result
+}
 
-	sort.Slice(comments, func(i, j int) bool {
-		var result bool
+// sortByPath упорядочивает комментарии по материализованному пути, как ORDER BY path в PostgreSQL
+func sortByPath(comments []*repomodel.Comment) {
+	slices.SortFunc(comments, func(a, b *repomodel.Comment) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+}
 
-		switch orderBy {
-		case "depth":
-			if comments[i].Depth == comments[j].Depth {
-				// Если глубина одинаковая, сортируем по времени создания
-				result = comments[i].CreatedAt.Before(comments[j].CreatedAt)
-			} else {
-				result = comments[i].Depth < comments[j].Depth
+// countByPost возвращает количество комментариев каждого поста.
+// Надгробия учитываются, как в счетчике comment_count PostgreSQL.
+func (r *CommentRepository) countByPost() map[uuid.UUID]int64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[uuid.UUID]int64)
+	for _, comment := range r.comments {
+		counts[comment.PostID]++
+	}
+	return counts
+}
+
+// commentCursor возвращает функцию позиции комментария в порядке ключа сортировки key.
+// Вызывается под блокировкой r.mu.
+func (r *CommentRepository) commentCursor(key repomodel.SortKey) (func(*repomodel.Comment) repomodel.Cursor, error) {
+	var replies map[uuid.UUID]int64
+	switch key {
+	case repomodel.SortByCreatedAt, repomodel.SortByUpdatedAt:
+	case repomodel.SortByReplyCount:
+		replies = make(map[uuid.UUID]int64)
+		for _, comment := range r.comments {
+			if comment.ParentID != nil {
+				replies[*comment.ParentID]++
 			}
-		default: // "created_at"
-			result = comments[i].CreatedAt.Before(comments[j].CreatedAt)
 		}
+	default:
+		return nil, fmt.Errorf("unsupported comment sort key %q", key)
+	}
 
-		if orderDir == "desc" {
-			result = !result
+	return func(comment *repomodel.Comment) repomodel.Cursor {
+		cursor := repomodel.Cursor{Key: key, ID: comment.ID}
+		switch key {
+		case repomodel.SortByUpdatedAt:
+			cursor.Time = comment.UpdatedAt
+		case repomodel.SortByReplyCount:
+			cursor.Count = replies[comment.ID]
+		default:
+			cursor.Time = comment.CreatedAt
 		}
-
-		return result
-	})
+		return cursor
+	}, nil
 }
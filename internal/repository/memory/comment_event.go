@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/NarthurN/habbr/internal/repository"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+	"github.com/google/uuid"
+)
+
+// eventRing - кольцевой буфер последних событий одного поста
+type eventRing struct {
+	events  []*repomodel.CommentEvent
+	next    int // позиция для следующей записи
+	full    bool
+	evicted int64 // ID последнего вытесненного события
+}
+
+// CommentEventRepository представляет in-memory журнал событий комментариев.
+//
+// Для каждого поста хранится кольцевой буфер из capacity последних событий.
+// Идентификаторы событий уникальны и возрастают только в пределах процесса.
+type CommentEventRepository struct {
+	mu       sync.RWMutex
+	rings    map[uuid.UUID]*eventRing
+	capacity int
+	lastID   int64
+}
+
+// NewCommentEventRepository создает in-memory журнал событий с указанной емкостью на пост
+func NewCommentEventRepository(capacity int) *CommentEventRepository {
+	if capacity <= 0 {
+		capacity = repository.CommentEventRetention
+	}
+
+	return &CommentEventRepository{
+		rings:    make(map[uuid.UUID]*eventRing),
+		capacity: capacity,
+	}
+}
+
+// Append добавляет событие в журнал поста, вытесняя самое старое при переполнении
+func (r *CommentEventRepository) Append(ctx context.Context, event *repomodel.CommentEvent) error {
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.append(event)
+	return nil
+}
+
+// AppendBatch добавляет события в журнал в порядке следования в срезе
+func (r *CommentEventRepository) AppendBatch(ctx context.Context, events []*repomodel.CommentEvent) error {
+	for _, event := range events {
+		if event == nil {
+			return fmt.Errorf("event cannot be nil")
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, event := range events {
+		r.append(event)
+	}
+	return nil
+}
+
+// append добавляет событие в буфер поста; вызывается под r.mu
+func (r *CommentEventRepository) append(event *repomodel.CommentEvent) {
+	ring, exists := r.rings[event.PostID]
+	if !exists {
+		ring = &eventRing{events: make([]*repomodel.CommentEvent, r.capacity)}
+		r.rings[event.PostID] = ring
+	}
+
+	r.lastID++
+	event.ID = r.lastID
+
+	if ring.full {
+		ring.evicted = ring.events[ring.next].ID
+	}
+
+	eventCopy := *event
+	ring.events[ring.next] = &eventCopy
+	ring.next = (ring.next + 1) % r.capacity
+	if ring.next == 0 {
+		ring.full = true
+	}
+}
+
+// ListAfter возвращает события поста с ID больше afterID в порядке возрастания или
+// repository.ErrEventCursorExpired, если события после afterID уже вытеснены
+func (r *CommentEventRepository) ListAfter(ctx context.Context, postID uuid.UUID, afterID int64, limit int) ([]*repomodel.CommentEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ring, exists := r.rings[postID]
+	if !exists {
+		return []*repomodel.CommentEvent{}, nil
+	}
+	if afterID < ring.evicted {
+		return nil, repository.ErrEventCursorExpired
+	}
+
+	// Обходим буфер от самого старого события к самому новому
+	start, size := 0, ring.next
+	if ring.full {
+		start, size = ring.next, r.capacity
+	}
+
+	result := make([]*repomodel.CommentEvent, 0)
+	for i := 0; i < size; i++ {
+		event := ring.events[(start+i)%r.capacity]
+		if event.ID <= afterID {
+			continue
+		}
+
+		eventCopy := *event
+		result = append(result, &eventCopy)
+		if limit > 0 && len(result) == limit {
+			break
+		}
+	}
+
+	return result, nil
+}
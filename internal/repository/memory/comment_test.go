@@ -0,0 +1,256 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NarthurN/habbr/internal/repository"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+)
+
+func TestCommentRepositoryDeleteSubtree(t *testing.T) {
+	ctx := context.Background()
+	repo := NewCommentRepository()
+	postID := uuid.New()
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	create := func(parent *repomodel.Comment, offset time.Duration) *repomodel.Comment {
+		comment := &repomodel.Comment{
+			ID:        uuid.New(),
+			PostID:    postID,
+			Content:   "Comment",
+			AuthorID:  uuid.New(),
+			CreatedAt: base.Add(offset),
+			UpdatedAt: base.Add(offset),
+		}
+		if parent != nil {
+			comment.ParentID = &parent.ID
+			comment.Depth = parent.Depth + 1
+		}
+		require.NoError(t, repo.Create(ctx, comment))
+		return comment
+	}
+
+	// root -> child -> grandchild, root -> second child; sibling остается
+	root := create(nil, 0)
+	child := create(root, time.Second)
+	secondChild := create(root, 2*time.Second)
+	grandchild := create(child, 3*time.Second)
+	sibling := create(nil, 4*time.Second)
+
+	deleted, err := repo.DeleteSubtree(ctx, root.ID)
+	require.NoError(t, err)
+
+	ids := make([]uuid.UUID, len(deleted))
+	for i, comment := range deleted {
+		ids[i] = comment.ID
+	}
+	assert.Equal(t, []uuid.UUID{root.ID, child.ID, secondChild.ID, grandchild.ID}, ids)
+
+	for _, id := range ids {
+		exists, err := repo.Exists(ctx, id)
+		require.NoError(t, err)
+		assert.False(t, exists, "comment %s must be deleted", id)
+	}
+
+	remaining, err := repo.GetByPostID(ctx, postID)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, sibling.ID, remaining[0].ID)
+
+	_, err = repo.DeleteSubtree(ctx, root.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestCommentRepositorySoftDelete(t *testing.T) {
+	ctx := context.Background()
+	repo := NewCommentRepository()
+	postID := uuid.New()
+	now := time.Now()
+
+	root := &repomodel.Comment{ID: uuid.New(), PostID: postID, Content: "Root", AuthorID: uuid.New(), CreatedAt: now, UpdatedAt: now}
+	reply := &repomodel.Comment{ID: uuid.New(), PostID: postID, ParentID: &root.ID, Content: "Reply", AuthorID: uuid.New(), Depth: 1, CreatedAt: now, UpdatedAt: now}
+	require.NoError(t, repo.Create(ctx, root))
+	require.NoError(t, repo.Create(ctx, reply))
+
+	hits, err := repo.Search(ctx, repomodel.SearchFilter{Query: "root", PostID: &postID})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+
+	// Комментарий с ответом становится надгробием, ответ сохраняется
+	tombstone, isTombstone, err := repo.SoftDelete(ctx, root.ID, "[deleted]")
+	require.NoError(t, err)
+	assert.True(t, isTombstone)
+	assert.Equal(t, "[deleted]", tombstone.Content)
+	assert.Equal(t, uuid.Nil, tombstone.AuthorID)
+	require.NotNil(t, tombstone.DeletedAt)
+
+	stored, err := repo.GetByID(ctx, root.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, stored.DeletedAt)
+
+	children, err := repo.GetChildren(ctx, root.ID)
+	require.NoError(t, err)
+	require.Len(t, children, 1)
+
+	// Надгробие не находится поиском
+	hits, err = repo.Search(ctx, repomodel.SearchFilter{Query: "root", PostID: &postID})
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+
+	// Надгробие учитывается в счетчиках, как триггеры и post_analytics в PostgreSQL
+	assert.Equal(t, int64(2), repo.countByPost()[postID])
+	assert.Equal(t, 2, repo.analyticsByPost()[postID].TotalComments)
+	assert.Equal(t, 1, repo.analyticsByPost()[postID].RootComments)
+
+	stats, err := NewStatsRepository(repo).GetCommentStats(ctx, postID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.TotalComments)
+	assert.Equal(t, 1, stats.UniqueCommenters)
+
+	// Лист удаляется полностью
+	deleted, isTombstone, err := repo.SoftDelete(ctx, reply.ID, "[deleted]")
+	require.NoError(t, err)
+	assert.False(t, isTombstone)
+	assert.Equal(t, reply.ID, deleted.ID)
+	assert.Nil(t, deleted.DeletedAt)
+
+	exists, err := repo.Exists(ctx, reply.ID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, _, err = repo.SoftDelete(ctx, reply.ID, "[deleted]")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	// Надгробие без ответов удаляется, живой комментарий остается
+	pruned, err := repo.PruneTombstones(ctx, root.ID)
+	require.NoError(t, err)
+	require.Len(t, pruned, 1)
+	assert.Equal(t, root.ID, pruned[0].ID)
+
+	exists, err = repo.Exists(ctx, root.ID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	live := &repomodel.Comment{ID: uuid.New(), PostID: postID, Content: "Live", AuthorID: uuid.New(), CreatedAt: now, UpdatedAt: now}
+	require.NoError(t, repo.Create(ctx, live))
+	pruned, err = repo.PruneTombstones(ctx, live.ID)
+	require.NoError(t, err)
+	assert.Empty(t, pruned)
+}
+
+func TestCommentRepositoryPaths(t *testing.T) {
+	ctx := context.Background()
+	repo := NewCommentRepository()
+	postID := uuid.New()
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	create := func(parent *repomodel.Comment, offset time.Duration) *repomodel.Comment {
+		comment := &repomodel.Comment{
+			ID:        uuid.New(),
+			PostID:    postID,
+			Content:   "Comment",
+			AuthorID:  uuid.New(),
+			CreatedAt: base.Add(offset),
+			UpdatedAt: base.Add(offset),
+		}
+		if parent != nil {
+			comment.ParentID = &parent.ID
+			comment.Depth = parent.Depth + 1
+		}
+		require.NoError(t, repo.Create(ctx, comment))
+		return comment
+	}
+	ids := func(comments []*repomodel.Comment) []uuid.UUID {
+		result := make([]uuid.UUID, len(comments))
+		for i, comment := range comments {
+			result[i] = comment.ID
+		}
+		return result
+	}
+
+	// Ответ first создан позже second, но обход ветки идет в глубину:
+	// root -> first -> nested, root -> second; other - отдельная ветка
+	root := create(nil, 0)
+	other := create(nil, time.Second)
+	first := create(root, 2*time.Second)
+	second := create(root, 3*time.Second)
+	nested := create(first, 4*time.Second)
+
+	t.Run("path extends parent path", func(t *testing.T) {
+		rootPath, err := repomodel.CommentPath("", root.ID, root.CreatedAt)
+		require.NoError(t, err)
+		assert.Equal(t, rootPath, root.Path)
+		nestedPath, err := repomodel.CommentPath(first.Path, nested.ID, nested.CreatedAt)
+		require.NoError(t, err)
+		assert.Equal(t, nestedPath, nested.Path)
+		assert.True(t, repomodel.IsInSubtree(nested.Path, root.Path))
+		assert.False(t, repomodel.IsInSubtree(root.Path, nested.Path))
+
+		// Путь не изменяется при обновлении комментария
+		update := *nested
+		update.Path = ""
+		update.Content = "Updated"
+		require.NoError(t, repo.Update(ctx, &update))
+		stored, err := repo.GetByID(ctx, nested.ID)
+		require.NoError(t, err)
+		assert.Equal(t, nested.Path, stored.Path)
+
+		// Родитель и время создания определяют путь и не изменяются
+		move := *nested
+		move.ParentID = &second.ID
+		move.CreatedAt = base
+		require.NoError(t, repo.Update(ctx, &move))
+		stored, err = repo.GetByID(ctx, nested.ID)
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, *stored.ParentID)
+		assert.True(t, nested.CreatedAt.Equal(stored.CreatedAt))
+		assert.Equal(t, nested.Path, stored.Path)
+	})
+
+	t.Run("created before epoch", func(t *testing.T) {
+		comment := &repomodel.Comment{
+			ID:        uuid.New(),
+			PostID:    postID,
+			Content:   "Comment",
+			AuthorID:  uuid.New(),
+			CreatedAt: time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC),
+		}
+		assert.Error(t, repo.Create(ctx, comment))
+	})
+
+	t.Run("thread order", func(t *testing.T) {
+		comments, err := repo.GetByPostID(ctx, postID)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{root.ID, first.ID, nested.ID, second.ID, other.ID}, ids(comments))
+	})
+
+	t.Run("subtree", func(t *testing.T) {
+		subtree, err := repo.GetSubtree(ctx, root.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{root.ID, first.ID, nested.ID, second.ID}, ids(subtree))
+
+		count, err := repo.CountSubtree(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		_, err = repo.GetSubtree(ctx, uuid.New())
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+		_, err = repo.CountSubtree(ctx, uuid.New())
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("missing parent", func(t *testing.T) {
+		missing := uuid.New()
+		err := repo.Create(ctx, &repomodel.Comment{
+			ID: uuid.New(), PostID: postID, ParentID: &missing, Content: "Orphan",
+			AuthorID: uuid.New(), Depth: 1, CreatedAt: base, UpdatedAt: base,
+		})
+		assert.Error(t, err)
+	})
+}
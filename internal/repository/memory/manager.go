@@ -2,23 +2,45 @@ package memory
 
 import (
 	"context"
+	"sync"
 
 	"github.com/NarthurN/habbr/internal/repository"
 )
 
 // Manager управляет in-memory репозиториями
 type Manager struct {
+	// gate выполняет транзакции по одной и на время транзакции задерживает изменения вне ее
+	gate     sync.RWMutex
+	posts    *PostRepository
+	comments *CommentRepository
+	events   *CommentEventRepository
+
 	repositories *repository.Repositories
 }
 
 // NewManager создает новый менеджер in-memory репозиториев
 func NewManager() *Manager {
-	return &Manager{
+	comments := NewCommentRepository()
+	posts := NewPostRepository()
+	posts.comments = comments
+	events := NewCommentEventRepository(repository.CommentEventRetention)
+
+	m := &Manager{
+		posts:    posts,
+		comments: comments,
+		events:   events,
 		repositories: &repository.Repositories{
-			Post:    NewPostRepository(),
-			Comment: NewCommentRepository(),
+			Post:         posts,
+			Comment:      comments,
+			CommentEvent: events,
+			Stats:        NewStatsRepository(comments),
+			Analytics:    NewAnalyticsRepository(posts, comments),
 		},
 	}
+	posts.gate = txGate{mu: &m.gate}
+	comments.gate = txGate{mu: &m.gate}
+
+	return m
 }
 
 // GetRepositories возвращает все репозитории
@@ -26,6 +48,43 @@ func (m *Manager) GetRepositories() *repository.Repositories {
 	return m.repositories
 }
 
+// WithinTx выполняет fn как единое целое над постами, комментариями и журналом событий.
+//
+// Перед первым изменением в транзакции репозиторий сохраняет копию своего состояния,
+// и при ошибке или панике fn эта копия восстанавливается. На время транзакции изменения
+// через репозитории вне fn ждут ее завершения, поэтому откат отменяет только изменения
+// самой транзакции. Чтение не блокируется и видит незафиксированные изменения. События,
+// добавленные в журнал из fn, записываются при фиксации и отбрасываются при откате.
+//
+// Транзакции выполняются по одной. Изменение через репозитории менеджера, а не через
+// repos, и вложенный вызов WithinTx из fn приведут к взаимоблокировке.
+func (m *Manager) WithinTx(ctx context.Context, fn func(repos *repository.Repositories) error) error {
+	m.gate.Lock()
+	defer m.gate.Unlock()
+
+	events := &txCommentEventRepository{CommentEventRepository: m.events}
+	repos := *m.repositories
+	repos.Post = txPostRepository{m.posts}
+	repos.Comment = txCommentRepository{m.comments}
+	repos.CommentEvent = events
+
+	m.posts.beginTx()
+	m.comments.beginTx()
+
+	committed := false
+	defer func() {
+		m.comments.endTx(committed)
+		m.posts.endTx(committed)
+	}()
+
+	if err := fn(&repos); err != nil {
+		return err
+	}
+
+	committed = true
+	return events.flush(ctx)
+}
+
 // Close закрывает соединения (для in-memory реализации не требуется)
 func (m *Manager) Close(ctx context.Context) error {
 	return nil
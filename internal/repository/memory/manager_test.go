@@ -0,0 +1,207 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NarthurN/habbr/internal/repository"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+)
+
+func TestManagerWithinTx(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	// setup создает менеджер с постом и комментарием с одним ответом
+	setup := func(t *testing.T) (*Manager, *repomodel.Post, *repomodel.Comment) {
+		manager := NewManager()
+		repos := manager.GetRepositories()
+
+		post := &repomodel.Post{
+			ID:              uuid.New(),
+			Title:           "Transactions",
+			Content:         "Original content",
+			AuthorID:        uuid.New(),
+			CommentsEnabled: true,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		require.NoError(t, repos.Post.Create(ctx, post))
+
+		root := &repomodel.Comment{
+			ID:        uuid.New(),
+			PostID:    post.ID,
+			Content:   "Original comment",
+			AuthorID:  uuid.New(),
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		require.NoError(t, repos.Comment.Create(ctx, root))
+		require.NoError(t, repos.Comment.Create(ctx, &repomodel.Comment{
+			ID:        uuid.New(),
+			PostID:    post.ID,
+			ParentID:  &root.ID,
+			Content:   "Reply",
+			AuthorID:  uuid.New(),
+			Depth:     1,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}))
+
+		return manager, post, root
+	}
+
+	// mutate изменяет пост и комментарии через репозитории транзакции
+	mutate := func(t *testing.T, repos *repository.Repositories, post *repomodel.Post, root *repomodel.Comment) {
+		updated := *post
+		updated.Content = "Changed content"
+		require.NoError(t, repos.Post.Update(ctx, &updated))
+
+		// Надгробие изменяет сохраненный комментарий на месте
+		_, tombstone, err := repos.Comment.SoftDelete(ctx, root.ID, "[deleted]")
+		require.NoError(t, err)
+		require.True(t, tombstone)
+	}
+
+	t.Run("commit keeps changes", func(t *testing.T) {
+		manager, post, root := setup(t)
+
+		err := manager.WithinTx(ctx, func(repos *repository.Repositories) error {
+			mutate(t, repos, post, root)
+			return nil
+		})
+		require.NoError(t, err)
+
+		repos := manager.GetRepositories()
+		stored, err := repos.Post.GetByID(ctx, post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Changed content", stored.Content)
+
+		comment, err := repos.Comment.GetByID(ctx, root.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, comment.DeletedAt)
+	})
+
+	t.Run("error rolls back posts, comments and search index", func(t *testing.T) {
+		manager, post, root := setup(t)
+		errAbort := errors.New("abort")
+
+		err := manager.WithinTx(ctx, func(repos *repository.Repositories) error {
+			mutate(t, repos, post, root)
+			require.NoError(t, repos.Comment.DeleteByPostID(ctx, post.ID))
+			require.NoError(t, repos.Post.Delete(ctx, post.ID))
+			return errAbort
+		})
+		assert.ErrorIs(t, err, errAbort)
+
+		repos := manager.GetRepositories()
+		stored, err := repos.Post.GetByID(ctx, post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Original content", stored.Content)
+
+		comment, err := repos.Comment.GetByID(ctx, root.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Original comment", comment.Content)
+		assert.Nil(t, comment.DeletedAt)
+
+		count, err := repos.Comment.CountByPostID(ctx, post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		hits, err := repos.Post.Search(ctx, repomodel.SearchFilter{Query: "original", Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, hits, 1)
+		assert.Equal(t, post.ID, hits[0].Post.ID)
+	})
+
+	t.Run("panic rolls back changes", func(t *testing.T) {
+		manager, post, root := setup(t)
+
+		assert.Panics(t, func() {
+			_ = manager.WithinTx(ctx, func(repos *repository.Repositories) error {
+				mutate(t, repos, post, root)
+				panic("boom")
+			})
+		})
+
+		stored, err := manager.GetRepositories().Post.GetByID(ctx, post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Original content", stored.Content)
+
+		// После отката изменения вне транзакции сохраняются как обычно
+		stored.Content = "Outside"
+		require.NoError(t, manager.GetRepositories().Post.Update(ctx, stored))
+		stored, err = manager.GetRepositories().Post.GetByID(ctx, post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Outside", stored.Content)
+	})
+
+	t.Run("rollback keeps changes made outside the transaction", func(t *testing.T) {
+		manager, post, root := setup(t)
+		outside := &repomodel.Post{
+			ID: uuid.New(), Title: "Outside", Content: "Content", AuthorID: uuid.New(),
+			CommentsEnabled: true, CreatedAt: now, UpdatedAt: now,
+		}
+
+		started := make(chan struct{})
+		written := make(chan error, 1)
+		err := manager.WithinTx(ctx, func(repos *repository.Repositories) error {
+			mutate(t, repos, post, root)
+
+			// Параллельный запрос ждет завершения транзакции
+			go func() {
+				close(started)
+				written <- manager.GetRepositories().Post.Create(ctx, outside)
+			}()
+			<-started
+			select {
+			case err := <-written:
+				t.Errorf("write outside the transaction was not delayed: %v", err)
+			case <-time.After(20 * time.Millisecond):
+			}
+			return errors.New("abort")
+		})
+		require.Error(t, err)
+		require.NoError(t, <-written)
+
+		repos := manager.GetRepositories()
+		exists, err := repos.Post.Exists(ctx, outside.ID)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		stored, err := repos.Post.GetByID(ctx, post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Original content", stored.Content)
+	})
+
+	t.Run("comment events are written on commit only", func(t *testing.T) {
+		manager, post, _ := setup(t)
+		appendEvent := func(fail bool) error {
+			return manager.WithinTx(ctx, func(repos *repository.Repositories) error {
+				event := &repomodel.CommentEvent{PostID: post.ID, ActionType: "CREATED", Payload: []byte("{}")}
+				require.NoError(t, repos.CommentEvent.Append(ctx, event))
+				if fail {
+					return errors.New("abort")
+				}
+				return nil
+			})
+		}
+
+		require.Error(t, appendEvent(true))
+		events, err := manager.GetRepositories().CommentEvent.ListAfter(ctx, post.ID, 0, 0)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+
+		require.NoError(t, appendEvent(false))
+		events, err = manager.GetRepositories().CommentEvent.ListAfter(ctx, post.ID, 0, 0)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.NotZero(t, events[0].ID)
+	})
+}
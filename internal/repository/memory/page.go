@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"bytes"
+	"cmp"
+	"slices"
+
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+)
+
+// compareCursors сравнивает позиции записей в порядке (ключ сортировки, id) по возрастанию.
+//
+// Позиции должны быть получены для одного ключа сортировки.
+func compareCursors(a, b repomodel.Cursor) int {
+	if a.Key.IsCount() {
+		if c := cmp.Compare(a.Count, b.Count); c != 0 {
+			return c
+		}
+	} else if c := a.Time.Compare(b.Time); c != 0 {
+		return c
+	}
+	return bytes.Compare(a.ID[:], b.ID[:])
+}
+
+// sortByCursor сортирует записи по (ключ сортировки, id) в направлении desc
+func sortByCursor[T any](items []T, cursor func(T) repomodel.Cursor, desc bool) {
+	slices.SortFunc(items, func(a, b T) int {
+		c := compareCursors(cursor(a), cursor(b))
+		if desc {
+			return -c
+		}
+		return c
+	})
+}
+
+// paginate применяет keyset-пагинацию к отсортированным sortByCursor записям.
+//
+// Повторяет семантику PostgreSQL реализации: After и Before не входят в окно,
+// First и Last берут записи с начала или конца окна, флаги соседних страниц
+// учитывают записи за границами окна.
+func paginate[T any](items []T, cursor func(T) repomodel.Cursor, desc bool, page repomodel.Page) ([]T, bool, bool) {
+	// position сравнивает запись с курсором в порядке сортировки
+	position := func(item T, c *repomodel.Cursor) int {
+		result := compareCursors(cursor(item), *c)
+		if desc {
+			return -result
+		}
+		return result
+	}
+
+	start, end := 0, len(items)
+	if page.After != nil {
+		for start < end && position(items[start], page.After) <= 0 {
+			start++
+		}
+	}
+	if page.Before != nil {
+		for end > start && position(items[end-1], page.Before) >= 0 {
+			end--
+		}
+	}
+
+	hasPrevious := start > 0
+	hasNext := end < len(items)
+
+	switch {
+	case page.First != nil && end-start > *page.First:
+		end = start + *page.First
+		hasNext = true
+	case page.Last != nil && end-start > *page.Last:
+		start = end - *page.Last
+		hasPrevious = true
+	}
+
+	return items[start:end], hasNext, hasPrevious
+}
+
+// cursorsOf возвращает позиции записей
+func cursorsOf[T any](items []T, cursor func(T) repomodel.Cursor) []repomodel.Cursor {
+	cursors := make([]repomodel.Cursor, len(items))
+	for i, item := range items {
+		cursors[i] = cursor(item)
+	}
+	return cursors
+}
@@ -0,0 +1,184 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+)
+
+func TestPostRepositoryKeysetPagination(t *testing.T) {
+	ctx := context.Background()
+	repo := NewPostRepository()
+
+	// Пять постов, два из них с одинаковым временем создания
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	times := []time.Time{base, base.Add(time.Nanosecond), base.Add(time.Nanosecond), base.Add(time.Second), base.Add(time.Minute)}
+	for _, createdAt := range times {
+		require.NoError(t, repo.Create(ctx, &repomodel.Post{
+			ID:        uuid.New(),
+			Title:     "Post",
+			Content:   "Content",
+			AuthorID:  uuid.New(),
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}))
+	}
+
+	all, err := repo.List(ctx, repomodel.PostFilter{})
+	require.NoError(t, err)
+	require.Len(t, all.Posts, 5)
+	assert.False(t, all.HasNextPage)
+	assert.False(t, all.HasPreviousPage)
+	for i := 1; i < len(all.Posts); i++ {
+		assert.Positive(t, compareCursors(all.Cursors[i-1], all.Cursors[i]), "posts must be ordered newest first")
+	}
+
+	ids := func(posts []*repomodel.Post) []uuid.UUID {
+		result := make([]uuid.UUID, len(posts))
+		for i, post := range posts {
+			result[i] = post.ID
+		}
+		return result
+	}
+	cursorAt := func(i int) *repomodel.Cursor {
+		return &all.Cursors[i]
+	}
+	two := 2
+
+	tests := []struct {
+		name         string
+		page         repomodel.Page
+		want         []*repomodel.Post
+		wantNext     bool
+		wantPrevious bool
+	}{
+		{"first", repomodel.Page{First: &two}, all.Posts[:2], true, false},
+		{"first after", repomodel.Page{First: &two, After: cursorAt(1)}, all.Posts[2:4], true, true},
+		{"first after tie", repomodel.Page{First: &two, After: cursorAt(2)}, all.Posts[3:5], false, true},
+		{"last", repomodel.Page{Last: &two}, all.Posts[3:], false, true},
+		{"last before", repomodel.Page{Last: &two, Before: cursorAt(3)}, all.Posts[1:3], true, true},
+		{"last before start", repomodel.Page{Last: &two, Before: cursorAt(1)}, all.Posts[:1], true, false},
+		{"after and before", repomodel.Page{After: cursorAt(0), Before: cursorAt(4)}, all.Posts[1:4], true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := repo.List(ctx, repomodel.PostFilter{Page: tt.page})
+			require.NoError(t, err)
+			assert.Equal(t, ids(tt.want), ids(page.Posts))
+			assert.Equal(t, tt.wantNext, page.HasNextPage, "HasNextPage")
+			assert.Equal(t, tt.wantPrevious, page.HasPreviousPage, "HasPreviousPage")
+		})
+	}
+}
+
+func TestKeysetPaginationSortKeys(t *testing.T) {
+	ctx := context.Background()
+	repos := NewManager().GetRepositories()
+
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	createPost := func(offset time.Duration) *repomodel.Post {
+		post := &repomodel.Post{
+			ID:        uuid.New(),
+			Title:     "Post",
+			Content:   "Content",
+			AuthorID:  uuid.New(),
+			CreatedAt: base.Add(offset),
+			UpdatedAt: base.Add(offset),
+		}
+		require.NoError(t, repos.Post.Create(ctx, post))
+		return post
+	}
+	createComment := func(post *repomodel.Post, parent *repomodel.Comment, offset time.Duration) *repomodel.Comment {
+		comment := &repomodel.Comment{
+			ID:        uuid.New(),
+			PostID:    post.ID,
+			Content:   "Comment",
+			AuthorID:  uuid.New(),
+			CreatedAt: base.Add(offset),
+			UpdatedAt: base.Add(offset),
+		}
+		if parent != nil {
+			comment.ParentID = &parent.ID
+			comment.Depth = parent.Depth + 1
+		}
+		require.NoError(t, repos.Comment.Create(ctx, comment))
+		return comment
+	}
+
+	quiet := createPost(0)
+	busy := createPost(time.Second)
+	edited := createPost(2 * time.Second)
+	edited.UpdatedAt = base.Add(time.Hour)
+	require.NoError(t, repos.Post.Update(ctx, edited))
+
+	first := createComment(busy, nil, 0)
+	popular := createComment(busy, nil, time.Second)
+	createComment(busy, popular, 2*time.Second)
+	createComment(busy, popular, 3*time.Second)
+	createComment(busy, first, 4*time.Second)
+	createComment(quiet, nil, 5*time.Second)
+
+	postIDs := func(filter repomodel.PostFilter) []uuid.UUID {
+		page, err := repos.Post.List(ctx, filter)
+		require.NoError(t, err)
+		result := make([]uuid.UUID, len(page.Posts))
+		for i, post := range page.Posts {
+			result[i] = post.ID
+		}
+		return result
+	}
+
+	t.Run("recently updated posts", func(t *testing.T) {
+		ids := postIDs(repomodel.PostFilter{OrderBy: repomodel.SortByUpdatedAt, OrderDir: "desc"})
+		assert.Equal(t, []uuid.UUID{edited.ID, busy.ID, quiet.ID}, ids)
+	})
+
+	t.Run("most commented posts", func(t *testing.T) {
+		ids := postIDs(repomodel.PostFilter{OrderBy: repomodel.SortByCommentCount, OrderDir: "desc"})
+		require.Len(t, ids, 3)
+		assert.Equal(t, []uuid.UUID{busy.ID, quiet.ID}, ids[:2])
+	})
+
+	t.Run("most commented posts paginated", func(t *testing.T) {
+		one := 1
+		filter := repomodel.PostFilter{OrderBy: repomodel.SortByCommentCount, OrderDir: "desc", Page: repomodel.Page{First: &one}}
+		page, err := repos.Post.List(ctx, filter)
+		require.NoError(t, err)
+		require.Len(t, page.Posts, 1)
+		assert.Equal(t, busy.ID, page.Posts[0].ID)
+		assert.Equal(t, repomodel.Cursor{Key: repomodel.SortByCommentCount, Count: 5, ID: busy.ID}, page.Cursors[0])
+
+		filter.Page.After = &page.Cursors[0]
+		page, err = repos.Post.List(ctx, filter)
+		require.NoError(t, err)
+		require.Len(t, page.Posts, 1)
+		assert.Equal(t, quiet.ID, page.Posts[0].ID)
+		assert.True(t, page.HasNextPage)
+		assert.True(t, page.HasPreviousPage)
+	})
+
+	t.Run("most replied comments", func(t *testing.T) {
+		page, err := repos.Comment.List(ctx, repomodel.CommentFilter{
+			PostID:   &busy.ID,
+			OrderBy:  repomodel.SortByReplyCount,
+			OrderDir: "desc",
+		})
+		require.NoError(t, err)
+		require.Len(t, page.Comments, 5)
+		assert.Equal(t, popular.ID, page.Comments[0].ID)
+		assert.Equal(t, first.ID, page.Comments[1].ID)
+		assert.Equal(t, int64(2), page.Cursors[0].Count)
+	})
+
+	t.Run("unknown sort key", func(t *testing.T) {
+		_, err := repos.Post.List(ctx, repomodel.PostFilter{OrderBy: repomodel.SortByReplyCount})
+		assert.Error(t, err)
+	})
+}
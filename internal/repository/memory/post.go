@@ -3,11 +3,11 @@ package memory
 import (
 	"context"
 	"fmt"
-	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/NarthurN/habbr/internal/repository"
 	repomodel "github.com/NarthurN/habbr/internal/repository/model"
 	"github.com/google/uuid"
 )
@@ -16,19 +16,34 @@ import (
 type PostRepository struct {
 	mu    sync.RWMutex
 	posts map[uuid.UUID]*repomodel.Post
+	index *searchIndex
+	tx    txState[repomodel.Post]
+	gate  txGate
+
+	// comments - репозиторий комментариев для сортировки по их количеству;
+	// если не задан, у всех постов считается ноль комментариев
+	comments *CommentRepository
 }
 
 // NewPostRepository создает новый in-memory репозиторий постов
 func NewPostRepository() *PostRepository {
 	return &PostRepository{
 		posts: make(map[uuid.UUID]*repomodel.Post),
+		index: newSearchIndex(),
 	}
 }
 
 // Create создает новый пост
 func (r *PostRepository) Create(ctx context.Context, post *repomodel.Post) error {
+	defer r.gate.enter()()
+	return r.createPost(ctx, post)
+}
+
+// createPost выполняет Create без ожидания открытой транзакции Manager.WithinTx
+func (r *PostRepository) createPost(ctx context.Context, post *repomodel.Post) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	r.tx.save(r.posts, r.index)
 
 	if post == nil {
 		return fmt.Errorf("post cannot be nil")
@@ -42,6 +57,7 @@ func (r *PostRepository) Create(ctx context.Context, post *repomodel.Post) error
 	// Создаем копию поста
 	postCopy := *post
 	r.posts[post.ID] = &postCopy
+	r.indexPost(&postCopy)
 
 	return nil
 }
@@ -53,7 +69,7 @@ func (r *PostRepository) GetByID(ctx context.Context, id uuid.UUID) (*repomodel.
 
 	post, exists := r.posts[id]
 	if !exists {
-		return nil, nil // не найден
+		return nil, repository.ErrNotFound
 	}
 
 	// Возвращаем копию
@@ -61,8 +77,13 @@ func (r *PostRepository) GetByID(ctx context.Context, id uuid.UUID) (*repomodel.
 	return &postCopy, nil
 }
 
-// List возвращает список постов с фильтрацией и пагинацией
-func (r *PostRepository) List(ctx context.Context, filter repomodel.PostFilter) ([]*repomodel.Post, error) {
+// List возвращает страницу постов с фильтрацией и keyset-пагинацией
+func (r *PostRepository) List(ctx context.Context, filter repomodel.PostFilter) (*repomodel.PostPage, error) {
+	cursor, err := r.postCursor(filter.OrderBy.OrDefault())
+	if err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -84,22 +105,17 @@ func (r *PostRepository) List(ctx context.Context, filter repomodel.PostFilter)
 		allPosts = append(allPosts, &postCopy)
 	}
 
-	// Сортируем
-	r.sortPosts(allPosts, filter.OrderBy, filter.OrderDir)
-
-	// Применяем пагинацию
-	start := filter.Offset
-	end := start + filter.Limit
-
-	if start >= len(allPosts) {
-		return []*repomodel.Post{}, nil
-	}
-
-	if end > len(allPosts) {
-		end = len(allPosts)
-	}
-
-	return allPosts[start:end], nil
+	// Сортируем и выбираем страницу
+	desc := !strings.EqualFold(filter.OrderDir, "asc")
+	sortByCursor(allPosts, cursor, desc)
+	posts, hasNext, hasPrevious := paginate(allPosts, cursor, desc, filter.Page)
+
+	return &repomodel.PostPage{
+		Posts:           posts,
+		Cursors:         cursorsOf(posts, cursor),
+		HasNextPage:     hasNext,
+		HasPreviousPage: hasPrevious,
+	}, nil
 }
 
 // Count возвращает общее количество постов с фильтрацией
@@ -126,8 +142,15 @@ func (r *PostRepository) Count(ctx context.Context, filter repomodel.PostFilter)
 
 // Update обновляет пост
 func (r *PostRepository) Update(ctx context.Context, post *repomodel.Post) error {
+	defer r.gate.enter()()
+	return r.updatePost(ctx, post)
+}
+
+// updatePost выполняет Update без ожидания открытой транзакции Manager.WithinTx
+func (r *PostRepository) updatePost(ctx context.Context, post *repomodel.Post) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	r.tx.save(r.posts, r.index)
 
 	if post == nil {
 		return fmt.Errorf("post cannot be nil")
@@ -143,20 +166,29 @@ func (r *PostRepository) Update(ctx context.Context, post *repomodel.Post) error
 	// Создаем копию и сохраняем
 	postCopy := *post
 	r.posts[post.ID] = &postCopy
+	r.indexPost(&postCopy)
 
 	return nil
 }
 
 // Delete удаляет пост
 func (r *PostRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	defer r.gate.enter()()
+	return r.deletePost(ctx, id)
+}
+
+// deletePost выполняет Delete без ожидания открытой транзакции Manager.WithinTx
+func (r *PostRepository) deletePost(ctx context.Context, id uuid.UUID) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	r.tx.save(r.posts, r.index)
 
 	if _, exists := r.posts[id]; !exists {
 		return fmt.Errorf("post with ID %s not found", id)
 	}
 
 	delete(r.posts, id)
+	r.index.remove(id)
 	return nil
 }
 
@@ -172,49 +204,80 @@ func (r *PostRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error)
 // ListWithCommentCounts возвращает посты с количеством комментариев
 func (r *PostRepository) ListWithCommentCounts(ctx context.Context, filter repomodel.PostFilter) ([]*repomodel.PostWithCommentCount, error) {
 	// Получаем обычный список постов
-	posts, err := r.List(ctx, filter)
+	page, err := r.List(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
 	// Конвертируем в PostWithCommentCount
-	result := make([]*repomodel.PostWithCommentCount, len(posts))
-	for i, post := range posts {
+	counts := r.commentCounts()
+	result := make([]*repomodel.PostWithCommentCount, len(page.Posts))
+	for i, post := range page.Posts {
 		result[i] = &repomodel.PostWithCommentCount{
 			Post:         *post,
-			CommentCount: 0, // Для in-memory реализации не считаем комментарии
+			CommentCount: int(counts[post.ID]),
 		}
 	}
 
 	return result, nil
 }
 
-// sortPosts сортирует посты по указанному полю и направлению
-func (r *PostRepository) sortPosts(posts []*repomodel.Post, orderBy, orderDir string) {
-	if orderBy == "" {
-		orderBy = "created_at"
-	}
+// beginTx открывает транзакцию Manager.WithinTx
+func (r *PostRepository) beginTx() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tx.begin()
+}
+
+// endTx закрывает транзакцию, при откате восстанавливая состояние до ее первого изменения
+func (r *PostRepository) endTx(commit bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	if orderDir == "" {
-		orderDir = "desc"
+	if backup := r.tx.end(); backup != nil && !commit {
+		r.posts = backup.items
+		r.index = backup.index
 	}
+}
 
-	sort.Slice(posts, func(i, j int) bool {
-		var result bool
+// indexPost добавляет пост в поисковый индекс
+func (r *PostRepository) indexPost(post *repomodel.Post) {
+	r.index.add(post.ID, post.Language,
+		searchField{text: post.Title, weight: titleWeight},
+		searchField{text: post.Content, weight: contentWeight},
+	)
+}
 
-		switch orderBy {
-		case "title":
-			result = strings.Compare(posts[i].Title, posts[j].Title) < 0
-		case "updated_at":
-			result = posts[i].UpdatedAt.Before(posts[j].UpdatedAt)
-		default: // "created_at"
-			result = posts[i].CreatedAt.Before(posts[j].CreatedAt)
-		}
+// commentCounts возвращает количество комментариев каждого поста
+func (r *PostRepository) commentCounts() map[uuid.UUID]int64 {
+	if r.comments == nil {
+		return nil
+	}
+	return r.comments.countByPost()
+}
 
-		if orderDir == "desc" {
-			result = !result
-		}
+// postCursor возвращает функцию позиции поста в порядке ключа сортировки key
+func (r *PostRepository) postCursor(key repomodel.SortKey) (func(*repomodel.Post) repomodel.Cursor, error) {
+	var counts map[uuid.UUID]int64
+	switch key {
+	case repomodel.SortByCreatedAt, repomodel.SortByUpdatedAt:
+	case repomodel.SortByCommentCount:
+		counts = r.commentCounts()
+	default:
+		return nil, fmt.Errorf("unsupported post sort key %q", key)
+	}
 
-		return result
-	})
+	return func(post *repomodel.Post) repomodel.Cursor {
+		cursor := repomodel.Cursor{Key: key, ID: post.ID}
+		switch key {
+		case repomodel.SortByUpdatedAt:
+			cursor.Time = post.UpdatedAt
+		case repomodel.SortByCommentCount:
+			cursor.Count = counts[post.ID]
+		default:
+			cursor.Time = post.CreatedAt
+		}
+		return cursor
+	}, nil
 }
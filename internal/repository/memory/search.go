@@ -0,0 +1,418 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+	"github.com/google/uuid"
+)
+
+// Веса полей документа, как у весов A и B в ts_rank PostgreSQL
+const (
+	titleWeight   float32 = 1.0
+	contentWeight float32 = 0.4
+)
+
+// snippetWords - количество слов во фрагменте результата поиска
+const snippetWords = 35
+
+// searchField - индексируемое поле документа с весом
+type searchField struct {
+	text   string
+	weight float32
+}
+
+// searchIndex - инвертированный индекс для полнотекстового поиска в памяти.
+//
+// Для каждого терма хранится вклад в релевантность каждого документа, содержащего терм.
+// Термы документа получены анализатором его языка, поэтому запрос сопоставляется
+// с документом только в разборе того же языка.
+// Индекс не синхронизирован: его защищает мьютекс репозитория-владельца.
+type searchIndex struct {
+	postings  map[string]map[uuid.UUID]float32
+	terms     map[uuid.UUID][]string
+	languages map[uuid.UUID]string
+}
+
+// newSearchIndex создает пустой индекс
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		postings:  make(map[string]map[uuid.UUID]float32),
+		terms:     make(map[uuid.UUID][]string),
+		languages: make(map[uuid.UUID]string),
+	}
+}
+
+// add индексирует документ на языке language, заменяя его предыдущую версию
+func (idx *searchIndex) add(id uuid.UUID, language string, fields ...searchField) {
+	idx.remove(id)
+
+	language = repomodel.SearchLanguageOrDefault(language)
+	weights := make(map[string]float32)
+	for _, field := range fields {
+		for _, term := range analyze(language, field.text) {
+			weights[term] += field.weight
+		}
+	}
+
+	terms := make([]string, 0, len(weights))
+	for term, weight := range weights {
+		docs, ok := idx.postings[term]
+		if !ok {
+			docs = make(map[uuid.UUID]float32)
+			idx.postings[term] = docs
+		}
+		// Повторы терма увеличивают релевантность логарифмически, как в ts_rank
+		docs[id] = float32(math.Log1p(float64(weight)))
+		terms = append(terms, term)
+	}
+	idx.terms[id] = terms
+	idx.languages[id] = language
+}
+
+// remove удаляет документ из индекса
+func (idx *searchIndex) remove(id uuid.UUID) {
+	for _, term := range idx.terms[id] {
+		delete(idx.postings[term], id)
+		if len(idx.postings[term]) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+	delete(idx.terms, id)
+	delete(idx.languages, id)
+}
+
+// search возвращает релевантность документов, удовлетворяющих запросу text.
+//
+// Запрос разбирается анализатором каждого языка (или только language, если он задан)
+// и сопоставляется с документами этого языка.
+func (idx *searchIndex) search(text, language string) map[uuid.UUID]float32 {
+	languages := repomodel.SearchLanguages
+	if language != "" {
+		languages = []string{language}
+	}
+
+	result := make(map[uuid.UUID]float32)
+	for _, lang := range languages {
+		for id, score := range idx.match(parseSearchQuery(lang, text)) {
+			if idx.languages[id] == lang {
+				result[id] = score
+			}
+		}
+	}
+	return result
+}
+
+// match возвращает релевантность документов, удовлетворяющих разобранному запросу
+func (idx *searchIndex) match(query searchQuery) map[uuid.UUID]float32 {
+	if len(query.clauses) == 0 {
+		return nil
+	}
+
+	var result map[uuid.UUID]float32
+	for _, clause := range query.clauses {
+		matched := make(map[uuid.UUID]float32)
+		for _, term := range clause {
+			for id, score := range idx.postings[term] {
+				matched[id] += score
+			}
+		}
+
+		if result == nil {
+			result = matched
+			continue
+		}
+		for id, score := range result {
+			if extra, ok := matched[id]; ok {
+				result[id] = score + extra
+			} else {
+				delete(result, id)
+			}
+		}
+	}
+
+	for _, term := range query.excluded {
+		for id := range idx.postings[term] {
+			delete(result, id)
+		}
+	}
+
+	return result
+}
+
+// searchQuery - разобранный поисковый запрос: все условия clauses должны выполняться,
+// условие выполняется при наличии любого из его термов; документы с термами excluded исключаются
+type searchQuery struct {
+	clauses  [][]string
+	excluded []string
+}
+
+// parseSearchQuery разбирает запрос в синтаксисе websearch_to_tsquery анализатором языка language.
+//
+// Слова объединяются по И, "or" объединяет соседние слова по ИЛИ, -слово исключает
+// документы. Слова в кавычках ищутся как отдельные слова без учета порядка.
+func parseSearchQuery(language, query string) searchQuery {
+	var result searchQuery
+	joinNext := false
+
+	for _, word := range strings.Fields(strings.ReplaceAll(query, `"`, " ")) {
+		if strings.EqualFold(word, "or") {
+			joinNext = len(result.clauses) > 0
+			continue
+		}
+
+		negated := strings.HasPrefix(word, "-")
+		terms := analyze(language, strings.TrimPrefix(word, "-"))
+		if len(terms) == 0 {
+			continue
+		}
+
+		if negated {
+			result.excluded = append(result.excluded, terms...)
+			joinNext = false
+			continue
+		}
+
+		if joinNext {
+			last := len(result.clauses) - 1
+			result.clauses[last] = append(result.clauses[last], terms[0])
+			terms = terms[1:]
+			joinNext = false
+		}
+		for _, term := range terms {
+			result.clauses = append(result.clauses, []string{term})
+		}
+	}
+
+	return result
+}
+
+// terms возвращает множество термов запроса для подсветки
+func (q searchQuery) terms() map[string]bool {
+	result := make(map[string]bool)
+	for _, clause := range q.clauses {
+		for _, term := range clause {
+			result[term] = true
+		}
+	}
+	return result
+}
+
+// analyze разбивает текст на термы, нормализованные анализатором языка language
+func analyze(language, text string) []string {
+	var terms []string
+	for _, word := range splitWords(text) {
+		if term := normalizeTerm(language, text[word[0]:word[1]]); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// splitWords возвращает границы слов текста в байтах
+func splitWords(text string) [][2]int {
+	var words [][2]int
+	start := -1
+	for i, r := range text {
+		isWordRune := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case isWordRune && start < 0:
+			start = i
+		case !isWordRune && start >= 0:
+			words = append(words, [2]int{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		words = append(words, [2]int{start, len(text)})
+	}
+	return words
+}
+
+// normalizeTerm приводит слово к терму по правилам конфигурации PostgreSQL языка language:
+// нижний регистр, без стоп-слов, основа слова.
+//
+// В конфигурации russian слова латиницей обрабатываются английским стеммером,
+// в конфигурации simple слово только приводится к нижнему регистру.
+func normalizeTerm(language, word string) string {
+	term := strings.ToLower(word)
+
+	switch language {
+	case "russian":
+		if !isASCIIWord(term) {
+			term = strings.ReplaceAll(term, "ё", "е")
+			if russianStopWords[term] {
+				return ""
+			}
+			return stemRussian(term)
+		}
+		fallthrough
+	case "english":
+		if englishStopWords[term] {
+			return ""
+		}
+		return stemEnglish(term)
+	default:
+		return term
+	}
+}
+
+// snippet возвращает фрагмент текста вокруг первого совпадения с подсвеченными термами.
+//
+// Слова текста нормализуются анализатором языка документа language.
+// Если совпадений в тексте нет, возвращается начало текста.
+func snippet(language, text string, terms map[string]bool) string {
+	words := splitWords(text)
+	if len(words) == 0 {
+		return ""
+	}
+
+	first := -1
+	for i, word := range words {
+		if terms[normalizeTerm(language, text[word[0]:word[1]])] {
+			first = i
+			break
+		}
+	}
+
+	start := 0
+	if first > snippetWords/3 {
+		start = first - snippetWords/3
+	}
+	end := min(start+snippetWords, len(words))
+
+	var b strings.Builder
+	pos := words[start][0]
+	for _, word := range words[start:end] {
+		b.WriteString(text[pos:word[0]])
+		token := text[word[0]:word[1]]
+		if terms[normalizeTerm(language, token)] {
+			b.WriteString(repomodel.HighlightStart)
+			b.WriteString(token)
+			b.WriteString(repomodel.HighlightStop)
+		} else {
+			b.WriteString(token)
+		}
+		pos = word[1]
+	}
+
+	return b.String()
+}
+
+// snippetTerms - термы запроса для подсветки, разобранные анализатором каждого языка
+type snippetTerms struct {
+	query      string
+	byLanguage map[string]map[string]bool
+}
+
+// newSnippetTerms создает кэш термов запроса для подсветки
+func newSnippetTerms(query string) *snippetTerms {
+	return &snippetTerms{query: query, byLanguage: make(map[string]map[string]bool)}
+}
+
+// get возвращает термы запроса в разборе языка language
+func (t *snippetTerms) get(language string) map[string]bool {
+	terms, ok := t.byLanguage[language]
+	if !ok {
+		terms = parseSearchQuery(language, t.query).terms()
+		t.byLanguage[language] = terms
+	}
+	return terms
+}
+
+// searchHit - найденный документ с релевантностью
+type searchHit struct {
+	id   uuid.UUID
+	rank float32
+}
+
+// rankHits сортирует документы по убыванию релевантности и ID и применяет курсор и лимит фильтра
+func rankHits(scores map[uuid.UUID]float32, filter repomodel.SearchFilter) []searchHit {
+	hits := make([]searchHit, 0, len(scores))
+	for id, rank := range scores {
+		hit := searchHit{id: id, rank: rank}
+		if filter.AfterRank != nil && filter.AfterID != nil && !hitAfter(hit, *filter.AfterRank, *filter.AfterID) {
+			continue
+		}
+		hits = append(hits, hit)
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].rank != hits[j].rank {
+			return hits[i].rank > hits[j].rank
+		}
+		return bytes.Compare(hits[i].id[:], hits[j].id[:]) < 0
+	})
+
+	if filter.Limit > 0 && len(hits) > filter.Limit {
+		hits = hits[:filter.Limit]
+	}
+
+	return hits
+}
+
+// hitAfter проверяет, что документ находится после позиции курсора
+func hitAfter(hit searchHit, rank float32, id uuid.UUID) bool {
+	if hit.rank != rank {
+		return hit.rank < rank
+	}
+	return bytes.Compare(hit.id[:], id[:]) > 0
+}
+
+// Search выполняет полнотекстовый поиск постов по инвертированному индексу
+func (r *PostRepository) Search(ctx context.Context, filter repomodel.SearchFilter) ([]*repomodel.PostSearchHit, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	terms := newSnippetTerms(filter.Query)
+
+	hits := rankHits(r.index.search(filter.Query, filter.Language), filter)
+	result := make([]*repomodel.PostSearchHit, 0, len(hits))
+	for _, hit := range hits {
+		post := r.posts[hit.id]
+		language := repomodel.SearchLanguageOrDefault(post.Language)
+		result = append(result, &repomodel.PostSearchHit{
+			Post:    *post,
+			Rank:    hit.rank,
+			Snippet: snippet(language, post.Content, terms.get(language)),
+		})
+	}
+
+	return result, nil
+}
+
+// Search выполняет полнотекстовый поиск комментариев по инвертированному индексу
+func (r *CommentRepository) Search(ctx context.Context, filter repomodel.SearchFilter) ([]*repomodel.CommentSearchHit, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	terms := newSnippetTerms(filter.Query)
+
+	scores := r.index.search(filter.Query, filter.Language)
+	if filter.PostID != nil {
+		for id := range scores {
+			if r.comments[id].PostID != *filter.PostID {
+				delete(scores, id)
+			}
+		}
+	}
+
+	hits := rankHits(scores, filter)
+	result := make([]*repomodel.CommentSearchHit, 0, len(hits))
+	for _, hit := range hits {
+		comment := r.comments[hit.id]
+		language := repomodel.SearchLanguageOrDefault(comment.Language)
+		result = append(result, &repomodel.CommentSearchHit{
+			Comment: *comment,
+			Rank:    hit.rank,
+			Snippet: snippet(language, comment.Content, terms.get(language)),
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/NarthurN/habbr/internal/repository"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+	"github.com/google/uuid"
+)
+
+// StatsRepository представляет in-memory реализацию репозитория статистики,
+// вычисляющую статистику по данным репозитория комментариев
+type StatsRepository struct {
+	comments *CommentRepository
+}
+
+// NewStatsRepository создает новый in-memory репозиторий статистики комментариев comments
+func NewStatsRepository(comments *CommentRepository) repository.StatsRepository {
+	return &StatsRepository{comments: comments}
+}
+
+// GetCommentStats вычисляет статистику комментариев поста за один проход
+func (r *StatsRepository) GetCommentStats(ctx context.Context, postID uuid.UUID) (*repomodel.CommentStats, error) {
+	r.comments.mu.RLock()
+	defer r.comments.mu.RUnlock()
+
+	stats := &repomodel.CommentStats{DepthHistogram: make([]repomodel.DepthCount, 0)}
+	authors := make(map[uuid.UUID]bool)
+	depths := make(map[int]int)
+	totalDepth := 0
+
+	for _, comment := range r.comments.comments {
+		if comment.PostID != postID {
+			continue
+		}
+
+		stats.TotalComments++
+		if comment.ParentID == nil {
+			stats.RootComments++
+		}
+		if comment.DeletedAt == nil {
+			authors[comment.AuthorID] = true
+		}
+
+		depths[comment.Depth]++
+		totalDepth += comment.Depth
+		stats.MaxDepth = max(stats.MaxDepth, comment.Depth)
+
+		if stats.LastCommentAt == nil || comment.CreatedAt.After(*stats.LastCommentAt) {
+			createdAt := comment.CreatedAt
+			stats.LastCommentAt = &createdAt
+		}
+	}
+
+	if stats.TotalComments == 0 {
+		return stats, nil
+	}
+
+	stats.UniqueCommenters = len(authors)
+	stats.AverageDepth = float64(totalDepth) / float64(stats.TotalComments)
+
+	for depth, count := range depths {
+		stats.DepthHistogram = append(stats.DepthHistogram, repomodel.DepthCount{Depth: depth, Count: count})
+	}
+	slices.SortFunc(stats.DepthHistogram, func(a, b repomodel.DepthCount) int {
+		return a.Depth - b.Depth
+	})
+
+	return stats, nil
+}
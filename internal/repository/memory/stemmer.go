@@ -0,0 +1,470 @@
+package memory
+
+import (
+	"strings"
+)
+
+// Стемминг для полнотекстового поиска в памяти.
+//
+// Реализованы алгоритмы Snowball, которые использует PostgreSQL в словарях english_stem
+// (Porter2) и russian_stem, чтобы in-memory поиск находил те же документы, что и
+// поиск по search_vector в базе данных.
+
+// englishStopWords - стоп-слова английского словаря Snowball
+var englishStopWords = makeWordSet(`
+	i me my myself we our ours ourselves you your yours yourself yourselves he him his himself
+	she her hers herself it its itself they them their theirs themselves what which who whom
+	this that these those am is are was were be been being have has had having do does did doing
+	a an the and but if or because as until while of at by for with about against between into
+	through during before after above below to from up down in out on off over under again further
+	then once here there when where why how all any both each few more most other some such no nor
+	not only own same so than too very s t can will just don should now
+`)
+
+// russianStopWords - стоп-слова русского словаря Snowball
+var russianStopWords = makeWordSet(`
+	и в во не что он на я с со как а то все она так его но да ты к у же вы за бы по только
+	ее мне было вот от меня еще нет о из ему теперь когда даже ну вдруг ли если уже или ни быть
+	был него до вас нибудь опять уж вам ведь там потом себя ничего ей может они тут где есть надо
+	ней для мы тебя их чем была сам чтоб без будто чего раз тоже себе под будет ж тогда кто этот
+	того потому этого какой совсем ним здесь этом один почти мой тем чтобы нее сейчас были куда
+	зачем всех никогда можно при наконец два об другой хоть после над больше тот через эти нас
+	про всего них какая много разве три эту моя впрочем хорошо свою этой перед иногда лучше чуть
+	том нельзя такой им более всегда конечно всю между
+`)
+
+// makeWordSet строит множество слов из списка, разделенного пробелами
+func makeWordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+	return set
+}
+
+// isASCIIWord проверяет, что слово состоит только из ASCII символов
+func isASCIIWord(word string) bool {
+	for i := 0; i < len(word); i++ {
+		if word[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// Porter2 (английский стеммер Snowball)
+
+// englishExceptions - слова с особыми формами, которые не обрабатываются общими правилами
+var englishExceptions = map[string]string{
+	"skis": "ski", "skies": "sky", "dying": "die", "lying": "lie", "tying": "tie",
+	"idly": "idl", "gently": "gentl", "ugly": "ugli", "early": "earli", "only": "onli",
+	"singly": "singl", "sky": "sky", "news": "news", "howe": "howe", "atlas": "atlas",
+	"cosmos": "cosmos", "bias": "bias", "andes": "andes",
+}
+
+// englishInvariants - слова, которые после шага 1a не изменяются
+var englishInvariants = makeWordSet(`inning outing canning herring earring proceed exceed succeed`)
+
+// englishStep2 - суффиксы шага 2 и их замены (в R1)
+var englishStep2 = map[string]string{
+	"tional": "tion", "enci": "ence", "anci": "ance", "abli": "able", "entli": "ent",
+	"izer": "ize", "ization": "ize", "ational": "ate", "ation": "ate", "ator": "ate",
+	"alism": "al", "aliti": "al", "alli": "al", "fulness": "ful", "ousli": "ous",
+	"ousness": "ous", "iveness": "ive", "iviti": "ive", "biliti": "ble", "bli": "ble",
+	"fulli": "ful", "lessli": "less", "ogi": "og", "li": "",
+}
+
+// englishStep3 - суффиксы шага 3 и их замены (в R1)
+var englishStep3 = map[string]string{
+	"tional": "tion", "ational": "ate", "alize": "al", "icate": "ic", "iciti": "ic",
+	"ical": "ic", "ful": "", "ness": "", "ative": "",
+}
+
+// englishStep4 - суффиксы шага 4, удаляемые в R2
+var englishStep4 = makeWordSet(`al ance ence er ic able ible ant ement ment ent ism ate iti ous ive ize ion`)
+
+// stemEnglish возвращает основу английского слова в нижнем регистре
+func stemEnglish(word string) string {
+	if stem, ok := englishExceptions[word]; ok {
+		return stem
+	}
+	if len(word) <= 2 {
+		return word
+	}
+
+	w := []byte(word)
+
+	// Y, выполняющая роль согласной, помечается заглавной
+	for i := range w {
+		if w[i] == 'y' && (i == 0 || isEnglishVowel(w[i-1])) {
+			w[i] = 'Y'
+		}
+	}
+
+	p1, p2 := englishRegions(w)
+
+	w = englishStep1a(w)
+	if englishInvariants[string(w)] {
+		return string(w)
+	}
+	w = englishStep1b(w, p1)
+	w = englishStep1c(w)
+	w = englishReplaceSuffix(w, englishStep2, p1)
+	w = englishStep3Apply(w, p1, p2)
+	w = englishStep4Apply(w, p2)
+	w = englishStep5(w, p1, p2)
+
+	return strings.ReplaceAll(string(w), "Y", "y")
+}
+
+// isEnglishVowel проверяет, что символ - гласная Porter2
+func isEnglishVowel(c byte) bool {
+	switch c {
+	case 'a', 'e', 'i', 'o', 'u', 'y':
+		return true
+	default:
+		return false
+	}
+}
+
+// englishRegions возвращает начала областей R1 и R2
+func englishRegions(w []byte) (int, int) {
+	p1 := len(w)
+	for _, prefix := range []string{"gener", "commun", "arsen"} {
+		if strings.HasPrefix(string(w), prefix) {
+			p1 = len(prefix)
+			break
+		}
+	}
+	if p1 == len(w) {
+		p1 = regionAfter(w, 0)
+	}
+	return p1, regionAfter(w, p1)
+}
+
+// regionAfter возвращает позицию после первой согласной, следующей за гласной, начиная со start
+func regionAfter(w []byte, start int) int {
+	for i := start + 1; i < len(w); i++ {
+		if !isEnglishVowel(w[i]) && isEnglishVowel(w[i-1]) {
+			return i + 1
+		}
+	}
+	return len(w)
+}
+
+// longestSuffix возвращает самый длинный суффикс слова из множества
+func longestSuffix[V any](w []byte, suffixes map[string]V) (string, bool) {
+	best, found := "", false
+	for suffix := range suffixes {
+		if len(suffix) <= len(w) && (!found || len(suffix) > len(best)) && string(w[len(w)-len(suffix):]) == suffix {
+			best, found = suffix, true
+		}
+	}
+	return best, found
+}
+
+// containsEnglishVowel проверяет наличие гласной в слове
+func containsEnglishVowel(w []byte) bool {
+	for _, c := range w {
+		if isEnglishVowel(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// endsWithShortSyllable проверяет, что слово оканчивается на короткий слог
+func endsWithShortSyllable(w []byte) bool {
+	n := len(w)
+	if n == 2 {
+		return isEnglishVowel(w[0]) && !isEnglishVowel(w[1])
+	}
+	if n < 3 {
+		return false
+	}
+	last := w[n-1]
+	return !isEnglishVowel(last) && last != 'w' && last != 'x' && last != 'Y' &&
+		isEnglishVowel(w[n-2]) && !isEnglishVowel(w[n-3])
+}
+
+// englishStep1a обрабатывает множественное число
+func englishStep1a(w []byte) []byte {
+	suffix, ok := longestSuffix(w, map[string]bool{"sses": true, "ied": true, "ies": true, "us": true, "ss": true, "s": true})
+	if !ok {
+		return w
+	}
+
+	stem := w[:len(w)-len(suffix)]
+	switch suffix {
+	case "sses":
+		return append(stem, "ss"...)
+	case "ied", "ies":
+		if len(stem) > 1 {
+			return append(stem, 'i')
+		}
+		return append(stem, "ie"...)
+	case "s":
+		if len(stem) > 1 && containsEnglishVowel(stem[:len(stem)-1]) {
+			return stem
+		}
+	}
+	return w
+}
+
+// englishStep1b обрабатывает окончания -ed и -ing
+func englishStep1b(w []byte, p1 int) []byte {
+	suffix, ok := longestSuffix(w, map[string]bool{"eed": true, "eedly": true, "ed": true, "edly": true, "ing": true, "ingly": true})
+	if !ok {
+		return w
+	}
+
+	stem := w[:len(w)-len(suffix)]
+	if suffix == "eed" || suffix == "eedly" {
+		if len(stem) >= p1 {
+			return append(stem, "ee"...)
+		}
+		return w
+	}
+
+	if !containsEnglishVowel(stem) {
+		return w
+	}
+
+	n := len(stem)
+	switch {
+	case n >= 2 && (string(stem[n-2:]) == "at" || string(stem[n-2:]) == "bl" || string(stem[n-2:]) == "iz"):
+		return append(stem, 'e')
+	case n >= 2 && stem[n-1] == stem[n-2] && strings.IndexByte("bdfgmnprt", stem[n-1]) >= 0:
+		return stem[:n-1]
+	case p1 >= n && endsWithShortSyllable(stem):
+		return append(stem, 'e')
+	}
+	return stem
+}
+
+// englishStep1c заменяет конечную y на i после согласной
+func englishStep1c(w []byte) []byte {
+	n := len(w)
+	if n > 2 && (w[n-1] == 'y' || w[n-1] == 'Y') && !isEnglishVowel(w[n-2]) {
+		w[n-1] = 'i'
+	}
+	return w
+}
+
+// englishReplaceSuffix заменяет самый длинный суффикс шага 2, если он находится в R1
+func englishReplaceSuffix(w []byte, suffixes map[string]string, p1 int) []byte {
+	suffix, ok := longestSuffix(w, suffixes)
+	if !ok || len(w)-len(suffix) < p1 {
+		return w
+	}
+
+	stem := w[:len(w)-len(suffix)]
+	switch suffix {
+	case "ogi":
+		if len(stem) == 0 || stem[len(stem)-1] != 'l' {
+			return w
+		}
+	case "li":
+		if len(stem) == 0 || strings.IndexByte("cdeghkmnrt", stem[len(stem)-1]) < 0 {
+			return w
+		}
+	}
+	return append(stem, suffixes[suffix]...)
+}
+
+// englishStep3Apply обрабатывает суффиксы шага 3
+func englishStep3Apply(w []byte, p1, p2 int) []byte {
+	suffix, ok := longestSuffix(w, englishStep3)
+	if !ok {
+		return w
+	}
+	start := len(w) - len(suffix)
+	if start < p1 || (suffix == "ative" && start < p2) {
+		return w
+	}
+	return append(w[:start], englishStep3[suffix]...)
+}
+
+// englishStep4Apply удаляет суффиксы шага 4 в R2
+func englishStep4Apply(w []byte, p2 int) []byte {
+	suffix, ok := longestSuffix(w, englishStep4)
+	if !ok {
+		return w
+	}
+	start := len(w) - len(suffix)
+	if start < p2 {
+		return w
+	}
+	if suffix == "ion" && (start == 0 || (w[start-1] != 's' && w[start-1] != 't')) {
+		return w
+	}
+	return w[:start]
+}
+
+// englishStep5 удаляет конечные e и l
+func englishStep5(w []byte, p1, p2 int) []byte {
+	n := len(w)
+	if n == 0 {
+		return w
+	}
+	switch w[n-1] {
+	case 'e':
+		if n-1 >= p2 || (n-1 >= p1 && !endsWithShortSyllable(w[:n-1])) {
+			return w[:n-1]
+		}
+	case 'l':
+		if n-1 >= p2 && n >= 2 && w[n-2] == 'l' {
+			return w[:n-1]
+		}
+	}
+	return w
+}
+
+// Русский стеммер Snowball
+
+// Группы окончаний русского стеммера. Окончания первой группы (с суффиксом 1)
+// удаляются только после "а" или "я".
+var (
+	russianPerfectiveGerund1 = makeWordSet(`в вши вшись`)
+	russianPerfectiveGerund2 = makeWordSet(`ив ивши ившись ыв ывши ывшись`)
+	russianAdjective         = makeWordSet(`ее ие ые ое ими ыми ей ий ый ой ем им ым ом его ого ему ому их ых ую юю ая яя ою ею`)
+	russianParticiple1       = makeWordSet(`ем нн вш ющ щ`)
+	russianParticiple2       = makeWordSet(`ивш ывш ующ`)
+	russianReflexive         = makeWordSet(`ся сь`)
+	russianVerb1             = makeWordSet(`ла на ете йте ли й л ем н ло но ет ют ны ть ешь нно`)
+	russianVerb2             = makeWordSet(`ила ыла ена ейте уйте ите или ыли ей уй ил ыл им ым ен ило ыло ено ят ует уют ит ыт ены ить ыть ишь ую ю`)
+	russianNoun              = makeWordSet(`а ев ов ие ье е иями ями ами еи ии и ией ей ой ий й иям ям ием ем ам ом о у ах иях ях ы ь ию ью ю ия ья я`)
+	russianDerivational      = makeWordSet(`ост ость`)
+	russianSuperlative       = makeWordSet(`ейш ейше`)
+)
+
+// stemRussian возвращает основу русского слова в нижнем регистре
+func stemRussian(word string) string {
+	w := []rune(strings.ReplaceAll(word, "ё", "е"))
+
+	rv, r2 := russianRegions(w)
+	if rv >= len(w) {
+		return string(w)
+	}
+
+	// Окончания ищутся только в области RV
+	prefix, body := w[:rv], w[rv:]
+
+	// Шаг 1: деепричастие совершенного вида, иначе возвратность и
+	// окончание прилагательного, глагола или существительного
+	if stem, ok := removeRussianGroups(body, russianPerfectiveGerund1, russianPerfectiveGerund2); ok {
+		body = stem
+	} else {
+		if stem, ok := removeRussianEnding(body, russianReflexive); ok {
+			body = stem
+		}
+		if stem, ok := removeRussianAdjectival(body); ok {
+			body = stem
+		} else if stem, ok := removeRussianGroups(body, russianVerb1, russianVerb2); ok {
+			body = stem
+		} else if stem, ok := removeRussianEnding(body, russianNoun); ok {
+			body = stem
+		}
+	}
+
+	// Шаг 2: конечная "и"
+	if n := len(body); n > 0 && body[n-1] == 'и' {
+		body = body[:n-1]
+	}
+
+	// Шаг 3: словообразовательный суффикс в R2
+	if suffix, ok := russianSuffix(body, russianDerivational); ok && rv+len(body)-len(suffix) >= r2 {
+		body = body[:len(body)-len(suffix)]
+	}
+
+	// Шаг 4: превосходная степень, двойная "н" и мягкий знак
+	if stem, ok := removeRussianEnding(body, russianSuperlative); ok {
+		body = undoubleRussianN(stem)
+	} else if n := len(body); n > 0 && body[n-1] == 'ь' {
+		body = body[:n-1]
+	} else {
+		body = undoubleRussianN(body)
+	}
+
+	return string(prefix) + string(body)
+}
+
+// undoubleRussianN заменяет конечную "нн" на "н"
+func undoubleRussianN(w []rune) []rune {
+	if n := len(w); n >= 2 && w[n-1] == 'н' && w[n-2] == 'н' {
+		return w[:n-1]
+	}
+	return w
+}
+
+// isRussianVowel проверяет, что символ - русская гласная
+func isRussianVowel(r rune) bool {
+	return strings.ContainsRune("аеиоуыэюя", r)
+}
+
+// russianRegions возвращает начала областей RV и R2
+func russianRegions(w []rune) (int, int) {
+	rv := goPastRussian(w, 0, true)
+	r1 := goPastRussian(w, rv, false)
+	r2 := goPastRussian(w, goPastRussian(w, r1, true), false)
+	return rv, r2
+}
+
+// goPastRussian возвращает позицию после первой гласной (vowel) или согласной, начиная со start
+func goPastRussian(w []rune, start int, vowel bool) int {
+	for i := start; i < len(w); i++ {
+		if isRussianVowel(w[i]) == vowel {
+			return i + 1
+		}
+	}
+	return len(w)
+}
+
+// russianSuffix возвращает самый длинный суффикс слова из множества
+func russianSuffix(w []rune, suffixes map[string]bool) ([]rune, bool) {
+	for n := min(len(w), 6); n > 0; n-- {
+		if suffixes[string(w[len(w)-n:])] {
+			return w[len(w)-n:], true
+		}
+	}
+	return nil, false
+}
+
+// removeRussianEnding удаляет самое длинное окончание из множества
+func removeRussianEnding(w []rune, endings map[string]bool) ([]rune, bool) {
+	suffix, ok := russianSuffix(w, endings)
+	if !ok {
+		return w, false
+	}
+	return w[:len(w)-len(suffix)], true
+}
+
+// removeRussianGroups удаляет самое длинное окончание из двух групп.
+// Окончание первой группы удаляется только после "а" или "я".
+func removeRussianGroups(w []rune, afterA, other map[string]bool) ([]rune, bool) {
+	suffix1, ok1 := russianSuffix(w, afterA)
+	suffix2, ok2 := russianSuffix(w, other)
+
+	switch {
+	case ok2 && (!ok1 || len(suffix2) >= len(suffix1)):
+		return w[:len(w)-len(suffix2)], true
+	case ok1:
+		stem := w[:len(w)-len(suffix1)]
+		if n := len(stem); n > 0 && (stem[n-1] == 'а' || stem[n-1] == 'я') {
+			return stem, true
+		}
+	}
+	return w, false
+}
+
+// removeRussianAdjectival удаляет окончание прилагательного вместе с суффиксом причастия
+func removeRussianAdjectival(w []rune) ([]rune, bool) {
+	stem, ok := removeRussianEnding(w, russianAdjective)
+	if !ok {
+		return w, false
+	}
+	if participle, ok := removeRussianGroups(stem, russianParticiple1, russianParticiple2); ok {
+		return participle, true
+	}
+	return stem, true
+}
@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStemEnglish(t *testing.T) {
+	for word, stem := range map[string]string{
+		"consigned":     "consign",
+		"consignment":   "consign",
+		"generously":    "generous",
+		"running":       "run",
+		"hoped":         "hope",
+		"caresses":      "caress",
+		"ponies":        "poni",
+		"ties":          "tie",
+		"happily":       "happili",
+		"subscriptions": "subscript",
+		"indexes":       "index",
+		"relational":    "relat",
+		"skies":         "sky",
+		"news":          "news",
+		"graphql":       "graphql",
+	} {
+		assert.Equal(t, stem, stemEnglish(word), word)
+	}
+}
+
+func TestStemRussian(t *testing.T) {
+	for word, stem := range map[string]string{
+		"комментарий":  "комментар",
+		"комментарии":  "комментар",
+		"комментариев": "комментар",
+		"важная":       "важн",
+		"важнейшими":   "важн",
+		"вагона":       "вагон",
+		"подписками":   "подписк",
+		"индексы":      "индекс",
+		"читавшись":    "чита",
+		"ёлки":         "елк",
+	} {
+		assert.Equal(t, stem, stemRussian(word), word)
+	}
+}
+
+func TestNormalizeTerm(t *testing.T) {
+	assert.Equal(t, "комментар", normalizeTerm("russian", "Комментарии"))
+	assert.Equal(t, "subscript", normalizeTerm("russian", "Subscriptions"), "latin words use the english stemmer")
+	assert.Empty(t, normalizeTerm("russian", "когда"))
+	assert.Empty(t, normalizeTerm("english", "the"))
+	assert.Equal(t, "subscriptions", normalizeTerm("simple", "Subscriptions"))
+	assert.Equal(t, "the", normalizeTerm("simple", "The"))
+}
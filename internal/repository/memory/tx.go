@@ -0,0 +1,203 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+	"github.com/google/uuid"
+)
+
+// txGate не дает изменениям вне транзакции Manager.WithinTx выполняться, пока она открыта.
+//
+// Изменения вне транзакции берут блокировку на чтение, транзакция держит блокировку на
+// запись до фиксации или отката, поэтому откат к снимку состояния не затирает чужие
+// изменения. Чтение шлюз не ограничивает. Репозитории, созданные без Manager, работают
+// без шлюза.
+type txGate struct {
+	mu *sync.RWMutex
+}
+
+// enter ожидает завершения открытой транзакции и возвращает функцию выхода из шлюза
+func (g txGate) enter() func() {
+	if g.mu == nil {
+		return func() {}
+	}
+	g.mu.RLock()
+	return g.mu.RUnlock
+}
+
+// txSnapshot - состояние репозитория до первого изменения в транзакции
+type txSnapshot[T any] struct {
+	items map[uuid.UUID]*T
+	index *searchIndex
+}
+
+// txState реализует копирование при записи для транзакций Manager.WithinTx.
+//
+// Пока транзакция открыта, первое изменение репозитория сохраняет копию его данных
+// и поискового индекса; откат восстанавливает эту копию. Другие изменения ждут
+// транзакцию в txGate, поэтому копия отличается от текущего состояния только
+// изменениями самой транзакции. Транзакции без изменений ничего не копируют.
+// Поля защищает мьютекс репозитория-владельца.
+type txState[T any] struct {
+	active bool
+	backup *txSnapshot[T]
+}
+
+// begin открывает транзакцию
+func (t *txState[T]) begin() {
+	t.active = true
+	t.backup = nil
+}
+
+// save сохраняет копию items и index перед первым изменением в открытой транзакции
+func (t *txState[T]) save(items map[uuid.UUID]*T, index *searchIndex) {
+	if !t.active || t.backup != nil {
+		return
+	}
+
+	// Записи копируются, так как некоторые операции изменяют их на месте
+	backup := make(map[uuid.UUID]*T, len(items))
+	for id, item := range items {
+		itemCopy := *item
+		backup[id] = &itemCopy
+	}
+	t.backup = &txSnapshot[T]{items: backup, index: index.clone()}
+}
+
+// end закрывает транзакцию и возвращает состояние для отката
+// (nil, если в транзакции не было изменений)
+func (t *txState[T]) end() *txSnapshot[T] {
+	backup := t.backup
+	t.active = false
+	t.backup = nil
+	return backup
+}
+
+// clone возвращает независимую копию индекса
+func (idx *searchIndex) clone() *searchIndex {
+	result := &searchIndex{
+		postings:  make(map[string]map[uuid.UUID]float32, len(idx.postings)),
+		terms:     maps.Clone(idx.terms),
+		languages: maps.Clone(idx.languages),
+	}
+	// Срезы термов не изменяются после индексации, поэтому копируются только словари
+	for term, docs := range idx.postings {
+		result.postings[term] = maps.Clone(docs)
+	}
+	return result
+}
+
+// txPostRepository - репозиторий постов транзакции: изменения выполняются без ожидания
+// в txGate, который держит сама транзакция
+type txPostRepository struct {
+	*PostRepository
+}
+
+// Create создает пост в транзакции
+func (r txPostRepository) Create(ctx context.Context, post *repomodel.Post) error {
+	return r.createPost(ctx, post)
+}
+
+// Update обновляет пост в транзакции
+func (r txPostRepository) Update(ctx context.Context, post *repomodel.Post) error {
+	return r.updatePost(ctx, post)
+}
+
+// Delete удаляет пост в транзакции
+func (r txPostRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.deletePost(ctx, id)
+}
+
+// txCommentRepository - репозиторий комментариев транзакции: изменения выполняются без
+// ожидания в txGate, который держит сама транзакция
+type txCommentRepository struct {
+	*CommentRepository
+}
+
+// Create создает комментарий в транзакции
+func (r txCommentRepository) Create(ctx context.Context, comment *repomodel.Comment) error {
+	return r.createComment(ctx, comment)
+}
+
+// Update обновляет комментарий в транзакции
+func (r txCommentRepository) Update(ctx context.Context, comment *repomodel.Comment) error {
+	return r.updateComment(ctx, comment)
+}
+
+// Delete удаляет комментарий в транзакции
+func (r txCommentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.deleteComment(ctx, id)
+}
+
+// DeleteSubtree удаляет поддерево комментария в транзакции
+func (r txCommentRepository) DeleteSubtree(ctx context.Context, id uuid.UUID) ([]*repomodel.Comment, error) {
+	return r.deleteSubtree(ctx, id)
+}
+
+// SoftDelete выполняет мягкое удаление комментария в транзакции
+func (r txCommentRepository) SoftDelete(ctx context.Context, id uuid.UUID, placeholder string) (*repomodel.Comment, bool, error) {
+	return r.softDelete(ctx, id, placeholder)
+}
+
+// PruneTombstones удаляет надгробия без ответов в транзакции
+func (r txCommentRepository) PruneTombstones(ctx context.Context, id uuid.UUID) ([]*repomodel.Comment, error) {
+	return r.pruneTombstones(ctx, id)
+}
+
+// DeleteByPostID удаляет комментарии поста в транзакции
+func (r txCommentRepository) DeleteByPostID(ctx context.Context, postID uuid.UUID) error {
+	return r.deleteByPostID(ctx, postID)
+}
+
+// txCommentEventRepository откладывает запись событий транзакции до ее фиксации:
+// при откате события отбрасываются. ID событию назначается при фиксации
+type txCommentEventRepository struct {
+	*CommentEventRepository
+
+	mu      sync.Mutex
+	pending []*repomodel.CommentEvent
+}
+
+// Append добавляет событие в очередь транзакции
+func (r *txCommentEventRepository) Append(ctx context.Context, event *repomodel.CommentEvent) error {
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pending = append(r.pending, event)
+	return nil
+}
+
+// AppendBatch добавляет события в очередь транзакции
+func (r *txCommentEventRepository) AppendBatch(ctx context.Context, events []*repomodel.CommentEvent) error {
+	for _, event := range events {
+		if event == nil {
+			return fmt.Errorf("event cannot be nil")
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pending = append(r.pending, events...)
+	return nil
+}
+
+// flush записывает события транзакции в журнал в порядке добавления
+func (r *txCommentEventRepository) flush(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.CommentEventRepository.AppendBatch(ctx, r.pending); err != nil {
+		return err
+	}
+	r.pending = nil
+	return nil
+}
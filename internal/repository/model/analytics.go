@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostAnalyticsSort - ключ сортировки аналитики постов, всегда по убыванию.
+// Посты с равным ключом упорядочиваются по возрастанию ID.
+type PostAnalyticsSort string
+
+// Ключи сортировки аналитики постов
+const (
+	PostAnalyticsSortTotalComments PostAnalyticsSort = "total_comments"
+	PostAnalyticsSortLastComment   PostAnalyticsSort = "last_comment_at" // посты без комментариев в конце
+	PostAnalyticsSortMaxDepth      PostAnalyticsSort = "max_comment_depth"
+	PostAnalyticsSortCreatedAt     PostAnalyticsSort = "created_at"
+)
+
+// PostAnalyticsFilter представляет параметры выборки аналитики постов в репозитории
+type PostAnalyticsFilter struct {
+	AuthorID      *uuid.UUID        `json:"author_id,omitempty"`
+	CreatedAfter  *time.Time        `json:"created_after,omitempty"`  // включительно
+	CreatedBefore *time.Time        `json:"created_before,omitempty"` // не включительно
+	MinComments   *int              `json:"min_comments,omitempty"`
+	Sort          PostAnalyticsSort `json:"sort"`
+	Limit         int               `json:"limit"`
+}
+
+// PostAnalytics представляет строку материализованного представления post_analytics
+type PostAnalytics struct {
+	PostID          uuid.UUID  `json:"post_id" db:"id"`
+	Title           string     `json:"title" db:"title"`
+	AuthorID        uuid.UUID  `json:"author_id" db:"author_id"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	TotalComments   int        `json:"total_comments" db:"total_comments"`
+	RootComments    int        `json:"root_comments" db:"root_comments"`
+	MaxCommentDepth int        `json:"max_comment_depth" db:"max_comment_depth"`
+	LastCommentAt   *time.Time `json:"last_comment_at" db:"last_comment_at"`
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,22 +16,67 @@ type Comment struct {
 	Content   string     `json:"content" db:"content"`
 	AuthorID  uuid.UUID  `json:"author_id" db:"author_id"`
 	Depth     int        `json:"depth" db:"depth"`
+	Language  string     `json:"language" db:"language"` // конфигурация полнотекстового поиска
 	CreatedAt time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at" db:"deleted_at"` // не nil - надгробие удаленного комментария с ответами
+	Path      string     `json:"path" db:"path"`             // материализованный путь, заполняется репозиторием при создании
 }
 
-// CommentFilter представляет фильтры для поиска комментариев в репозитории
+// CommentPathSeparator разделяет метки материализованного пути комментария
+const CommentPathSeparator = "."
+
+// CommentPath возвращает материализованный путь комментария с родителем по пути parentPath
+// (пустой для корневого комментария).
+//
+// Метка комментария - время создания в целых микросекундах от начала эпохи Unix
+// (16 цифр, дробная часть отбрасывается) и ID без дефисов, как в функции
+// comment_path_label миграции 008. Метки одной длины, поэтому лексикографический порядок
+// путей - обход ветки в глубину с ответами в порядке (created_at, id). Время создания
+// до 1970 года дало бы метку со знаком минус, поэтому отклоняется.
+func CommentPath(parentPath string, id uuid.UUID, createdAt time.Time) (string, error) {
+	micros := createdAt.UnixMicro()
+	if micros < 0 {
+		return "", fmt.Errorf("comment created_at %s is before the Unix epoch", createdAt.Format(time.RFC3339))
+	}
+
+	label := fmt.Sprintf("%016d_%s", micros, strings.ReplaceAll(id.String(), "-", ""))
+	if parentPath == "" {
+		return label, nil
+	}
+	return parentPath + CommentPathSeparator + label, nil
+}
+
+// IsInSubtree сообщает, лежит ли путь path в поддереве с корнем по пути rootPath (включая корень)
+func IsInSubtree(path, rootPath string) bool {
+	return path == rootPath || strings.HasPrefix(path, rootPath+CommentPathSeparator)
+}
+
+// CommentFilter представляет фильтры для поиска комментариев в репозитории.
+//
+// Комментарии упорядочены по (OrderBy, id) в направлении OrderDir (по умолчанию "asc").
 type CommentFilter struct {
 	PostID   *uuid.UUID `json:"post_id,omitempty"`
 	ParentID *uuid.UUID `json:"parent_id,omitempty"`
 	AuthorID *uuid.UUID `json:"author_id,omitempty"`
 	MaxDepth *int       `json:"max_depth,omitempty"`
-	Limit    int        `json:"limit"`
-	Offset   int        `json:"offset"`
-	OrderBy  string     `json:"order_by"`  // "created_at", "depth"
+	Page     Page       `json:"page"`
+	OrderBy  SortKey    `json:"order_by"`  // пусто - SortByCreatedAt
 	OrderDir string     `json:"order_dir"` // "asc", "desc"
 }
 
+// CommentTreeFilter описывает загрузку ветки дерева комментариев.
+//
+// Верхний уровень ветки - ответы на ParentID или, если ParentID не указан, корневые
+// комментарии поста PostID; Page применяется к верхнему уровню. Ниже загружается не
+// более Levels уровней ответов, не более RepliesLimit ответов на каждый комментарий.
+// AuthorID, MaxDepth и сортировка применяются на каждом уровне.
+type CommentTreeFilter struct {
+	CommentFilter
+	Levels       int `json:"levels"`
+	RepliesLimit int `json:"replies_limit"`
+}
+
 // CommentWithChildren расширяет Comment информацией о дочерних комментариях
 type CommentWithChildren struct {
 	Comment
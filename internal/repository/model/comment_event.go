@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CommentEvent представляет запись журнала событий комментариев в репозиторном слое
+type CommentEvent struct {
+	ID         int64     `json:"id" db:"id"`
+	PostID     uuid.UUID `json:"post_id" db:"post_id"`
+	ActionType string    `json:"action_type" db:"action_type"`
+	Payload    []byte    `json:"payload" db:"payload"` // событие в формате JSON
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SortKey определяет значение, по которому упорядочиваются записи при keyset-пагинации
+type SortKey string
+
+// Ключи сортировки
+const (
+	SortByCreatedAt    SortKey = "created_at"
+	SortByUpdatedAt    SortKey = "updated_at"
+	SortByCommentCount SortKey = "comment_count" // количество комментариев поста
+	SortByReplyCount   SortKey = "reply_count"   // количество прямых ответов на комментарий
+)
+
+// OrDefault возвращает ключ сортировки, подставляя SortByCreatedAt вместо пустого значения
+func (k SortKey) OrDefault() SortKey {
+	if k == "" {
+		return SortByCreatedAt
+	}
+	return k
+}
+
+// IsCount сообщает, что ключ сортировки - счетчик, а не время
+func (k SortKey) IsCount() bool {
+	return k == SortByCommentCount || k == SortByReplyCount
+}
+
+// Cursor представляет позицию записи в порядке keyset-пагинации (ключ сортировки, id).
+//
+// Значение ключа хранится в Time для ключей-времени и в Count для ключей-счетчиков.
+// ID делает порядок строгим для записей с одинаковым значением ключа.
+type Cursor struct {
+	Key   SortKey   `json:"key"`
+	Time  time.Time `json:"time,omitempty"`
+	Count int64     `json:"count,omitempty"`
+	ID    uuid.UUID `json:"id"`
+}
+
+// Value возвращает значение ключа сортировки позиции
+func (c Cursor) Value() interface{} {
+	if c.Key.IsCount() {
+		return c.Count
+	}
+	return c.Time
+}
+
+// Page задает keyset-пагинацию в терминах Relay Cursor Connections.
+//
+// After и Before ограничивают окно записей и сами в него не входят. First берет
+// первые записи окна, Last - последние; заданным может быть только одно из них.
+// Если оба не заданы, возвращаются все записи окна.
+type Page struct {
+	First  *int    `json:"first,omitempty"`
+	After  *Cursor `json:"after,omitempty"`
+	Last   *int    `json:"last,omitempty"`
+	Before *Cursor `json:"before,omitempty"`
+}
+
+// PostPage представляет страницу постов в порядке сортировки фильтра
+type PostPage struct {
+	Posts           []*Post  `json:"posts"`
+	Cursors         []Cursor `json:"cursors"`           // позиции постов, в порядке Posts
+	HasNextPage     bool     `json:"has_next_page"`     // после страницы есть записи
+	HasPreviousPage bool     `json:"has_previous_page"` // перед страницей есть записи
+}
+
+// CommentPage представляет страницу комментариев в порядке сортировки фильтра
+type CommentPage struct {
+	Comments        []*Comment `json:"comments"`
+	Cursors         []Cursor   `json:"cursors"`           // позиции комментариев, в порядке Comments
+	HasNextPage     bool       `json:"has_next_page"`     // после страницы есть записи
+	HasPreviousPage bool       `json:"has_previous_page"` // перед страницей есть записи
+}
+
+// CommentTreePage представляет ветку дерева комментариев
+type CommentTreePage struct {
+	CommentPage // страница комментариев верхнего уровня
+
+	Replies      []*Comment `json:"replies"`       // загруженные ответы всех уровней в порядке сортировки фильтра
+	ReplyCursors []Cursor   `json:"reply_cursors"` // позиции ответов, в порядке Replies
+
+	// ReplyCounts - количество прямых ответов, удовлетворяющих фильтру, для каждого
+	// комментария ветки, включая комментарии последнего загруженного уровня
+	ReplyCounts map[uuid.UUID]int `json:"reply_counts"`
+}
@@ -13,17 +13,19 @@ type Post struct {
 	Content         string    `json:"content" db:"content"`
 	AuthorID        uuid.UUID `json:"author_id" db:"author_id"`
 	CommentsEnabled bool      `json:"comments_enabled" db:"comments_enabled"`
+	Language        string    `json:"language" db:"language"` // конфигурация полнотекстового поиска
 	CreatedAt       time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
 }
 
-// PostFilter представляет фильтры для поиска постов в репозитории
+// PostFilter представляет фильтры для поиска постов в репозитории.
+//
+// Посты упорядочены по (OrderBy, id) в направлении OrderDir (по умолчанию "desc").
 type PostFilter struct {
 	AuthorID     *uuid.UUID `json:"author_id,omitempty"`
 	WithComments *bool      `json:"with_comments,omitempty"`
-	Limit        int        `json:"limit"`
-	Offset       int        `json:"offset"`
-	OrderBy      string     `json:"order_by"`  // "created_at", "updated_at", "title"
+	Page         Page       `json:"page"`
+	OrderBy      SortKey    `json:"order_by"`  // пусто - SortByCreatedAt
 	OrderDir     string     `json:"order_dir"` // "asc", "desc"
 }
 
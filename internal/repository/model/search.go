@@ -0,0 +1,56 @@
+package model
+
+import (
+	"github.com/google/uuid"
+)
+
+// Маркеры совпадений во фрагментах результатов поиска
+const (
+	HighlightStart = "<mark>"
+	HighlightStop  = "</mark>"
+)
+
+// SearchLanguages - конфигурации полнотекстового поиска, в которых индексируются документы.
+// Совпадают со значениями model.Language и именами конфигураций PostgreSQL.
+var SearchLanguages = []string{"russian", "english", "simple"}
+
+// DefaultSearchLanguage - язык документа без явно заданного языка,
+// как значение по умолчанию столбца language
+const DefaultSearchLanguage = "simple"
+
+// SearchLanguageOrDefault возвращает язык документа, подставляя DefaultSearchLanguage вместо пустого значения
+func SearchLanguageOrDefault(language string) string {
+	if language == "" {
+		return DefaultSearchLanguage
+	}
+	return language
+}
+
+// SearchFilter представляет параметры полнотекстового поиска в репозитории.
+//
+// Результаты упорядочены по убыванию Rank, при равном Rank - по возрастанию ID.
+// AfterRank и AfterID задаются вместе и означают позицию последнего полученного результата.
+// Если Language задан, ищутся только документы этого языка, иначе запрос разбирается
+// отдельно для языка каждого документа.
+type SearchFilter struct {
+	Query     string     `json:"query"`
+	PostID    *uuid.UUID `json:"post_id,omitempty"` // только для поиска комментариев
+	Language  string     `json:"language,omitempty"` // пусто - поиск по документам всех языков
+	Limit     int        `json:"limit"`
+	AfterRank *float32   `json:"after_rank,omitempty"`
+	AfterID   *uuid.UUID `json:"after_id,omitempty"`
+}
+
+// PostSearchHit представляет найденный пост с релевантностью и фрагментом текста
+type PostSearchHit struct {
+	Post
+	Rank    float32 `json:"rank" db:"rank"`
+	Snippet string  `json:"snippet" db:"snippet"` // фрагмент содержимого с маркерами HighlightStart/HighlightStop
+}
+
+// CommentSearchHit представляет найденный комментарий с релевантностью и фрагментом текста
+type CommentSearchHit struct {
+	Comment
+	Rank    float32 `json:"rank" db:"rank"`
+	Snippet string  `json:"snippet" db:"snippet"` // фрагмент содержимого с маркерами HighlightStart/HighlightStop
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+)
+
+// CommentStats представляет агрегированную статистику комментариев поста
+type CommentStats struct {
+	TotalComments    int          `json:"total_comments"`    // все комментарии, включая надгробия
+	RootComments     int          `json:"root_comments"`     // комментарии без родителя
+	MaxDepth         int          `json:"max_depth"`         // 0, если комментариев нет
+	AverageDepth     float64      `json:"average_depth"`     // 0, если комментариев нет
+	UniqueCommenters int          `json:"unique_commenters"` // различные авторы, без надгробий
+	LastCommentAt    *time.Time   `json:"last_comment_at"`   // nil, если комментариев нет
+	DepthHistogram   []DepthCount `json:"depth_histogram"`   // по возрастанию глубины, только непустые уровни
+}
+
+// DepthCount - количество комментариев на одном уровне вложенности
+type DepthCount struct {
+	Depth int `json:"depth"`
+	Count int `json:"count"`
+}
@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/NarthurN/habbr/internal/repository"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+	"go.uber.org/zap"
+)
+
+// analyticsOrderBy - выражения сортировки материализованного представления для каждого ключа
+var analyticsOrderBy = map[repomodel.PostAnalyticsSort]string{
+	repomodel.PostAnalyticsSortTotalComments: "total_comments DESC, id",
+	repomodel.PostAnalyticsSortLastComment:   "last_comment_at DESC NULLS LAST, id",
+	repomodel.PostAnalyticsSortMaxDepth:      "COALESCE(max_comment_depth, 0) DESC, id",
+	repomodel.PostAnalyticsSortCreatedAt:     "created_at DESC, id",
+}
+
+// AnalyticsRepository реализует repository.AnalyticsRepository для PostgreSQL.
+//
+// Аналитика читается из материализованного представления post_analytics
+// (миграция 003), поэтому запрос не агрегирует комментарии. Представление
+// отражает состояние на момент последнего вызова Refresh. Надгробия мягко удаленных
+// комментариев остаются строками comments и учитываются в счетчиках.
+type AnalyticsRepository struct {
+	db     DBTX
+	logger *zap.Logger
+}
+
+// NewAnalyticsRepository создает новый PostgreSQL репозиторий аналитики постов
+func NewAnalyticsRepository(db DBTX, logger *zap.Logger) repository.AnalyticsRepository {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &AnalyticsRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// List возвращает аналитику постов из представления post_analytics
+func (r *AnalyticsRepository) List(ctx context.Context, filter repomodel.PostAnalyticsFilter) ([]*repomodel.PostAnalytics, error) {
+	orderBy, ok := analyticsOrderBy[filter.Sort]
+	if !ok {
+		return nil, fmt.Errorf("unsupported post analytics sort: %q", filter.Sort)
+	}
+
+	var conditions []string
+	var args []interface{}
+
+	if filter.AuthorID != nil {
+		args = append(args, *filter.AuthorID)
+		conditions = append(conditions, fmt.Sprintf("author_id = $%d", len(args)))
+	}
+	if filter.CreatedAfter != nil {
+		args = append(args, *filter.CreatedAfter)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	if filter.CreatedBefore != nil {
+		args = append(args, *filter.CreatedBefore)
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+	if filter.MinComments != nil {
+		args = append(args, *filter.MinComments)
+		conditions = append(conditions, fmt.Sprintf("total_comments >= $%d", len(args)))
+	}
+
+	query := `
+		SELECT id, title, author_id, created_at, total_comments, root_comments,
+			COALESCE(max_comment_depth, 0), last_comment_at
+		FROM post_analytics
+	`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY %s LIMIT $%d", orderBy, len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Failed to list post analytics", zap.Error(err))
+		return nil, fmt.Errorf("failed to list post analytics: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*repomodel.PostAnalytics
+	for rows.Next() {
+		var item repomodel.PostAnalytics
+		err := rows.Scan(
+			&item.PostID,
+			&item.Title,
+			&item.AuthorID,
+			&item.CreatedAt,
+			&item.TotalComments,
+			&item.RootComments,
+			&item.MaxCommentDepth,
+			&item.LastCommentAt,
+		)
+		if err != nil {
+			r.logger.Error("Failed to scan post analytics", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan post analytics: %w", err)
+		}
+		items = append(items, &item)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error listing post analytics", zap.Error(err))
+		return nil, fmt.Errorf("failed to list post analytics: %w", err)
+	}
+
+	return items, nil
+}
+
+// Refresh пересчитывает представление post_analytics.
+//
+// CONCURRENTLY использует уникальный индекс idx_post_analytics_id и не блокирует
+// чтение представления на время пересчета.
+func (r *AnalyticsRepository) Refresh(ctx context.Context) error {
+	if _, err := r.db.Exec(ctx, "REFRESH MATERIALIZED VIEW CONCURRENTLY post_analytics"); err != nil {
+		r.logger.Error("Failed to refresh post analytics", zap.Error(err))
+		return fmt.Errorf("failed to refresh post analytics: %w", err)
+	}
+	return nil
+}
@@ -2,55 +2,59 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/NarthurN/habbr/internal/repository"
 	repomodel "github.com/NarthurN/habbr/internal/repository/model"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
 
 // CommentRepository реализует repository.CommentRepository для PostgreSQL
 type CommentRepository struct {
-	pool   *pgxpool.Pool
+	db     DBTX
 	logger *zap.Logger
 }
 
 // NewCommentRepository создает новый PostgreSQL репозиторий комментариев
-func NewCommentRepository(pool *pgxpool.Pool, logger *zap.Logger) repository.CommentRepository {
+func NewCommentRepository(db DBTX, logger *zap.Logger) repository.CommentRepository {
 	if logger == nil {
 		logger = zap.NewNop()
 	}
 	return &CommentRepository{
-		pool:   pool,
+		db:     db,
 		logger: logger,
 	}
 }
 
-// Create создает новый комментарий в базе данных
+// Create создает новый комментарий в базе данных.
+// Материализованный путь вычисляется триггером set_comments_path и записывается в comment.Path
 func (r *CommentRepository) Create(ctx context.Context, comment *repomodel.Comment) error {
 	if comment == nil {
 		return fmt.Errorf("comment cannot be nil")
 	}
 
 	query := `
-		INSERT INTO comments (id, post_id, parent_id, content, author_id, depth, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO comments (id, post_id, parent_id, content, author_id, depth, language, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING path::text
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	err := r.db.QueryRow(ctx, query,
 		comment.ID,
 		comment.PostID,
 		comment.ParentID,
 		comment.Content,
 		comment.AuthorID,
 		comment.Depth,
+		repomodel.SearchLanguageOrDefault(comment.Language),
 		comment.CreatedAt,
 		comment.UpdatedAt,
-	)
+	).Scan(&comment.Path)
 
 	if err != nil {
 		r.logger.Error("Failed to create comment",
@@ -71,12 +75,12 @@ func (r *CommentRepository) Create(ctx context.Context, comment *repomodel.Comme
 // GetByID получает комментарий по ID
 func (r *CommentRepository) GetByID(ctx context.Context, id uuid.UUID) (*repomodel.Comment, error) {
 	query := `
-		SELECT id, post_id, parent_id, content, author_id, depth, created_at, updated_at
+		SELECT id, post_id, parent_id, content, author_id, depth, language, created_at, updated_at, deleted_at, path::text
 		FROM comments
 		WHERE id = $1
 	`
 
-	row := r.pool.QueryRow(ctx, query, id)
+	row := r.db.QueryRow(ctx, query, id)
 
 	var comment repomodel.Comment
 	err := row.Scan(
@@ -86,8 +90,11 @@ func (r *CommentRepository) GetByID(ctx context.Context, id uuid.UUID) (*repomod
 		&comment.Content,
 		&comment.AuthorID,
 		&comment.Depth,
+		&comment.Language,
 		&comment.CreatedAt,
 		&comment.UpdatedAt,
+		&comment.DeletedAt,
+		&comment.Path,
 	)
 
 	if err != nil {
@@ -104,153 +111,389 @@ func (r *CommentRepository) GetByID(ctx context.Context, id uuid.UUID) (*repomod
 	return &comment, nil
 }
 
-// List получает список комментариев с фильтрацией и пагинацией
-func (r *CommentRepository) List(ctx context.Context, filter repomodel.CommentFilter) ([]*repomodel.Comment, error) {
-	var conditions []string
-	var args []interface{}
-	argIndex := 1
+// List получает страницу комментариев с фильтрацией и keyset-пагинацией
+func (r *CommentRepository) List(ctx context.Context, filter repomodel.CommentFilter) (*repomodel.CommentPage, error) {
+	sortExpr, err := commentSortExpression(filter.OrderBy)
+	if err != nil {
+		return nil, err
+	}
 
-	baseQuery := `
-		SELECT id, post_id, parent_id, content, author_id, depth, created_at, updated_at
-		FROM comments
-	`
+	conditions, args := commentFilterConditions(filter)
+
+	query := keysetQuery{
+		columns:    "id, post_id, parent_id, content, author_id, depth, language, created_at, updated_at, deleted_at, path::text",
+		from:       "comments",
+		existsFrom: "comments",
+		sortExpr:   sortExpr,
+		idExpr:     "comments.id",
+		key:        filter.OrderBy.OrDefault(),
+		conditions: conditions,
+		args:       args,
+		desc:       strings.EqualFold(filter.OrderDir, "desc"),
+		page:       filter.Page,
+	}
 
-	// Добавляем условия фильтрации
-	if filter.PostID != nil {
-		conditions = append(conditions, fmt.Sprintf("post_id = $%d", argIndex))
-		args = append(args, *filter.PostID)
-		argIndex++
+	page, err := queryKeysetPage(ctx, r.db, query, func() (*repomodel.Comment, *uuid.UUID, []interface{}) {
+		var comment repomodel.Comment
+		return &comment, &comment.ID, []interface{}{
+			&comment.ID,
+			&comment.PostID,
+			&comment.ParentID,
+			&comment.Content,
+			&comment.AuthorID,
+			&comment.Depth,
+			&comment.Language,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+			&comment.DeletedAt,
+			&comment.Path,
+		}
+	})
+	if err != nil {
+		r.logger.Error("Failed to list comments", zap.Error(err))
+		return nil, fmt.Errorf("failed to list comments: %w", err)
 	}
 
-	if filter.ParentID != nil {
-		conditions = append(conditions, fmt.Sprintf("parent_id = $%d", argIndex))
-		args = append(args, *filter.ParentID)
-		argIndex++
+	return &repomodel.CommentPage{
+		Comments:        page.items,
+		Cursors:         page.cursors,
+		HasNextPage:     page.hasNext,
+		HasPreviousPage: page.hasPrevious,
+	}, nil
+}
+
+// ListByPostIDs получает страницы комментариев нескольких постов одним запросом
+func (r *CommentRepository) ListByPostIDs(ctx context.Context, postIDs []uuid.UUID, filter repomodel.CommentFilter) (map[uuid.UUID]*repomodel.CommentPage, error) {
+	return r.listByPartition(ctx, "post_id", postIDs, filter)
+}
+
+// ListByParentIDs получает страницы прямых ответов на несколько комментариев одним запросом
+func (r *CommentRepository) ListByParentIDs(ctx context.Context, parentIDs []uuid.UUID, filter repomodel.CommentFilter) (map[uuid.UUID]*repomodel.CommentPage, error) {
+	return r.listByPartition(ctx, "parent_id", parentIDs, filter)
+}
+
+// listByPartition получает страницы комментариев, сгруппированных по столбцу column,
+// для каждого из значений ids
+func (r *CommentRepository) listByPartition(ctx context.Context, column string, ids []uuid.UUID, filter repomodel.CommentFilter) (map[uuid.UUID]*repomodel.CommentPage, error) {
+	sortExpr, err := commentSortExpression(filter.OrderBy)
+	if err != nil {
+		return nil, err
 	}
 
-	if filter.AuthorID != nil {
-		conditions = append(conditions, fmt.Sprintf("author_id = $%d", argIndex))
-		args = append(args, *filter.AuthorID)
-		argIndex++
+	filter.PostID = nil
+	filter.ParentID = nil
+	conditions, args := commentFilterConditions(filter)
+
+	query := keysetQuery{
+		columns:    "id, post_id, parent_id, content, author_id, depth, language, created_at, updated_at, deleted_at, path::text",
+		from:       "comments",
+		existsFrom: "comments",
+		sortExpr:   sortExpr,
+		idExpr:     "comments.id",
+		key:        filter.OrderBy.OrDefault(),
+		conditions: conditions,
+		args:       args,
+		desc:       strings.EqualFold(filter.OrderDir, "desc"),
+		page:       filter.Page,
 	}
 
-	if filter.MaxDepth != nil {
-		conditions = append(conditions, fmt.Sprintf("depth <= $%d", argIndex))
-		args = append(args, *filter.MaxDepth)
-		argIndex++
+	pages, err := queryKeysetPartitions(ctx, r.db, query, "comments."+column, ids, func() (*repomodel.Comment, *uuid.UUID, []interface{}) {
+		var comment repomodel.Comment
+		return &comment, &comment.ID, []interface{}{
+			&comment.ID,
+			&comment.PostID,
+			&comment.ParentID,
+			&comment.Content,
+			&comment.AuthorID,
+			&comment.Depth,
+			&comment.Language,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+			&comment.DeletedAt,
+			&comment.Path,
+		}
+	})
+	if err != nil {
+		r.logger.Error("Failed to list comments by "+column,
+			zap.Int("count", len(ids)),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to list comments by %s: %w", column, err)
 	}
 
-	// Собираем полный запрос
-	query := baseQuery
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
+	result := make(map[uuid.UUID]*repomodel.CommentPage, len(pages))
+	for id, page := range pages {
+		result[id] = &repomodel.CommentPage{
+			Comments:        page.items,
+			Cursors:         page.cursors,
+			HasNextPage:     page.hasNext,
+			HasPreviousPage: page.hasPrevious,
+		}
+	}
+
+	return result, nil
+}
+
+// ListTree получает ветку дерева комментариев.
+//
+// Верхний уровень выбирается keyset-страницей, как в List. Ответы загружаются одним
+// рекурсивным CTE: на каждом шаге подзапрос LATERAL выбирает для каждого комментария
+// предыдущего уровня первые RepliesLimit ответов в порядке сортировки, а рекурсия
+// останавливается на уровне Levels. Количество ответов подсчитывается отдельным запросом
+// для всех комментариев ветки.
+func (r *CommentRepository) ListTree(ctx context.Context, filter repomodel.CommentTreeFilter) (*repomodel.CommentTreePage, error) {
+	sortExpr, err := commentSortExpression(filter.OrderBy)
+	if err != nil {
+		return nil, err
 	}
 
-	// Добавляем сортировку
-	orderBy := "created_at"
-	if filter.OrderBy != "" {
-		orderBy = filter.OrderBy
+	conditions, args := commentFilterConditions(filter.CommentFilter)
+	if filter.ParentID == nil {
+		conditions = append(conditions, "parent_id IS NULL")
 	}
-	orderDir := "ASC"
-	if filter.OrderDir != "" {
-		orderDir = strings.ToUpper(filter.OrderDir)
+
+	desc := strings.EqualFold(filter.OrderDir, "desc")
+	query := keysetQuery{
+		columns:    "id, post_id, parent_id, content, author_id, depth, language, created_at, updated_at, deleted_at, path::text",
+		from:       "comments",
+		existsFrom: "comments",
+		sortExpr:   sortExpr,
+		idExpr:     "comments.id",
+		key:        filter.OrderBy.OrDefault(),
+		conditions: conditions,
+		args:       args,
+		desc:       desc,
+		page:       filter.Page,
 	}
-	query += fmt.Sprintf(" ORDER BY %s %s", orderBy, orderDir)
 
-	// Добавляем пагинацию
-	if filter.Limit > 0 {
-		query += fmt.Sprintf(" LIMIT $%d", argIndex)
-		args = append(args, filter.Limit)
-		argIndex++
+	page, err := queryKeysetPage(ctx, r.db, query, func() (*repomodel.Comment, *uuid.UUID, []interface{}) {
+		var comment repomodel.Comment
+		return &comment, &comment.ID, []interface{}{
+			&comment.ID,
+			&comment.PostID,
+			&comment.ParentID,
+			&comment.Content,
+			&comment.AuthorID,
+			&comment.Depth,
+			&comment.Language,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+			&comment.DeletedAt,
+			&comment.Path,
+		}
+	})
+	if err != nil {
+		r.logger.Error("Failed to list comment tree", zap.Error(err))
+		return nil, fmt.Errorf("failed to list comment tree: %w", err)
 	}
 
-	if filter.Offset > 0 {
-		query += fmt.Sprintf(" OFFSET $%d", argIndex)
-		args = append(args, filter.Offset)
-		argIndex++
+	result := &repomodel.CommentTreePage{
+		CommentPage: repomodel.CommentPage{
+			Comments:        page.items,
+			Cursors:         page.cursors,
+			HasNextPage:     page.hasNext,
+			HasPreviousPage: page.hasPrevious,
+		},
+		ReplyCounts: make(map[uuid.UUID]int),
+	}
+
+	topIDs := make([]uuid.UUID, len(page.items))
+	for i, comment := range page.items {
+		topIDs[i] = comment.ID
+	}
+	if len(topIDs) == 0 {
+		return result, nil
 	}
 
-	rows, err := r.pool.Query(ctx, query, args...)
+	// Фильтр ответов: автор и глубина, пост и родитель задаются деревом
+	replyFilter := repomodel.CommentFilter{AuthorID: filter.AuthorID, MaxDepth: filter.MaxDepth}
+
+	if filter.Levels > 0 && filter.RepliesLimit > 0 {
+		result.Replies, result.ReplyCursors, err = r.listTreeReplies(ctx, topIDs, replyFilter, query, filter.Levels, filter.RepliesLimit)
+		if err != nil {
+			r.logger.Error("Failed to list comment tree replies",
+				zap.Int("count", len(topIDs)),
+				zap.Error(err),
+			)
+			return nil, fmt.Errorf("failed to list comment tree replies: %w", err)
+		}
+	}
+
+	ids := topIDs
+	for _, reply := range result.Replies {
+		ids = append(ids, reply.ID)
+	}
+
+	conditions, args = commentFilterConditions(replyFilter)
+	args = append(args, ids)
+	conditions = append(conditions, fmt.Sprintf("parent_id = ANY($%d)", len(args)))
+
+	rows, err := r.db.Query(ctx,
+		"SELECT parent_id, COUNT(*) FROM comments WHERE "+strings.Join(conditions, " AND ")+" GROUP BY parent_id",
+		args...)
 	if err != nil {
-		r.logger.Error("Failed to list comments", zap.Error(err))
-		return nil, fmt.Errorf("failed to list comments: %w", err)
+		r.logger.Error("Failed to count comment tree replies", zap.Error(err))
+		return nil, fmt.Errorf("failed to count comment tree replies: %w", err)
 	}
 	defer rows.Close()
 
-	var comments []*repomodel.Comment
+	for rows.Next() {
+		var parentID uuid.UUID
+		var count int
+		if err := rows.Scan(&parentID, &count); err != nil {
+			r.logger.Error("Failed to scan comment tree reply count", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan comment tree reply count: %w", err)
+		}
+		result.ReplyCounts[parentID] = count
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error counting comment tree replies", zap.Error(err))
+		return nil, fmt.Errorf("failed to count comment tree replies: %w", err)
+	}
+
+	return result, nil
+}
+
+// listTreeReplies загружает до levels уровней ответов на комментарии rootIDs,
+// не более limit ответов на каждый комментарий, в порядке сортировки страницы page
+func (r *CommentRepository) listTreeReplies(ctx context.Context, rootIDs []uuid.UUID, filter repomodel.CommentFilter, page keysetQuery, levels, limit int) ([]*repomodel.Comment, []repomodel.Cursor, error) {
+	sortExpr, key := page.sortExpr, page.key
+	direction := "ASC"
+	if page.desc {
+		direction = "DESC"
+	}
+
+	conditions, args := commentFilterConditions(filter)
+	conditions = append(conditions, "comments.parent_id = tree.id")
+	args = append(args, rootIDs, limit, levels)
+
+	query := fmt.Sprintf(`
+		WITH RECURSIVE tree AS (
+			SELECT id, 0 AS tree_level FROM comments WHERE id = ANY($%[1]d)
+
+			UNION ALL
+
+			SELECT reply.id, tree.tree_level + 1
+			FROM tree
+			CROSS JOIN LATERAL (
+				SELECT comments.id
+				FROM comments
+				WHERE %[4]s
+				ORDER BY %[5]s %[6]s, comments.id %[6]s
+				LIMIT $%[2]d
+			) reply
+			WHERE tree.tree_level < $%[3]d
+		)
+		SELECT comments.id, comments.post_id, comments.parent_id, comments.content, comments.author_id,
+			comments.depth, comments.language, comments.created_at, comments.updated_at, comments.deleted_at, comments.path::text,
+			%[5]s
+		FROM comments
+		INNER JOIN tree ON tree.id = comments.id
+		WHERE tree.tree_level > 0
+		ORDER BY %[5]s %[6]s, comments.id %[6]s
+	`, len(args)-2, len(args)-1, len(args), strings.Join(conditions, " AND "), sortExpr, direction)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var replies []*repomodel.Comment
+	var cursors []repomodel.Cursor
 	for rows.Next() {
 		var comment repomodel.Comment
-		err := rows.Scan(
+		var sortTime time.Time
+		var sortCount int64
+		dest := []interface{}{
 			&comment.ID,
 			&comment.PostID,
 			&comment.ParentID,
 			&comment.Content,
 			&comment.AuthorID,
 			&comment.Depth,
+			&comment.Language,
 			&comment.CreatedAt,
 			&comment.UpdatedAt,
-		)
-		if err != nil {
-			r.logger.Error("Failed to scan comment", zap.Error(err))
-			return nil, fmt.Errorf("failed to scan comment: %w", err)
+			&comment.DeletedAt,
+			&comment.Path,
+		}
+		if key.IsCount() {
+			dest = append(dest, &sortCount)
+		} else {
+			dest = append(dest, &sortTime)
 		}
-		comments = append(comments, &comment)
-	}
 
-	if err := rows.Err(); err != nil {
-		r.logger.Error("Error iterating comments", zap.Error(err))
-		return nil, fmt.Errorf("error iterating comments: %w", err)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, nil, err
+		}
+		replies = append(replies, &comment)
+		cursors = append(cursors, repomodel.Cursor{Key: key, Time: sortTime, Count: sortCount, ID: comment.ID})
 	}
 
-	return comments, nil
+	return replies, cursors, rows.Err()
 }
 
 // Count подсчитывает общее количество комментариев с фильтрацией
 func (r *CommentRepository) Count(ctx context.Context, filter repomodel.CommentFilter) (int, error) {
+	conditions, args := commentFilterConditions(filter)
+
+	query := "SELECT COUNT(*) FROM comments"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var count int
+	err := r.db.QueryRow(ctx, query, args...).Scan(&count)
+	if err != nil {
+		r.logger.Error("Failed to count comments", zap.Error(err))
+		return 0, fmt.Errorf("failed to count comments: %w", err)
+	}
+
+	return count, nil
+}
+
+// commentFilterConditions возвращает условия фильтра комментариев и их аргументы
+func commentFilterConditions(filter repomodel.CommentFilter) ([]string, []interface{}) {
 	var conditions []string
 	var args []interface{}
-	argIndex := 1
-
-	baseQuery := "SELECT COUNT(*) FROM comments"
 
-	// Добавляем условия фильтрации
 	if filter.PostID != nil {
-		conditions = append(conditions, fmt.Sprintf("post_id = $%d", argIndex))
 		args = append(args, *filter.PostID)
-		argIndex++
+		conditions = append(conditions, fmt.Sprintf("post_id = $%d", len(args)))
 	}
 
 	if filter.ParentID != nil {
-		conditions = append(conditions, fmt.Sprintf("parent_id = $%d", argIndex))
 		args = append(args, *filter.ParentID)
-		argIndex++
+		conditions = append(conditions, fmt.Sprintf("parent_id = $%d", len(args)))
 	}
 
 	if filter.AuthorID != nil {
-		conditions = append(conditions, fmt.Sprintf("author_id = $%d", argIndex))
 		args = append(args, *filter.AuthorID)
-		argIndex++
+		conditions = append(conditions, fmt.Sprintf("author_id = $%d", len(args)))
 	}
 
 	if filter.MaxDepth != nil {
-		conditions = append(conditions, fmt.Sprintf("depth <= $%d", argIndex))
 		args = append(args, *filter.MaxDepth)
-		argIndex++
+		conditions = append(conditions, fmt.Sprintf("depth <= $%d", len(args)))
 	}
 
-	query := baseQuery
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
-	}
+	return conditions, args
+}
 
-	var count int
-	err := r.pool.QueryRow(ctx, query, args...).Scan(&count)
-	if err != nil {
-		r.logger.Error("Failed to count comments", zap.Error(err))
-		return 0, fmt.Errorf("failed to count comments: %w", err)
+// commentSortExpression возвращает SQL выражение ключа сортировки комментариев.
+// Количество ответов хранит счетчик reply_count, который обновляют триггеры миграции 006
+func commentSortExpression(key repomodel.SortKey) (string, error) {
+	switch key.OrDefault() {
+	case repomodel.SortByCreatedAt:
+		return "comments.created_at", nil
+	case repomodel.SortByUpdatedAt:
+		return "comments.updated_at", nil
+	case repomodel.SortByReplyCount:
+		return "comments.reply_count", nil
+	default:
+		return "", fmt.Errorf("unsupported comment sort key %q", key)
 	}
-
-	return count, nil
 }
 
 // Update обновляет комментарий
@@ -261,13 +504,14 @@ func (r *CommentRepository) Update(ctx context.Context, comment *repomodel.Comme
 
 	query := `
 		UPDATE comments
-		SET content = $2, updated_at = $3
+		SET content = $2, language = $3, updated_at = $4
 		WHERE id = $1
 	`
 
-	result, err := r.pool.Exec(ctx, query,
+	result, err := r.db.Exec(ctx, query,
 		comment.ID,
 		comment.Content,
+		repomodel.SearchLanguageOrDefault(comment.Language),
 		comment.UpdatedAt,
 	)
 
@@ -292,7 +536,7 @@ func (r *CommentRepository) Update(ctx context.Context, comment *repomodel.Comme
 func (r *CommentRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := "DELETE FROM comments WHERE id = $1"
 
-	result, err := r.pool.Exec(ctx, query, id)
+	result, err := r.db.Exec(ctx, query, id)
 	if err != nil {
 		r.logger.Error("Failed to delete comment",
 			zap.String("comment_id", id.String()),
@@ -310,12 +554,249 @@ func (r *CommentRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// DeleteSubtree удаляет комментарий со всеми вложенными ответами одним запросом.
+//
+// Поддерево выбирается по материализованному пути и удаляется одним оператором DELETE,
+// поэтому операция атомарна: удаляются либо все комментарии поддерева, либо ни один.
+// Удаленные комментарии возвращаются в порядке (depth, created_at, id).
+func (r *CommentRepository) DeleteSubtree(ctx context.Context, id uuid.UUID) ([]*repomodel.Comment, error) {
+	query := `
+		WITH deleted AS (
+			DELETE FROM comments
+			WHERE path <@ (SELECT path FROM comments WHERE id = $1)
+			RETURNING id, post_id, parent_id, content, author_id, depth, language, created_at, updated_at, deleted_at, path::text
+		)
+		SELECT id, post_id, parent_id, content, author_id, depth, language, created_at, updated_at, deleted_at, path::text
+		FROM deleted
+		ORDER BY depth ASC, created_at ASC, id ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		r.logger.Error("Failed to delete comment subtree",
+			zap.String("comment_id", id.String()),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to delete comment subtree: %w", err)
+	}
+	defer rows.Close()
+
+	var comments []*repomodel.Comment
+	for rows.Next() {
+		var comment repomodel.Comment
+		err := rows.Scan(
+			&comment.ID,
+			&comment.PostID,
+			&comment.ParentID,
+			&comment.Content,
+			&comment.AuthorID,
+			&comment.Depth,
+			&comment.Language,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+			&comment.DeletedAt,
+			&comment.Path,
+		)
+		if err != nil {
+			r.logger.Error("Failed to scan deleted comment", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan deleted comment: %w", err)
+		}
+		comments = append(comments, &comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error deleting comment subtree", zap.Error(err))
+		return nil, fmt.Errorf("failed to delete comment subtree: %w", err)
+	}
+
+	if len(comments) == 0 {
+		return nil, repository.ErrNotFound
+	}
+
+	r.logger.Debug("Comment subtree deleted successfully",
+		zap.String("comment_id", id.String()),
+		zap.Int("deleted_count", len(comments)),
+	)
+	return comments, nil
+}
+
+// SoftDelete выполняет мягкое удаление комментария в транзакции.
+//
+// Комментарий блокируется FOR UPDATE: вставка ответа берет на родителя блокировку
+// FOR KEY SHARE и ждет завершения транзакции, поэтому проверка ответов видит все
+// ответы, а ответ на удаленный лист будет отклонен внешним ключом. Внутри
+// Manager.WithinTx транзакция становится точкой сохранения внешней транзакции.
+func (r *CommentRepository) SoftDelete(ctx context.Context, id uuid.UUID, placeholder string) (*repomodel.Comment, bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to begin soft delete transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			r.logger.Error("Failed to rollback soft delete transaction", zap.Error(err))
+		}
+	}()
+
+	var locked int
+	err = tx.QueryRow(ctx, "SELECT 1 FROM comments WHERE id = $1 FOR UPDATE", id).Scan(&locked)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, repository.ErrNotFound
+		}
+		return nil, false, fmt.Errorf("failed to lock comment: %w", err)
+	}
+
+	// Отдельный запрос после блокировки видит ответы, зафиксированные во время ожидания
+	var hasReplies bool
+	err = tx.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM comments WHERE parent_id = $1)", id).Scan(&hasReplies)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to check comment replies: %w", err)
+	}
+
+	var query string
+	args := []interface{}{id}
+	if hasReplies {
+		query = `
+			UPDATE comments
+			SET content = $2, author_id = $3, deleted_at = COALESCE(deleted_at, NOW())
+			WHERE id = $1
+			RETURNING id, post_id, parent_id, content, author_id, depth, language, created_at, updated_at, deleted_at, path::text
+		`
+		args = append(args, placeholder, uuid.Nil)
+	} else {
+		query = `
+			DELETE FROM comments
+			WHERE id = $1
+			RETURNING id, post_id, parent_id, content, author_id, depth, language, created_at, updated_at, deleted_at, path::text
+		`
+	}
+
+	var comment repomodel.Comment
+	err = tx.QueryRow(ctx, query, args...).Scan(
+		&comment.ID,
+		&comment.PostID,
+		&comment.ParentID,
+		&comment.Content,
+		&comment.AuthorID,
+		&comment.Depth,
+		&comment.Language,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+		&comment.DeletedAt,
+		&comment.Path,
+	)
+	if err != nil {
+		r.logger.Error("Failed to soft delete comment",
+			zap.String("comment_id", id.String()),
+			zap.Error(err),
+		)
+		return nil, false, fmt.Errorf("failed to soft delete comment: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, false, fmt.Errorf("failed to commit soft delete: %w", err)
+	}
+
+	r.logger.Debug("Comment soft deleted successfully",
+		zap.String("comment_id", id.String()),
+		zap.Bool("tombstone", hasReplies),
+	)
+	return &comment, hasReplies, nil
+}
+
+// PruneTombstones удаляет надгробия без ответов, начиная с id и вверх по ветке, в транзакции.
+//
+// Каждое надгробие блокируется FOR UPDATE до проверки ответов: параллельное удаление
+// другого ответа ждет блокировки и после фиксации этой транзакции видит ее результат,
+// поэтому последнее удаление ответа всегда удаляет и надгробие. Внутри Manager.WithinTx
+// транзакция становится точкой сохранения внешней транзакции.
+func (r *CommentRepository) PruneTombstones(ctx context.Context, id uuid.UUID) ([]*repomodel.Comment, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin prune tombstones transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			r.logger.Error("Failed to rollback prune tombstones transaction", zap.Error(err))
+		}
+	}()
+
+	var comments []*repomodel.Comment
+	for {
+		var tombstone bool
+		err = tx.QueryRow(ctx, "SELECT deleted_at IS NOT NULL FROM comments WHERE id = $1 FOR UPDATE", id).Scan(&tombstone)
+		if errors.Is(err, pgx.ErrNoRows) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to lock comment: %w", err)
+		}
+		if !tombstone {
+			break
+		}
+
+		// Отдельный запрос после блокировки видит удаления ответов, зафиксированные во время ожидания
+		var hasReplies bool
+		err = tx.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM comments WHERE parent_id = $1)", id).Scan(&hasReplies)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check comment replies: %w", err)
+		}
+		if hasReplies {
+			break
+		}
+
+		var comment repomodel.Comment
+		err = tx.QueryRow(ctx, `
+			DELETE FROM comments
+			WHERE id = $1
+			RETURNING id, post_id, parent_id, content, author_id, depth, language, created_at, updated_at, deleted_at, path::text
+		`, id).Scan(
+			&comment.ID,
+			&comment.PostID,
+			&comment.ParentID,
+			&comment.Content,
+			&comment.AuthorID,
+			&comment.Depth,
+			&comment.Language,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+			&comment.DeletedAt,
+			&comment.Path,
+		)
+		if err != nil {
+			r.logger.Error("Failed to delete tombstone",
+				zap.String("comment_id", id.String()),
+				zap.Error(err),
+			)
+			return nil, fmt.Errorf("failed to delete tombstone: %w", err)
+		}
+		comments = append(comments, &comment)
+
+		if comment.ParentID == nil {
+			break
+		}
+		id = *comment.ParentID
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit prune tombstones: %w", err)
+	}
+
+	if len(comments) > 0 {
+		r.logger.Debug("Tombstones pruned successfully",
+			zap.String("comment_id", comments[0].ID.String()),
+			zap.Int("pruned_count", len(comments)),
+		)
+	}
+	return comments, nil
+}
+
 // Exists проверяет существование комментария
 func (r *CommentRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
 	query := "SELECT EXISTS(SELECT 1 FROM comments WHERE id = $1)"
 
 	var exists bool
-	err := r.pool.QueryRow(ctx, query, id).Scan(&exists)
+	err := r.db.QueryRow(ctx, query, id).Scan(&exists)
 	if err != nil {
 		r.logger.Error("Failed to check comment existence",
 			zap.String("comment_id", id.String()),
@@ -327,16 +808,17 @@ func (r *CommentRepository) Exists(ctx context.Context, id uuid.UUID) (bool, err
 	return exists, nil
 }
 
-// GetByPostID получает все комментарии к посту (для построения дерева)
+// GetByPostID получает все комментарии к посту в порядке обхода веток в глубину
+// (для построения дерева). Порядок задает материализованный путь, индекс (post_id, path)
 func (r *CommentRepository) GetByPostID(ctx context.Context, postID uuid.UUID) ([]*repomodel.Comment, error) {
 	query := `
-		SELECT id, post_id, parent_id, content, author_id, depth, created_at, updated_at
+		SELECT id, post_id, parent_id, content, author_id, depth, language, created_at, updated_at, deleted_at, path::text
 		FROM comments
 		WHERE post_id = $1
-		ORDER BY depth ASC, created_at ASC
+		ORDER BY path ASC
 	`
 
-	rows, err := r.pool.Query(ctx, query, postID)
+	rows, err := r.db.Query(ctx, query, postID)
 	if err != nil {
 		r.logger.Error("Failed to get comments by post ID",
 			zap.String("post_id", postID.String()),
@@ -356,8 +838,11 @@ func (r *CommentRepository) GetByPostID(ctx context.Context, postID uuid.UUID) (
 			&comment.Content,
 			&comment.AuthorID,
 			&comment.Depth,
+			&comment.Language,
 			&comment.CreatedAt,
 			&comment.UpdatedAt,
+			&comment.DeletedAt,
+			&comment.Path,
 		)
 		if err != nil {
 			r.logger.Error("Failed to scan comment", zap.Error(err))
@@ -374,16 +859,92 @@ func (r *CommentRepository) GetByPostID(ctx context.Context, postID uuid.UUID) (
 	return comments, nil
 }
 
+// GetSubtree получает комментарий со всеми вложенными ответами одним запросом по
+// материализованному пути. Сортировка по path дает обход ветки в глубину
+func (r *CommentRepository) GetSubtree(ctx context.Context, id uuid.UUID) ([]*repomodel.Comment, error) {
+	query := `
+		SELECT id, post_id, parent_id, content, author_id, depth, language, created_at, updated_at, deleted_at, path::text
+		FROM comments
+		WHERE path <@ (SELECT path FROM comments WHERE id = $1)
+		ORDER BY path ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		r.logger.Error("Failed to get comment subtree",
+			zap.String("comment_id", id.String()),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to get comment subtree: %w", err)
+	}
+	defer rows.Close()
+
+	var comments []*repomodel.Comment
+	for rows.Next() {
+		var comment repomodel.Comment
+		err := rows.Scan(
+			&comment.ID,
+			&comment.PostID,
+			&comment.ParentID,
+			&comment.Content,
+			&comment.AuthorID,
+			&comment.Depth,
+			&comment.Language,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+			&comment.DeletedAt,
+			&comment.Path,
+		)
+		if err != nil {
+			r.logger.Error("Failed to scan subtree comment", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan subtree comment: %w", err)
+		}
+		comments = append(comments, &comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating comment subtree", zap.Error(err))
+		return nil, fmt.Errorf("error iterating comment subtree: %w", err)
+	}
+
+	if len(comments) == 0 {
+		return nil, repository.ErrNotFound
+	}
+
+	return comments, nil
+}
+
+// CountSubtree подсчитывает комментарии поддерева, включая сам комментарий
+func (r *CommentRepository) CountSubtree(ctx context.Context, id uuid.UUID) (int, error) {
+	query := "SELECT COUNT(*) FROM comments WHERE path <@ (SELECT path FROM comments WHERE id = $1)"
+
+	var count int
+	err := r.db.QueryRow(ctx, query, id).Scan(&count)
+	if err != nil {
+		r.logger.Error("Failed to count comment subtree",
+			zap.String("comment_id", id.String()),
+			zap.Error(err),
+		)
+		return 0, fmt.Errorf("failed to count comment subtree: %w", err)
+	}
+
+	if count == 0 {
+		return 0, repository.ErrNotFound
+	}
+
+	return count, nil
+}
+
 // GetChildren получает дочерние комментарии
 func (r *CommentRepository) GetChildren(ctx context.Context, parentID uuid.UUID) ([]*repomodel.Comment, error) {
 	query := `
-		SELECT id, post_id, parent_id, content, author_id, depth, created_at, updated_at
+		SELECT id, post_id, parent_id, content, author_id, depth, language, created_at, updated_at, deleted_at, path::text
 		FROM comments
 		WHERE parent_id = $1
 		ORDER BY created_at ASC
 	`
 
-	rows, err := r.pool.Query(ctx, query, parentID)
+	rows, err := r.db.Query(ctx, query, parentID)
 	if err != nil {
 		r.logger.Error("Failed to get child comments",
 			zap.String("parent_id", parentID.String()),
@@ -403,8 +964,11 @@ func (r *CommentRepository) GetChildren(ctx context.Context, parentID uuid.UUID)
 			&comment.Content,
 			&comment.AuthorID,
 			&comment.Depth,
+			&comment.Language,
 			&comment.CreatedAt,
 			&comment.UpdatedAt,
+			&comment.DeletedAt,
+			&comment.Path,
 		)
 		if err != nil {
 			r.logger.Error("Failed to scan child comment", zap.Error(err))
@@ -430,7 +994,7 @@ func (r *CommentRepository) GetMaxDepthForPost(ctx context.Context, postID uuid.
 	`
 
 	var maxDepth int
-	err := r.pool.QueryRow(ctx, query, postID).Scan(&maxDepth)
+	err := r.db.QueryRow(ctx, query, postID).Scan(&maxDepth)
 	if err != nil {
 		r.logger.Error("Failed to get max depth for post",
 			zap.String("post_id", postID.String()),
@@ -446,7 +1010,7 @@ func (r *CommentRepository) GetMaxDepthForPost(ctx context.Context, postID uuid.
 func (r *CommentRepository) DeleteByPostID(ctx context.Context, postID uuid.UUID) error {
 	query := "DELETE FROM comments WHERE post_id = $1"
 
-	result, err := r.pool.Exec(ctx, query, postID)
+	result, err := r.db.Exec(ctx, query, postID)
 	if err != nil {
 		r.logger.Error("Failed to delete comments by post ID",
 			zap.String("post_id", postID.String()),
@@ -469,7 +1033,7 @@ func (r *CommentRepository) CountByPostID(ctx context.Context, postID uuid.UUID)
 	query := "SELECT COUNT(*) FROM comments WHERE post_id = $1"
 
 	var count int
-	err := r.pool.QueryRow(ctx, query, postID).Scan(&count)
+	err := r.db.QueryRow(ctx, query, postID).Scan(&count)
 	if err != nil {
 		r.logger.Error("Failed to count comments by post ID",
 			zap.String("post_id", postID.String()),
@@ -488,7 +1052,7 @@ func (r *CommentRepository) GetCommentsWithPagination(ctx context.Context, postI
 	argIndex := 1
 
 	baseQuery := `
-		SELECT id, post_id, parent_id, content, author_id, depth, created_at, updated_at
+		SELECT id, post_id, parent_id, content, author_id, depth, language, created_at, updated_at, deleted_at, path::text
 		FROM comments
 	`
 
@@ -513,7 +1077,7 @@ func (r *CommentRepository) GetCommentsWithPagination(ctx context.Context, postI
 		args = append(args, limit)
 	}
 
-	rows, err := r.pool.Query(ctx, query, args...)
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		r.logger.Error("Failed to get comments with pagination",
 			zap.String("post_id", postID.String()),
@@ -533,8 +1097,11 @@ func (r *CommentRepository) GetCommentsWithPagination(ctx context.Context, postI
 			&comment.Content,
 			&comment.AuthorID,
 			&comment.Depth,
+			&comment.Language,
 			&comment.CreatedAt,
 			&comment.UpdatedAt,
+			&comment.DeletedAt,
+			&comment.Path,
 		)
 		if err != nil {
 			r.logger.Error("Failed to scan comment", zap.Error(err))
@@ -551,28 +1118,23 @@ func (r *CommentRepository) GetCommentsWithPagination(ctx context.Context, postI
 	return comments, nil
 }
 
-// GetCommentPath получает путь от корневого комментария до указанного
-func (r *CommentRepository) GetCommentPath(ctx context.Context, commentID uuid.UUID) ([]*repomodel.Comment, error) {
+// GetCommentPath получает путь к комментарию от самого дальнего из не более чем
+// maxAncestors ближайших предков. Предки выбираются одним запросом по материализованному
+// пути (GiST индекс), поэтому стоимость запроса не зависит от глубины комментария
+func (r *CommentRepository) GetCommentPath(ctx context.Context, commentID uuid.UUID, maxAncestors int) ([]*repomodel.Comment, error) {
 	query := `
-		WITH RECURSIVE comment_path AS (
-			-- Базовый случай: начинаем с указанного комментария
-			SELECT id, post_id, parent_id, content, author_id, depth, created_at, updated_at, 0 as level
-			FROM comments
-			WHERE id = $1
-
-			UNION ALL
-
-			-- Рекурсивный случай: поднимаемся к родителям
-			SELECT c.id, c.post_id, c.parent_id, c.content, c.author_id, c.depth, c.created_at, c.updated_at, cp.level + 1
-			FROM comments c
-			INNER JOIN comment_path cp ON c.id = cp.parent_id
+		WITH target AS (
+			SELECT path FROM comments WHERE id = $1
 		)
-		SELECT id, post_id, parent_id, content, author_id, depth, created_at, updated_at
-		FROM comment_path
-		ORDER BY level DESC
+		SELECT c.id, c.post_id, c.parent_id, c.content, c.author_id, c.depth, c.language,
+			c.created_at, c.updated_at, c.deleted_at, c.path::text
+		FROM comments c, target
+		WHERE c.path @> target.path
+			AND nlevel(c.path) >= nlevel(target.path) - $2
+		ORDER BY nlevel(c.path) ASC
 	`
 
-	rows, err := r.pool.Query(ctx, query, commentID)
+	rows, err := r.db.Query(ctx, query, commentID, maxAncestors)
 	if err != nil {
 		r.logger.Error("Failed to get comment path",
 			zap.String("comment_id", commentID.String()),
@@ -592,8 +1154,11 @@ func (r *CommentRepository) GetCommentPath(ctx context.Context, commentID uuid.U
 			&comment.Content,
 			&comment.AuthorID,
 			&comment.Depth,
+			&comment.Language,
 			&comment.CreatedAt,
 			&comment.UpdatedAt,
+			&comment.DeletedAt,
+			&comment.Path,
 		)
 		if err != nil {
 			r.logger.Error("Failed to scan comment in path", zap.Error(err))
@@ -0,0 +1,249 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/NarthurN/habbr/internal/repository"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// CommentEventRepository реализует repository.CommentEventRepository для PostgreSQL.
+//
+// События хранятся в таблице comment_events; идентификатор BIGSERIAL общий для всех
+// экземпляров сервиса, поэтому события можно повторно получить на любой реплике.
+//
+// Значения BIGSERIAL выдаются до фиксации, и без синхронизации событие с меньшим ID
+// могло бы стать видимым позже события с большим: клиент, получивший большее, пропустил
+// бы меньшее при повторной доставке. Поэтому вставка событий поста выполняется под
+// транзакционной advisory блокировкой поста, и события одного поста фиксируются в
+// порядке ID. Граница удаленных из журнала событий хранится в comment_event_horizons.
+type CommentEventRepository struct {
+	db        DBTX
+	retention int
+	logger    *zap.Logger
+}
+
+// NewCommentEventRepository создает новый PostgreSQL журнал событий комментариев
+func NewCommentEventRepository(db DBTX, retention int, logger *zap.Logger) repository.CommentEventRepository {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	if retention <= 0 {
+		retention = repository.CommentEventRetention
+	}
+	return &CommentEventRepository{
+		db:        db,
+		retention: retention,
+		logger:    logger,
+	}
+}
+
+// Append сохраняет событие и удаляет события поста, вышедшие за пределы журнала
+func (r *CommentEventRepository) Append(ctx context.Context, event *repomodel.CommentEvent) error {
+	return r.AppendBatch(ctx, []*repomodel.CommentEvent{event})
+}
+
+// AppendBatch сохраняет события одним запросом и удаляет события их постов,
+// вышедшие за пределы журнала
+func (r *CommentEventRepository) AppendBatch(ctx context.Context, events []*repomodel.CommentEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	postIDs := make([]uuid.UUID, 0, 1)
+	seen := make(map[uuid.UUID]bool)
+	for _, event := range events {
+		if event == nil {
+			return fmt.Errorf("event cannot be nil")
+		}
+		if !seen[event.PostID] {
+			seen[event.PostID] = true
+			postIDs = append(postIDs, event.PostID)
+		}
+	}
+
+	if err := r.insert(ctx, postIDs, events); err != nil {
+		r.logger.Error("Failed to append comment events",
+			zap.Int("count", len(events)),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to append comment events: %w", err)
+	}
+
+	for _, postID := range postIDs {
+		r.trim(ctx, postID)
+	}
+
+	return nil
+}
+
+// insert сохраняет события в транзакции под advisory блокировками их постов: ID выдаются
+// после получения блокировок, а блокировки снимаются после фиксации, поэтому события
+// поста фиксируются в порядке ID. Блокировки берутся в порядке ключей, чтобы пакеты
+// с общими постами не блокировали друг друга взаимно. Внутри Manager.WithinTx транзакция
+// становится точкой сохранения, и блокировки удерживаются до фиксации внешней транзакции
+func (r *CommentEventRepository) insert(ctx context.Context, postIDs []uuid.UUID, events []*repomodel.CommentEvent) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			r.logger.Error("Failed to rollback comment event transaction", zap.Error(err))
+		}
+	}()
+
+	lockQuery := `
+		SELECT pg_advisory_xact_lock(key)
+		FROM (
+			SELECT DISTINCT hashtextextended(post_id::text, 0) AS key
+			FROM unnest($1::uuid[]) AS post_id
+			ORDER BY key
+		) AS locks
+	`
+	if _, err := tx.Exec(ctx, lockQuery, postIDs); err != nil {
+		return fmt.Errorf("failed to lock post events: %w", err)
+	}
+
+	eventPostIDs := make([]uuid.UUID, len(events))
+	actionTypes := make([]string, len(events))
+	payloads := make([]string, len(events))
+	for i, event := range events {
+		eventPostIDs[i] = event.PostID
+		actionTypes[i] = event.ActionType
+		payloads[i] = string(event.Payload)
+	}
+
+	// Строки вставляются в порядке ordinality, поэтому возрастающие ID
+	// соответствуют порядку событий в срезе
+	query := `
+		INSERT INTO comment_events (post_id, action_type, payload)
+		SELECT post_id, action_type, payload::jsonb
+		FROM unnest($1::uuid[], $2::text[], $3::text[]) WITH ORDINALITY AS e(post_id, action_type, payload, ord)
+		ORDER BY ord
+		RETURNING id, created_at
+	`
+	rows, err := tx.Query(ctx, query, eventPostIDs, actionTypes, payloads)
+	if err != nil {
+		return err
+	}
+
+	type inserted struct {
+		id        int64
+		createdAt time.Time
+	}
+	results := make([]inserted, 0, len(events))
+	for rows.Next() {
+		var result inserted
+		if err := rows.Scan(&result.id, &result.createdAt); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan comment event: %w", err)
+		}
+		results = append(results, result)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(results) != len(events) {
+		return fmt.Errorf("inserted %d comment events, expected %d", len(results), len(events))
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	// Порядок строк RETURNING не гарантирован, сопоставляем по возрастанию ID
+	sort.Slice(results, func(i, j int) bool { return results[i].id < results[j].id })
+	for i, event := range events {
+		event.ID = results[i].id
+		event.CreatedAt = results[i].createdAt
+	}
+
+	return nil
+}
+
+// trim удаляет самые старые события поста сверх лимита и сдвигает границу удаленных
+func (r *CommentEventRepository) trim(ctx context.Context, postID uuid.UUID) {
+	query := `
+		WITH trimmed AS (
+			DELETE FROM comment_events
+			WHERE post_id = $1 AND id <= (
+				SELECT id FROM comment_events
+				WHERE post_id = $1
+				ORDER BY id DESC
+				OFFSET $2 LIMIT 1
+			)
+			RETURNING id
+		)
+		INSERT INTO comment_event_horizons (post_id, trimmed_id)
+		SELECT $1, MAX(id) FROM trimmed HAVING COUNT(*) > 0
+		ON CONFLICT (post_id) DO UPDATE
+		SET trimmed_id = GREATEST(comment_event_horizons.trimmed_id, EXCLUDED.trimmed_id)
+	`
+	if _, err := r.db.Exec(ctx, query, postID, r.retention); err != nil {
+		// События уже сохранены, лишние записи будут удалены при следующей вставке
+		r.logger.Warn("Failed to trim comment events",
+			zap.String("post_id", postID.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+// ListAfter возвращает события поста с ID больше afterID в порядке возрастания или
+// repository.ErrEventCursorExpired, если события после afterID уже удалены из журнала
+func (r *CommentEventRepository) ListAfter(ctx context.Context, postID uuid.UUID, afterID int64, limit int) ([]*repomodel.CommentEvent, error) {
+	if limit <= 0 {
+		limit = r.retention
+	}
+
+	query := `
+		SELECT id, post_id, action_type, payload, created_at
+		FROM comment_events
+		WHERE post_id = $1 AND id > $2
+		ORDER BY id ASC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, postID, afterID, limit)
+	if err != nil {
+		r.logger.Error("Failed to list comment events", zap.Error(err))
+		return nil, fmt.Errorf("failed to list comment events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*repomodel.CommentEvent, 0)
+	for rows.Next() {
+		event := &repomodel.CommentEvent{}
+		if err := rows.Scan(&event.ID, &event.PostID, &event.ActionType, &event.Payload, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan comment event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate comment events: %w", err)
+	}
+	rows.Close()
+
+	// Граница проверяется после чтения событий: сокращение журнала во время чтения
+	// сдвигает границу, и курсор считается устаревшим, а не теряет события молча
+	var trimmedID int64
+	err = r.db.QueryRow(ctx, "SELECT trimmed_id FROM comment_event_horizons WHERE post_id = $1", postID).Scan(&trimmedID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		r.logger.Error("Failed to get comment events horizon", zap.Error(err))
+		return nil, fmt.Errorf("failed to get comment events horizon: %w", err)
+	}
+	if afterID < trimmedID {
+		return nil, repository.ErrEventCursorExpired
+	}
+
+	return events, nil
+}
@@ -5,11 +5,19 @@ package postgres
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sync"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/NarthurN/habbr/internal/config"
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/NarthurN/habbr/internal/repository"
 	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+	"github.com/NarthurN/habbr/migrations"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -133,21 +141,56 @@ func TestPostRepository_Integration(t *testing.T) {
 		}
 
 		// Тестируем список без фильтров
+		first := 10
 		filter := repomodel.PostFilter{
-			Limit: 10,
+			Page: repomodel.Page{First: &first},
 		}
 		result, err := repo.List(ctx, filter)
 		require.NoError(t, err)
-		assert.GreaterOrEqual(t, len(result), 2)
+		assert.GreaterOrEqual(t, len(result.Posts), 2)
 
 		// Тестируем фильтр по автору
 		filter = repomodel.PostFilter{
 			AuthorID: &authorID,
-			Limit:    10,
+			Page:     repomodel.Page{First: &first},
 		}
 		result, err = repo.List(ctx, filter)
 		require.NoError(t, err)
-		assert.Len(t, result, 2)
+		assert.Len(t, result.Posts, 2)
+
+		// Тестируем keyset-пагинацию: по одному посту, новые первыми
+		one := 1
+		page, err := repo.List(ctx, repomodel.PostFilter{
+			AuthorID: &authorID,
+			Page:     repomodel.Page{First: &one},
+		})
+		require.NoError(t, err)
+		require.Len(t, page.Posts, 1)
+		assert.Equal(t, posts[1].ID, page.Posts[0].ID)
+		assert.True(t, page.HasNextPage)
+		assert.False(t, page.HasPreviousPage)
+
+		after := page.Cursors[0]
+		page, err = repo.List(ctx, repomodel.PostFilter{
+			AuthorID: &authorID,
+			Page:     repomodel.Page{First: &one, After: &after},
+		})
+		require.NoError(t, err)
+		require.Len(t, page.Posts, 1)
+		assert.Equal(t, posts[0].ID, page.Posts[0].ID)
+		assert.False(t, page.HasNextPage)
+		assert.True(t, page.HasPreviousPage)
+
+		before := page.Cursors[0]
+		page, err = repo.List(ctx, repomodel.PostFilter{
+			AuthorID: &authorID,
+			Page:     repomodel.Page{Last: &one, Before: &before},
+		})
+		require.NoError(t, err)
+		require.Len(t, page.Posts, 1)
+		assert.Equal(t, posts[1].ID, page.Posts[0].ID)
+		assert.True(t, page.HasNextPage)
+		assert.False(t, page.HasPreviousPage)
 
 		// Тестируем подсчет
 		count, err := repo.Count(ctx, filter)
@@ -250,6 +293,49 @@ func TestCommentRepository_Integration(t *testing.T) {
 		assert.Len(t, children, 1)
 		assert.Equal(t, childComment.ID, children[0].ID)
 
+		// Сортировка по количеству ответов: корневой комментарий имеет один ответ
+		page, err := commentRepo.List(ctx, repomodel.CommentFilter{
+			PostID:   &post.ID,
+			OrderBy:  repomodel.SortByReplyCount,
+			OrderDir: "desc",
+		})
+		require.NoError(t, err)
+		require.Len(t, page.Comments, 2)
+		assert.Equal(t, rootComment.ID, page.Comments[0].ID)
+		assert.Equal(t, int64(1), page.Cursors[0].Count)
+
+		// Сортировка постов по количеству комментариев с курсором
+		one := 1
+		posts, err := postRepo.List(ctx, repomodel.PostFilter{
+			AuthorID: &post.AuthorID,
+			OrderBy:  repomodel.SortByCommentCount,
+			Page:     repomodel.Page{First: &one},
+		})
+		require.NoError(t, err)
+		require.Len(t, posts.Posts, 1)
+		assert.Equal(t, int64(2), posts.Cursors[0].Count)
+
+		// Счетчики обновляются триггерами при вставке и удалении и не изменяют updated_at поста
+		storedPost, err := postRepo.GetByID(ctx, post.ID)
+		require.NoError(t, err)
+		extra := &repomodel.Comment{
+			ID: uuid.New(), PostID: post.ID, ParentID: &rootComment.ID, Content: "Extra reply",
+			AuthorID: uuid.New(), Depth: 1, CreatedAt: time.Now(), UpdatedAt: time.Now(),
+		}
+		require.NoError(t, commentRepo.Create(ctx, extra))
+		require.NoError(t, commentRepo.Delete(ctx, extra.ID))
+		afterCounters, err := postRepo.GetByID(ctx, post.ID)
+		require.NoError(t, err)
+		assert.True(t, storedPost.UpdatedAt.Equal(afterCounters.UpdatedAt))
+
+		var commentCount, replyCount int64
+		require.NoError(t, manager.Pool().QueryRow(ctx,
+			"SELECT p.comment_count, c.reply_count FROM posts p, comments c WHERE p.id = $1 AND c.id = $2",
+			post.ID, rootComment.ID,
+		).Scan(&commentCount, &replyCount))
+		assert.Equal(t, int64(2), commentCount)
+		assert.Equal(t, int64(1), replyCount)
+
 		// Получаем максимальную глубину
 		maxDepth, err := commentRepo.GetMaxDepthForPost(ctx, post.ID)
 		require.NoError(t, err)
@@ -260,6 +346,72 @@ func TestCommentRepository_Integration(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, 2, count)
 
+		// Пакетная загрузка: страница для каждого поста, в том числе без комментариев
+		emptyPostID := uuid.New()
+		batch, err := commentRepo.ListByPostIDs(ctx, []uuid.UUID{post.ID, emptyPostID}, repomodel.CommentFilter{
+			Page: repomodel.Page{First: &one},
+		})
+		require.NoError(t, err)
+		require.Len(t, batch, 2)
+		require.Len(t, batch[post.ID].Comments, 1)
+		assert.Equal(t, rootComment.ID, batch[post.ID].Comments[0].ID)
+		assert.True(t, batch[post.ID].HasNextPage)
+		assert.Empty(t, batch[emptyPostID].Comments)
+
+		replies, err := commentRepo.ListByParentIDs(ctx, []uuid.UUID{rootComment.ID, childComment.ID}, repomodel.CommentFilter{})
+		require.NoError(t, err)
+		require.Len(t, replies[rootComment.ID].Comments, 1)
+		assert.Equal(t, childComment.ID, replies[rootComment.ID].Comments[0].ID)
+		assert.Empty(t, replies[childComment.ID].Comments)
+
+		// Мягкое удаление: корень с ответом становится надгробием
+		tombstone, isTombstone, err := commentRepo.SoftDelete(ctx, rootComment.ID, "[deleted]")
+		require.NoError(t, err)
+		assert.True(t, isTombstone)
+		assert.Equal(t, "[deleted]", tombstone.Content)
+		assert.Equal(t, uuid.Nil, tombstone.AuthorID)
+		assert.NotNil(t, tombstone.DeletedAt)
+
+		stored, err := commentRepo.GetByID(ctx, rootComment.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, stored.DeletedAt)
+
+		// Удаление поддерева возвращает все удаленные комментарии, начиная с корня
+		grandchild := &repomodel.Comment{
+			ID:        uuid.New(),
+			PostID:    post.ID,
+			ParentID:  &childComment.ID,
+			Content:   "Grandchild comment",
+			AuthorID:  uuid.New(),
+			Depth:     2,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		require.NoError(t, commentRepo.Create(ctx, grandchild))
+
+		deleted, err := commentRepo.DeleteSubtree(ctx, childComment.ID)
+		require.NoError(t, err)
+		require.Len(t, deleted, 2)
+		assert.Equal(t, childComment.ID, deleted[0].ID)
+		assert.Equal(t, grandchild.ID, deleted[1].ID)
+
+		_, err = commentRepo.DeleteSubtree(ctx, childComment.ID)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+
+		count, err = commentRepo.CountByPostID(ctx, post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		// Надгробие без ответов удаляется
+		pruned, err := commentRepo.PruneTombstones(ctx, rootComment.ID)
+		require.NoError(t, err)
+		require.Len(t, pruned, 1)
+		assert.Equal(t, rootComment.ID, pruned[0].ID)
+
+		count, err = commentRepo.CountByPostID(ctx, post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+
 		// Удаляем все комментарии к посту
 		err = commentRepo.DeleteByPostID(ctx, post.ID)
 		require.NoError(t, err)
@@ -272,6 +424,387 @@ func TestCommentRepository_Integration(t *testing.T) {
 		// Очищаем тестовый пост
 		postRepo.Delete(ctx, post.ID)
 	})
+
+	t.Run("Comment Tree", func(t *testing.T) {
+		post := &repomodel.Post{
+			ID:              uuid.New(),
+			Title:           "Test Post for Comment Tree",
+			Content:         "Content",
+			AuthorID:        uuid.New(),
+			CommentsEnabled: true,
+			CreatedAt:       time.Now(),
+			UpdatedAt:       time.Now(),
+		}
+		require.NoError(t, postRepo.Create(ctx, post))
+		defer postRepo.Delete(ctx, post.ID)
+
+		// Комментарии создаются с возрастающим временем для детерминированного порядка
+		created := time.Now()
+		create := func(parent *repomodel.Comment) *repomodel.Comment {
+			created = created.Add(time.Second)
+			comment := &repomodel.Comment{
+				ID:        uuid.New(),
+				PostID:    post.ID,
+				Content:   "Tree comment",
+				AuthorID:  uuid.New(),
+				CreatedAt: created,
+				UpdatedAt: created,
+			}
+			if parent != nil {
+				comment.ParentID = &parent.ID
+				comment.Depth = parent.Depth + 1
+			}
+			require.NoError(t, commentRepo.Create(ctx, comment))
+			return comment
+		}
+
+		root := create(nil)
+		first := create(root)
+		second := create(root)
+		create(root)
+		nested := create(first)
+		create(nil)
+
+		one := 1
+		tree, err := commentRepo.ListTree(ctx, repomodel.CommentTreeFilter{
+			CommentFilter: repomodel.CommentFilter{PostID: &post.ID, Page: repomodel.Page{First: &one}},
+			Levels:        1,
+			RepliesLimit:  2,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, tree.Comments, 1)
+		assert.Equal(t, root.ID, tree.Comments[0].ID)
+		assert.True(t, tree.HasNextPage)
+
+		// Второй уровень не загружается, но ответы на него подсчитаны
+		require.Len(t, tree.Replies, 2)
+		assert.Equal(t, first.ID, tree.Replies[0].ID)
+		assert.Equal(t, second.ID, tree.Replies[1].ID)
+		assert.Equal(t, second.ID, tree.ReplyCursors[1].ID)
+		assert.Equal(t, 3, tree.ReplyCounts[root.ID])
+		assert.Equal(t, 1, tree.ReplyCounts[first.ID])
+
+		// Ветка ответов на комментарий после курсора
+		tree, err = commentRepo.ListTree(ctx, repomodel.CommentTreeFilter{
+			CommentFilter: repomodel.CommentFilter{
+				PostID:   &post.ID,
+				ParentID: &root.ID,
+				Page:     repomodel.Page{After: &tree.ReplyCursors[0]},
+			},
+			Levels:       2,
+			RepliesLimit: 2,
+		})
+		require.NoError(t, err)
+		require.Len(t, tree.Comments, 2)
+		assert.Equal(t, second.ID, tree.Comments[0].ID)
+		assert.True(t, tree.HasPreviousPage)
+		assert.Empty(t, tree.Replies)
+
+		tree, err = commentRepo.ListTree(ctx, repomodel.CommentTreeFilter{
+			CommentFilter: repomodel.CommentFilter{PostID: &post.ID, ParentID: &root.ID},
+			Levels:        2,
+			RepliesLimit:  2,
+		})
+		require.NoError(t, err)
+		require.Len(t, tree.Replies, 1)
+		assert.Equal(t, nested.ID, tree.Replies[0].ID)
+	})
+
+	t.Run("Comment Path", func(t *testing.T) {
+		post := &repomodel.Post{
+			ID:              uuid.New(),
+			Title:           "Test Post for Comment Path",
+			Content:         "Content",
+			AuthorID:        uuid.New(),
+			CommentsEnabled: true,
+			CreatedAt:       time.Now(),
+			UpdatedAt:       time.Now(),
+		}
+		require.NoError(t, postRepo.Create(ctx, post))
+		defer postRepo.Delete(ctx, post.ID)
+
+		// Цепочка из четырех комментариев: chain[0] - корень
+		var chain []*repomodel.Comment
+		for i := 0; i < 4; i++ {
+			comment := &repomodel.Comment{
+				ID:        uuid.New(),
+				PostID:    post.ID,
+				Content:   "Path comment",
+				AuthorID:  uuid.New(),
+				Depth:     i,
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			}
+			if i > 0 {
+				comment.ParentID = &chain[i-1].ID
+			}
+			require.NoError(t, commentRepo.Create(ctx, comment))
+			chain = append(chain, comment)
+		}
+
+		pathIDs := func(path []*repomodel.Comment) []uuid.UUID {
+			var ids []uuid.UUID
+			for _, comment := range path {
+				ids = append(ids, comment.ID)
+			}
+			return ids
+		}
+
+		path, err := commentRepo.GetCommentPath(ctx, chain[3].ID, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{chain[1].ID, chain[2].ID, chain[3].ID}, pathIDs(path))
+
+		path, err = commentRepo.GetCommentPath(ctx, chain[3].ID, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{chain[0].ID, chain[1].ID, chain[2].ID, chain[3].ID}, pathIDs(path))
+
+		path, err = commentRepo.GetCommentPath(ctx, uuid.New(), 10)
+		require.NoError(t, err)
+		assert.Empty(t, path)
+	})
+
+	t.Run("Comment Subtree", func(t *testing.T) {
+		post := &repomodel.Post{
+			ID:              uuid.New(),
+			Title:           "Test Post for Comment Subtree",
+			Content:         "Content",
+			AuthorID:        uuid.New(),
+			CommentsEnabled: true,
+			CreatedAt:       time.Now(),
+			UpdatedAt:       time.Now(),
+		}
+		require.NoError(t, postRepo.Create(ctx, post))
+		defer postRepo.Delete(ctx, post.ID)
+
+		// Время с точностью до микросекунд, как хранит PostgreSQL
+		base := time.Now().Truncate(time.Microsecond)
+		create := func(parent *repomodel.Comment, offset time.Duration) *repomodel.Comment {
+			comment := &repomodel.Comment{
+				ID:        uuid.New(),
+				PostID:    post.ID,
+				Content:   "Subtree comment",
+				AuthorID:  uuid.New(),
+				CreatedAt: base.Add(offset),
+				UpdatedAt: base.Add(offset),
+			}
+			if parent != nil {
+				comment.ParentID = &parent.ID
+				comment.Depth = parent.Depth + 1
+			}
+			require.NoError(t, commentRepo.Create(ctx, comment))
+			return comment
+		}
+		ids := func(comments []*repomodel.Comment) []uuid.UUID {
+			var result []uuid.UUID
+			for _, comment := range comments {
+				result = append(result, comment.ID)
+			}
+			return result
+		}
+
+		// root -> first -> nested, root -> second; other - отдельная ветка
+		root := create(nil, 0)
+		other := create(nil, time.Second)
+		first := create(root, 2*time.Second)
+		second := create(root, 3*time.Second)
+		nested := create(first, 4*time.Second)
+
+		// Путь, вычисленный триггером, совпадает с путем memory репозитория
+		rootPath, err := repomodel.CommentPath("", root.ID, root.CreatedAt)
+		require.NoError(t, err)
+		assert.Equal(t, rootPath, root.Path)
+		stored, err := commentRepo.GetByID(ctx, nested.ID)
+		require.NoError(t, err)
+		nestedPath, err := repomodel.CommentPath(first.Path, nested.ID, nested.CreatedAt)
+		require.NoError(t, err)
+		assert.Equal(t, nestedPath, stored.Path)
+
+		// Триггер guard_comments_path не дает изменить родителя или время создания
+		_, err = manager.Pool().Exec(ctx, "UPDATE comments SET parent_id = $1 WHERE id = $2", second.ID, nested.ID)
+		assert.Error(t, err)
+		_, err = manager.Pool().Exec(ctx, "UPDATE comments SET created_at = created_at - interval '1 second' WHERE id = $1", nested.ID)
+		assert.Error(t, err)
+
+		comments, err := commentRepo.GetByPostID(ctx, post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{root.ID, first.ID, nested.ID, second.ID, other.ID}, ids(comments))
+
+		subtree, err := commentRepo.GetSubtree(ctx, root.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{root.ID, first.ID, nested.ID, second.ID}, ids(subtree))
+
+		count, err := commentRepo.CountSubtree(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		_, err = commentRepo.GetSubtree(ctx, uuid.New())
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+
+		deleted, err := commentRepo.DeleteSubtree(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{first.ID, nested.ID}, ids(deleted))
+
+		count, err = commentRepo.CountSubtree(ctx, root.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("Comment Stats", func(t *testing.T) {
+		statsRepo := manager.GetRepositories().Stats
+
+		post := &repomodel.Post{
+			ID:              uuid.New(),
+			Title:           "Test Post for Comment Stats",
+			Content:         "Content",
+			AuthorID:        uuid.New(),
+			CommentsEnabled: true,
+			CreatedAt:       time.Now(),
+			UpdatedAt:       time.Now(),
+		}
+		require.NoError(t, postRepo.Create(ctx, post))
+		defer postRepo.Delete(ctx, post.ID)
+
+		stats, err := statsRepo.GetCommentStats(ctx, post.ID)
+		require.NoError(t, err)
+		assert.Zero(t, stats.TotalComments)
+		assert.Nil(t, stats.LastCommentAt)
+		assert.Empty(t, stats.DepthHistogram)
+
+		author := uuid.New()
+		created := time.Now().Truncate(time.Microsecond)
+		create := func(parent *repomodel.Comment, authorID uuid.UUID) *repomodel.Comment {
+			created = created.Add(time.Second)
+			comment := &repomodel.Comment{
+				ID:        uuid.New(),
+				PostID:    post.ID,
+				Content:   "Stats comment",
+				AuthorID:  authorID,
+				CreatedAt: created,
+				UpdatedAt: created,
+			}
+			if parent != nil {
+				comment.ParentID = &parent.ID
+				comment.Depth = parent.Depth + 1
+			}
+			require.NoError(t, commentRepo.Create(ctx, comment))
+			return comment
+		}
+
+		root := create(nil, author)
+		reply := create(root, author)
+		create(reply, uuid.New())
+		last := create(nil, uuid.New())
+
+		// Надгробие учитывается в счетчиках, но не среди авторов
+		_, tombstone, err := commentRepo.SoftDelete(ctx, reply.ID, "deleted")
+		require.NoError(t, err)
+		require.True(t, tombstone)
+
+		stats, err = statsRepo.GetCommentStats(ctx, post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 4, stats.TotalComments)
+		assert.Equal(t, 2, stats.RootComments)
+		assert.Equal(t, 2, stats.MaxDepth)
+		assert.InDelta(t, 0.75, stats.AverageDepth, 1e-9)
+		assert.Equal(t, 3, stats.UniqueCommenters)
+		require.NotNil(t, stats.LastCommentAt)
+		assert.True(t, last.CreatedAt.Equal(*stats.LastCommentAt))
+		assert.Equal(t, []repomodel.DepthCount{
+			{Depth: 0, Count: 2},
+			{Depth: 1, Count: 1},
+			{Depth: 2, Count: 1},
+		}, stats.DepthHistogram)
+	})
+
+	t.Run("Post Analytics", func(t *testing.T) {
+		analyticsRepo := manager.GetRepositories().Analytics
+
+		// Отдельный автор отделяет посты теста от остальных строк представления
+		author := uuid.New()
+		created := time.Now().Truncate(time.Microsecond)
+		newPost := func() *repomodel.Post {
+			created = created.Add(time.Second)
+			post := &repomodel.Post{
+				ID:              uuid.New(),
+				Title:           "Test Post for Analytics",
+				Content:         "Content",
+				AuthorID:        author,
+				CommentsEnabled: true,
+				CreatedAt:       created,
+				UpdatedAt:       created,
+			}
+			require.NoError(t, postRepo.Create(ctx, post))
+			return post
+		}
+
+		commented := newPost()
+		defer postRepo.Delete(ctx, commented.ID)
+		empty := newPost()
+		defer postRepo.Delete(ctx, empty.ID)
+
+		root := &repomodel.Comment{
+			ID: uuid.New(), PostID: commented.ID, Content: "Root", AuthorID: uuid.New(),
+			CreatedAt: created.Add(time.Second), UpdatedAt: created.Add(time.Second),
+		}
+		require.NoError(t, commentRepo.Create(ctx, root))
+		reply := &repomodel.Comment{
+			ID: uuid.New(), PostID: commented.ID, ParentID: &root.ID, Depth: 1, Content: "Reply",
+			AuthorID: uuid.New(), CreatedAt: created.Add(2 * time.Second), UpdatedAt: created.Add(2 * time.Second),
+		}
+		require.NoError(t, commentRepo.Create(ctx, reply))
+
+		filter := repomodel.PostAnalyticsFilter{
+			AuthorID: &author,
+			Sort:     repomodel.PostAnalyticsSortTotalComments,
+			Limit:    10,
+		}
+
+		// До обновления представление не содержит новых постов
+		items, err := analyticsRepo.List(ctx, filter)
+		require.NoError(t, err)
+		assert.Empty(t, items)
+
+		require.NoError(t, analyticsRepo.Refresh(ctx))
+
+		items, err = analyticsRepo.List(ctx, filter)
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		assert.Equal(t, commented.ID, items[0].PostID)
+		assert.Equal(t, 2, items[0].TotalComments)
+		assert.Equal(t, 1, items[0].RootComments)
+		assert.Equal(t, 1, items[0].MaxCommentDepth)
+		require.NotNil(t, items[0].LastCommentAt)
+		assert.True(t, reply.CreatedAt.Equal(*items[0].LastCommentAt))
+		assert.Equal(t, empty.ID, items[1].PostID)
+		assert.Zero(t, items[1].MaxCommentDepth)
+		assert.Nil(t, items[1].LastCommentAt)
+
+		// Надгробие учитывается в представлении и в счетчике comment_count, как в in-memory хранилище
+		_, tombstone, err := commentRepo.SoftDelete(ctx, root.ID, "[deleted]")
+		require.NoError(t, err)
+		require.True(t, tombstone)
+		require.NoError(t, analyticsRepo.Refresh(ctx))
+
+		items, err = analyticsRepo.List(ctx, filter)
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		assert.Equal(t, 2, items[0].TotalComments)
+		assert.Equal(t, 1, items[0].RootComments)
+
+		var commentCount int
+		err = manager.Pool().QueryRow(ctx, "SELECT comment_count FROM posts WHERE id = $1", commented.ID).Scan(&commentCount)
+		require.NoError(t, err)
+		assert.Equal(t, 2, commentCount)
+
+		minComments := 1
+		filter.Sort = repomodel.PostAnalyticsSortCreatedAt
+		filter.MinComments = &minComments
+		items, err = analyticsRepo.List(ctx, filter)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, commented.ID, items[0].PostID)
+	})
 }
 
 // TestManager_Migration тестирует систему миграций
@@ -310,3 +843,546 @@ func TestManager_Migration(t *testing.T) {
 	err = manager.HealthCheck(ctx)
 	require.NoError(t, err)
 }
+
+// TestMigrator_Integration тестирует откат, повторное применение и обнаружение изменений миграций
+func TestMigrator_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	cfg := &config.DatabaseConfig{
+		Host:           "localhost",
+		Port:           5432,
+		Name:           "habbr_test",
+		User:           "postgres",
+		Password:       "password",
+		SSLMode:        "disable",
+		MaxConnections: 5,
+		MaxIdleTime:    time.Minute,
+		MaxLifetime:    time.Hour,
+	}
+
+	manager, err := NewManager(ctx, cfg, logger)
+	require.NoError(t, err)
+	defer manager.Close(ctx)
+
+	require.NoError(t, manager.Migrate(ctx))
+
+	migrator, err := manager.Migrator()
+	require.NoError(t, err)
+
+	t.Run("status after up", func(t *testing.T) {
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, statuses)
+		for _, status := range statuses {
+			assert.True(t, status.Applied, "migration %d", status.Version)
+			assert.False(t, status.Drifted, "migration %d", status.Version)
+		}
+	})
+
+	t.Run("down and up", func(t *testing.T) {
+		reverted, err := migrator.Down(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, reverted)
+
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		assert.False(t, statuses[len(statuses)-1].Applied)
+
+		applied, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, applied)
+	})
+
+	t.Run("redo", func(t *testing.T) {
+		require.NoError(t, migrator.Redo(ctx))
+
+		applied, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, applied)
+	})
+
+	t.Run("language backfill matches DetectLanguage", func(t *testing.T) {
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		steps := 0
+		for _, status := range statuses {
+			if status.Version >= 4 {
+				steps++
+			}
+		}
+		_, err = migrator.Down(ctx, steps)
+		require.NoError(t, err)
+
+		contents := []string{
+			"Обзор GraphQL API и cursor pagination",
+			"GraphQL API overview и пример",
+			"Ґрунтовний огляд",
+			"Café crème brûlée",
+			"2026 — 42",
+		}
+		ids := make([]uuid.UUID, len(contents))
+		for i, content := range contents {
+			ids[i] = uuid.New()
+			_, err := manager.Pool().Exec(ctx,
+				"INSERT INTO posts (id, title, content, author_id) VALUES ($1, $2, $3, $4)",
+				ids[i], "#", content, uuid.New())
+			require.NoError(t, err)
+		}
+
+		applied, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, steps, applied)
+
+		for i, content := range contents {
+			var language string
+			err := manager.Pool().QueryRow(ctx, "SELECT language FROM posts WHERE id = $1", ids[i]).Scan(&language)
+			require.NoError(t, err)
+			assert.Equal(t, string(model.DetectLanguage("#", content)), language, content)
+		}
+	})
+
+	t.Run("backfills keep updated_at", func(t *testing.T) {
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		steps := 0
+		for _, status := range statuses {
+			if status.Version >= 4 {
+				steps++
+			}
+		}
+		_, err = migrator.Down(ctx, steps)
+		require.NoError(t, err)
+
+		edited := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		postID, rootID, replyID := uuid.New(), uuid.New(), uuid.New()
+		_, err = manager.Pool().Exec(ctx,
+			"INSERT INTO posts (id, title, content, author_id, created_at, updated_at) VALUES ($1, 'Post', 'Content', $2, $3, $3)",
+			postID, uuid.New(), edited)
+		require.NoError(t, err)
+		for _, comment := range []struct{ id, parentID any }{{rootID, nil}, {replyID, rootID}} {
+			_, err = manager.Pool().Exec(ctx,
+				"INSERT INTO comments (id, post_id, parent_id, content, author_id, created_at, updated_at) VALUES ($1, $2, $3, 'Comment', $4, $5, $5)",
+				comment.id, postID, comment.parentID, uuid.New(), edited)
+			require.NoError(t, err)
+		}
+
+		applied, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, steps, applied)
+
+		var updatedAt time.Time
+		require.NoError(t, manager.Pool().QueryRow(ctx, "SELECT updated_at FROM posts WHERE id = $1", postID).Scan(&updatedAt))
+		assert.True(t, edited.Equal(updatedAt), "post updated_at changed to %s", updatedAt)
+		for _, id := range []uuid.UUID{rootID, replyID} {
+			require.NoError(t, manager.Pool().QueryRow(ctx, "SELECT updated_at FROM comments WHERE id = $1", id).Scan(&updatedAt))
+			assert.True(t, edited.Equal(updatedAt), "comment updated_at changed to %s", updatedAt)
+		}
+	})
+
+	// changedFS возвращает копию встроенных миграций с измененным файлом name
+	changedFS := func(t *testing.T, name string) fstest.MapFS {
+		fsys := fstest.MapFS{}
+		entries, err := fs.ReadDir(migrations.FS, ".")
+		require.NoError(t, err)
+		for _, entry := range entries {
+			content, err := fs.ReadFile(migrations.FS, entry.Name())
+			require.NoError(t, err)
+			fsys[entry.Name()] = &fstest.MapFile{Data: content}
+		}
+		file, ok := fsys[name]
+		require.True(t, ok, name)
+		file.Data = append(append([]byte{}, file.Data...), []byte("\n-- changed\n")...)
+		return fsys
+	}
+
+	t.Run("drift is detected", func(t *testing.T) {
+		drifted, err := NewMigrator(manager.Pool(), changedFS(t, "001_initial_schema.up.sql"), logger)
+		require.NoError(t, err)
+
+		_, err = drifted.Up(ctx)
+		require.ErrorIs(t, err, ErrMigrationDrift)
+		_, err = drifted.Down(ctx, 1)
+		require.ErrorIs(t, err, ErrMigrationDrift)
+		require.ErrorIs(t, drifted.Redo(ctx), ErrMigrationDrift)
+
+		statuses, err := drifted.Status(ctx)
+		require.NoError(t, err)
+		assert.True(t, statuses[0].Drifted)
+	})
+
+	t.Run("drifted down file is not applied", func(t *testing.T) {
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		latest := statuses[len(statuses)-1]
+		name := fmt.Sprintf("%03d_%s.down.sql", latest.Version, latest.Name)
+		drifted, err := NewMigrator(manager.Pool(), changedFS(t, name), logger)
+		require.NoError(t, err)
+
+		_, err = drifted.Down(ctx, 1)
+		require.ErrorIs(t, err, ErrMigrationDrift)
+		require.ErrorIs(t, drifted.Redo(ctx), ErrMigrationDrift)
+
+		statuses, err = drifted.Status(ctx)
+		require.NoError(t, err)
+		assert.True(t, statuses[len(statuses)-1].Drifted)
+		assert.True(t, statuses[len(statuses)-1].Applied)
+	})
+
+	t.Run("records without checksum are rejected until baseline", func(t *testing.T) {
+		// Так выглядят записи встроенного мигратора прежних версий
+		_, err := manager.Pool().Exec(ctx, "UPDATE schema_migrations SET checksum = '' WHERE version = 1")
+		require.NoError(t, err)
+
+		_, err = migrator.Up(ctx)
+		require.ErrorIs(t, err, ErrUnverifiedMigration)
+
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		assert.True(t, statuses[0].Unverified)
+		assert.False(t, statuses[0].Drifted)
+
+		recorded, err := migrator.Baseline(ctx, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, recorded)
+
+		applied, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, applied)
+	})
+
+	t.Run("schema without migration records requires baseline", func(t *testing.T) {
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		latest := statuses[len(statuses)-1].Version
+
+		// Так выглядит база, созданная через docker-entrypoint-initdb.d
+		_, err = manager.Pool().Exec(ctx, "DELETE FROM schema_migrations")
+		require.NoError(t, err)
+
+		_, err = migrator.Up(ctx)
+		require.ErrorIs(t, err, ErrUntrackedSchema)
+
+		recorded, err := migrator.Baseline(ctx, latest)
+		require.NoError(t, err)
+		assert.Equal(t, len(statuses), recorded)
+
+		_, err = migrator.Baseline(ctx, latest)
+		assert.Error(t, err)
+
+		applied, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, applied)
+
+		statuses, err = migrator.Status(ctx)
+		require.NoError(t, err)
+		for _, status := range statuses {
+			assert.True(t, status.Applied, status.Name)
+			assert.False(t, status.Drifted, status.Name)
+			assert.False(t, status.Unverified, status.Name)
+		}
+	})
+}
+
+// TestCommentEventRepository_Integration тестирует PostgreSQL журнал событий комментариев
+func TestCommentEventRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	cfg := &config.DatabaseConfig{
+		Host:           "localhost",
+		Port:           5432,
+		Name:           "habbr_test",
+		User:           "postgres",
+		Password:       "password",
+		SSLMode:        "disable",
+		MaxConnections: 5,
+		MaxIdleTime:    time.Minute,
+		MaxLifetime:    time.Hour,
+	}
+
+	manager, err := NewManager(ctx, cfg, logger)
+	require.NoError(t, err)
+	defer manager.Close(ctx)
+
+	err = manager.Migrate(ctx)
+	require.NoError(t, err)
+
+	// Журнал с емкостью 3 события на пост
+	repo := NewCommentEventRepository(manager.Pool(), 3, logger)
+	postID := uuid.New()
+
+	ids := make([]int64, 0, 5)
+	for i := 0; i < 5; i++ {
+		event := &repomodel.CommentEvent{
+			PostID:     postID,
+			ActionType: "CREATED",
+			Payload:    []byte(`{"action_type":"CREATED"}`),
+		}
+		require.NoError(t, repo.Append(ctx, event))
+		ids = append(ids, event.ID)
+	}
+
+	t.Run("old events are trimmed", func(t *testing.T) {
+		events, err := repo.ListAfter(ctx, postID, ids[1], 10)
+		require.NoError(t, err)
+		require.Len(t, events, 3)
+		assert.Equal(t, ids[2], events[0].ID)
+		assert.Equal(t, ids[4], events[2].ID)
+		assert.JSONEq(t, `{"action_type":"CREATED"}`, string(events[0].Payload))
+	})
+
+	t.Run("cursor before trimmed events expires", func(t *testing.T) {
+		_, err := repo.ListAfter(ctx, postID, ids[0], 10)
+		assert.ErrorIs(t, err, repository.ErrEventCursorExpired)
+		_, err = repo.ListAfter(ctx, postID, 0, 10)
+		assert.ErrorIs(t, err, repository.ErrEventCursorExpired)
+
+		// Журнал другого поста не сокращался
+		events, err := repo.ListAfter(ctx, uuid.New(), 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("batch append assigns IDs in order", func(t *testing.T) {
+		batchPostID := uuid.New()
+		events := make([]*repomodel.CommentEvent, 4)
+		for i := range events {
+			events[i] = &repomodel.CommentEvent{PostID: batchPostID, ActionType: "DELETED", Payload: []byte(`{"deleted_count":1}`)}
+		}
+		require.NoError(t, repo.AppendBatch(ctx, events))
+		for i := 1; i < len(events); i++ {
+			assert.Greater(t, events[i].ID, events[i-1].ID)
+		}
+
+		// Пакет больше емкости журнала сокращается до последних событий
+		_, err := repo.ListAfter(ctx, batchPostID, 0, 10)
+		assert.ErrorIs(t, err, repository.ErrEventCursorExpired)
+
+		stored, err := repo.ListAfter(ctx, batchPostID, events[0].ID, 10)
+		require.NoError(t, err)
+		require.Len(t, stored, 3)
+		assert.Equal(t, events[1].ID, stored[0].ID)
+		assert.JSONEq(t, `{"deleted_count":1}`, string(stored[0].Payload))
+	})
+
+	t.Run("concurrent appends become visible in ID order", func(t *testing.T) {
+		otherPostID := uuid.New()
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				event := &repomodel.CommentEvent{PostID: otherPostID, ActionType: "CREATED", Payload: []byte(`{}`)}
+				assert.NoError(t, repo.Append(ctx, event))
+			}()
+		}
+		wg.Wait()
+
+		events, err := repo.ListAfter(ctx, otherPostID, 0, 10)
+		require.NoError(t, err)
+		assert.Len(t, events, 3)
+	})
+
+	t.Run("list after event", func(t *testing.T) {
+		events, err := repo.ListAfter(ctx, postID, ids[3], 10)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, ids[4], events[0].ID)
+	})
+}
+
+// TestSearch_Integration тестирует полнотекстовый поиск PostgreSQL
+func TestSearch_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	cfg := &config.DatabaseConfig{
+		Host:           "localhost",
+		Port:           5432,
+		Name:           "habbr_test",
+		User:           "postgres",
+		Password:       "password",
+		SSLMode:        "disable",
+		MaxConnections: 5,
+		MaxIdleTime:    time.Minute,
+		MaxLifetime:    time.Hour,
+	}
+
+	manager, err := NewManager(ctx, cfg, logger)
+	require.NoError(t, err)
+	defer manager.Close(ctx)
+
+	require.NoError(t, manager.Migrate(ctx))
+
+	repos := manager.GetRepositories()
+	marker := "zebrafish" + uuid.New().String()[:8]
+
+	inTitle := &repomodel.Post{
+		ID: uuid.New(), Title: "About " + marker, Content: "Post body", AuthorID: uuid.New(),
+		CommentsEnabled: true, CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}
+	inContent := &repomodel.Post{
+		ID: uuid.New(), Title: "Notes", Content: "Something about " + marker + " here", AuthorID: uuid.New(),
+		CommentsEnabled: true, CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}
+	for _, post := range []*repomodel.Post{inTitle, inContent} {
+		require.NoError(t, repos.Post.Create(ctx, post))
+		defer repos.Post.Delete(ctx, post.ID)
+	}
+
+	t.Run("posts ranked by relevance", func(t *testing.T) {
+		hits, err := repos.Post.Search(ctx, repomodel.SearchFilter{Query: marker, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, hits, 2)
+		assert.Equal(t, inTitle.ID, hits[0].ID)
+		assert.Greater(t, hits[0].Rank, hits[1].Rank)
+		assert.Contains(t, hits[1].Snippet, repomodel.HighlightStart+marker+repomodel.HighlightStop)
+
+		next, err := repos.Post.Search(ctx, repomodel.SearchFilter{
+			Query:     marker,
+			Limit:     10,
+			AfterRank: &hits[0].Rank,
+			AfterID:   &hits[0].ID,
+		})
+		require.NoError(t, err)
+		require.Len(t, next, 1)
+		assert.Equal(t, inContent.ID, next[0].ID)
+	})
+
+	t.Run("comments filtered by post", func(t *testing.T) {
+		comment := &repomodel.Comment{
+			ID: uuid.New(), PostID: inContent.ID, Content: "Reply about " + marker, AuthorID: uuid.New(),
+			CreatedAt: time.Now(), UpdatedAt: time.Now(),
+		}
+		require.NoError(t, repos.Comment.Create(ctx, comment))
+
+		hits, err := repos.Comment.Search(ctx, repomodel.SearchFilter{Query: marker, PostID: &inContent.ID, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, hits, 1)
+		assert.Equal(t, comment.ID, hits[0].ID)
+
+		hits, err = repos.Comment.Search(ctx, repomodel.SearchFilter{Query: marker, PostID: &inTitle.ID, Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, hits)
+	})
+
+	t.Run("russian stemming", func(t *testing.T) {
+		post := &repomodel.Post{
+			ID: uuid.New(), Title: "Модерация", Content: "Новые комментарии " + marker, AuthorID: uuid.New(),
+			CommentsEnabled: true, Language: "russian", CreatedAt: time.Now(), UpdatedAt: time.Now(),
+		}
+		require.NoError(t, repos.Post.Create(ctx, post))
+		defer repos.Post.Delete(ctx, post.ID)
+
+		hits, err := repos.Post.Search(ctx, repomodel.SearchFilter{Query: "комментарий " + marker, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, hits, 1)
+		assert.Equal(t, post.ID, hits[0].ID)
+		assert.Equal(t, "russian", hits[0].Language)
+		assert.Contains(t, hits[0].Snippet, repomodel.HighlightStart+"комментарии"+repomodel.HighlightStop)
+
+		hits, err = repos.Post.Search(ctx, repomodel.SearchFilter{Query: "комментарий " + marker, Language: "english", Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, hits)
+	})
+}
+
+// TestWithinTx_Integration тестирует транзакции менеджера PostgreSQL
+func TestWithinTx_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	cfg := &config.DatabaseConfig{
+		Host:           "localhost",
+		Port:           5432,
+		Name:           "habbr_test",
+		User:           "postgres",
+		Password:       "password",
+		SSLMode:        "disable",
+		MaxConnections: 5,
+		MaxIdleTime:    time.Minute,
+		MaxLifetime:    time.Hour,
+	}
+
+	manager, err := NewManager(ctx, cfg, logger)
+	require.NoError(t, err)
+	defer manager.Close(ctx)
+
+	require.NoError(t, manager.Migrate(ctx))
+
+	repos := manager.GetRepositories()
+
+	newPost := func() *repomodel.Post {
+		return &repomodel.Post{
+			ID: uuid.New(), Title: "Transactions", Content: "Original content", AuthorID: uuid.New(),
+			CommentsEnabled: true, CreatedAt: time.Now(), UpdatedAt: time.Now(),
+		}
+	}
+
+	t.Run("error rolls back all repositories", func(t *testing.T) {
+		post := newPost()
+		require.NoError(t, repos.Post.Create(ctx, post))
+		defer repos.Post.Delete(ctx, post.ID)
+
+		errAbort := errors.New("abort")
+		err := manager.WithinTx(ctx, func(tx *repository.Repositories) error {
+			comment := &repomodel.Comment{
+				ID: uuid.New(), PostID: post.ID, Content: "In transaction", AuthorID: uuid.New(),
+				CreatedAt: time.Now(), UpdatedAt: time.Now(),
+			}
+			require.NoError(t, tx.Comment.Create(ctx, comment))
+
+			// Внутри транзакции изменения видны ее репозиториям
+			count, err := tx.Comment.CountByPostID(ctx, post.ID)
+			require.NoError(t, err)
+			require.Equal(t, 1, count)
+
+			// Мягкое удаление внутри транзакции использует точку сохранения
+			_, tombstone, err := tx.Comment.SoftDelete(ctx, comment.ID, "[deleted]")
+			require.NoError(t, err)
+			require.False(t, tombstone)
+
+			require.NoError(t, tx.Post.Delete(ctx, post.ID))
+			return errAbort
+		})
+		assert.ErrorIs(t, err, errAbort)
+
+		exists, err := repos.Post.Exists(ctx, post.ID)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("commit applies changes", func(t *testing.T) {
+		post := newPost()
+		require.NoError(t, repos.Post.Create(ctx, post))
+
+		err := manager.WithinTx(ctx, func(tx *repository.Repositories) error {
+			if err := tx.Comment.DeleteByPostID(ctx, post.ID); err != nil {
+				return err
+			}
+			return tx.Post.Delete(ctx, post.ID)
+		})
+		require.NoError(t, err)
+
+		exists, err := repos.Post.Exists(ctx, post.ID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
@@ -2,10 +2,12 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/NarthurN/habbr/internal/config"
 	"github.com/NarthurN/habbr/internal/repository"
+	"github.com/NarthurN/habbr/migrations"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
@@ -75,10 +77,7 @@ func NewManager(ctx context.Context, cfg *config.DatabaseConfig, logger *zap.Log
 	}
 
 	// Инициализируем репозитории
-	manager.repos = &repository.Repositories{
-		Post:    NewPostRepository(pool, logger),
-		Comment: NewCommentRepository(pool, logger),
-	}
+	manager.repos = newRepositories(pool, logger)
 
 	logger.Info("PostgreSQL manager initialized successfully",
 		zap.String("host", cfg.Host),
@@ -95,6 +94,48 @@ func (m *Manager) GetRepositories() *repository.Repositories {
 	return m.repos
 }
 
+// WithinTx выполняет fn в транзакции PostgreSQL.
+//
+// Репозитории, переданные в fn, выполняют запросы в транзакции. Если fn возвращает
+// ошибку или паникует, транзакция откатывается, а ошибка fn возвращается без изменений.
+func (m *Manager) WithinTx(ctx context.Context, fn func(repos *repository.Repositories) error) error {
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			m.logger.Error("Failed to rollback transaction", zap.Error(err))
+		}
+	}()
+
+	if err := fn(newRepositories(tx, m.logger)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// newRepositories создает репозитории, выполняющие запросы через db
+func newRepositories(db DBTX, logger *zap.Logger) *repository.Repositories {
+	return &repository.Repositories{
+		Post:         NewPostRepository(db, logger),
+		Comment:      NewCommentRepository(db, logger),
+		CommentEvent: NewCommentEventRepository(db, repository.CommentEventRetention, logger),
+		Stats:        NewStatsRepository(db, logger),
+		Analytics:    NewAnalyticsRepository(db, logger),
+	}
+}
+
+// Pool возвращает пул соединений для компонентов, которым нужен прямой доступ
+// к PostgreSQL (например, LISTEN/NOTIFY брокер подписок)
+func (m *Manager) Pool() *pgxpool.Pool {
+	return m.pool
+}
+
 // Close закрывает все соединения с базой данных
 func (m *Manager) Close(ctx context.Context) error {
 	if m.pool != nil {
@@ -126,173 +167,30 @@ func (m *Manager) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
-// Migrate выполняет миграции базы данных
+// Migrate применяет непримененные миграции из каталога migrations.
+//
+// SQL файлы встроены в бинарник (migrations.FS), поэтому схема базы всегда
+// соответствует версии сервиса. Откат и просмотр состояния доступны через Migrator.
 func (m *Manager) Migrate(ctx context.Context) error {
-	if m.pool == nil {
-		return fmt.Errorf("connection pool is not initialized")
+	migrator, err := m.Migrator()
+	if err != nil {
+		return err
 	}
 
 	m.logger.Info("Starting database migration")
 
-	// Получаем соединение из пула
-	conn, err := m.pool.Acquire(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to acquire connection for migration: %w", err)
-	}
-	defer conn.Release()
-
-	// Начинаем транзакцию для миграции
-	tx, err := conn.Begin(ctx)
+	applied, err := migrator.Up(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to begin migration transaction: %w", err)
-	}
-	defer func() {
-		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
-			m.logger.Error("Failed to rollback migration transaction", zap.Error(err))
-		}
-	}()
-
-	// Создаем таблицу миграций если её нет
-	if err := m.createMigrationsTable(ctx, tx); err != nil {
-		return fmt.Errorf("failed to create migrations table: %w", err)
-	}
-
-	// Выполняем миграции
-	migrations := []Migration{
-		{
-			Version:     1,
-			Description: "Initial schema with posts and comments",
-			SQL: `
-				-- Создание таблицы постов
-				CREATE TABLE IF NOT EXISTS posts (
-					id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-					title VARCHAR(200) NOT NULL,
-					content TEXT NOT NULL,
-					author_id UUID NOT NULL,
-					comments_enabled BOOLEAN NOT NULL DEFAULT true,
-					created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-					updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
-				);
-
-				-- Создание индексов для постов
-				CREATE INDEX IF NOT EXISTS idx_posts_author_id ON posts(author_id);
-				CREATE INDEX IF NOT EXISTS idx_posts_created_at ON posts(created_at DESC);
-				CREATE INDEX IF NOT EXISTS idx_posts_comments_enabled ON posts(comments_enabled);
-
-				-- Создание таблицы комментариев
-				CREATE TABLE IF NOT EXISTS comments (
-					id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-					post_id UUID NOT NULL,
-					parent_id UUID NULL,
-					content TEXT NOT NULL CHECK (LENGTH(content) <= 2000),
-					author_id UUID NOT NULL,
-					depth INTEGER NOT NULL DEFAULT 0,
-					created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-					updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-					FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
-					FOREIGN KEY (parent_id) REFERENCES comments(id) ON DELETE CASCADE
-				);
-
-				-- Создание индексов для комментариев
-				CREATE INDEX IF NOT EXISTS idx_comments_post_id ON comments(post_id);
-				CREATE INDEX IF NOT EXISTS idx_comments_parent_id ON comments(parent_id);
-				CREATE INDEX IF NOT EXISTS idx_comments_author_id ON comments(author_id);
-				CREATE INDEX IF NOT EXISTS idx_comments_created_at ON comments(created_at DESC);
-				CREATE INDEX IF NOT EXISTS idx_comments_depth ON comments(depth);
-
-				-- Составной индекс для эффективного получения комментариев к посту
-				CREATE INDEX IF NOT EXISTS idx_comments_post_depth_created ON comments(post_id, depth, created_at);
-
-				-- Триггер для автоматического обновления updated_at в постах
-				CREATE OR REPLACE FUNCTION update_updated_at_column()
-				RETURNS TRIGGER AS $$
-				BEGIN
-					NEW.updated_at = NOW();
-					RETURN NEW;
-				END;
-				$$ language 'plpgsql';
-
-				CREATE TRIGGER update_posts_updated_at
-					BEFORE UPDATE ON posts
-					FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
-
-				CREATE TRIGGER update_comments_updated_at
-					BEFORE UPDATE ON comments
-					FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
-			`,
-		},
-	}
-
-	for _, migration := range migrations {
-		if err := m.runMigration(ctx, tx, migration); err != nil {
-			return fmt.Errorf("failed to run migration %d: %w", migration.Version, err)
-		}
+		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
-	// Коммитим транзакцию
-	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("failed to commit migration transaction: %w", err)
-	}
-
-	m.logger.Info("Database migration completed successfully")
+	m.logger.Info("Database migration completed successfully", zap.Int("applied", applied))
 	return nil
 }
 
-// Migration представляет миграцию базы данных
-type Migration struct {
-	Version     int
-	Description string
-	SQL         string
-}
-
-// createMigrationsTable создает таблицу для отслеживания миграций
-func (m *Manager) createMigrationsTable(ctx context.Context, tx pgx.Tx) error {
-	query := `
-		CREATE TABLE IF NOT EXISTS schema_migrations (
-			version INTEGER PRIMARY KEY,
-			description TEXT NOT NULL,
-			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
-		);
-	`
-	_, err := tx.Exec(ctx, query)
-	return err
-}
-
-// runMigration выполняет конкретную миграцию
-func (m *Manager) runMigration(ctx context.Context, tx pgx.Tx, migration Migration) error {
-	// Проверяем, была ли уже применена эта миграция
-	var count int
-	err := tx.QueryRow(ctx, "SELECT COUNT(*) FROM schema_migrations WHERE version = $1", migration.Version).Scan(&count)
-	if err != nil {
-		return fmt.Errorf("failed to check migration status: %w", err)
-	}
-
-	if count > 0 {
-		m.logger.Debug("Migration already applied", zap.Int("version", migration.Version))
-		return nil
-	}
-
-	// Выполняем миграцию
-	m.logger.Info("Applying migration",
-		zap.Int("version", migration.Version),
-		zap.String("description", migration.Description),
-	)
-
-	if _, err := tx.Exec(ctx, migration.SQL); err != nil {
-		return fmt.Errorf("failed to execute migration SQL: %w", err)
-	}
-
-	// Записываем информацию о примененной миграции
-	_, err = tx.Exec(ctx,
-		"INSERT INTO schema_migrations (version, description) VALUES ($1, $2)",
-		migration.Version, migration.Description,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to record migration: %w", err)
-	}
-
-	m.logger.Info("Migration applied successfully", zap.Int("version", migration.Version))
-	return nil
+// Migrator создает мигратор для встроенных миграций
+func (m *Manager) Migrator() (*Migrator, error) {
+	return NewMigrator(m.pool, migrations.FS, m.logger)
 }
 
 // queryTracer реализует pgx.QueryTracer для логирования запросов
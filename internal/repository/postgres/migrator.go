@@ -0,0 +1,652 @@
+package postgres
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// migrationLockKey - ключ advisory lock, под которым выполняются миграции.
+// Блокировка не дает нескольким экземплярам сервиса мигрировать базу одновременно.
+const migrationLockKey int64 = 0x68616262 // "habb"
+
+// ErrMigrationDrift возвращается, если файл уже примененной миграции был изменен
+var ErrMigrationDrift = errors.New("applied migration differs from migration file")
+
+// ErrUnverifiedMigration возвращается, если примененная миграция записана без контрольной суммы.
+// Такие записи оставил встроенный мигратор прежних версий сервиса: его схема не совпадает
+// с файлами миграций (нет ограничения глубины, триггеров и представлений из 001), поэтому
+// применять следующие миграции поверх нее нельзя. После приведения схемы к файлам миграций
+// записи подтверждаются командой Baseline.
+var ErrUnverifiedMigration = errors.New("applied migration has no checksum")
+
+// ErrUntrackedSchema возвращается, если таблицы сервиса уже есть, а schema_migrations пуста.
+// Так выглядит база, схему которой создал не мигратор, например docker-entrypoint-initdb.d
+// прежнего docker-compose: Up начал бы с 001 и упал на существующих таблицах. После
+// приведения схемы к файлам миграций версии записываются командой Baseline.
+var ErrUntrackedSchema = errors.New("database schema exists but no migrations are recorded")
+
+// migrationFilePattern описывает имя файла миграции: 001_initial_schema.up.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([A-Za-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration представляет версионированную миграцию базы данных
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string
+	Down     string // пустая строка означает, что миграцию нельзя откатить
+	Checksum string // SHA-256 текста Up, сохраняется в schema_migrations
+
+	// DownChecksum - SHA-256 текста Down, сохраняется вместе с Checksum,
+	// чтобы откат не выполнил измененный после применения down файл
+	DownChecksum string
+}
+
+// MigrationStatus описывает состояние миграции в базе данных
+type MigrationStatus struct {
+	Version    int
+	Name       string
+	Applied    bool
+	AppliedAt  *time.Time
+	Drifted    bool // файл миграции изменен после применения
+	Unverified bool // миграция записана без контрольной суммы (см. ErrUnverifiedMigration)
+	Missing    bool // миграция применена, но ее файла нет
+}
+
+// appliedMigration - запись таблицы schema_migrations
+type appliedMigration struct {
+	version      int
+	name         string
+	checksum     string
+	downChecksum string
+	appliedAt    time.Time
+}
+
+// LoadMigrations читает миграции из файловой системы.
+//
+// Файлы, не оканчивающиеся на .sql, пропускаются; SQL файлы с именем не по шаблону
+// NNN_name.up.sql / NNN_name.down.sql считаются ошибкой, чтобы опечатка в имени
+// не приводила к молча пропущенной миграции.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+
+		parts := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if parts == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s (expected NNN_name.up.sql or NNN_name.down.sql)", entry.Name())
+		}
+
+		version, err := strconv.Atoi(parts[1])
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %s", entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: parts[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != parts[2] {
+			return nil, fmt.Errorf("migration %d has different names: %s and %s", version, migration.Name, parts[2])
+		}
+
+		switch parts[3] {
+		case "up":
+			migration.Up = string(content)
+		case "down":
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+
+		sum := sha256.Sum256([]byte(migration.Up))
+		migration.Checksum = hex.EncodeToString(sum[:])
+		if migration.Down != "" {
+			sum := sha256.Sum256([]byte(migration.Down))
+			migration.DownChecksum = hex.EncodeToString(sum[:])
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Migrator применяет и откатывает версионированные миграции.
+//
+// Примененные миграции записываются в таблицу schema_migrations вместе с контрольной
+// суммой файлов. Если файл уже примененной миграции изменился, Up, Down и Redo
+// отказываются продолжать (ErrMigrationDrift): изменения схемы нужно оформлять новой миграцией.
+// Каждая миграция выполняется в отдельной транзакции.
+//
+// Пример использования:
+//
+//	migrator, err := postgres.NewMigrator(pool, migrations.FS, logger)
+//	if err != nil {
+//	    return err
+//	}
+//	applied, err := migrator.Up(ctx)
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+	logger     *zap.Logger
+}
+
+// NewMigrator создает мигратор для миграций из файловой системы fsys
+func NewMigrator(pool *pgxpool.Pool, fsys fs.FS, logger *zap.Logger) (*Migrator, error) {
+	if pool == nil {
+		return nil, fmt.Errorf("connection pool is not initialized")
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		pool:       pool,
+		migrations: migrations,
+		logger:     logger,
+	}, nil
+}
+
+// Up применяет все непримененные миграции по возрастанию версии.
+//
+// Возвращает количество примененных миграций.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	count := 0
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.loadApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			if err := m.checkUntracked(ctx, conn); err != nil {
+				return err
+			}
+		}
+		if err := m.checkDrift(applied); err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration); err != nil {
+				return err
+			}
+			count++
+		}
+
+		return nil
+	})
+
+	return count, err
+}
+
+// Down откатывает steps последних примененных миграций.
+//
+// Возвращает количество откаченных миграций.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if steps <= 0 {
+		return 0, fmt.Errorf("steps must be positive")
+	}
+
+	count := 0
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.loadApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.checkDrift(applied); err != nil {
+			return err
+		}
+
+		for _, version := range sortedVersions(applied, true) {
+			if count == steps {
+				break
+			}
+			if err := m.revert(ctx, conn, applied[version]); err != nil {
+				return err
+			}
+			count++
+		}
+
+		return nil
+	})
+
+	return count, err
+}
+
+// Redo откатывает и заново применяет последнюю примененную миграцию
+func (m *Migrator) Redo(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.loadApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.checkDrift(applied); err != nil {
+			return err
+		}
+
+		versions := sortedVersions(applied, true)
+		if len(versions) == 0 {
+			return fmt.Errorf("no applied migrations to redo")
+		}
+
+		migration, ok := m.find(versions[0])
+		if !ok {
+			return fmt.Errorf("migration %d is applied but its file is missing", versions[0])
+		}
+
+		if err := m.revert(ctx, conn, applied[migration.Version]); err != nil {
+			return err
+		}
+		return m.apply(ctx, conn, migration)
+	})
+}
+
+// Status возвращает состояние всех известных и примененных миграций по возрастанию версии
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var result []MigrationStatus
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.loadApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		result = make([]MigrationStatus, 0, len(m.migrations))
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+			if record, ok := applied[migration.Version]; ok {
+				appliedAt := record.appliedAt
+				status.Applied = true
+				status.AppliedAt = &appliedAt
+				status.Unverified = record.checksum == ""
+				status.Drifted = !status.Unverified && (record.checksum != migration.Checksum ||
+					record.downChecksum != "" && record.downChecksum != migration.DownChecksum)
+			}
+			result = append(result, status)
+		}
+
+		for _, version := range sortedVersions(applied, false) {
+			if _, ok := m.find(version); ok {
+				continue
+			}
+			record := applied[version]
+			appliedAt := record.appliedAt
+			result = append(result, MigrationStatus{
+				Version:   version,
+				Name:      record.name,
+				Applied:   true,
+				AppliedAt: &appliedAt,
+				Missing:   true,
+			})
+		}
+
+		sort.Slice(result, func(i, j int) bool {
+			return result[i].Version < result[j].Version
+		})
+
+		return nil
+	})
+
+	return result, err
+}
+
+// withLock выполняет fn на выделенном соединении под advisory lock миграций
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection for migration: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockKey); err != nil {
+			m.logger.Error("Failed to release migration lock", zap.Error(err))
+		}
+	}()
+
+	if err := m.createMigrationsTable(ctx, conn); err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// createMigrationsTable создает таблицу для отслеживания миграций.
+//
+// Колонки checksum и down_checksum добавляются и в таблицу, созданную предыдущими версиями сервиса.
+func (m *Migrator) createMigrationsTable(ctx context.Context, conn *pgxpool.Conn) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			description TEXT NOT NULL,
+			checksum TEXT NOT NULL DEFAULT '',
+			down_checksum TEXT NOT NULL DEFAULT '',
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+		ALTER TABLE schema_migrations ADD COLUMN IF NOT EXISTS checksum TEXT NOT NULL DEFAULT '';
+		ALTER TABLE schema_migrations ADD COLUMN IF NOT EXISTS down_checksum TEXT NOT NULL DEFAULT '';
+	`
+	_, err := conn.Exec(ctx, query)
+	return err
+}
+
+// loadApplied возвращает примененные миграции по версиям
+func (m *Migrator) loadApplied(ctx context.Context, conn *pgxpool.Conn) (map[int]appliedMigration, error) {
+	rows, err := conn.Query(ctx, "SELECT version, description, checksum, down_checksum, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]appliedMigration)
+	for rows.Next() {
+		var record appliedMigration
+		if err := rows.Scan(&record.version, &record.name, &record.checksum, &record.downChecksum, &record.appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan applied migration: %w", err)
+		}
+		applied[record.version] = record
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate applied migrations: %w", err)
+	}
+
+	return applied, nil
+}
+
+// checkDrift сверяет контрольные суммы примененных миграций с файлами.
+//
+// Записи без контрольной суммы не подтверждаются автоматически: схема, созданная прежним
+// мигратором, не совпадает с файлами, и ошибка требует от оператора привести ее к ним.
+func (m *Migrator) checkDrift(applied map[int]appliedMigration) error {
+	var unverified []string
+	for _, version := range sortedVersions(applied, false) {
+		record := applied[version]
+
+		migration, ok := m.find(version)
+		if !ok {
+			m.logger.Warn("Applied migration has no file", zap.Int("version", version), zap.String("name", record.name))
+			continue
+		}
+
+		if record.checksum == "" {
+			unverified = append(unverified, fmt.Sprintf("%03d_%s", version, migration.Name))
+			continue
+		}
+
+		if record.checksum != migration.Checksum {
+			return fmt.Errorf("%w: migration %d_%s", ErrMigrationDrift, version, migration.Name)
+		}
+	}
+
+	if len(unverified) > 0 {
+		m.logger.Error("Migrations were applied by the legacy runner; bring the schema up to date and run baseline",
+			zap.Strings("migrations", unverified),
+		)
+		return fmt.Errorf("%w: %s (bring the schema up to date with the migration files, then run \"migrate baseline\")",
+			ErrUnverifiedMigration, strings.Join(unverified, ", "))
+	}
+
+	return nil
+}
+
+// checkUntracked проверяет, что база без записей о миграциях действительно пуста.
+//
+// Наличие таблицы posts без записей в schema_migrations означает, что схему создал
+// не мигратор (см. ErrUntrackedSchema).
+func (m *Migrator) checkUntracked(ctx context.Context, conn *pgxpool.Conn) error {
+	var exists bool
+	if err := conn.QueryRow(ctx, "SELECT to_regclass('public.posts') IS NOT NULL").Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check existing schema: %w", err)
+	}
+	if !exists {
+		return nil
+	}
+
+	latest := m.migrations[len(m.migrations)-1].Version
+	m.logger.Error("Schema was created without the migrator; bring it up to date and run baseline",
+		zap.Int("latest_version", latest),
+	)
+	return fmt.Errorf("%w (bring the schema up to date with the migration files, then run \"migrate baseline %d\")",
+		ErrUntrackedSchema, latest)
+}
+
+// Baseline подтверждает, что схема базы уже приведена к файлам миграций вручную;
+// Up после него продолжает с непримененных версий. Возвращает количество подтвержденных записей.
+//
+// При version = 0 контрольные суммы текущих файлов записываются в записи примененных
+// миграций без нее (ErrUnverifiedMigration). При version > 0 в пустую schema_migrations
+// записываются все миграции до version включительно (ErrUntrackedSchema).
+func (m *Migrator) Baseline(ctx context.Context, version int) (int, error) {
+	if version < 0 {
+		return 0, fmt.Errorf("baseline version must not be negative")
+	}
+	if version > 0 {
+		if _, ok := m.find(version); !ok {
+			return 0, fmt.Errorf("migration %d not found", version)
+		}
+	}
+
+	count := 0
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.loadApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if version > 0 {
+			if len(applied) > 0 {
+				return fmt.Errorf("baseline version can only be set when no migrations are recorded")
+			}
+			return m.inTx(ctx, conn, func(tx pgx.Tx) error {
+				for _, migration := range m.migrations {
+					if migration.Version > version {
+						break
+					}
+					if _, err := tx.Exec(ctx,
+						"INSERT INTO schema_migrations (version, description, checksum, down_checksum) VALUES ($1, $2, $3, $4)",
+						migration.Version, migration.Name, migration.Checksum, migration.DownChecksum,
+					); err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					m.logger.Warn("Recorded migration applied without the migrator",
+						zap.Int("version", migration.Version),
+						zap.String("name", migration.Name),
+					)
+					count++
+				}
+				return nil
+			})
+		}
+
+		for _, version := range sortedVersions(applied, false) {
+			if applied[version].checksum != "" {
+				continue
+			}
+			migration, ok := m.find(version)
+			if !ok {
+				return fmt.Errorf("migration %d is applied but its file is missing", version)
+			}
+
+			if _, err := conn.Exec(ctx,
+				"UPDATE schema_migrations SET checksum = $1, down_checksum = $2 WHERE version = $3",
+				migration.Checksum, migration.DownChecksum, version,
+			); err != nil {
+				return fmt.Errorf("failed to record checksum of migration %d: %w", version, err)
+			}
+			m.logger.Warn("Recorded checksum for migration applied without it",
+				zap.Int("version", version),
+				zap.String("name", migration.Name),
+			)
+			count++
+		}
+
+		return nil
+	})
+
+	return count, err
+}
+
+// apply выполняет Up миграции и записывает ее в schema_migrations в одной транзакции
+func (m *Migrator) apply(ctx context.Context, conn *pgxpool.Conn, migration Migration) error {
+	m.logger.Info("Applying migration",
+		zap.Int("version", migration.Version),
+		zap.String("name", migration.Name),
+	)
+
+	err := m.inTx(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, migration.Up); err != nil {
+			return fmt.Errorf("failed to execute migration SQL: %w", err)
+		}
+
+		_, err := tx.Exec(ctx,
+			"INSERT INTO schema_migrations (version, description, checksum, down_checksum) VALUES ($1, $2, $3, $4)",
+			migration.Version, migration.Name, migration.Checksum, migration.DownChecksum,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record migration: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	m.logger.Info("Migration applied successfully", zap.Int("version", migration.Version))
+	return nil
+}
+
+// revert выполняет Down миграции и удаляет ее из schema_migrations в одной транзакции.
+//
+// Записи, сделанные до появления down_checksum, не содержат суммы down файла;
+// такой откат выполняется с предупреждением.
+func (m *Migrator) revert(ctx context.Context, conn *pgxpool.Conn, record appliedMigration) error {
+	migration, ok := m.find(record.version)
+	if !ok {
+		return fmt.Errorf("migration %d is applied but its file is missing", record.version)
+	}
+	if migration.Down == "" {
+		return fmt.Errorf("migration %d_%s has no down file", migration.Version, migration.Name)
+	}
+	switch {
+	case record.downChecksum == "":
+		m.logger.Warn("Down file checksum was not recorded; reverting unverified down file",
+			zap.Int("version", migration.Version),
+			zap.String("name", migration.Name),
+		)
+	case record.downChecksum != migration.DownChecksum:
+		return fmt.Errorf("%w: down file of migration %d_%s", ErrMigrationDrift, migration.Version, migration.Name)
+	}
+
+	m.logger.Info("Reverting migration",
+		zap.Int("version", migration.Version),
+		zap.String("name", migration.Name),
+	)
+
+	err := m.inTx(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, migration.Down); err != nil {
+			return fmt.Errorf("failed to execute migration SQL: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version); err != nil {
+			return fmt.Errorf("failed to delete migration record: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revert migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	m.logger.Info("Migration reverted successfully", zap.Int("version", migration.Version))
+	return nil
+}
+
+// inTx выполняет fn в транзакции и откатывает ее при ошибке
+func (m *Migrator) inTx(ctx context.Context, conn *pgxpool.Conn, fn func(tx pgx.Tx) error) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin migration transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			m.logger.Error("Failed to rollback migration transaction", zap.Error(err))
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// find возвращает миграцию по версии
+func (m *Migrator) find(version int) (Migration, bool) {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
+
+// sortedVersions возвращает версии примененных миграций по возрастанию или убыванию
+func sortedVersions(applied map[int]appliedMigration, descending bool) []int {
+	versions := make([]int, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		if descending {
+			return versions[i] > versions[j]
+		}
+		return versions[i] < versions[j]
+	})
+
+	return versions
+}
@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NarthurN/habbr/migrations"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	t.Run("embedded migrations", func(t *testing.T) {
+		loaded, err := LoadMigrations(migrations.FS)
+		require.NoError(t, err)
+		require.NotEmpty(t, loaded)
+
+		for i, migration := range loaded {
+			assert.Equal(t, i+1, migration.Version, "migration versions must be sequential")
+			assert.NotEmpty(t, migration.Up)
+			assert.NotEmpty(t, migration.Down, "migration %d_%s has no down file", migration.Version, migration.Name)
+			assert.Len(t, migration.Checksum, 64)
+		}
+	})
+
+	t.Run("sorted by version with checksums", func(t *testing.T) {
+		loaded, err := LoadMigrations(fstest.MapFS{
+			"010_second.up.sql":  {Data: []byte("CREATE TABLE b ();")},
+			"002_first.up.sql":   {Data: []byte("CREATE TABLE a ();")},
+			"002_first.down.sql": {Data: []byte("DROP TABLE a;")},
+			"README.md":          {Data: []byte("not a migration")},
+		})
+		require.NoError(t, err)
+		require.Len(t, loaded, 2)
+
+		assert.Equal(t, 2, loaded[0].Version)
+		assert.Equal(t, "first", loaded[0].Name)
+		assert.Equal(t, "DROP TABLE a;", loaded[0].Down)
+		assert.Equal(t, 10, loaded[1].Version)
+		assert.Empty(t, loaded[1].Down)
+		assert.NotEqual(t, loaded[0].Checksum, loaded[1].Checksum)
+	})
+
+	t.Run("invalid files", func(t *testing.T) {
+		tests := []struct {
+			name string
+			fsys fstest.MapFS
+		}{
+			{
+				name: "malformed name",
+				fsys: fstest.MapFS{"001_initial.sql": {Data: []byte("SELECT 1;")}},
+			},
+			{
+				name: "down without up",
+				fsys: fstest.MapFS{"001_initial.down.sql": {Data: []byte("SELECT 1;")}},
+			},
+			{
+				name: "different names for one version",
+				fsys: fstest.MapFS{
+					"001_initial.up.sql": {Data: []byte("SELECT 1;")},
+					"001_other.down.sql": {Data: []byte("SELECT 1;")},
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := LoadMigrations(tt.fsys)
+				assert.Error(t, err)
+			})
+		}
+	})
+}
@@ -0,0 +1,350 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+	"github.com/google/uuid"
+)
+
+// keysetSide определяет, какие записи относительно курсора выбирает условие
+type keysetSide int
+
+const (
+	afterCursor     keysetSide = iota // записи после курсора
+	beforeCursor                      // записи перед курсором
+	notAfterCursor                    // курсор и записи перед ним
+	notBeforeCursor                   // курсор и записи после него
+)
+
+// keysetQuery описывает запрос страницы keyset-пагинации по (sortExpr, idExpr).
+//
+// Условия фильтра и их аргументы задаются вызывающим. sortExpr - выражение ключа
+// сортировки key: столбец или коррелированный подзапрос, он же выбирается последним
+// столбцом результата для построения курсоров.
+type keysetQuery struct {
+	columns    string // выбираемые столбцы записи
+	from       string // источник записей: таблица с псевдонимом и соединениями
+	existsFrom string // источник для проверки соседних страниц, без соединений
+	sortExpr   string
+	idExpr     string
+	key        repomodel.SortKey
+	conditions []string
+	args       []interface{}
+	desc       bool
+	page       repomodel.Page
+
+	partitionsArg int // номер аргумента с массивом групп, заданный partitioned
+}
+
+// keysetCondition возвращает условие сравнения (sortExpr, idExpr) с курсором
+// и добавляет значения курсора в args.
+//
+// Сравнение строк (created_at, id) > ($1, $2) использует составной индекс
+// и не требует OFFSET.
+func (q keysetQuery) keysetCondition(args []interface{}, cursor *repomodel.Cursor, side keysetSide) (string, []interface{}) {
+	operators := map[keysetSide]string{afterCursor: ">", beforeCursor: "<", notAfterCursor: "<=", notBeforeCursor: ">="}
+	if q.desc {
+		operators = map[keysetSide]string{afterCursor: "<", beforeCursor: ">", notAfterCursor: ">=", notBeforeCursor: "<="}
+	}
+
+	args = append(args, cursor.Value(), cursor.ID)
+	return fmt.Sprintf("(%s, %s) %s ($%d, $%d)",
+		q.sortExpr, q.idExpr, operators[side], len(args)-1, len(args)), args
+}
+
+// window возвращает условия фильтра вместе с границами After и Before
+func (q keysetQuery) window() ([]string, []interface{}) {
+	conditions := slices.Clone(q.conditions)
+	args := slices.Clone(q.args)
+
+	if q.page.After != nil {
+		var condition string
+		condition, args = q.keysetCondition(args, q.page.After, afterCursor)
+		conditions = append(conditions, condition)
+	}
+	if q.page.Before != nil {
+		var condition string
+		condition, args = q.keysetCondition(args, q.page.Before, beforeCursor)
+		conditions = append(conditions, condition)
+	}
+
+	return conditions, args
+}
+
+// sql возвращает текст запроса страницы и его аргументы.
+//
+// Для Last записи выбираются в обратном порядке, чтобы LIMIT взял последние записи
+// окна; queryKeysetPage разворачивает их обратно. Запрашивается на одну запись больше
+// размера страницы: лишняя запись означает, что страница не исчерпывает окно.
+func (q keysetQuery) sql() (string, []interface{}) {
+	conditions, args := q.window()
+
+	query := fmt.Sprintf("SELECT %s, %s FROM %s", q.columns, q.sortExpr, q.from)
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY " + q.orderBy()
+
+	if limit := pageLimit(q.page); limit != nil {
+		args = append(args, *limit+1)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	return query, args
+}
+
+// orderBy возвращает порядок выборки записей страницы: для Last - обратный порядку сортировки
+func (q keysetQuery) orderBy() string {
+	direction := "ASC"
+	if q.desc != (q.page.Last != nil) {
+		direction = "DESC"
+	}
+	return fmt.Sprintf("%[1]s %[3]s, %[2]s %[3]s", q.sortExpr, q.idExpr, direction)
+}
+
+// exists проверяет наличие записей фильтра по сторону side от курсора
+func (q keysetQuery) exists(ctx context.Context, db DBTX, cursor *repomodel.Cursor, side keysetSide) (bool, error) {
+	condition, args := q.keysetCondition(slices.Clone(q.args), cursor, side)
+	conditions := append(slices.Clone(q.conditions), condition)
+
+	query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE %s)", q.existsFrom, strings.Join(conditions, " AND "))
+
+	var exists bool
+	if err := db.QueryRow(ctx, query, args...).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// pageLimit возвращает размер страницы: First или Last, nil - без ограничения
+func pageLimit(page repomodel.Page) *int {
+	if page.First != nil {
+		return page.First
+	}
+	return page.Last
+}
+
+// keysetPage представляет результат queryKeysetPage
+type keysetPage[T any] struct {
+	items       []T
+	cursors     []repomodel.Cursor // позиции записей, в порядке items
+	hasNext     bool
+	hasPrevious bool
+}
+
+// queryKeysetPage выполняет запрос страницы и определяет наличие соседних страниц.
+//
+// newRow возвращает новую запись, адрес ее ID и адреса для сканирования столбцов q.columns.
+// Следующая страница есть, если First не исчерпал окно или после Before есть записи;
+// предыдущая - если Last не исчерпал окно или перед After есть записи
+// (включая запись самого курсора).
+func queryKeysetPage[T any](ctx context.Context, db DBTX, q keysetQuery, newRow func() (T, *uuid.UUID, []interface{})) (*keysetPage[T], error) {
+	query, args := q.sql()
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := &keysetPage[T]{items: make([]T, 0)}
+	for rows.Next() {
+		item, id, dest := newRow()
+
+		var sortTime time.Time
+		var sortCount int64
+		if q.key.IsCount() {
+			dest = append(dest, &sortCount)
+		} else {
+			dest = append(dest, &sortTime)
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		result.items = append(result.items, item)
+		result.cursors = append(result.cursors, repomodel.Cursor{Key: q.key, Time: sortTime, Count: sortCount, ID: *id})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if limit := pageLimit(q.page); limit != nil && len(result.items) > *limit {
+		result.items = result.items[:*limit]
+		result.cursors = result.cursors[:*limit]
+		if q.page.Last != nil {
+			result.hasPrevious = true
+		} else {
+			result.hasNext = true
+		}
+	}
+	if q.page.Last != nil {
+		slices.Reverse(result.items)
+		slices.Reverse(result.cursors)
+	}
+
+	if !result.hasNext && q.page.Before != nil {
+		if result.hasNext, err = q.exists(ctx, db, q.page.Before, notBeforeCursor); err != nil {
+			return nil, err
+		}
+	}
+	if !result.hasPrevious && q.page.After != nil {
+		if result.hasPrevious, err = q.exists(ctx, db, q.page.After, notAfterCursor); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// partitioned возвращает копию запроса, ограниченную записями групп partitions;
+// partitionExpr - выражение группы, например столбец post_id
+func (q keysetQuery) partitioned(partitionExpr string, partitions []uuid.UUID) keysetQuery {
+	q.args = append(slices.Clone(q.args), partitions)
+	q.partitionsArg = len(q.args)
+	q.conditions = append(slices.Clone(q.conditions), fmt.Sprintf("%s = ANY($%d)", partitionExpr, q.partitionsArg))
+	return q
+}
+
+// partitionedSQL возвращает текст запроса страниц всех групп и его аргументы.
+//
+// Страница каждой группы выбирается отдельным подзапросом LATERAL с LIMIT, как
+// в sql: запрос читает из индекса не больше First или Last записей на группу,
+// а не все записи групп. Записи упорядочены внутри группы в порядке выборки страницы.
+func (q keysetQuery) partitionedSQL(partitionExpr string) (string, []interface{}) {
+	conditions, args := q.window()
+	conditions = append(conditions, partitionExpr+" = keyset_partitions.keyset_partition")
+
+	page := fmt.Sprintf("SELECT %s, %s AS keyset_sort, %s AS keyset_id FROM %s WHERE %s ORDER BY %s",
+		q.columns, q.sortExpr, q.idExpr, q.from, strings.Join(conditions, " AND "), q.orderBy())
+	if limit := pageLimit(q.page); limit != nil {
+		args = append(args, *limit+1)
+		page += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	direction := "ASC"
+	if q.desc != (q.page.Last != nil) {
+		direction = "DESC"
+	}
+	query := fmt.Sprintf(`SELECT keyset_page.*, keyset_partitions.keyset_partition
+		FROM unnest($%[1]d::uuid[]) AS keyset_partitions(keyset_partition)
+		CROSS JOIN LATERAL (%[2]s) keyset_page
+		ORDER BY keyset_partitions.keyset_partition, keyset_page.keyset_sort %[3]s, keyset_page.keyset_id %[3]s`,
+		q.partitionsArg, page, direction)
+
+	return query, args
+}
+
+// existingPartitions возвращает группы, в которых есть записи по сторону side от курсора
+func (q keysetQuery) existingPartitions(ctx context.Context, db DBTX, partitionExpr string, cursor *repomodel.Cursor, side keysetSide) (map[uuid.UUID]bool, error) {
+	condition, args := q.keysetCondition(slices.Clone(q.args), cursor, side)
+	conditions := append(slices.Clone(q.conditions), condition)
+
+	query := fmt.Sprintf("SELECT DISTINCT %s FROM %s WHERE %s", partitionExpr, q.existsFrom, strings.Join(conditions, " AND "))
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[uuid.UUID]bool)
+	for rows.Next() {
+		var partition uuid.UUID
+		if err := rows.Scan(&partition); err != nil {
+			return nil, err
+		}
+		result[partition] = true
+	}
+	return result, rows.Err()
+}
+
+// queryKeysetPartitions выполняет запрос страниц для нескольких групп записей одним запросом.
+//
+// Страница q.page применяется к каждой группе отдельно, как в queryKeysetPage.
+// Результат содержит страницу для каждой из partitions, в том числе пустую.
+func queryKeysetPartitions[T any](ctx context.Context, db DBTX, q keysetQuery, partitionExpr string, partitions []uuid.UUID, newRow func() (T, *uuid.UUID, []interface{})) (map[uuid.UUID]*keysetPage[T], error) {
+	result := make(map[uuid.UUID]*keysetPage[T], len(partitions))
+	unique := make([]uuid.UUID, 0, len(partitions))
+	for _, partition := range partitions {
+		if _, ok := result[partition]; !ok {
+			result[partition] = &keysetPage[T]{items: make([]T, 0)}
+			unique = append(unique, partition)
+		}
+	}
+	if len(unique) == 0 {
+		return result, nil
+	}
+
+	q = q.partitioned(partitionExpr, unique)
+	query, args := q.partitionedSQL(partitionExpr)
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, id, dest := newRow()
+
+		var sortTime time.Time
+		var sortCount int64
+		var sortID, partition uuid.UUID
+		if q.key.IsCount() {
+			dest = append(dest, &sortCount)
+		} else {
+			dest = append(dest, &sortTime)
+		}
+		dest = append(dest, &sortID, &partition)
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		page := result[partition]
+		page.items = append(page.items, item)
+		page.cursors = append(page.cursors, repomodel.Cursor{Key: q.key, Time: sortTime, Count: sortCount, ID: *id})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	var hasNext, hasPrevious map[uuid.UUID]bool
+	if q.page.Before != nil {
+		if hasNext, err = q.existingPartitions(ctx, db, partitionExpr, q.page.Before, notBeforeCursor); err != nil {
+			return nil, err
+		}
+	}
+	if q.page.After != nil {
+		if hasPrevious, err = q.existingPartitions(ctx, db, partitionExpr, q.page.After, notAfterCursor); err != nil {
+			return nil, err
+		}
+	}
+
+	for partition, page := range result {
+		if limit := pageLimit(q.page); limit != nil && len(page.items) > *limit {
+			page.items = page.items[:*limit]
+			page.cursors = page.cursors[:*limit]
+			if q.page.Last != nil {
+				page.hasPrevious = true
+			} else {
+				page.hasNext = true
+			}
+		}
+		if q.page.Last != nil {
+			slices.Reverse(page.items)
+			slices.Reverse(page.cursors)
+		}
+		page.hasNext = page.hasNext || hasNext[partition]
+		page.hasPrevious = page.hasPrevious || hasPrevious[partition]
+	}
+
+	return result, nil
+}
@@ -9,23 +9,22 @@ import (
 	repomodel "github.com/NarthurN/habbr/internal/repository/model"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
 
 // PostRepository реализует repository.PostRepository для PostgreSQL
 type PostRepository struct {
-	pool   *pgxpool.Pool
+	db     DBTX
 	logger *zap.Logger
 }
 
 // NewPostRepository создает новый PostgreSQL репозиторий постов
-func NewPostRepository(pool *pgxpool.Pool, logger *zap.Logger) repository.PostRepository {
+func NewPostRepository(db DBTX, logger *zap.Logger) repository.PostRepository {
 	if logger == nil {
 		logger = zap.NewNop()
 	}
 	return &PostRepository{
-		pool:   pool,
+		db:     db,
 		logger: logger,
 	}
 }
@@ -37,16 +36,17 @@ func (r *PostRepository) Create(ctx context.Context, post *repomodel.Post) error
 	}
 
 	query := `
-		INSERT INTO posts (id, title, content, author_id, comments_enabled, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO posts (id, title, content, author_id, comments_enabled, language, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := r.db.Exec(ctx, query,
 		post.ID,
 		post.Title,
 		post.Content,
 		post.AuthorID,
 		post.CommentsEnabled,
+		repomodel.SearchLanguageOrDefault(post.Language),
 		post.CreatedAt,
 		post.UpdatedAt,
 	)
@@ -66,12 +66,12 @@ func (r *PostRepository) Create(ctx context.Context, post *repomodel.Post) error
 // GetByID получает пост по ID
 func (r *PostRepository) GetByID(ctx context.Context, id uuid.UUID) (*repomodel.Post, error) {
 	query := `
-		SELECT id, title, content, author_id, comments_enabled, created_at, updated_at
+		SELECT id, title, content, author_id, comments_enabled, language, created_at, updated_at
 		FROM posts
 		WHERE id = $1
 	`
 
-	row := r.pool.QueryRow(ctx, query, id)
+	row := r.db.QueryRow(ctx, query, id)
 
 	var post repomodel.Post
 	err := row.Scan(
@@ -80,6 +80,7 @@ func (r *PostRepository) GetByID(ctx context.Context, id uuid.UUID) (*repomodel.
 		&post.Content,
 		&post.AuthorID,
 		&post.CommentsEnabled,
+		&post.Language,
 		&post.CreatedAt,
 		&post.UpdatedAt,
 	)
@@ -98,128 +99,106 @@ func (r *PostRepository) GetByID(ctx context.Context, id uuid.UUID) (*repomodel.
 	return &post, nil
 }
 
-// List получает список постов с фильтрацией и пагинацией
-func (r *PostRepository) List(ctx context.Context, filter repomodel.PostFilter) ([]*repomodel.Post, error) {
-	var conditions []string
-	var args []interface{}
-	argIndex := 1
-
-	baseQuery := `
-		SELECT id, title, content, author_id, comments_enabled, created_at, updated_at
-		FROM posts
-	`
-
-	// Добавляем условия фильтрации
-	if filter.AuthorID != nil {
-		conditions = append(conditions, fmt.Sprintf("author_id = $%d", argIndex))
-		args = append(args, *filter.AuthorID)
-		argIndex++
-	}
-
-	if filter.WithComments != nil {
-		conditions = append(conditions, fmt.Sprintf("comments_enabled = $%d", argIndex))
-		args = append(args, *filter.WithComments)
-		argIndex++
-	}
-
-	// Собираем полный запрос
-	query := baseQuery
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
-	}
-
-	// Добавляем сортировку
-	orderBy := "created_at"
-	if filter.OrderBy != "" {
-		orderBy = filter.OrderBy
-	}
-	orderDir := "DESC"
-	if filter.OrderDir != "" {
-		orderDir = strings.ToUpper(filter.OrderDir)
+// List получает страницу постов с фильтрацией и keyset-пагинацией
+func (r *PostRepository) List(ctx context.Context, filter repomodel.PostFilter) (*repomodel.PostPage, error) {
+	sortExpr, err := postSortExpression(filter.OrderBy, "posts")
+	if err != nil {
+		return nil, err
 	}
-	query += fmt.Sprintf(" ORDER BY %s %s", orderBy, orderDir)
 
-	// Добавляем пагинацию
-	if filter.Limit > 0 {
-		query += fmt.Sprintf(" LIMIT $%d", argIndex)
-		args = append(args, filter.Limit)
-		argIndex++
-	}
+	conditions, args := postFilterConditions(filter, "")
 
-	if filter.Offset > 0 {
-		query += fmt.Sprintf(" OFFSET $%d", argIndex)
-		args = append(args, filter.Offset)
-		argIndex++
+	query := keysetQuery{
+		columns:    "id, title, content, author_id, comments_enabled, language, created_at, updated_at",
+		from:       "posts",
+		existsFrom: "posts",
+		sortExpr:   sortExpr,
+		idExpr:     "posts.id",
+		key:        filter.OrderBy.OrDefault(),
+		conditions: conditions,
+		args:       args,
+		desc:       !strings.EqualFold(filter.OrderDir, "asc"),
+		page:       filter.Page,
 	}
 
-	rows, err := r.pool.Query(ctx, query, args...)
-	if err != nil {
-		r.logger.Error("Failed to list posts", zap.Error(err))
-		return nil, fmt.Errorf("failed to list posts: %w", err)
-	}
-	defer rows.Close()
-
-	var posts []*repomodel.Post
-	for rows.Next() {
+	page, err := queryKeysetPage(ctx, r.db, query, func() (*repomodel.Post, *uuid.UUID, []interface{}) {
 		var post repomodel.Post
-		err := rows.Scan(
+		return &post, &post.ID, []interface{}{
 			&post.ID,
 			&post.Title,
 			&post.Content,
 			&post.AuthorID,
 			&post.CommentsEnabled,
+			&post.Language,
 			&post.CreatedAt,
 			&post.UpdatedAt,
-		)
-		if err != nil {
-			r.logger.Error("Failed to scan post", zap.Error(err))
-			return nil, fmt.Errorf("failed to scan post: %w", err)
 		}
-		posts = append(posts, &post)
-	}
-
-	if err := rows.Err(); err != nil {
-		r.logger.Error("Error iterating posts", zap.Error(err))
-		return nil, fmt.Errorf("error iterating posts: %w", err)
+	})
+	if err != nil {
+		r.logger.Error("Failed to list posts", zap.Error(err))
+		return nil, fmt.Errorf("failed to list posts: %w", err)
 	}
 
-	return posts, nil
+	return &repomodel.PostPage{
+		Posts:           page.items,
+		Cursors:         page.cursors,
+		HasNextPage:     page.hasNext,
+		HasPreviousPage: page.hasPrevious,
+	}, nil
 }
 
 // Count подсчитывает общее количество постов с фильтрацией
 func (r *PostRepository) Count(ctx context.Context, filter repomodel.PostFilter) (int, error) {
+	conditions, args := postFilterConditions(filter, "")
+
+	query := "SELECT COUNT(*) FROM posts"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var count int
+	err := r.db.QueryRow(ctx, query, args...).Scan(&count)
+	if err != nil {
+		r.logger.Error("Failed to count posts", zap.Error(err))
+		return 0, fmt.Errorf("failed to count posts: %w", err)
+	}
+
+	return count, nil
+}
+
+// postFilterConditions возвращает условия фильтра постов и их аргументы;
+// prefix - псевдоним таблицы posts с точкой
+func postFilterConditions(filter repomodel.PostFilter, prefix string) ([]string, []interface{}) {
 	var conditions []string
 	var args []interface{}
-	argIndex := 1
-
-	baseQuery := "SELECT COUNT(*) FROM posts"
 
-	// Добавляем условия фильтрации
 	if filter.AuthorID != nil {
-		conditions = append(conditions, fmt.Sprintf("author_id = $%d", argIndex))
 		args = append(args, *filter.AuthorID)
-		argIndex++
+		conditions = append(conditions, fmt.Sprintf("%sauthor_id = $%d", prefix, len(args)))
 	}
 
 	if filter.WithComments != nil {
-		conditions = append(conditions, fmt.Sprintf("comments_enabled = $%d", argIndex))
 		args = append(args, *filter.WithComments)
-		argIndex++
+		conditions = append(conditions, fmt.Sprintf("%scomments_enabled = $%d", prefix, len(args)))
 	}
 
-	query := baseQuery
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
-	}
+	return conditions, args
+}
 
-	var count int
-	err := r.pool.QueryRow(ctx, query, args...).Scan(&count)
-	if err != nil {
-		r.logger.Error("Failed to count posts", zap.Error(err))
-		return 0, fmt.Errorf("failed to count posts: %w", err)
+// postSortExpression возвращает SQL выражение ключа сортировки постов;
+// table - имя или псевдоним таблицы posts в запросе. Количество комментариев хранит
+// счетчик comment_count, который обновляют триггеры миграции 006; надгробия учитываются
+func postSortExpression(key repomodel.SortKey, table string) (string, error) {
+	switch key.OrDefault() {
+	case repomodel.SortByCreatedAt:
+		return table + ".created_at", nil
+	case repomodel.SortByUpdatedAt:
+		return table + ".updated_at", nil
+	case repomodel.SortByCommentCount:
+		return table + ".comment_count", nil
+	default:
+		return "", fmt.Errorf("unsupported post sort key %q", key)
 	}
-
-	return count, nil
 }
 
 // Update обновляет пост
@@ -230,15 +209,16 @@ func (r *PostRepository) Update(ctx context.Context, post *repomodel.Post) error
 
 	query := `
 		UPDATE posts
-		SET title = $2, content = $3, comments_enabled = $4, updated_at = $5
+		SET title = $2, content = $3, comments_enabled = $4, language = $5, updated_at = $6
 		WHERE id = $1
 	`
 
-	result, err := r.pool.Exec(ctx, query,
+	result, err := r.db.Exec(ctx, query,
 		post.ID,
 		post.Title,
 		post.Content,
 		post.CommentsEnabled,
+		repomodel.SearchLanguageOrDefault(post.Language),
 		post.UpdatedAt,
 	)
 
@@ -263,7 +243,7 @@ func (r *PostRepository) Update(ctx context.Context, post *repomodel.Post) error
 func (r *PostRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := "DELETE FROM posts WHERE id = $1"
 
-	result, err := r.pool.Exec(ctx, query, id)
+	result, err := r.db.Exec(ctx, query, id)
 	if err != nil {
 		r.logger.Error("Failed to delete post",
 			zap.String("post_id", id.String()),
@@ -286,7 +266,7 @@ func (r *PostRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error)
 	query := "SELECT EXISTS(SELECT 1 FROM posts WHERE id = $1)"
 
 	var exists bool
-	err := r.pool.QueryRow(ctx, query, id).Scan(&exists)
+	err := r.db.QueryRow(ctx, query, id).Scan(&exists)
 	if err != nil {
 		r.logger.Error("Failed to check post existence",
 			zap.String("post_id", id.String()),
@@ -298,99 +278,57 @@ func (r *PostRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error)
 	return exists, nil
 }
 
-// ListWithCommentCounts получает посты с количеством комментариев
+// ListWithCommentCounts получает страницу постов с количеством комментариев
 func (r *PostRepository) ListWithCommentCounts(ctx context.Context, filter repomodel.PostFilter) ([]*repomodel.PostWithCommentCount, error) {
-	var conditions []string
-	var args []interface{}
-	argIndex := 1
-
-	baseQuery := `
-		SELECT
-			p.id, p.title, p.content, p.author_id, p.comments_enabled,
-			p.created_at, p.updated_at,
-			COALESCE(c.comment_count, 0) as comment_count
-		FROM posts p
-		LEFT JOIN (
-			SELECT post_id, COUNT(*) as comment_count
-			FROM comments
-			GROUP BY post_id
-		) c ON p.id = c.post_id
-	`
-
-	// Добавляем условия фильтрации
-	if filter.AuthorID != nil {
-		conditions = append(conditions, fmt.Sprintf("p.author_id = $%d", argIndex))
-		args = append(args, *filter.AuthorID)
-		argIndex++
-	}
-
-	if filter.WithComments != nil {
-		conditions = append(conditions, fmt.Sprintf("p.comments_enabled = $%d", argIndex))
-		args = append(args, *filter.WithComments)
-		argIndex++
-	}
-
-	// Собираем полный запрос
-	query := baseQuery
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
-	}
-
-	// Добавляем сортировку
-	orderBy := "created_at"
-	if filter.OrderBy != "" {
-		orderBy = "p." + filter.OrderBy
-	}
-	orderDir := "DESC"
-	if filter.OrderDir != "" {
-		orderDir = strings.ToUpper(filter.OrderDir)
-	}
-	query += fmt.Sprintf(" ORDER BY %s %s", orderBy, orderDir)
-
-	// Добавляем пагинацию
-	if filter.Limit > 0 {
-		query += fmt.Sprintf(" LIMIT $%d", argIndex)
-		args = append(args, filter.Limit)
-		argIndex++
-	}
-
-	if filter.Offset > 0 {
-		query += fmt.Sprintf(" OFFSET $%d", argIndex)
-		args = append(args, filter.Offset)
-		argIndex++
-	}
-
-	rows, err := r.pool.Query(ctx, query, args...)
+	sortExpr, err := postSortExpression(filter.OrderBy, "p")
 	if err != nil {
-		r.logger.Error("Failed to list posts with comment counts", zap.Error(err))
-		return nil, fmt.Errorf("failed to list posts with comment counts: %w", err)
+		return nil, err
 	}
-	defer rows.Close()
 
-	var posts []*repomodel.PostWithCommentCount
-	for rows.Next() {
+	conditions, args := postFilterConditions(filter, "p.")
+
+	query := keysetQuery{
+		columns: `
+			p.id, p.title, p.content, p.author_id, p.comments_enabled, p.language,
+			p.created_at, p.updated_at,
+			COALESCE(c.comment_count, 0) as comment_count
+		`,
+		from: `
+			posts p
+			LEFT JOIN (
+				SELECT post_id, COUNT(*) as comment_count
+				FROM comments
+				GROUP BY post_id
+			) c ON p.id = c.post_id
+		`,
+		existsFrom: "posts p",
+		sortExpr:   sortExpr,
+		idExpr:     "p.id",
+		key:        filter.OrderBy.OrDefault(),
+		conditions: conditions,
+		args:       args,
+		desc:       !strings.EqualFold(filter.OrderDir, "asc"),
+		page:       filter.Page,
+	}
+
+	page, err := queryKeysetPage(ctx, r.db, query, func() (*repomodel.PostWithCommentCount, *uuid.UUID, []interface{}) {
 		var postWithCount repomodel.PostWithCommentCount
-		err := rows.Scan(
+		return &postWithCount, &postWithCount.Post.ID, []interface{}{
 			&postWithCount.Post.ID,
 			&postWithCount.Post.Title,
 			&postWithCount.Post.Content,
 			&postWithCount.Post.AuthorID,
 			&postWithCount.Post.CommentsEnabled,
+			&postWithCount.Post.Language,
 			&postWithCount.Post.CreatedAt,
 			&postWithCount.Post.UpdatedAt,
 			&postWithCount.CommentCount,
-		)
-		if err != nil {
-			r.logger.Error("Failed to scan post with comment count", zap.Error(err))
-			return nil, fmt.Errorf("failed to scan post with comment count: %w", err)
 		}
-		posts = append(posts, &postWithCount)
-	}
-
-	if err := rows.Err(); err != nil {
-		r.logger.Error("Error iterating posts with comment counts", zap.Error(err))
-		return nil, fmt.Errorf("error iterating posts with comment counts: %w", err)
+	})
+	if err != nil {
+		r.logger.Error("Failed to list posts with comment counts", zap.Error(err))
+		return nil, fmt.Errorf("failed to list posts with comment counts: %w", err)
 	}
 
-	return posts, nil
+	return page.items, nil
 }
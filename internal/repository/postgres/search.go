@@ -0,0 +1,202 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+	"go.uber.org/zap"
+)
+
+// headlineOptions - параметры ts_headline для фрагментов результатов поиска
+var headlineOptions = fmt.Sprintf("StartSel=%s, StopSel=%s, MaxWords=35, MinWords=15",
+	repomodel.HighlightStart, repomodel.HighlightStop)
+
+// Search выполняет полнотекстовый поиск постов.
+//
+// Запрос разбирается websearch_to_tsquery (поддерживаются "фразы", OR и -исключения)
+// конфигурацией языка каждого поста, поэтому "комментарии" находит пост со словом
+// "комментарий". Совпадения в заголовке весят больше совпадений в содержимом.
+// Условие поиска использует GIN индекс по search_vector, а ts_headline
+// вычисляется только для строк текущей страницы.
+func (r *PostRepository) Search(ctx context.Context, filter repomodel.SearchFilter) ([]*repomodel.PostSearchHit, error) {
+	condition, tsquery, err := searchCondition("p", filter.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []interface{}{filter.Query, headlineOptions}
+
+	query := fmt.Sprintf(`
+		SELECT id, title, content, author_id, comments_enabled, language, created_at, updated_at, rank,
+			ts_headline(language::regconfig, content, query, $2) AS snippet
+		FROM (
+			SELECT p.id, p.title, p.content, p.author_id, p.comments_enabled, p.language, p.created_at, p.updated_at,
+				%[2]s AS query,
+				ts_rank(p.search_vector, %[2]s) AS rank
+			FROM posts p
+			WHERE %[1]s
+		) hits
+	`, condition, tsquery)
+
+	query, args = appendSearchPage(query, args, filter)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Failed to search posts", zap.String("query", filter.Query), zap.Error(err))
+		return nil, fmt.Errorf("failed to search posts: %w", err)
+	}
+	defer rows.Close()
+
+	var hits []*repomodel.PostSearchHit
+	for rows.Next() {
+		var hit repomodel.PostSearchHit
+		err := rows.Scan(
+			&hit.ID,
+			&hit.Title,
+			&hit.Content,
+			&hit.AuthorID,
+			&hit.CommentsEnabled,
+			&hit.Language,
+			&hit.CreatedAt,
+			&hit.UpdatedAt,
+			&hit.Rank,
+			&hit.Snippet,
+		)
+		if err != nil {
+			r.logger.Error("Failed to scan post search hit", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan post search hit: %w", err)
+		}
+		hits = append(hits, &hit)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating post search hits", zap.Error(err))
+		return nil, fmt.Errorf("error iterating post search hits: %w", err)
+	}
+
+	return hits, nil
+}
+
+// Search выполняет полнотекстовый поиск комментариев, при заданном filter.PostID - в пределах поста
+func (r *CommentRepository) Search(ctx context.Context, filter repomodel.SearchFilter) ([]*repomodel.CommentSearchHit, error) {
+	condition, tsquery, err := searchCondition("c", filter.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	// Надгробия удаленных комментариев не участвуют в поиске
+	condition += " AND c.deleted_at IS NULL"
+
+	args := []interface{}{filter.Query, headlineOptions}
+
+	if filter.PostID != nil {
+		args = append(args, *filter.PostID)
+		condition += fmt.Sprintf(" AND c.post_id = $%d", len(args))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, post_id, parent_id, content, author_id, depth, language, created_at, updated_at, rank,
+			ts_headline(language::regconfig, content, query, $2) AS snippet
+		FROM (
+			SELECT c.id, c.post_id, c.parent_id, c.content, c.author_id, c.depth, c.language, c.created_at, c.updated_at,
+				%[2]s AS query,
+				ts_rank(c.search_vector, %[2]s) AS rank
+			FROM comments c
+			WHERE %[1]s
+		) hits
+	`, condition, tsquery)
+
+	query, args = appendSearchPage(query, args, filter)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Failed to search comments", zap.String("query", filter.Query), zap.Error(err))
+		return nil, fmt.Errorf("failed to search comments: %w", err)
+	}
+	defer rows.Close()
+
+	var hits []*repomodel.CommentSearchHit
+	for rows.Next() {
+		var hit repomodel.CommentSearchHit
+		err := rows.Scan(
+			&hit.ID,
+			&hit.PostID,
+			&hit.ParentID,
+			&hit.Content,
+			&hit.AuthorID,
+			&hit.Depth,
+			&hit.Language,
+			&hit.CreatedAt,
+			&hit.UpdatedAt,
+			&hit.Rank,
+			&hit.Snippet,
+		)
+		if err != nil {
+			r.logger.Error("Failed to scan comment search hit", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan comment search hit: %w", err)
+		}
+		hits = append(hits, &hit)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating comment search hits", zap.Error(err))
+		return nil, fmt.Errorf("error iterating comment search hits: %w", err)
+	}
+
+	return hits, nil
+}
+
+// searchCondition строит условие поиска по search_vector таблицы alias и выражение
+// разобранного запроса ($1) в конфигурации языка каждой строки.
+//
+// Для каждого языка условие сравнивает search_vector только с запросом, разобранным той же
+// конфигурацией: стемминг запроса и документа должен совпадать. Пустой language означает
+// поиск по документам всех языков.
+func searchCondition(alias, language string) (condition, tsquery string, err error) {
+	languages := repomodel.SearchLanguages
+	if language != "" {
+		if !slices.Contains(repomodel.SearchLanguages, language) {
+			return "", "", fmt.Errorf("unsupported search language: %q", language)
+		}
+		languages = []string{language}
+	}
+
+	conditions := make([]string, len(languages))
+	var cases strings.Builder
+	for i, lang := range languages {
+		conditions[i] = fmt.Sprintf("(%[1]s.language = '%[2]s' AND %[1]s.search_vector @@ websearch_to_tsquery('%[2]s', $1))",
+			alias, lang)
+		fmt.Fprintf(&cases, " WHEN '%[1]s' THEN websearch_to_tsquery('%[1]s', $1)", lang)
+	}
+
+	condition = "(" + strings.Join(conditions, " OR ") + ")"
+	tsquery = fmt.Sprintf("CASE %s.language%s END", alias, cases.String())
+	return condition, tsquery, nil
+}
+
+// appendSearchPage добавляет к запросу поиска позицию курсора, сортировку по релевантности и лимит
+func appendSearchPage(query string, args []interface{}, filter repomodel.SearchFilter) (string, []interface{}) {
+	var conditions []string
+
+	if filter.AfterRank != nil && filter.AfterID != nil {
+		args = append(args, *filter.AfterRank, *filter.AfterID)
+		conditions = append(conditions, fmt.Sprintf("(rank < $%d OR (rank = $%d AND id > $%d))",
+			len(args)-1, len(args)-1, len(args)))
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY rank DESC, id ASC"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	return query, args
+}
@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NarthurN/habbr/internal/repository"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// StatsRepository реализует repository.StatsRepository для PostgreSQL.
+//
+// Статистика вычисляется агрегатными запросами по индексу comments(post_id),
+// поэтому комментарии поста не загружаются в приложение.
+type StatsRepository struct {
+	db     DBTX
+	logger *zap.Logger
+}
+
+// NewStatsRepository создает новый PostgreSQL репозиторий статистики
+func NewStatsRepository(db DBTX, logger *zap.Logger) repository.StatsRepository {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &StatsRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetCommentStats вычисляет статистику комментариев поста двумя запросами:
+// агрегаты по всем комментариям и распределение комментариев по глубине
+func (r *StatsRepository) GetCommentStats(ctx context.Context, postID uuid.UUID) (*repomodel.CommentStats, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE parent_id IS NULL),
+			COALESCE(MAX(depth), 0),
+			COALESCE(AVG(depth), 0)::float8,
+			COUNT(DISTINCT author_id) FILTER (WHERE deleted_at IS NULL),
+			MAX(created_at)
+		FROM comments
+		WHERE post_id = $1
+	`
+
+	var stats repomodel.CommentStats
+	err := r.db.QueryRow(ctx, query, postID).Scan(
+		&stats.TotalComments,
+		&stats.RootComments,
+		&stats.MaxDepth,
+		&stats.AverageDepth,
+		&stats.UniqueCommenters,
+		&stats.LastCommentAt,
+	)
+	if err != nil {
+		r.logger.Error("Failed to get comment stats",
+			zap.String("post_id", postID.String()),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to get comment stats: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT depth, COUNT(*)
+		FROM comments
+		WHERE post_id = $1
+		GROUP BY depth
+		ORDER BY depth
+	`, postID)
+	if err != nil {
+		r.logger.Error("Failed to get comment depth histogram",
+			zap.String("post_id", postID.String()),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to get comment depth histogram: %w", err)
+	}
+	defer rows.Close()
+
+	stats.DepthHistogram = make([]repomodel.DepthCount, 0, stats.MaxDepth+1)
+	for rows.Next() {
+		var level repomodel.DepthCount
+		if err := rows.Scan(&level.Depth, &level.Count); err != nil {
+			r.logger.Error("Failed to scan comment depth histogram", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan comment depth histogram: %w", err)
+		}
+		stats.DepthHistogram = append(stats.DepthHistogram, level)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error getting comment depth histogram", zap.Error(err))
+		return nil, fmt.Errorf("failed to get comment depth histogram: %w", err)
+	}
+
+	return &stats, nil
+}
@@ -0,0 +1,164 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NarthurN/habbr/internal/config"
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/NarthurN/habbr/internal/repository"
+	"github.com/NarthurN/habbr/internal/repository/converter"
+	"go.uber.org/zap"
+)
+
+// Metrics содержит метрики обновления аналитики
+type Metrics struct {
+	// LastRefreshAt - время последнего успешного обновления (nil, если обновлений не было)
+	LastRefreshAt *time.Time `json:"last_refresh_at"`
+
+	// RefreshFailures - количество неудачных обновлений
+	RefreshFailures int64 `json:"refresh_failures"`
+}
+
+// Service реализует аналитику постов для редакционной панели.
+//
+// Данные читаются из repository.AnalyticsRepository. Если задан интервал
+// config.AnalyticsConfig.RefreshInterval, фоновая горутина обновляет их при запуске
+// и затем с этим интервалом; ошибки обновления логируются, и выдаются прежние данные.
+type Service struct {
+	analyticsRepo repository.AnalyticsRepository
+	logger        *zap.Logger
+
+	mu      sync.Mutex
+	metrics Metrics
+
+	cancel    context.CancelFunc
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewService создает новый сервис аналитики и запускает фоновое обновление
+func NewService(repos *repository.Repositories, cfg config.AnalyticsConfig, logger *zap.Logger) *Service {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	service := &Service{
+		analyticsRepo: repos.Analytics,
+		logger:        logger,
+		cancel:        cancel,
+		done:          make(chan struct{}),
+	}
+
+	if cfg.RefreshInterval > 0 {
+		go service.startRefreshRoutine(ctx, cfg.RefreshInterval)
+	} else {
+		close(service.done)
+	}
+
+	logger.Info("Analytics service initialized", zap.Duration("refresh_interval", cfg.RefreshInterval))
+
+	return service
+}
+
+// ListPostAnalytics возвращает аналитику постов, соответствующих фильтру,
+// не больше first записей (по умолчанию model.DefaultPostAnalyticsLimit)
+func (s *Service) ListPostAnalytics(ctx context.Context, filter model.PostAnalyticsFilter, first *int) ([]*model.PostAnalytics, error) {
+	s.logger.Debug("Listing post analytics", zap.Any("filter", filter), zap.Any("first", first))
+
+	if !filter.Order.OrDefault().IsValid() {
+		return nil, model.NewValidationError("order", fmt.Sprintf("unsupported post analytics order %q", filter.Order))
+	}
+
+	limit := model.DefaultPostAnalyticsLimit
+	if first != nil {
+		if *first <= 0 || *first > model.MaxPostAnalyticsLimit {
+			return nil, model.NewValidationError("first", fmt.Sprintf("first must be between 1 and %d", model.MaxPostAnalyticsLimit))
+		}
+		limit = *first
+	}
+
+	if filter.MinComments != nil && *filter.MinComments < 0 {
+		return nil, model.NewValidationError("min_comments", "min comments cannot be negative")
+	}
+
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return nil, model.NewValidationError("created_after", "created after must be earlier than created before")
+	}
+
+	repoItems, err := s.analyticsRepo.List(ctx, converter.PostAnalyticsFilterToRepo(filter, limit))
+	if err != nil {
+		s.logger.Error("Failed to list post analytics from repository", zap.Error(err))
+		return nil, model.NewInternalError(fmt.Sprintf("failed to list post analytics: %v", err))
+	}
+
+	items := make([]*model.PostAnalytics, len(repoItems))
+	for i, repoItem := range repoItems {
+		items[i] = converter.PostAnalyticsFromRepo(repoItem)
+	}
+
+	s.logger.Debug("Post analytics listed successfully", zap.Int("count", len(items)))
+
+	return items, nil
+}
+
+// RefreshPostAnalytics обновляет данные аналитики постов
+func (s *Service) RefreshPostAnalytics(ctx context.Context) error {
+	start := time.Now()
+
+	if err := s.analyticsRepo.Refresh(ctx); err != nil {
+		s.mu.Lock()
+		s.metrics.RefreshFailures++
+		s.mu.Unlock()
+
+		s.logger.Error("Failed to refresh post analytics", zap.Error(err))
+		return model.NewInternalError(fmt.Sprintf("failed to refresh post analytics: %v", err))
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	s.metrics.LastRefreshAt = &now
+	s.mu.Unlock()
+
+	s.logger.Debug("Post analytics refreshed", zap.Duration("duration", now.Sub(start)))
+
+	return nil
+}
+
+// GetMetrics возвращает метрики обновления аналитики
+func (s *Service) GetMetrics() Metrics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.metrics
+}
+
+// Close останавливает фоновое обновление и дожидается завершения текущего
+func (s *Service) Close() {
+	s.closeOnce.Do(func() {
+		s.logger.Info("Shutting down analytics service")
+		s.cancel()
+		<-s.done
+	})
+}
+
+// startRefreshRoutine обновляет аналитику при запуске и затем с интервалом interval
+func (s *Service) startRefreshRoutine(ctx context.Context, interval time.Duration) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// Ошибка уже залогирована, следующая попытка - через интервал
+		_ = s.RefreshPostAnalytics(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
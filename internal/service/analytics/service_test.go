@@ -0,0 +1,178 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/NarthurN/habbr/internal/config"
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/NarthurN/habbr/internal/repository"
+	"github.com/NarthurN/habbr/internal/repository/memory"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+)
+
+func TestListPostAnalytics(t *testing.T) {
+	ctx := context.Background()
+	repos := memory.NewManager().GetRepositories()
+	service := NewService(repos, config.AnalyticsConfig{}, zap.NewNop())
+	defer service.Close()
+
+	created := time.Now().Add(-time.Hour)
+	author := uuid.New()
+	newPost := func(authorID uuid.UUID) *repomodel.Post {
+		created = created.Add(time.Minute)
+		post := &repomodel.Post{
+			ID: uuid.New(), Title: "Post", Content: "Content", AuthorID: authorID,
+			CommentsEnabled: true, CreatedAt: created, UpdatedAt: created,
+		}
+		require.NoError(t, repos.Post.Create(ctx, post))
+		return post
+	}
+	comment := func(post *repomodel.Post, parent *repomodel.Comment) *repomodel.Comment {
+		created = created.Add(time.Minute)
+		c := &repomodel.Comment{
+			ID: uuid.New(), PostID: post.ID, Content: "Comment", AuthorID: uuid.New(),
+			CreatedAt: created, UpdatedAt: created,
+		}
+		if parent != nil {
+			c.ParentID = &parent.ID
+			c.Depth = parent.Depth + 1
+		}
+		require.NoError(t, repos.Comment.Create(ctx, c))
+		return c
+	}
+
+	// busy: 3 комментария, глубина 1; deep: 3 комментария, глубина 2, прокомментирован последним;
+	// empty: без комментариев, создан последним
+	busy := newPost(author)
+	deep := newPost(uuid.New())
+	empty := newPost(author)
+
+	root := comment(busy, nil)
+	comment(busy, root)
+	comment(busy, nil)
+	deepRoot := comment(deep, nil)
+	comment(deep, comment(deep, deepRoot))
+
+	ids := func(items []*model.PostAnalytics) []uuid.UUID {
+		var values []uuid.UUID
+		for _, item := range items {
+			values = append(values, item.PostID)
+		}
+		return values
+	}
+
+	t.Run("aggregates", func(t *testing.T) {
+		items, err := service.ListPostAnalytics(ctx, model.PostAnalyticsFilter{AuthorID: &author}, nil)
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+
+		assert.Equal(t, busy.ID, items[0].PostID)
+		assert.Equal(t, 3, items[0].TotalComments)
+		assert.Equal(t, 2, items[0].RootComments)
+		assert.Equal(t, 1, items[0].MaxCommentDepth)
+		require.NotNil(t, items[0].LastCommentAt)
+
+		assert.Equal(t, empty.ID, items[1].PostID)
+		assert.Zero(t, items[1].TotalComments)
+		assert.Nil(t, items[1].LastCommentAt)
+	})
+
+	t.Run("orders", func(t *testing.T) {
+		for order, want := range map[model.PostAnalyticsOrder][]uuid.UUID{
+			model.PostAnalyticsOrderRecentlyCommented: {deep.ID, busy.ID, empty.ID},
+			model.PostAnalyticsOrderDeepest:           {deep.ID, busy.ID, empty.ID},
+			model.PostAnalyticsOrderNewest:            {empty.ID, deep.ID, busy.ID},
+		} {
+			items, err := service.ListPostAnalytics(ctx, model.PostAnalyticsFilter{Order: order}, nil)
+			require.NoError(t, err)
+			assert.Equal(t, want, ids(items), order)
+		}
+	})
+
+	t.Run("filters and limit", func(t *testing.T) {
+		minComments := 1
+		first := 1
+		items, err := service.ListPostAnalytics(ctx, model.PostAnalyticsFilter{
+			MinComments: &minComments,
+			Order:       model.PostAnalyticsOrderNewest,
+		}, &first)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{deep.ID}, ids(items))
+
+		items, err = service.ListPostAnalytics(ctx, model.PostAnalyticsFilter{
+			CreatedAfter:  &deep.CreatedAt,
+			CreatedBefore: &empty.CreatedAt,
+		}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{deep.ID}, ids(items))
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		tooMany := model.MaxPostAnalyticsLimit + 1
+		_, err := service.ListPostAnalytics(ctx, model.PostAnalyticsFilter{}, &tooMany)
+		assert.Error(t, err)
+
+		_, err = service.ListPostAnalytics(ctx, model.PostAnalyticsFilter{Order: "POPULAR"}, nil)
+		assert.Error(t, err)
+
+		negative := -1
+		_, err = service.ListPostAnalytics(ctx, model.PostAnalyticsFilter{MinComments: &negative}, nil)
+		assert.Error(t, err)
+
+		_, err = service.ListPostAnalytics(ctx, model.PostAnalyticsFilter{
+			CreatedAfter:  &empty.CreatedAt,
+			CreatedBefore: &busy.CreatedAt,
+		}, nil)
+		assert.Error(t, err)
+	})
+}
+
+// refreshCounter считает вызовы Refresh и возвращает err
+type refreshCounter struct {
+	repository.AnalyticsRepository
+	refreshes chan struct{}
+	err       error
+}
+
+func (r *refreshCounter) Refresh(ctx context.Context) error {
+	r.refreshes <- struct{}{}
+	return r.err
+}
+
+func TestRefreshRoutine(t *testing.T) {
+	repo := &refreshCounter{refreshes: make(chan struct{}, 10), err: errors.New("refresh failed")}
+	service := NewService(&repository.Repositories{Analytics: repo}, config.AnalyticsConfig{RefreshInterval: 10 * time.Millisecond}, zap.NewNop())
+
+	// Первое обновление при запуске, следующие - по интервалу, несмотря на ошибки
+	for range 3 {
+		select {
+		case <-repo.refreshes:
+		case <-time.After(time.Second):
+			t.Fatal("analytics was not refreshed")
+		}
+	}
+
+	service.Close()
+	assert.GreaterOrEqual(t, service.GetMetrics().RefreshFailures, int64(3))
+	assert.Nil(t, service.GetMetrics().LastRefreshAt)
+
+	// После Close обновления прекращаются
+	for len(repo.refreshes) > 0 {
+		<-repo.refreshes
+	}
+	time.Sleep(30 * time.Millisecond)
+	assert.Empty(t, repo.refreshes)
+
+	repo.err = nil
+	require.NoError(t, service.RefreshPostAnalytics(context.Background()))
+	<-repo.refreshes
+	assert.NotNil(t, service.GetMetrics().LastRefreshAt)
+}
@@ -0,0 +1,243 @@
+package comment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/NarthurN/habbr/internal/repository"
+	"github.com/NarthurN/habbr/internal/repository/converter"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DeleteCommentsBatch удаляет комментарии поста вместе с ответами.
+//
+// Все ID проверяются до удаления: комментарий должен существовать и принадлежать
+// посту postID, а пользователь должен быть его автором или модератором. В атомарном
+// режиме проверка и удаление выполняются в одной транзакции, и если хотя бы один
+// комментарий не удается удалить, не удаляется ни один. В обычном режиме удаляются
+// все комментарии, прошедшие проверку.
+func (s *Service) DeleteCommentsBatch(ctx context.Context, postID uuid.UUID, ids []uuid.UUID, actor *model.Principal, atomic bool) (*model.CommentBatchDeleteResult, error) {
+	s.logger.Debug("Deleting comments batch",
+		zap.String("post_id", postID.String()),
+		zap.Int("count", len(ids)),
+		zap.Bool("atomic", atomic),
+	)
+
+	if postID == uuid.Nil {
+		s.logger.Warn("Attempt to delete comments batch with nil post ID")
+		return nil, model.NewValidationError("post_id", "post ID is required")
+	}
+
+	if actor == nil || actor.UserID == uuid.Nil {
+		s.logger.Warn("Attempt to delete comments batch without user",
+			zap.String("post_id", postID.String()),
+		)
+		return nil, model.NewUnauthorizedError()
+	}
+
+	if len(ids) == 0 {
+		return nil, model.NewValidationError("comment_ids", "at least one comment ID is required")
+	}
+
+	if len(ids) > model.MaxCommentBatchSize {
+		s.logger.Warn("Comments batch is too large",
+			zap.String("post_id", postID.String()),
+			zap.Int("count", len(ids)),
+		)
+		return nil, model.NewValidationError("comment_ids", fmt.Sprintf("batch cannot contain more than %d comments", model.MaxCommentBatchSize))
+	}
+
+	// Повторы ID получают один итог
+	items := make([]*model.CommentDeleteItem, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			items = append(items, &model.CommentDeleteItem{ID: id})
+		}
+	}
+
+	var deleted []*model.Comment
+	var err error
+	if atomic {
+		deleted, err = s.deleteBatchAtomic(ctx, postID, items, actor)
+	} else {
+		deleted, err = s.deleteBatchEach(ctx, postID, items, actor)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.CommentBatchDeleteResult{
+		Items:      items,
+		DeletedIDs: make([]uuid.UUID, len(deleted)),
+	}
+	for i, deletedComment := range deleted {
+		result.DeletedIDs[i] = deletedComment.ID
+	}
+
+	s.logger.Info("Comments batch deletion completed",
+		zap.String("post_id", postID.String()),
+		zap.String("user_id", actor.UserID.String()),
+		zap.Bool("atomic", atomic),
+		zap.Bool("success", result.Success()),
+		zap.Int("requested", len(items)),
+		zap.Int("deleted_comments_count", len(deleted)),
+	)
+
+	s.publishDeleted(deleted)
+
+	return result, nil
+}
+
+// deleteBatchAtomic удаляет все комментарии пакета в одной транзакции или не удаляет ни одного
+func (s *Service) deleteBatchAtomic(ctx context.Context, postID uuid.UUID, items []*model.CommentDeleteItem, actor *model.Principal) ([]*model.Comment, error) {
+	var deleted []*model.Comment
+	err := s.withinTx(ctx, func(tx *Service) error {
+		valid, err := tx.checkBatch(ctx, postID, items, actor)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			return errRollback
+		}
+
+		removed := make(map[uuid.UUID]bool)
+		for _, item := range items {
+			deleted = append(deleted, tx.deleteBatchItem(ctx, item, removed)...)
+			if item.Status != model.CommentDeleteStatusDeleted {
+				return errRollback
+			}
+		}
+		return nil
+	})
+
+	if errors.Is(err, errRollback) {
+		// Удаления откачены: прошедшие проверку комментарии отмечаются как пропущенные
+		for _, item := range items {
+			if item.Status == "" || item.Status == model.CommentDeleteStatusDeleted {
+				item.Status = model.CommentDeleteStatusSkipped
+				item.Message = "batch deletion aborted by another comment"
+			}
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return deleted, nil
+}
+
+// deleteBatchEach удаляет каждый прошедший проверку комментарий пакета отдельно
+func (s *Service) deleteBatchEach(ctx context.Context, postID uuid.UUID, items []*model.CommentDeleteItem, actor *model.Principal) ([]*model.Comment, error) {
+	if _, err := s.checkBatch(ctx, postID, items, actor); err != nil {
+		return nil, err
+	}
+
+	var deleted []*model.Comment
+	removed := make(map[uuid.UUID]bool)
+	for _, item := range items {
+		if item.Status == "" {
+			deleted = append(deleted, s.deleteBatchItem(ctx, item, removed)...)
+		}
+	}
+
+	return deleted, nil
+}
+
+// checkBatch проверяет пост и каждый комментарий пакета, отмечая итог не прошедших
+// проверку. Возвращает true, если проверку прошли все комментарии
+func (s *Service) checkBatch(ctx context.Context, postID uuid.UUID, items []*model.CommentDeleteItem, actor *model.Principal) (bool, error) {
+	exists, err := s.postRepo.Exists(ctx, postID)
+	if err != nil {
+		s.logger.Error("Failed to check post existence for batch deletion",
+			zap.Error(err),
+			zap.String("post_id", postID.String()),
+		)
+		return false, model.NewInternalError(fmt.Sprintf("failed to check post existence: %v", err))
+	}
+	if !exists {
+		return false, model.NewNotFoundError("post", postID)
+	}
+
+	moderator := actor.HasRole(model.RoleModerator)
+	valid := true
+	for _, item := range items {
+		repoComment, err := s.commentRepo.GetByID(ctx, item.ID)
+		if err != nil && err != repository.ErrNotFound {
+			s.logger.Error("Failed to get comment for batch deletion",
+				zap.Error(err),
+				zap.String("comment_id", item.ID.String()),
+			)
+			return false, model.NewInternalError(fmt.Sprintf("failed to get comment: %v", err))
+		}
+
+		switch {
+		case err == repository.ErrNotFound:
+			item.Status = model.CommentDeleteStatusNotFound
+			item.Message = "comment not found"
+		case repoComment.PostID != postID:
+			item.Status = model.CommentDeleteStatusWrongPost
+			item.Message = "comment belongs to another post"
+		case repoComment.AuthorID != actor.UserID && !moderator:
+			s.logger.Warn("Unauthorized attempt to delete comment in batch",
+				zap.String("comment_id", item.ID.String()),
+				zap.String("comment_author", repoComment.AuthorID.String()),
+				zap.String("requesting_user", actor.UserID.String()),
+			)
+			item.Status = model.CommentDeleteStatusForbidden
+			item.Message = "only the author or a moderator can delete the comment"
+		default:
+			continue
+		}
+		valid = false
+	}
+
+	return valid, nil
+}
+
+// deleteBatchItem удаляет комментарий пакета с ответами и надгробиями предков, оставшимися
+// без ответов, и отмечает его итог.
+// removed содержит уже удаленные в пакете комментарии: ответ удаленного ранее
+// комментария считается удаленным
+func (s *Service) deleteBatchItem(ctx context.Context, item *model.CommentDeleteItem, removed map[uuid.UUID]bool) []*model.Comment {
+	if removed[item.ID] {
+		item.Status = model.CommentDeleteStatusDeleted
+		return nil
+	}
+
+	repoDeleted, err := s.commentRepo.DeleteSubtree(ctx, item.ID)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			item.Status = model.CommentDeleteStatusNotFound
+			item.Message = "comment not found"
+			return nil
+		}
+
+		s.logger.Error("Failed to delete comment subtree in batch",
+			zap.Error(err),
+			zap.String("comment_id", item.ID.String()),
+		)
+		item.Status = model.CommentDeleteStatusFailed
+		item.Message = "failed to delete comment"
+		return nil
+	}
+
+	deleted := converter.CommentsFromRepo(repoDeleted)
+
+	// Поддерево уже удалено, поэтому ошибка удаления надгробий предков только
+	// записывается в журнал: надгробия остаются в дереве
+	if pruned, err := s.pruneTombstones(ctx, deleted[0].ParentID); err == nil {
+		deleted = append(deleted, pruned...)
+	}
+
+	for _, deletedComment := range deleted {
+		removed[deletedComment.ID] = true
+	}
+	item.Status = model.CommentDeleteStatusDeleted
+	return deleted
+}
@@ -0,0 +1,140 @@
+package comment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/NarthurN/habbr/internal/repository/memory"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+)
+
+func TestDeleteCommentsBatch(t *testing.T) {
+	ctx := context.Background()
+	author := &model.Principal{UserID: uuid.New(), Role: model.RoleAuthor}
+	stranger := uuid.New()
+
+	// setup создает пост с комментариями автора (первый с ответом), чужим
+	// комментарием и комментарием другого поста
+	setup := func(t *testing.T) (*Service, *memory.Manager, uuid.UUID, []uuid.UUID) {
+		manager := memory.NewManager()
+		repos := manager.GetRepositories()
+		service := NewService(repos, manager, zap.NewNop(), nil)
+
+		post := &repomodel.Post{
+			ID: uuid.New(), Title: "Post", Content: "Content", AuthorID: author.UserID,
+			CommentsEnabled: true, CreatedAt: time.Now(), UpdatedAt: time.Now(),
+		}
+		require.NoError(t, repos.Post.Create(ctx, post))
+		other := &repomodel.Post{
+			ID: uuid.New(), Title: "Other", Content: "Content", AuthorID: author.UserID,
+			CommentsEnabled: true, CreatedAt: time.Now(), UpdatedAt: time.Now(),
+		}
+		require.NoError(t, repos.Post.Create(ctx, other))
+
+		created := time.Now()
+		create := func(postID uuid.UUID, authorID uuid.UUID, parentID *uuid.UUID) uuid.UUID {
+			created = created.Add(time.Second)
+			comment := &repomodel.Comment{
+				ID: uuid.New(), PostID: postID, ParentID: parentID, Content: "Comment",
+				AuthorID: authorID, CreatedAt: created, UpdatedAt: created,
+			}
+			if parentID != nil {
+				comment.Depth = 1
+			}
+			require.NoError(t, repos.Comment.Create(ctx, comment))
+			return comment.ID
+		}
+
+		postID := post.ID
+		own := create(postID, author.UserID, nil)
+		reply := create(postID, stranger, &own)
+		second := create(postID, author.UserID, nil)
+		foreign := create(postID, stranger, nil)
+		otherPost := create(other.ID, author.UserID, nil)
+
+		return service, manager, postID, []uuid.UUID{own, reply, second, foreign, otherPost}
+	}
+
+	statuses := func(result *model.CommentBatchDeleteResult) []model.CommentDeleteStatus {
+		var values []model.CommentDeleteStatus
+		for _, item := range result.Items {
+			values = append(values, item.Status)
+		}
+		return values
+	}
+
+	t.Run("best effort deletes valid comments", func(t *testing.T) {
+		service, manager, postID, ids := setup(t)
+		own, reply, second, foreign, otherPost := ids[0], ids[1], ids[2], ids[3], ids[4]
+		missing := uuid.New()
+
+		result, err := service.DeleteCommentsBatch(ctx, postID, []uuid.UUID{own, foreign, otherPost, missing, second, own}, author, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, []model.CommentDeleteStatus{
+			model.CommentDeleteStatusDeleted,
+			model.CommentDeleteStatusForbidden,
+			model.CommentDeleteStatusWrongPost,
+			model.CommentDeleteStatusNotFound,
+			model.CommentDeleteStatusDeleted,
+		}, statuses(result))
+		assert.False(t, result.Success())
+		assert.ElementsMatch(t, []uuid.UUID{own, reply, second}, result.DeletedIDs)
+
+		exists, err := manager.GetRepositories().Comment.Exists(ctx, foreign)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("atomic deletes nothing when one comment fails", func(t *testing.T) {
+		service, manager, postID, ids := setup(t)
+		own, foreign := ids[0], ids[3]
+
+		result, err := service.DeleteCommentsBatch(ctx, postID, []uuid.UUID{own, foreign}, author, true)
+		require.NoError(t, err)
+
+		assert.Equal(t, []model.CommentDeleteStatus{
+			model.CommentDeleteStatusSkipped,
+			model.CommentDeleteStatusForbidden,
+		}, statuses(result))
+		assert.Empty(t, result.DeletedIDs)
+
+		exists, err := manager.GetRepositories().Comment.Exists(ctx, own)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("atomic with reply requested after its parent", func(t *testing.T) {
+		service, _, postID, ids := setup(t)
+		own, reply, second := ids[0], ids[1], ids[2]
+		moderator := &model.Principal{UserID: uuid.New(), Role: model.RoleModerator}
+
+		result, err := service.DeleteCommentsBatch(ctx, postID, []uuid.UUID{own, reply, second}, moderator, true)
+		require.NoError(t, err)
+
+		assert.True(t, result.Success())
+		assert.Equal(t, []uuid.UUID{own, reply, second}, result.DeletedIDs)
+	})
+
+	t.Run("operation errors", func(t *testing.T) {
+		service, _, postID, ids := setup(t)
+
+		_, err := service.DeleteCommentsBatch(ctx, postID, nil, author, false)
+		assert.Error(t, err)
+
+		_, err = service.DeleteCommentsBatch(ctx, postID, ids, nil, false)
+		assert.Error(t, err)
+
+		_, err = service.DeleteCommentsBatch(ctx, uuid.New(), ids, author, true)
+		var domainErr *model.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "NOT_FOUND", domainErr.Type)
+	})
+}
@@ -0,0 +1,104 @@
+package comment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/NarthurN/habbr/internal/repository/converter"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+	"github.com/NarthurN/habbr/internal/service/pagination"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// GetCommentContext загружает комментарий с ближайшими предками и первой страницей
+// прямых ответов.
+//
+// ancestors ограничивает количество предков (по умолчанию model.DefaultCommentContextAncestors),
+// replies - количество ответов (по умолчанию model.DefaultCommentTreePageSize). Ответы
+// упорядочены от старых к новым; для ответов, у которых есть свои ответы, установлен
+// признак продолжения ветки. TotalReplies считается по всему поддереву комментария.
+func (s *Service) GetCommentContext(ctx context.Context, id uuid.UUID, ancestors, replies *int) (*model.CommentContext, error) {
+	s.logger.Debug("Getting comment context",
+		zap.String("comment_id", id.String()),
+		zap.Any("ancestors", ancestors),
+		zap.Any("replies", replies),
+	)
+
+	if id == uuid.Nil {
+		return nil, model.NewValidationError("id", "comment ID is required")
+	}
+
+	maxAncestors, err := treeLimit("ancestors", ancestors, model.DefaultCommentContextAncestors, 0, model.MaxCommentContextAncestors)
+	if err != nil {
+		return nil, err
+	}
+
+	repliesFirst, err := treeLimit("replies", replies, model.DefaultCommentTreePageSize, 1, model.MaxCommentTreePageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := s.commentRepo.GetCommentPath(ctx, id, maxAncestors)
+	if err != nil {
+		s.logger.Error("Failed to get comment path from repository",
+			zap.Error(err),
+			zap.String("comment_id", id.String()),
+		)
+		return nil, model.NewInternalError(fmt.Sprintf("failed to get comment path: %v", err))
+	}
+	if len(path) == 0 {
+		return nil, model.NewNotFoundError("comment", id)
+	}
+
+	result := &model.CommentContext{
+		Comment:   converter.CommentFromRepo(path[len(path)-1]),
+		Ancestors: make([]*model.Comment, len(path)-1),
+	}
+	for i, repoComment := range path[:len(path)-1] {
+		result.Ancestors[i] = converter.CommentFromRepo(repoComment)
+	}
+	result.HasMoreAncestors = path[0].ParentID != nil
+
+	// Прямые ответы загружаются как ветка без уровней ответов
+	filter := model.CommentFilter{PostID: &result.Comment.PostID, ParentID: &id}
+	key, _ := converter.CommentOrderToRepo(filter.Order)
+	page, err := pagination.Page(model.PaginationInput{First: &repliesFirst}, key,
+		model.DefaultCommentTreePageSize, model.MaxCommentTreePageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	repoTree, err := s.commentRepo.ListTree(ctx, repomodel.CommentTreeFilter{
+		CommentFilter: converter.CommentFilterToRepo(filter, page),
+	})
+	if err != nil {
+		s.logger.Error("Failed to list comment replies from repository",
+			zap.Error(err),
+			zap.String("comment_id", id.String()),
+		)
+		return nil, model.NewInternalError(fmt.Sprintf("failed to list comment replies: %v", err))
+	}
+	result.Replies = buildCommentTree(repoTree, 0)
+
+	// Поддерево включает сам комментарий
+	subtreeSize, err := s.commentRepo.CountSubtree(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to count comment subtree in repository",
+			zap.Error(err),
+			zap.String("comment_id", id.String()),
+		)
+		return nil, model.NewInternalError(fmt.Sprintf("failed to count comment subtree: %v", err))
+	}
+	result.TotalReplies = subtreeSize - 1
+
+	s.logger.Debug("Comment context loaded successfully",
+		zap.String("comment_id", id.String()),
+		zap.Int("ancestors", len(result.Ancestors)),
+		zap.Int("replies", len(result.Replies.Nodes)),
+		zap.Int("total_replies", result.TotalReplies),
+	)
+
+	return result, nil
+}
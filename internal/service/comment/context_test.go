@@ -0,0 +1,125 @@
+package comment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/NarthurN/habbr/internal/repository/memory"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+)
+
+func TestGetCommentContext(t *testing.T) {
+	ctx := context.Background()
+	manager := memory.NewManager()
+	repos := manager.GetRepositories()
+	service := NewService(repos, manager, zap.NewNop(), nil)
+
+	post := &repomodel.Post{
+		ID: uuid.New(), Title: "Post", Content: "Content", AuthorID: uuid.New(),
+		CommentsEnabled: true, CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}
+	require.NoError(t, repos.Post.Create(ctx, post))
+
+	created := time.Now()
+	create := func(parent *repomodel.Comment) *repomodel.Comment {
+		created = created.Add(time.Second)
+		comment := &repomodel.Comment{
+			ID: uuid.New(), PostID: post.ID, Content: "Comment",
+			AuthorID: uuid.New(), CreatedAt: created, UpdatedAt: created,
+		}
+		if parent != nil {
+			comment.ParentID = &parent.ID
+			comment.Depth = parent.Depth + 1
+		}
+		require.NoError(t, repos.Comment.Create(ctx, comment))
+		return comment
+	}
+
+	// root -> a -> b -> target -> (r1 -> r11, r2, r3)
+	root := create(nil)
+	a := create(root)
+	b := create(a)
+	target := create(b)
+	r1 := create(target)
+	create(r1)
+	r2 := create(target)
+	r3 := create(target)
+
+	ids := func(comments []*model.Comment) []uuid.UUID {
+		var values []uuid.UUID
+		for _, comment := range comments {
+			values = append(values, comment.ID)
+		}
+		return values
+	}
+	intPtr := func(value int) *int { return &value }
+
+	t.Run("bounded ancestors and replies", func(t *testing.T) {
+		commentCtx, err := service.GetCommentContext(ctx, target.ID, intPtr(2), intPtr(2))
+		require.NoError(t, err)
+
+		assert.Equal(t, target.ID, commentCtx.Comment.ID)
+		assert.Equal(t, []uuid.UUID{a.ID, b.ID}, ids(commentCtx.Ancestors))
+		assert.True(t, commentCtx.HasMoreAncestors)
+
+		require.Len(t, commentCtx.Replies.Nodes, 2)
+		assert.Equal(t, r1.ID, commentCtx.Replies.Nodes[0].Comment.ID)
+		assert.True(t, commentCtx.Replies.Nodes[0].ContinueThread)
+		assert.Equal(t, r2.ID, commentCtx.Replies.Nodes[1].Comment.ID)
+		assert.False(t, commentCtx.Replies.Nodes[1].ContinueThread)
+		assert.True(t, commentCtx.Replies.PageInfo.HasNextPage)
+
+		// Ответы считаются по всему поддереву, а не по загруженной странице
+		assert.Equal(t, 4, commentCtx.TotalReplies)
+
+		// Следующая страница ответов загружается веткой дерева
+		more, err := service.GetCommentTree(ctx, model.CommentTreeQuery{
+			PostID:   post.ID,
+			ParentID: &target.ID,
+			After:    commentCtx.Replies.PageInfo.EndCursor,
+		})
+		require.NoError(t, err)
+		require.Len(t, more.Nodes, 1)
+		assert.Equal(t, r3.ID, more.Nodes[0].Comment.ID)
+	})
+
+	t.Run("whole chain to root", func(t *testing.T) {
+		commentCtx, err := service.GetCommentContext(ctx, target.ID, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{root.ID, a.ID, b.ID}, ids(commentCtx.Ancestors))
+		assert.False(t, commentCtx.HasMoreAncestors)
+		assert.Len(t, commentCtx.Replies.Nodes, 3)
+	})
+
+	t.Run("no ancestors requested", func(t *testing.T) {
+		commentCtx, err := service.GetCommentContext(ctx, target.ID, intPtr(0), nil)
+		require.NoError(t, err)
+		assert.Empty(t, commentCtx.Ancestors)
+		assert.True(t, commentCtx.HasMoreAncestors)
+
+		commentCtx, err = service.GetCommentContext(ctx, root.ID, intPtr(0), nil)
+		require.NoError(t, err)
+		assert.False(t, commentCtx.HasMoreAncestors)
+		assert.Equal(t, 7, commentCtx.TotalReplies)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		_, err := service.GetCommentContext(ctx, target.ID, intPtr(model.MaxCommentContextAncestors+1), nil)
+		assert.Error(t, err)
+
+		_, err = service.GetCommentContext(ctx, target.ID, nil, intPtr(0))
+		assert.Error(t, err)
+
+		_, err = service.GetCommentContext(ctx, uuid.New(), nil, nil)
+		var domainErr *model.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "NOT_FOUND", domainErr.Type)
+	})
+}
@@ -2,20 +2,30 @@ package comment
 
 import (
 	"context"
-	"encoding/base64"
+	"errors"
 	"fmt"
 
 	"github.com/NarthurN/habbr/internal/model"
 	"github.com/NarthurN/habbr/internal/repository"
 	"github.com/NarthurN/habbr/internal/repository/converter"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+	"github.com/NarthurN/habbr/internal/service/pagination"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// Размеры страницы списка комментариев
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
 // Service реализует бизнес-логику для работы с комментариями
 type Service struct {
 	commentRepo     repository.CommentRepository
 	postRepo        repository.PostRepository
+	statsRepo       repository.StatsRepository
+	transactor      repository.Transactor
 	logger          *zap.Logger
 	maxDepth        int
 	subscriptionSvc SubscriptionNotifier
@@ -24,10 +34,14 @@ type Service struct {
 // SubscriptionNotifier определяет интерфейс для отправки уведомлений о событиях комментариев
 type SubscriptionNotifier interface {
 	Publish(postID uuid.UUID, payload *model.CommentSubscriptionPayload)
+	PublishBatch(payloads []*model.CommentSubscriptionPayload)
 }
 
-// NewService создает новый сервис комментариев
-func NewService(repos *repository.Repositories, logger *zap.Logger, subscriptionSvc SubscriptionNotifier) *Service {
+// NewService создает новый сервис комментариев.
+//
+// transactor выполняет многошаговые изменения атомарно; nil означает выполнение
+// без транзакции.
+func NewService(repos *repository.Repositories, transactor repository.Transactor, logger *zap.Logger, subscriptionSvc SubscriptionNotifier) *Service {
 	if logger == nil {
 		logger = zap.NewNop()
 	}
@@ -35,6 +49,8 @@ func NewService(repos *repository.Repositories, logger *zap.Logger, subscription
 	return &Service{
 		commentRepo:     repos.Comment,
 		postRepo:        repos.Post,
+		statsRepo:       repos.Stats,
+		transactor:      transactor,
 		logger:          logger,
 		maxDepth:        50, // Ограничение глубины для предотвращения злоупотреблений
 		subscriptionSvc: subscriptionSvc,
@@ -59,85 +75,15 @@ func (s *Service) CreateComment(ctx context.Context, input model.CommentInput) (
 		return nil, model.NewValidationError("input", err.Error())
 	}
 
-	// Проверка существования поста
-	post, err := s.postRepo.GetByID(ctx, input.PostID)
+	// Проверки поста и родителя выполняются в одной транзакции с созданием
+	var comment *model.Comment
+	err := s.withinTx(ctx, func(tx *Service) error {
+		var err error
+		comment, err = tx.createComment(ctx, input)
+		return err
+	})
 	if err != nil {
-		if err == repository.ErrNotFound {
-			s.logger.Debug("Post not found for comment creation",
-				zap.String("post_id", input.PostID.String()),
-			)
-			return nil, model.NewNotFoundError("post", input.PostID)
-		}
-
-		s.logger.Error("Failed to get post for comment creation",
-			zap.Error(err),
-			zap.String("post_id", input.PostID.String()),
-		)
-		return nil, model.NewInternalError(fmt.Sprintf("failed to get post: %v", err))
-	}
-
-	// Проверка возможности комментирования
-	if !post.CommentsEnabled {
-		s.logger.Warn("Attempt to comment on post with disabled comments",
-			zap.String("post_id", input.PostID.String()),
-			zap.String("author_id", input.AuthorID.String()),
-		)
-		return nil, model.NewForbiddenError("comments are disabled for this post")
-	}
-
-	// Определение глубины комментария
-	depth := 0
-	if input.ParentID != nil {
-		parentComment, err := s.commentRepo.GetByID(ctx, *input.ParentID)
-		if err != nil {
-			if err == repository.ErrNotFound {
-				s.logger.Debug("Parent comment not found",
-					zap.String("parent_id", input.ParentID.String()),
-				)
-				return nil, model.NewNotFoundError("parent comment", *input.ParentID)
-			}
-
-			s.logger.Error("Failed to get parent comment",
-				zap.Error(err),
-				zap.String("parent_id", input.ParentID.String()),
-			)
-			return nil, model.NewInternalError(fmt.Sprintf("failed to get parent comment: %v", err))
-		}
-
-		// Проверка, что родительский комментарий принадлежит тому же посту
-		if parentComment.PostID != input.PostID {
-			s.logger.Warn("Parent comment belongs to different post",
-				zap.String("parent_post_id", parentComment.PostID.String()),
-				zap.String("expected_post_id", input.PostID.String()),
-			)
-			return nil, model.NewValidationError("parent_id", "parent comment must belong to the same post")
-		}
-
-		depth = parentComment.Depth + 1
-
-		// Проверка максимальной глубины
-		if depth > s.maxDepth {
-			s.logger.Warn("Comment depth limit exceeded",
-				zap.Int("depth", depth),
-				zap.Int("max_depth", s.maxDepth),
-				zap.String("post_id", input.PostID.String()),
-			)
-			return nil, model.NewValidationError("depth", fmt.Sprintf("comment depth cannot exceed %d", s.maxDepth))
-		}
-	}
-
-	// Создание доменной модели
-	comment := model.NewComment(input, depth)
-
-	// Конвертация в модель репозитория и сохранение
-	repoComment := converter.CommentToRepo(comment)
-	if err := s.commentRepo.Create(ctx, repoComment); err != nil {
-		s.logger.Error("Failed to create comment in repository",
-			zap.Error(err),
-			zap.String("comment_id", comment.ID.String()),
-			zap.String("post_id", comment.PostID.String()),
-		)
-		return nil, model.NewInternalError(fmt.Sprintf("failed to create comment: %v", err))
+		return nil, err
 	}
 
 	s.logger.Info("Comment created successfully",
@@ -192,23 +138,24 @@ func (s *Service) GetComment(ctx context.Context, id uuid.UUID) (*model.Comment,
 }
 
 // ListComments возвращает список комментариев с пагинацией
-func (s *Service) ListComments(ctx context.Context, filter model.CommentFilter, pagination model.PaginationInput) (*model.CommentConnection, error) {
+func (s *Service) ListComments(ctx context.Context, filter model.CommentFilter, paginationInput model.PaginationInput) (*model.CommentConnection, error) {
 	s.logger.Debug("Listing comments",
 		zap.Any("filter", filter),
-		zap.Any("pagination", pagination),
+		zap.Any("pagination", paginationInput),
 	)
 
-	// Валидация пагинации
-	if err := s.validatePagination(pagination); err != nil {
+	// Валидация порядка и пагинации, декодирование курсоров
+	page, err := s.buildPage(filter, paginationInput)
+	if err != nil {
 		s.logger.Warn("Invalid pagination parameters", zap.Error(err))
 		return nil, err
 	}
 
 	// Конвертация фильтра
-	repoFilter := converter.CommentFilterToRepo(filter, pagination)
+	repoFilter := converter.CommentFilterToRepo(filter, page)
 
-	// Получение комментариев
-	repoComments, err := s.commentRepo.List(ctx, repoFilter)
+	// Получение страницы комментариев
+	repoPage, err := s.commentRepo.List(ctx, repoFilter)
 	if err != nil {
 		s.logger.Error("Failed to list comments from repository",
 			zap.Error(err),
@@ -217,38 +164,88 @@ func (s *Service) ListComments(ctx context.Context, filter model.CommentFilter,
 		return nil, model.NewInternalError(fmt.Sprintf("failed to list comments: %v", err))
 	}
 
-	// Получение общего количества
-	totalCount, err := s.commentRepo.Count(ctx, repoFilter)
-	if err != nil {
-		s.logger.Error("Failed to count comments from repository",
-			zap.Error(err),
-			zap.Any("filter", repoFilter),
-		)
-		return nil, model.NewInternalError(fmt.Sprintf("failed to count comments: %v", err))
-	}
-
 	// Конвертация в доменные модели
-	comments := converter.CommentsFromRepo(repoComments)
+	comments := converter.CommentsFromRepo(repoPage.Comments)
 
 	// Создание connection с пагинацией
-	connection := s.buildCommentConnection(comments, pagination, totalCount)
+	connection := s.buildCommentConnection(comments, repoPage)
 
 	s.logger.Debug("Comments listed successfully",
 		zap.Int("count", len(comments)),
-		zap.Int("total_count", totalCount),
 		zap.Bool("has_next_page", connection.PageInfo.HasNextPage),
+		zap.Bool("has_previous_page", connection.PageInfo.HasPreviousPage),
 	)
 
 	return connection, nil
 }
 
-// GetCommentsTree возвращает дерево комментариев к посту
-func (s *Service) GetCommentsTree(ctx context.Context, postID uuid.UUID) ([]*model.Comment, error) {
+// ListCommentsByPostIDs возвращает комментарии нескольких постов одним запросом к репозиторию
+func (s *Service) ListCommentsByPostIDs(ctx context.Context, postIDs []uuid.UUID, filter model.CommentFilter, paginationInput model.PaginationInput) (map[uuid.UUID]*model.CommentConnection, error) {
+	return s.listBatch(ctx, postIDs, filter, paginationInput, s.commentRepo.ListByPostIDs)
+}
+
+// ListRepliesByParentIDs возвращает прямые ответы на несколько комментариев одним запросом к репозиторию
+func (s *Service) ListRepliesByParentIDs(ctx context.Context, parentIDs []uuid.UUID, filter model.CommentFilter, paginationInput model.PaginationInput) (map[uuid.UUID]*model.CommentConnection, error) {
+	return s.listBatch(ctx, parentIDs, filter, paginationInput, s.commentRepo.ListByParentIDs)
+}
+
+// listBatch загружает страницы комментариев для нескольких ID методом репозитория list
+func (s *Service) listBatch(
+	ctx context.Context,
+	ids []uuid.UUID,
+	filter model.CommentFilter,
+	paginationInput model.PaginationInput,
+	list func(context.Context, []uuid.UUID, repomodel.CommentFilter) (map[uuid.UUID]*repomodel.CommentPage, error),
+) (map[uuid.UUID]*model.CommentConnection, error) {
+	s.logger.Debug("Listing comments batch",
+		zap.Int("count", len(ids)),
+		zap.Any("filter", filter),
+		zap.Any("pagination", paginationInput),
+	)
+
+	// Валидация порядка и пагинации, декодирование курсоров
+	page, err := s.buildPage(filter, paginationInput)
+	if err != nil {
+		s.logger.Warn("Invalid pagination parameters", zap.Error(err))
+		return nil, err
+	}
+
+	filter.PostID = nil
+	filter.ParentID = nil
+	repoFilter := converter.CommentFilterToRepo(filter, page)
+
+	repoPages, err := list(ctx, ids, repoFilter)
+	if err != nil {
+		s.logger.Error("Failed to list comments batch from repository",
+			zap.Error(err),
+			zap.Int("count", len(ids)),
+		)
+		return nil, model.NewInternalError(fmt.Sprintf("failed to list comments: %v", err))
+	}
+
+	result := make(map[uuid.UUID]*model.CommentConnection, len(ids))
+	for _, id := range ids {
+		repoPage, ok := repoPages[id]
+		if !ok {
+			repoPage = &repomodel.CommentPage{}
+		}
+		result[id] = s.buildCommentConnection(converter.CommentsFromRepo(repoPage.Comments), repoPage)
+	}
+
+	return result, nil
+}
+
+// GetFullCommentTree возвращает все дерево комментариев к посту без ограничения глубины
+func (s *Service) GetFullCommentTree(ctx context.Context, postID uuid.UUID, order model.CommentOrder) ([]*model.Comment, error) {
 	if postID == uuid.Nil {
 		s.logger.Warn("Attempt to get comments tree with nil post ID")
 		return nil, model.NewValidationError("post_id", "post ID is required")
 	}
 
+	if !order.OrDefault().IsValid() {
+		return nil, model.NewValidationError("order", fmt.Sprintf("unsupported comment order %q", order))
+	}
+
 	s.logger.Debug("Getting comments tree", zap.String("post_id", postID.String()))
 
 	// Проверка существования поста
@@ -268,8 +265,21 @@ func (s *Service) GetCommentsTree(ctx context.Context, postID uuid.UUID) ([]*mod
 		return nil, model.NewNotFoundError("post", postID)
 	}
 
-	// Получение всех комментариев к посту
-	repoComments, err := s.commentRepo.GetByPostID(ctx, postID)
+	// Получение всех комментариев к посту в порядке сортировки. Порядок по умолчанию
+	// совпадает с обходом веток по материализованному пути и не требует сортировки
+	var repoComments []*repomodel.Comment
+	if order.OrDefault() == model.CommentOrderOldest {
+		repoComments, err = s.commentRepo.GetByPostID(ctx, postID)
+	} else {
+		var repoPage *repomodel.CommentPage
+		repoPage, err = s.commentRepo.List(ctx, converter.CommentFilterToRepo(model.CommentFilter{
+			PostID: &postID,
+			Order:  order,
+		}, repomodel.Page{}))
+		if err == nil {
+			repoComments = repoPage.Comments
+		}
+	}
 	if err != nil {
 		s.logger.Error("Failed to get post comments from repository",
 			zap.Error(err),
@@ -281,7 +291,7 @@ func (s *Service) GetCommentsTree(ctx context.Context, postID uuid.UUID) ([]*mod
 	// Конвертация в доменные модели
 	comments := converter.CommentsFromRepo(repoComments)
 
-	// Построение дерева
+	// Построение дерева: BuildCommentsTree сохраняет порядок комментариев на каждом уровне
 	tree := model.BuildCommentsTree(comments)
 
 	s.logger.Debug("Comments tree built successfully",
@@ -321,42 +331,17 @@ func (s *Service) UpdateComment(ctx context.Context, id uuid.UUID, input model.C
 		return nil, model.NewValidationError("author_id", "author ID is required")
 	}
 
-	// Получение существующего комментария
-	existingComment, err := s.GetComment(ctx, id)
+	var existingComment *model.Comment
+	var originalContent string
+	err := s.withinTx(ctx, func(tx *Service) error {
+		var err error
+		existingComment, originalContent, err = tx.updateComment(ctx, id, input, authorID)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Проверка прав на редактирование
-	if existingComment.AuthorID != authorID {
-		s.logger.Warn("Unauthorized attempt to update comment",
-			zap.String("comment_id", id.String()),
-			zap.String("comment_author", existingComment.AuthorID.String()),
-			zap.String("requesting_user", authorID.String()),
-		)
-		return nil, model.NewForbiddenError("update comment")
-	}
-
-	// Сохранение исходного контента для логирования
-	originalContent := existingComment.Content
-
-	// Обновление комментария
-	existingComment.Update(input)
-
-	// Сохранение изменений
-	repoComment := converter.CommentToRepo(existingComment)
-	if err := s.commentRepo.Update(ctx, repoComment); err != nil {
-		if err == repository.ErrNotFound {
-			return nil, model.NewNotFoundError("comment", id)
-		}
-
-		s.logger.Error("Failed to update comment in repository",
-			zap.Error(err),
-			zap.String("comment_id", id.String()),
-		)
-		return nil, model.NewInternalError(fmt.Sprintf("failed to update comment: %v", err))
-	}
-
 	s.logger.Info("Comment updated successfully",
 		zap.String("comment_id", id.String()),
 		zap.String("author_id", authorID.String()),
@@ -376,8 +361,15 @@ func (s *Service) UpdateComment(ctx context.Context, id uuid.UUID, input model.C
 	return existingComment, nil
 }
 
-// DeleteComment удаляет комментарий
+// DeleteComment удаляет комментарий со всеми вложенными ответами
 func (s *Service) DeleteComment(ctx context.Context, id uuid.UUID, authorID uuid.UUID) error {
+	_, err := s.DeleteCommentsTree(ctx, id, authorID)
+	return err
+}
+
+// DeleteCommentsTree удаляет комментарий со всеми вложенными ответами и возвращает ID удаленных
+// комментариев, включая надгробия предков, у которых не осталось ответов
+func (s *Service) DeleteCommentsTree(ctx context.Context, id uuid.UUID, authorID uuid.UUID) ([]uuid.UUID, error) {
 	s.logger.Debug("Deleting comment",
 		zap.String("comment_id", id.String()),
 		zap.String("author_id", authorID.String()),
@@ -385,226 +377,460 @@ func (s *Service) DeleteComment(ctx context.Context, id uuid.UUID, authorID uuid
 
 	if id == uuid.Nil {
 		s.logger.Warn("Attempt to delete comment with nil ID")
-		return model.NewValidationError("id", "comment ID is required")
+		return nil, model.NewValidationError("id", "comment ID is required")
 	}
 
 	if authorID == uuid.Nil {
 		s.logger.Warn("Attempt to delete comment with nil author ID",
 			zap.String("comment_id", id.String()),
 		)
-		return model.NewValidationError("author_id", "author ID is required")
+		return nil, model.NewValidationError("author_id", "author ID is required")
 	}
 
-	// Получение комментария для проверки прав
-	comment, err := s.GetComment(ctx, id)
-	if err != nil {
+	var comment *model.Comment
+	var deleted []*model.Comment
+	err := s.withinTx(ctx, func(tx *Service) error {
+		var err error
+		comment, deleted, err = tx.deleteCommentsTree(ctx, id, authorID)
 		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Проверка прав на удаление
-	if comment.AuthorID != authorID {
-		s.logger.Warn("Unauthorized attempt to delete comment",
+	deletedIDs := make([]uuid.UUID, len(deleted))
+	for i, deletedComment := range deleted {
+		deletedIDs[i] = deletedComment.ID
+	}
+
+	s.logger.Info("Comment deleted successfully",
+		zap.String("comment_id", id.String()),
+		zap.String("post_id", comment.PostID.String()),
+		zap.String("author_id", authorID.String()),
+		zap.Int("deleted_comments_count", len(deleted)),
+	)
+
+	s.publishDeleted(deleted)
+
+	return deletedIDs, nil
+}
+
+// publishDeleted отправляет уведомление об удалении каждого комментария поддерева,
+// начиная с корня, чтобы клиенты могли убрать из дерева каждый узел; затем следуют
+// удаленные вместе с поддеревом надгробия предков. События отправляются одним пакетом
+func (s *Service) publishDeleted(deleted []*model.Comment) {
+	if s.subscriptionSvc == nil || len(deleted) == 0 {
+		return
+	}
+
+	payloads := make([]*model.CommentSubscriptionPayload, len(deleted))
+	for i, deletedComment := range deleted {
+		payloads[i] = &model.CommentSubscriptionPayload{
+			PostID:       deletedComment.PostID,
+			Comment:      deletedComment,
+			ActionType:   "DELETED",
+			DeletedCount: 1,
+		}
+	}
+	s.subscriptionSvc.PublishBatch(payloads)
+}
+
+// SoftDeleteComment выполняет мягкое удаление комментария.
+//
+// Комментарий с ответами становится надгробием, комментарий без ответов удаляется
+// вместе с надгробиями предков, у которых не осталось ответов.
+func (s *Service) SoftDeleteComment(ctx context.Context, id uuid.UUID, authorID uuid.UUID) (*model.Comment, bool, error) {
+	s.logger.Debug("Soft deleting comment",
+		zap.String("comment_id", id.String()),
+		zap.String("author_id", authorID.String()),
+	)
+
+	if id == uuid.Nil {
+		s.logger.Warn("Attempt to soft delete comment with nil ID")
+		return nil, false, model.NewValidationError("id", "comment ID is required")
+	}
+
+	if authorID == uuid.Nil {
+		s.logger.Warn("Attempt to soft delete comment with nil author ID",
 			zap.String("comment_id", id.String()),
-			zap.String("comment_author", comment.AuthorID.String()),
-			zap.String("requesting_user", authorID.String()),
 		)
-		return model.NewForbiddenError("delete comment")
+		return nil, false, model.NewValidationError("author_id", "author ID is required")
+	}
+
+	var result *model.Comment
+	var tombstone bool
+	var pruned []*model.Comment
+	err := s.withinTx(ctx, func(tx *Service) error {
+		var err error
+		result, tombstone, pruned, err = tx.softDeleteComment(ctx, id, authorID)
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	s.logger.Info("Comment soft deleted successfully",
+		zap.String("comment_id", id.String()),
+		zap.String("post_id", result.PostID.String()),
+		zap.Bool("tombstone", tombstone),
+		zap.Int("pruned_tombstones", len(pruned)),
+	)
+
+	// Надгробие остается в дереве и отправляется отдельным событием TOMBSTONED,
+	// комментарий без ответов и освободившиеся надгробия предков удалены полностью
+	if tombstone {
+		if s.subscriptionSvc != nil {
+			s.subscriptionSvc.Publish(result.PostID, &model.CommentSubscriptionPayload{
+				PostID:     result.PostID,
+				Comment:    result,
+				ActionType: "TOMBSTONED",
+			})
+		}
+	} else {
+		s.publishDeleted(append([]*model.Comment{result}, pruned...))
 	}
 
-	// Получение дочерних комментариев для подсчета
-	children, err := s.commentRepo.GetChildren(ctx, id)
+	return result, tombstone, nil
+}
+
+// createComment проверяет пост и родителя и сохраняет комментарий через репозитории сервиса
+func (s *Service) createComment(ctx context.Context, input model.CommentInput) (*model.Comment, error) {
+	// Проверка существования поста
+	post, err := s.postRepo.GetByID(ctx, input.PostID)
 	if err != nil {
-		s.logger.Error("Failed to get child comments for deletion",
+		if err == repository.ErrNotFound {
+			s.logger.Debug("Post not found for comment creation",
+				zap.String("post_id", input.PostID.String()),
+			)
+			return nil, model.NewNotFoundError("post", input.PostID)
+		}
+
+		s.logger.Error("Failed to get post for comment creation",
 			zap.Error(err),
-			zap.String("comment_id", id.String()),
+			zap.String("post_id", input.PostID.String()),
 		)
-		return model.NewInternalError(fmt.Sprintf("failed to get child comments: %v", err))
+		return nil, model.NewInternalError(fmt.Sprintf("failed to get post: %v", err))
 	}
 
-	deletedCount := len(children) + 1 // включая сам комментарий
+	// Проверка возможности комментирования
+	if !post.CommentsEnabled {
+		s.logger.Warn("Attempt to comment on post with disabled comments",
+			zap.String("post_id", input.PostID.String()),
+			zap.String("author_id", input.AuthorID.String()),
+		)
+		return nil, model.NewForbiddenError("comments are disabled for this post")
+	}
 
-	// Удаление всех дочерних комментариев (каскадное удаление)
-	for _, child := range children {
-		if err := s.commentRepo.Delete(ctx, child.ID); err != nil {
-			s.logger.Error("Failed to delete child comment",
+	// Определение глубины комментария
+	depth := 0
+	if input.ParentID != nil {
+		parentComment, err := s.commentRepo.GetByID(ctx, *input.ParentID)
+		if err != nil {
+			if err == repository.ErrNotFound {
+				s.logger.Debug("Parent comment not found",
+					zap.String("parent_id", input.ParentID.String()),
+				)
+				return nil, model.NewNotFoundError("parent comment", *input.ParentID)
+			}
+
+			s.logger.Error("Failed to get parent comment",
 				zap.Error(err),
-				zap.String("child_comment_id", child.ID.String()),
-				zap.String("parent_comment_id", id.String()),
+				zap.String("parent_id", input.ParentID.String()),
 			)
-			return model.NewInternalError(fmt.Sprintf("failed to delete child comment: %v", err))
+			return nil, model.NewInternalError(fmt.Sprintf("failed to get parent comment: %v", err))
 		}
-	}
 
-	// Удаление самого комментария
-	if err := s.commentRepo.Delete(ctx, id); err != nil {
-		if err == repository.ErrNotFound {
-			return model.NewNotFoundError("comment", id)
+		// На надгробие удаленного комментария отвечать нельзя
+		if !converter.CommentFromRepo(parentComment).CanBeRepliedTo() {
+			s.logger.Debug("Attempt to reply to deleted comment",
+				zap.String("parent_id", input.ParentID.String()),
+			)
+			return nil, model.NewValidationError("parent_id", "cannot reply to a deleted comment")
+		}
+
+		// Проверка, что родительский комментарий принадлежит тому же посту
+		if parentComment.PostID != input.PostID {
+			s.logger.Warn("Parent comment belongs to different post",
+				zap.String("parent_post_id", parentComment.PostID.String()),
+				zap.String("expected_post_id", input.PostID.String()),
+			)
+			return nil, model.NewValidationError("parent_id", "parent comment must belong to the same post")
 		}
 
-		s.logger.Error("Failed to delete comment from repository",
+		depth = parentComment.Depth + 1
+
+		// Проверка максимальной глубины
+		if depth > s.maxDepth {
+			s.logger.Warn("Comment depth limit exceeded",
+				zap.Int("depth", depth),
+				zap.Int("max_depth", s.maxDepth),
+				zap.String("post_id", input.PostID.String()),
+			)
+			return nil, model.NewValidationError("depth", fmt.Sprintf("comment depth cannot exceed %d", s.maxDepth))
+		}
+	}
+
+	// Создание доменной модели
+	comment := model.NewComment(input, depth)
+
+	// Конвертация в модель репозитория и сохранение
+	repoComment := converter.CommentToRepo(comment)
+	if err := s.commentRepo.Create(ctx, repoComment); err != nil {
+		s.logger.Error("Failed to create comment in repository",
 			zap.Error(err),
+			zap.String("comment_id", comment.ID.String()),
+			zap.String("post_id", comment.PostID.String()),
+		)
+		return nil, model.NewInternalError(fmt.Sprintf("failed to create comment: %v", err))
+	}
+
+	return comment, nil
+}
+
+// updateComment проверяет права и сохраняет изменения комментария через репозитории сервиса.
+// Возвращает обновленный комментарий и его исходный текст
+func (s *Service) updateComment(ctx context.Context, id uuid.UUID, input model.CommentUpdateInput, authorID uuid.UUID) (*model.Comment, string, error) {
+	// Получение существующего комментария
+	existingComment, err := s.GetComment(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Проверка прав на редактирование
+	if existingComment.AuthorID != authorID {
+		s.logger.Warn("Unauthorized attempt to update comment",
 			zap.String("comment_id", id.String()),
+			zap.String("comment_author", existingComment.AuthorID.String()),
+			zap.String("requesting_user", authorID.String()),
 		)
-		return model.NewInternalError(fmt.Sprintf("failed to delete comment: %v", err))
+		return nil, "", model.NewForbiddenError("update comment")
 	}
 
-	s.logger.Info("Comment deleted successfully",
-		zap.String("comment_id", id.String()),
-		zap.String("post_id", comment.PostID.String()),
-		zap.String("author_id", authorID.String()),
-		zap.Int("deleted_comments_count", deletedCount),
-	)
+	// Сохранение исходного контента для логирования
+	originalContent := existingComment.Content
 
-	// Отправляем уведомление об удалении комментария
-	if s.subscriptionSvc != nil {
-		s.subscriptionSvc.Publish(comment.PostID, &model.CommentSubscriptionPayload{
-			PostID:     comment.PostID,
-			Comment:    comment,
-			ActionType: "DELETED",
-		})
+	// Обновление комментария
+	existingComment.Update(input)
+
+	// Сохранение изменений
+	repoComment := converter.CommentToRepo(existingComment)
+	if err := s.commentRepo.Update(ctx, repoComment); err != nil {
+		if err == repository.ErrNotFound {
+			return nil, "", model.NewNotFoundError("comment", id)
+		}
+
+		s.logger.Error("Failed to update comment in repository",
+			zap.Error(err),
+			zap.String("comment_id", id.String()),
+		)
+		return nil, "", model.NewInternalError(fmt.Sprintf("failed to update comment: %v", err))
 	}
 
-	return nil
+	return existingComment, originalContent, nil
 }
 
-// GetCommentWithChildren возвращает комментарий со всеми дочерними комментариями
-func (s *Service) GetCommentWithChildren(ctx context.Context, commentID uuid.UUID) (*model.Comment, error) {
-	s.logger.Debug("Getting comment with children", zap.String("comment_id", commentID.String()))
-
-	// Получение основного комментария
-	comment, err := s.GetComment(ctx, commentID)
+// deleteCommentsTree проверяет права и удаляет поддерево комментария через репозитории
+// сервиса. Возвращает корень и все удаленные комментарии
+func (s *Service) deleteCommentsTree(ctx context.Context, id uuid.UUID, authorID uuid.UUID) (*model.Comment, []*model.Comment, error) {
+	// Получение комментария для проверки прав
+	comment, err := s.GetComment(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	// Получение всех комментариев к посту для построения дерева
-	repoComments, err := s.commentRepo.GetByPostID(ctx, comment.PostID)
+	// Проверка прав на удаление
+	if comment.AuthorID != authorID {
+		s.logger.Warn("Unauthorized attempt to delete comment",
+			zap.String("comment_id", id.String()),
+			zap.String("comment_author", comment.AuthorID.String()),
+			zap.String("requesting_user", authorID.String()),
+		)
+		return nil, nil, model.NewForbiddenError("delete comment")
+	}
+
+	// Атомарное удаление комментария и всех вложенных ответов
+	repoDeleted, err := s.commentRepo.DeleteSubtree(ctx, id)
 	if err != nil {
-		s.logger.Error("Failed to get post comments for building tree",
+		if err == repository.ErrNotFound {
+			return nil, nil, model.NewNotFoundError("comment", id)
+		}
+
+		s.logger.Error("Failed to delete comment subtree from repository",
 			zap.Error(err),
-			zap.String("post_id", comment.PostID.String()),
+			zap.String("comment_id", id.String()),
 		)
-		return nil, model.NewInternalError(fmt.Sprintf("failed to get post comments: %v", err))
+		return nil, nil, model.NewInternalError(fmt.Sprintf("failed to delete comment: %v", err))
 	}
 
-	// Конвертация в доменные модели и построение дерева
-	allComments := converter.CommentsFromRepo(repoComments)
-	tree := model.BuildCommentsTree(allComments)
+	deleted := converter.CommentsFromRepo(repoDeleted)
 
-	// Поиск нужного комментария в дереве
-	targetComment := s.findCommentInTree(tree, commentID)
-	if targetComment == nil {
-		return comment, nil // Возвращаем комментарий без детей, если не нашли в дереве
+	pruned, err := s.pruneTombstones(ctx, comment.ParentID)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return targetComment, nil
+	return comment, append(deleted, pruned...), nil
 }
 
-// GetCommentDepthStatistics возвращает статистику глубины комментариев для поста
-func (s *Service) GetCommentDepthStatistics(ctx context.Context, postID uuid.UUID) (map[int]int, error) {
-	s.logger.Debug("Getting comment depth statistics", zap.String("post_id", postID.String()))
+// pruneTombstones удаляет надгробия, оставшиеся без ответов после удаления ответа на parentID
+func (s *Service) pruneTombstones(ctx context.Context, parentID *uuid.UUID) ([]*model.Comment, error) {
+	if parentID == nil {
+		return nil, nil
+	}
 
-	repoComments, err := s.commentRepo.GetByPostID(ctx, postID)
+	repoPruned, err := s.commentRepo.PruneTombstones(ctx, *parentID)
 	if err != nil {
-		s.logger.Error("Failed to get comments for depth statistics",
+		s.logger.Error("Failed to prune tombstones in repository",
 			zap.Error(err),
-			zap.String("post_id", postID.String()),
+			zap.String("parent_id", parentID.String()),
 		)
-		return nil, model.NewInternalError(fmt.Sprintf("failed to get comments: %v", err))
+		return nil, model.NewInternalError(fmt.Sprintf("failed to prune tombstones: %v", err))
 	}
 
-	statistics := make(map[int]int)
-	for _, comment := range repoComments {
-		statistics[comment.Depth]++
+	return converter.CommentsFromRepo(repoPruned), nil
+}
+
+// softDeleteComment проверяет права и выполняет мягкое удаление через репозитории сервиса.
+// Возвращает итоговое состояние комментария, признак надгробия и надгробия предков,
+// удаленные вместе с комментарием без ответов
+func (s *Service) softDeleteComment(ctx context.Context, id uuid.UUID, authorID uuid.UUID) (*model.Comment, bool, []*model.Comment, error) {
+	// Получение комментария для проверки прав; автор надгробия скрыт,
+	// поэтому повторное удаление надгробия отклоняется этой же проверкой
+	comment, err := s.GetComment(ctx, id)
+	if err != nil {
+		return nil, false, nil, err
 	}
 
-	s.logger.Debug("Comment depth statistics calculated",
-		zap.String("post_id", postID.String()),
-		zap.Any("statistics", statistics),
-	)
+	if comment.AuthorID != authorID {
+		s.logger.Warn("Unauthorized attempt to soft delete comment",
+			zap.String("comment_id", id.String()),
+			zap.String("comment_author", comment.AuthorID.String()),
+			zap.String("requesting_user", authorID.String()),
+		)
+		return nil, false, nil, model.NewForbiddenError("delete comment")
+	}
+
+	repoComment, tombstone, err := s.commentRepo.SoftDelete(ctx, id, model.DeletedCommentPlaceholder)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return nil, false, nil, model.NewNotFoundError("comment", id)
+		}
+
+		s.logger.Error("Failed to soft delete comment in repository",
+			zap.Error(err),
+			zap.String("comment_id", id.String()),
+		)
+		return nil, false, nil, model.NewInternalError(fmt.Sprintf("failed to delete comment: %v", err))
+	}
 
-	return statistics, nil
+	result := converter.CommentFromRepo(repoComment)
+	if tombstone {
+		return result, true, nil, nil
+	}
+
+	pruned, err := s.pruneTombstones(ctx, result.ParentID)
+	if err != nil {
+		return nil, false, nil, err
+	}
+
+	return result, false, pruned, nil
 }
 
-// findCommentInTree рекурсивно ищет комментарий в дереве
-func (s *Service) findCommentInTree(tree []*model.Comment, commentID uuid.UUID) *model.Comment {
-	for _, comment := range tree {
-		if comment.ID == commentID {
-			return comment
-		}
-		if len(comment.Children) > 0 {
-			if found := s.findCommentInTree(comment.Children, commentID); found != nil {
-				return found
-			}
-		}
+// errRollback возвращается из fn в withinTx, чтобы откатить транзакцию, когда
+// результат операции уже сформирован и ошибкой для вызывающего не является
+var errRollback = errors.New("transaction rolled back")
+
+// withinTx выполняет fn в транзакции с копией сервиса, работающей через репозитории
+// транзакции. Уведомления отправляются после возврата из withinTx, чтобы подписчики
+// не узнавали об откаченных изменениях
+func (s *Service) withinTx(ctx context.Context, fn func(tx *Service) error) error {
+	if s.transactor == nil {
+		return fn(s)
+	}
+
+	err := s.transactor.WithinTx(ctx, func(repos *repository.Repositories) error {
+		tx := *s
+		tx.commentRepo = repos.Comment
+		tx.postRepo = repos.Post
+		return fn(&tx)
+	})
+
+	// Ошибки начала и фиксации транзакции не являются доменными
+	var domainErr *model.DomainError
+	if err != nil && !errors.Is(err, errRollback) && !errors.As(err, &domainErr) {
+		s.logger.Error("Comment transaction failed", zap.Error(err))
+		return model.NewInternalError(fmt.Sprintf("comment transaction failed: %v", err))
 	}
-	return nil
+	return err
 }
 
-// validatePagination проверяет корректность параметров пагинации
-func (s *Service) validatePagination(pagination model.PaginationInput) error {
-	const maxPageSize = 100
+// GetCommentWithChildren возвращает комментарий со всеми дочерними комментариями.
+// Поддерево загружается одним запросом по материализованному пути
+func (s *Service) GetCommentWithChildren(ctx context.Context, commentID uuid.UUID) (*model.Comment, error) {
+	if commentID == uuid.Nil {
+		s.logger.Warn("Attempt to get comment subtree with nil ID")
+		return nil, model.NewValidationError("id", "comment ID is required")
+	}
 
-	if pagination.First != nil {
-		if *pagination.First < 0 {
-			return model.NewValidationError("first", "first must be non-negative")
-		}
-		if *pagination.First > maxPageSize {
-			return model.NewValidationError("first", fmt.Sprintf("first cannot exceed %d", maxPageSize))
+	s.logger.Debug("Getting comment with children", zap.String("comment_id", commentID.String()))
+
+	repoComments, err := s.commentRepo.GetSubtree(ctx, commentID)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return nil, model.NewNotFoundError("comment", commentID)
 		}
+
+		s.logger.Error("Failed to get comment subtree",
+			zap.Error(err),
+			zap.String("comment_id", commentID.String()),
+		)
+		return nil, model.NewInternalError(fmt.Sprintf("failed to get comment subtree: %v", err))
 	}
 
-	if pagination.Last != nil {
-		if *pagination.Last < 0 {
-			return model.NewValidationError("last", "last must be non-negative")
-		}
-		if *pagination.Last > maxPageSize {
-			return model.NewValidationError("last", fmt.Sprintf("last cannot exceed %d", maxPageSize))
+	// Поддерево упорядочено обходом в глубину: родитель всегда предшествует ответам
+	comments := converter.CommentsFromRepo(repoComments)
+	byID := make(map[uuid.UUID]*model.Comment, len(comments))
+	for _, comment := range comments {
+		comment.Children = make([]*model.Comment, 0)
+		byID[comment.ID] = comment
+		if comment.ID != commentID {
+			byID[*comment.ParentID].AddChild(comment)
 		}
 	}
 
-	if pagination.First != nil && pagination.Last != nil {
-		return model.NewValidationError("pagination", "cannot specify both first and last")
+	return comments[0], nil
+}
+
+// buildPage проверяет порядок сортировки и параметры пагинации
+// и преобразует их в страницу репозитория
+func (s *Service) buildPage(filter model.CommentFilter, paginationInput model.PaginationInput) (repomodel.Page, error) {
+	if !filter.Order.OrDefault().IsValid() {
+		return repomodel.Page{}, model.NewValidationError("order", fmt.Sprintf("unsupported comment order %q", filter.Order))
 	}
 
-	return nil
+	key, _ := converter.CommentOrderToRepo(filter.Order)
+	return pagination.Page(paginationInput, key, defaultPageSize, maxPageSize)
 }
 
-// buildCommentConnection строит CommentConnection с правильной пагинацией
-func (s *Service) buildCommentConnection(comments []*model.Comment, pagination model.PaginationInput, totalCount int) *model.CommentConnection {
+// buildCommentConnection строит CommentConnection по странице, полученной из репозитория
+func (s *Service) buildCommentConnection(comments []*model.Comment, page *repomodel.CommentPage) *model.CommentConnection {
 	edges := make([]*model.CommentEdge, len(comments))
 
 	for i, comment := range comments {
-		// Создание курсора (base64 кодированный timestamp + ID для стабильной сортировки)
-		cursorData := fmt.Sprintf("%d_%s", comment.CreatedAt.Unix(), comment.ID.String())
-		cursor := base64.StdEncoding.EncodeToString([]byte(cursorData))
-
 		edges[i] = &model.CommentEdge{
 			Node:   comment,
-			Cursor: cursor,
+			Cursor: pagination.EncodeCursor(page.Cursors[i]),
 		}
 	}
 
-	// Определение информации о пагинации
 	pageInfo := &model.PageInfo{
-		HasNextPage:     false,
-		HasPreviousPage: false,
+		HasNextPage:     page.HasNextPage,
+		HasPreviousPage: page.HasPreviousPage,
 	}
 
 	if len(edges) > 0 {
 		pageInfo.StartCursor = &edges[0].Cursor
 		pageInfo.EndCursor = &edges[len(edges)-1].Cursor
-
-		// Более точное определение наличия следующей/предыдущей страницы
-		if pagination.First != nil {
-			requestedSize := *pagination.First
-			pageInfo.HasNextPage = len(edges) == requestedSize && totalCount > requestedSize
-		}
-
-		if pagination.Last != nil {
-			requestedSize := *pagination.Last
-			pageInfo.HasPreviousPage = len(edges) == requestedSize && totalCount > requestedSize
-		}
 	}
 
 	return &model.CommentConnection{
@@ -613,11 +839,11 @@ func (s *Service) buildCommentConnection(comments []*model.Comment, pagination m
 	}
 }
 
-// GetCommentStats возвращает общее количество комментариев к посту
-func (s *Service) GetCommentStats(ctx context.Context, postID uuid.UUID) (int, error) {
+// GetCommentStats возвращает статистику комментариев к посту, вычисленную в хранилище
+func (s *Service) GetCommentStats(ctx context.Context, postID uuid.UUID) (*model.CommentStats, error) {
 	if postID == uuid.Nil {
 		s.logger.Warn("Attempt to get comment stats with nil post ID")
-		return 0, model.NewValidationError("post_id", "post ID is required")
+		return nil, model.NewValidationError("post_id", "post ID is required")
 	}
 
 	s.logger.Debug("Getting comment stats", zap.String("post_id", postID.String()))
@@ -629,30 +855,33 @@ func (s *Service) GetCommentStats(ctx context.Context, postID uuid.UUID) (int, e
 			zap.Error(err),
 			zap.String("post_id", postID.String()),
 		)
-		return 0, model.NewInternalError(fmt.Sprintf("failed to check post existence: %v", err))
+		return nil, model.NewInternalError(fmt.Sprintf("failed to check post existence: %v", err))
 	}
 
 	if !exists {
 		s.logger.Debug("Post not found for comment stats",
 			zap.String("post_id", postID.String()),
 		)
-		return 0, model.NewNotFoundError("post", postID)
+		return nil, model.NewNotFoundError("post", postID)
 	}
 
-	// Подсчет комментариев к посту
-	count, err := s.commentRepo.CountByPostID(ctx, postID)
+	// Агрегаты вычисляются в хранилище без загрузки комментариев
+	repoStats, err := s.statsRepo.GetCommentStats(ctx, postID)
 	if err != nil {
-		s.logger.Error("Failed to count comments by post ID",
+		s.logger.Error("Failed to get comment stats from repository",
 			zap.Error(err),
 			zap.String("post_id", postID.String()),
 		)
-		return 0, model.NewInternalError(fmt.Sprintf("failed to count comments: %v", err))
+		return nil, model.NewInternalError(fmt.Sprintf("failed to get comment stats: %v", err))
 	}
 
+	stats := converter.CommentStatsFromRepo(postID, repoStats)
+
 	s.logger.Debug("Comment stats retrieved successfully",
 		zap.String("post_id", postID.String()),
-		zap.Int("comment_count", count),
+		zap.Int("comment_count", stats.TotalComments),
+		zap.Int("max_depth", stats.MaxDepth),
 	)
 
-	return count, nil
+	return stats, nil
 }
@@ -0,0 +1,145 @@
+package comment
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/NarthurN/habbr/internal/repository/memory"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+)
+
+// recordingNotifier запоминает отправленные события комментариев
+type recordingNotifier struct {
+	mu     sync.Mutex
+	events []*model.CommentSubscriptionPayload
+}
+
+func (n *recordingNotifier) Publish(postID uuid.UUID, payload *model.CommentSubscriptionPayload) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.events = append(n.events, payload)
+}
+
+func (n *recordingNotifier) PublishBatch(payloads []*model.CommentSubscriptionPayload) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.events = append(n.events, payloads...)
+}
+
+// take возвращает события в формате "тип:имя комментария" и очищает список
+func (n *recordingNotifier) take(names map[uuid.UUID]string) []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var result []string
+	for _, event := range n.events {
+		result = append(result, event.ActionType+":"+names[event.Comment.ID])
+	}
+	n.events = nil
+	return result
+}
+
+func TestSoftDeleteComment(t *testing.T) {
+	ctx := context.Background()
+	manager := memory.NewManager()
+	repos := manager.GetRepositories()
+	notifier := &recordingNotifier{}
+	service := NewService(repos, manager, zap.NewNop(), notifier)
+
+	authorID := uuid.New()
+	post := &repomodel.Post{
+		ID: uuid.New(), Title: "Post", Content: "Content", AuthorID: authorID,
+		CommentsEnabled: true, CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}
+	require.NoError(t, repos.Post.Create(ctx, post))
+
+	names := make(map[uuid.UUID]string)
+	created := time.Now()
+	create := func(name string, parent *repomodel.Comment) *repomodel.Comment {
+		created = created.Add(time.Second)
+		comment := &repomodel.Comment{
+			ID: uuid.New(), PostID: post.ID, Content: "Comment",
+			AuthorID: authorID, CreatedAt: created, UpdatedAt: created,
+		}
+		if parent != nil {
+			comment.ParentID = &parent.ID
+			comment.Depth = parent.Depth + 1
+		}
+		require.NoError(t, repos.Comment.Create(ctx, comment))
+		names[comment.ID] = name
+		return comment
+	}
+	exists := func(comment *repomodel.Comment) bool {
+		exists, err := repos.Comment.Exists(ctx, comment.ID)
+		require.NoError(t, err)
+		return exists
+	}
+
+	t.Run("tombstone is removed with its last reply", func(t *testing.T) {
+		root := create("root", nil)
+		first := create("first", root)
+		second := create("second", root)
+
+		_, tombstone, err := service.SoftDeleteComment(ctx, root.ID, authorID)
+		require.NoError(t, err)
+		assert.True(t, tombstone)
+		assert.Equal(t, []string{"TOMBSTONED:root"}, notifier.take(names))
+
+		// У надгробия остался ответ second
+		_, tombstone, err = service.SoftDeleteComment(ctx, first.ID, authorID)
+		require.NoError(t, err)
+		assert.False(t, tombstone)
+		assert.Equal(t, []string{"DELETED:first"}, notifier.take(names))
+		assert.True(t, exists(root))
+
+		// Удаление последнего ответа удаляет и надгробие
+		deletedIDs, err := service.DeleteCommentsTree(ctx, second.ID, authorID)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{second.ID, root.ID}, deletedIDs)
+		assert.Equal(t, []string{"DELETED:second", "DELETED:root"}, notifier.take(names))
+		assert.False(t, exists(root))
+	})
+
+	t.Run("chain of tombstones is removed up to a live comment", func(t *testing.T) {
+		live := create("live", nil)
+		upper := create("upper", live)
+		lower := create("lower", upper)
+		leaf := create("leaf", lower)
+
+		for _, comment := range []*repomodel.Comment{upper, lower} {
+			_, tombstone, err := service.SoftDeleteComment(ctx, comment.ID, authorID)
+			require.NoError(t, err)
+			assert.True(t, tombstone)
+		}
+		notifier.take(names)
+
+		_, tombstone, err := service.SoftDeleteComment(ctx, leaf.ID, authorID)
+		require.NoError(t, err)
+		assert.False(t, tombstone)
+		assert.Equal(t, []string{"DELETED:leaf", "DELETED:lower", "DELETED:upper"}, notifier.take(names))
+		assert.True(t, exists(live))
+	})
+
+	t.Run("batch deletion removes tombstones", func(t *testing.T) {
+		root := create("root", nil)
+		reply := create("reply", root)
+
+		_, _, err := service.SoftDeleteComment(ctx, root.ID, authorID)
+		require.NoError(t, err)
+		notifier.take(names)
+
+		actor := &model.Principal{UserID: authorID, Role: model.RoleAuthor}
+		result, err := service.DeleteCommentsBatch(ctx, post.ID, []uuid.UUID{reply.ID}, actor, true)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{reply.ID, root.ID}, result.DeletedIDs)
+		assert.Equal(t, []string{"DELETED:reply", "DELETED:root"}, notifier.take(names))
+	})
+}
@@ -0,0 +1,109 @@
+package comment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/NarthurN/habbr/internal/repository/memory"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+)
+
+func TestGetCommentStats(t *testing.T) {
+	ctx := context.Background()
+	manager := memory.NewManager()
+	repos := manager.GetRepositories()
+	service := NewService(repos, manager, zap.NewNop(), nil)
+
+	newPost := func() uuid.UUID {
+		post := &repomodel.Post{
+			ID: uuid.New(), Title: "Post", Content: "Content", AuthorID: uuid.New(),
+			CommentsEnabled: true, CreatedAt: time.Now(), UpdatedAt: time.Now(),
+		}
+		require.NoError(t, repos.Post.Create(ctx, post))
+		return post.ID
+	}
+	postID := newPost()
+
+	author := uuid.New()
+	created := time.Now()
+	create := func(parent *repomodel.Comment, authorID uuid.UUID) *repomodel.Comment {
+		created = created.Add(time.Second)
+		comment := &repomodel.Comment{
+			ID: uuid.New(), PostID: postID, Content: "Comment",
+			AuthorID: authorID, CreatedAt: created, UpdatedAt: created,
+		}
+		if parent != nil {
+			comment.ParentID = &parent.ID
+			comment.Depth = parent.Depth + 1
+		}
+		require.NoError(t, repos.Comment.Create(ctx, comment))
+		return comment
+	}
+
+	// root (author)
+	// ├── a (author)
+	// │   └── a1
+	// └── b
+	// other
+	root := create(nil, author)
+	a := create(root, author)
+	create(a, uuid.New())
+	b := create(root, uuid.New())
+	other := create(nil, uuid.New())
+
+	t.Run("aggregates", func(t *testing.T) {
+		stats, err := service.GetCommentStats(ctx, postID)
+		require.NoError(t, err)
+
+		assert.Equal(t, postID, stats.PostID)
+		assert.Equal(t, 5, stats.TotalComments)
+		assert.Equal(t, 2, stats.RootComments)
+		assert.Equal(t, 3, stats.Replies)
+		assert.Equal(t, 2, stats.MaxDepth)
+		assert.InDelta(t, 0.8, stats.AverageDepth, 1e-9)
+		assert.Equal(t, 4, stats.UniqueCommenters)
+		require.NotNil(t, stats.LastCommentAt)
+		assert.True(t, other.CreatedAt.Equal(*stats.LastCommentAt))
+		assert.Equal(t, []model.DepthCount{{Depth: 0, Count: 2}, {Depth: 1, Count: 2}, {Depth: 2, Count: 1}}, stats.DepthHistogram)
+	})
+
+	t.Run("tombstone keeps counts but not author", func(t *testing.T) {
+		_, tombstone, err := repos.Comment.SoftDelete(ctx, a.ID, model.DeletedCommentPlaceholder)
+		require.NoError(t, err)
+		require.True(t, tombstone)
+
+		stats, err := service.GetCommentStats(ctx, postID)
+		require.NoError(t, err)
+		assert.Equal(t, 5, stats.TotalComments)
+		assert.Equal(t, 4, stats.UniqueCommenters)
+
+		require.NoError(t, repos.Comment.Delete(ctx, b.ID))
+		stats, err = service.GetCommentStats(ctx, postID)
+		require.NoError(t, err)
+		assert.Equal(t, 4, stats.TotalComments)
+		assert.Equal(t, 3, stats.UniqueCommenters)
+	})
+
+	t.Run("post without comments", func(t *testing.T) {
+		stats, err := service.GetCommentStats(ctx, newPost())
+		require.NoError(t, err)
+		assert.Zero(t, stats.TotalComments)
+		assert.Zero(t, stats.AverageDepth)
+		assert.Nil(t, stats.LastCommentAt)
+		assert.Empty(t, stats.DepthHistogram)
+	})
+
+	t.Run("missing post", func(t *testing.T) {
+		_, err := service.GetCommentStats(ctx, uuid.New())
+		var domainErr *model.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "NOT_FOUND", domainErr.Type)
+	})
+}
@@ -0,0 +1,198 @@
+package comment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/NarthurN/habbr/internal/repository"
+	"github.com/NarthurN/habbr/internal/repository/converter"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+	"github.com/NarthurN/habbr/internal/service/pagination"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// GetCommentTree загружает ветку дерева комментариев.
+//
+// Глубина и количество ответов ограничиваются в репозитории, поэтому размер ответа
+// не зависит от размера обсуждения. Для свернутых узлов возвращаются курсор догрузки
+// ответов и признак продолжения ветки.
+func (s *Service) GetCommentTree(ctx context.Context, query model.CommentTreeQuery) (*model.CommentTree, error) {
+	s.logger.Debug("Getting comment tree",
+		zap.String("post_id", query.PostID.String()),
+		zap.Any("query", query),
+	)
+
+	if query.PostID == uuid.Nil {
+		s.logger.Warn("Attempt to get comment tree with nil post ID")
+		return nil, model.NewValidationError("post_id", "post ID is required")
+	}
+
+	depth, err := treeLimit("max_depth", query.Depth, model.DefaultCommentTreeDepth, 0, model.MaxCommentTreeDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	repliesFirst, err := treeLimit("replies_first", query.RepliesFirst, model.DefaultCommentTreeReplies, 1, model.MaxCommentTreeReplies)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := query.Filter
+	filter.PostID = &query.PostID
+	filter.ParentID = query.ParentID
+
+	if !filter.Order.OrDefault().IsValid() {
+		return nil, model.NewValidationError("order", fmt.Sprintf("unsupported comment order %q", filter.Order))
+	}
+
+	// Страница верхнего уровня; First всегда задан, так как Last не поддерживается
+	key, _ := converter.CommentOrderToRepo(filter.Order)
+	page, err := pagination.Page(model.PaginationInput{First: query.First, After: query.After}, key,
+		model.DefaultCommentTreePageSize, model.MaxCommentTreePageSize)
+	if err != nil {
+		s.logger.Warn("Invalid comment tree pagination parameters", zap.Error(err))
+		return nil, err
+	}
+	first := *page.First
+
+	if size := model.CommentTreeSize(first, repliesFirst, depth); size > model.MaxCommentTreeNodes {
+		return nil, model.NewValidationError("max_depth", fmt.Sprintf(
+			"comment tree of %d comments exceeds the limit of %d, reduce first, repliesFirst or maxDepth",
+			size, model.MaxCommentTreeNodes))
+	}
+
+	if err := s.checkTreeScope(ctx, query.PostID, query.ParentID); err != nil {
+		return nil, err
+	}
+
+	repoTree, err := s.commentRepo.ListTree(ctx, repomodel.CommentTreeFilter{
+		CommentFilter: converter.CommentFilterToRepo(filter, page),
+		Levels:        depth,
+		RepliesLimit:  repliesFirst,
+	})
+	if err != nil {
+		s.logger.Error("Failed to list comment tree from repository",
+			zap.Error(err),
+			zap.String("post_id", query.PostID.String()),
+		)
+		return nil, model.NewInternalError(fmt.Sprintf("failed to list comment tree: %v", err))
+	}
+
+	tree := buildCommentTree(repoTree, depth)
+
+	s.logger.Debug("Comment tree loaded successfully",
+		zap.String("post_id", query.PostID.String()),
+		zap.Int("top_level_comments", len(repoTree.Comments)),
+		zap.Int("total_comments", len(tree.Nodes)),
+	)
+
+	return tree, nil
+}
+
+// treeLimit возвращает значение ограничения ветки или значение по умолчанию,
+// проверяя, что оно лежит в диапазоне [minValue, maxValue]
+func treeLimit(field string, value *int, defaultValue, minValue, maxValue int) (int, error) {
+	if value == nil {
+		return defaultValue, nil
+	}
+	if *value < minValue || *value > maxValue {
+		return 0, model.NewValidationError(field, fmt.Sprintf("%s must be between %d and %d", field, minValue, maxValue))
+	}
+	return *value, nil
+}
+
+// checkTreeScope проверяет существование поста и принадлежность ему родителя ветки
+func (s *Service) checkTreeScope(ctx context.Context, postID uuid.UUID, parentID *uuid.UUID) error {
+	exists, err := s.postRepo.Exists(ctx, postID)
+	if err != nil {
+		s.logger.Error("Failed to check post existence",
+			zap.Error(err),
+			zap.String("post_id", postID.String()),
+		)
+		return model.NewInternalError(fmt.Sprintf("failed to check post existence: %v", err))
+	}
+	if !exists {
+		return model.NewNotFoundError("post", postID)
+	}
+
+	if parentID == nil {
+		return nil
+	}
+
+	parent, err := s.commentRepo.GetByID(ctx, *parentID)
+	if err != nil && err != repository.ErrNotFound {
+		s.logger.Error("Failed to get parent comment",
+			zap.Error(err),
+			zap.String("parent_id", parentID.String()),
+		)
+		return model.NewInternalError(fmt.Sprintf("failed to get parent comment: %v", err))
+	}
+	if err == repository.ErrNotFound || parent.PostID != postID {
+		return model.NewNotFoundError("comment", *parentID)
+	}
+
+	return nil
+}
+
+// buildCommentTree раскладывает ветку из репозитория в порядке обхода в глубину и
+// отмечает свернутые узлы. depth - количество загруженных уровней ответов
+func buildCommentTree(repoTree *repomodel.CommentTreePage, depth int) *model.CommentTree {
+	type reply struct {
+		comment *model.Comment
+		cursor  string
+	}
+
+	// Replies упорядочены по сортировке, поэтому ответы каждого родителя тоже
+	replies := make(map[uuid.UUID][]reply)
+	for i, repoComment := range repoTree.Replies {
+		comment := converter.CommentFromRepo(repoComment)
+		replies[*comment.ParentID] = append(replies[*comment.ParentID], reply{
+			comment: comment,
+			cursor:  pagination.EncodeCursor(repoTree.ReplyCursors[i]),
+		})
+	}
+
+	tree := &model.CommentTree{
+		Nodes: make([]*model.CommentTreeNode, 0, len(repoTree.Comments)+len(repoTree.Replies)),
+		PageInfo: &model.PageInfo{
+			HasNextPage:     repoTree.HasNextPage,
+			HasPreviousPage: repoTree.HasPreviousPage,
+		},
+	}
+
+	var visit func(comment *model.Comment, cursor string, level int)
+	visit = func(comment *model.Comment, cursor string, level int) {
+		children := replies[comment.ID]
+		node := &model.CommentTreeNode{
+			Comment:          comment,
+			Cursor:           cursor,
+			ReplyCount:       repoTree.ReplyCounts[comment.ID],
+			LoadedReplyCount: len(children),
+		}
+		switch {
+		case level == depth:
+			node.ContinueThread = node.ReplyCount > 0
+		case len(children) > 0 && node.ReplyCount > len(children):
+			node.MoreRepliesCursor = &children[len(children)-1].cursor
+		}
+
+		tree.Nodes = append(tree.Nodes, node)
+		for _, child := range children {
+			visit(child.comment, child.cursor, level+1)
+		}
+	}
+
+	for i, repoComment := range repoTree.Comments {
+		visit(converter.CommentFromRepo(repoComment), pagination.EncodeCursor(repoTree.Cursors[i]), 0)
+	}
+
+	if len(repoTree.Comments) > 0 {
+		tree.PageInfo.StartCursor = &tree.Nodes[0].Cursor
+		last := pagination.EncodeCursor(repoTree.Cursors[len(repoTree.Cursors)-1])
+		tree.PageInfo.EndCursor = &last
+	}
+
+	return tree
+}
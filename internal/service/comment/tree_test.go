@@ -0,0 +1,197 @@
+package comment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/NarthurN/habbr/internal/repository/memory"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+)
+
+func TestGetCommentTree(t *testing.T) {
+	ctx := context.Background()
+	manager := memory.NewManager()
+	repos := manager.GetRepositories()
+	service := NewService(repos, manager, zap.NewNop(), nil)
+
+	post := &repomodel.Post{
+		ID: uuid.New(), Title: "Post", Content: "Content", AuthorID: uuid.New(),
+		CommentsEnabled: true, CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}
+	require.NoError(t, repos.Post.Create(ctx, post))
+
+	// Комментарии создаются с возрастающим временем для детерминированного порядка
+	author := uuid.New()
+	created := time.Now()
+	create := func(parent *uuid.UUID, authorID uuid.UUID) uuid.UUID {
+		created = created.Add(time.Second)
+		comment := &repomodel.Comment{
+			ID: uuid.New(), PostID: post.ID, ParentID: parent, Content: "Comment",
+			AuthorID: authorID, CreatedAt: created, UpdatedAt: created,
+		}
+		if parent != nil {
+			stored, err := repos.Comment.GetByID(ctx, *parent)
+			require.NoError(t, err)
+			comment.Depth = stored.Depth + 1
+		}
+		require.NoError(t, repos.Comment.Create(ctx, comment))
+		return comment.ID
+	}
+
+	// root
+	// ├── a (автор author)
+	// │   └── a1
+	// │       └── a11
+	// ├── b
+	// └── c (автор author)
+	// other
+	root := create(nil, author)
+	a := create(&root, author)
+	a1 := create(&a, uuid.New())
+	a11 := create(&a1, uuid.New())
+	b := create(&root, uuid.New())
+	c := create(&root, author)
+	other := create(nil, uuid.New())
+
+	ids := func(tree *model.CommentTree) []uuid.UUID {
+		var values []uuid.UUID
+		for _, node := range tree.Nodes {
+			values = append(values, node.Comment.ID)
+		}
+		return values
+	}
+	intPtr := func(value int) *int { return &value }
+
+	t.Run("depth and per-level limits", func(t *testing.T) {
+		tree, err := service.GetCommentTree(ctx, model.CommentTreeQuery{
+			PostID:       post.ID,
+			Depth:        intPtr(2),
+			First:        intPtr(1),
+			RepliesFirst: intPtr(2),
+		})
+		require.NoError(t, err)
+
+		// Обход в глубину: корень, первые два ответа и ответ на a
+		assert.Equal(t, []uuid.UUID{root, a, a1, b}, ids(tree))
+		assert.True(t, tree.PageInfo.HasNextPage)
+
+		rootNode, aNode, a1Node, bNode := tree.Nodes[0], tree.Nodes[1], tree.Nodes[2], tree.Nodes[3]
+		assert.Equal(t, 3, rootNode.ReplyCount)
+		assert.Equal(t, 2, rootNode.LoadedReplyCount)
+		require.NotNil(t, rootNode.MoreRepliesCursor)
+		assert.Equal(t, bNode.Cursor, *rootNode.MoreRepliesCursor)
+		assert.False(t, rootNode.ContinueThread)
+
+		assert.Nil(t, aNode.MoreRepliesCursor)
+		assert.False(t, aNode.ContinueThread)
+
+		// a1 на последнем уровне: его ответ не загружен
+		assert.Equal(t, 1, a1Node.ReplyCount)
+		assert.Equal(t, 0, a1Node.LoadedReplyCount)
+		assert.True(t, a1Node.ContinueThread)
+		assert.Nil(t, a1Node.MoreRepliesCursor)
+
+		// Догрузка оставшихся ответов корня
+		more, err := service.GetCommentTree(ctx, model.CommentTreeQuery{
+			PostID:   post.ID,
+			ParentID: &root,
+			After:    rootNode.MoreRepliesCursor,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{c}, ids(more))
+		assert.False(t, more.PageInfo.HasNextPage)
+
+		// Продолжение ветки
+		thread, err := service.GetCommentTree(ctx, model.CommentTreeQuery{PostID: post.ID, ParentID: &a1})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{a11}, ids(thread))
+
+		// Следующая страница корневых комментариев
+		next, err := service.GetCommentTree(ctx, model.CommentTreeQuery{
+			PostID: post.ID,
+			First:  intPtr(1),
+			After:  tree.PageInfo.EndCursor,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{other}, ids(next))
+	})
+
+	t.Run("filter applies on every level", func(t *testing.T) {
+		tree, err := service.GetCommentTree(ctx, model.CommentTreeQuery{
+			PostID: post.ID,
+			Filter: model.CommentFilter{AuthorID: &author},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{root, a, c}, ids(tree))
+		assert.Equal(t, 2, tree.Nodes[0].ReplyCount)
+		assert.Equal(t, 0, tree.Nodes[1].ReplyCount)
+
+		tree, err = service.GetCommentTree(ctx, model.CommentTreeQuery{
+			PostID: post.ID,
+			Filter: model.CommentFilter{MaxDepth: intPtr(1), Order: model.CommentOrderNewest},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{other, root, c, b, a}, ids(tree))
+		assert.False(t, tree.Nodes[4].ContinueThread)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		_, err := service.GetCommentTree(ctx, model.CommentTreeQuery{PostID: post.ID, Depth: intPtr(model.MaxCommentTreeDepth + 1)})
+		assert.Error(t, err)
+
+		_, err = service.GetCommentTree(ctx, model.CommentTreeQuery{PostID: post.ID, RepliesFirst: intPtr(0)})
+		assert.Error(t, err)
+
+		// 50 * (1 + 20 + 400) комментариев больше допустимого размера ветки
+		_, err = service.GetCommentTree(ctx, model.CommentTreeQuery{
+			PostID:       post.ID,
+			Depth:        intPtr(2),
+			First:        intPtr(model.MaxCommentTreePageSize),
+			RepliesFirst: intPtr(model.MaxCommentTreeReplies),
+		})
+		assert.Error(t, err)
+
+		otherPost := uuid.New()
+		_, err = service.GetCommentTree(ctx, model.CommentTreeQuery{PostID: otherPost})
+		var domainErr *model.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "NOT_FOUND", domainErr.Type)
+
+		// Родитель из другого поста не найден
+		require.NoError(t, repos.Post.Create(ctx, &repomodel.Post{
+			ID: otherPost, Title: "Other", Content: "Content", AuthorID: uuid.New(),
+			CommentsEnabled: true, CreatedAt: time.Now(), UpdatedAt: time.Now(),
+		}))
+		_, err = service.GetCommentTree(ctx, model.CommentTreeQuery{PostID: otherPost, ParentID: &root})
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "NOT_FOUND", domainErr.Type)
+	})
+
+	t.Run("subtree by materialized path", func(t *testing.T) {
+		comment, err := service.GetCommentWithChildren(ctx, a)
+		require.NoError(t, err)
+		assert.Equal(t, a, comment.ID)
+		require.Len(t, comment.Children, 1)
+		assert.Equal(t, a1, comment.Children[0].ID)
+		require.Len(t, comment.Children[0].Children, 1)
+		assert.Equal(t, a11, comment.Children[0].Children[0].ID)
+
+		tree, err := service.GetFullCommentTree(ctx, post.ID, "")
+		require.NoError(t, err)
+		require.Len(t, tree, 2)
+		assert.Equal(t, []uuid.UUID{root, other}, []uuid.UUID{tree[0].ID, tree[1].ID})
+		assert.Len(t, tree[0].Children, 3)
+
+		_, err = service.GetCommentWithChildren(ctx, uuid.New())
+		var domainErr *model.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "NOT_FOUND", domainErr.Type)
+	})
+}
@@ -23,7 +23,7 @@ import (
 // - Возвращать доменные ошибки с понятными сообщениями
 //
 // Пример использования:
-//   postService := post.NewService(repositories, logger)
+//   postService := post.NewService(repositories, repoManager, logger, subscriptionService)
 //   post, err := postService.CreatePost(ctx, postInput)
 //   if err != nil {
 //       return fmt.Errorf("failed to create post: %w", err)
@@ -209,7 +209,7 @@ type PostService interface {
 // - Обеспечивать целостность данных при удалении
 //
 // Пример использования:
-//   commentService := comment.NewService(repositories, logger, subscriptionService)
+//   commentService := comment.NewService(repositories, repoManager, logger, subscriptionService)
 //   comment, err := commentService.CreateComment(ctx, commentInput)
 type CommentService interface {
 	// CreateComment создает новый комментарий к посту или ответ на существующий комментарий.
@@ -288,6 +288,28 @@ type CommentService interface {
 	//   connection, err := service.ListComments(ctx, filter, pagination)
 	ListComments(ctx context.Context, filter model.CommentFilter, pagination model.PaginationInput) (*model.CommentConnection, error)
 
+	// ListCommentsByPostIDs возвращает комментарии нескольких постов одним запросом к хранилищу.
+	//
+	// Фильтр, порядок сортировки и пагинация применяются к комментариям каждого поста
+	// отдельно, filter.PostID и filter.ParentID не учитываются. Используется для пакетной
+	// загрузки поля Post.comments.
+	//
+	// Параметры:
+	//   - ctx: контекст запроса для отмены операции
+	//   - postIDs: идентификаторы постов
+	//   - filter: критерии фильтрации комментариев
+	//   - pagination: параметры пагинации комментариев каждого поста
+	//
+	// Возвращает:
+	//   - map[uuid.UUID]*model.CommentConnection: страница комментариев для каждого из postIDs
+	//   - error: ошибка валидации или выполнения запроса
+	ListCommentsByPostIDs(ctx context.Context, postIDs []uuid.UUID, filter model.CommentFilter, pagination model.PaginationInput) (map[uuid.UUID]*model.CommentConnection, error)
+
+	// ListRepliesByParentIDs возвращает прямые ответы на несколько комментариев одним
+	// запросом к хранилищу, аналогично ListCommentsByPostIDs. Используется для пакетной
+	// загрузки поля Comment.children.
+	ListRepliesByParentIDs(ctx context.Context, parentIDs []uuid.UUID, filter model.CommentFilter, pagination model.PaginationInput) (map[uuid.UUID]*model.CommentConnection, error)
+
 	// UpdateComment обновляет содержимое существующего комментария.
 	//
 	// Метод проверяет права доступа (только автор может изменять комментарий),
@@ -312,7 +334,8 @@ type CommentService interface {
 
 	// DeleteComment удаляет комментарий и все его дочерние комментарии.
 	//
-	// Метод выполняет каскадное удаление комментария и всех его ответов.
+	// Метод выполняет каскадное удаление комментария и всех его ответов
+	// аналогично DeleteCommentsTree.
 	// Проверяет права доступа и отправляет уведомления подписчикам.
 	// Операция необратима и выполняется в транзакции.
 	//
@@ -330,15 +353,91 @@ type CommentService interface {
 	//   - model.InternalError: проблемы с базой данных
 	DeleteComment(ctx context.Context, id uuid.UUID, authorID uuid.UUID) error
 
-	// GetCommentsTree возвращает полное дерево комментариев для поста.
+	// SoftDeleteComment выполняет мягкое удаление комментария.
+	//
+	// Комментарий с ответами становится надгробием: содержимое заменяется на
+	// model.DeletedCommentPlaceholder, автор скрывается, устанавливается DeletedAt,
+	// а поддерево ответов сохраняется. Комментарий без ответов удаляется полностью
+	// вместе с надгробиями предков, у которых не осталось ответов.
+	// Подписчики получают событие TOMBSTONED для надгробия или DELETED для каждого
+	// удаленного комментария.
+	//
+	// Параметры:
+	//   - ctx: контекст запроса для отмены операции
+	//   - id: уникальный идентификатор удаляемого комментария
+	//   - authorID: идентификатор пользователя, выполняющего удаление
+	//
+	// Возвращает:
+	//   - *model.Comment: надгробие или удаленный комментарий
+	//   - bool: true, если комментарий стал надгробием
+	//   - error: ошибка прав доступа или системная ошибка
+	//
+	// Возможные ошибки:
+	//   - model.NotFoundError: комментарий не найден
+	//   - model.ForbiddenError: пользователь не является автором (в том числе для надгробия)
+	//   - model.InternalError: проблемы с базой данных
+	SoftDeleteComment(ctx context.Context, id uuid.UUID, authorID uuid.UUID) (*model.Comment, bool, error)
+
+	// DeleteCommentsTree удаляет комментарий и все вложенные ответы любой глубины.
+	//
+	// Поддерево удаляется атомарно одной операцией хранилища, вместе с ним удаляются
+	// надгробия предков, у которых не осталось ответов. Для каждого удаленного
+	// комментария подписчикам отправляется событие DELETED.
+	//
+	// Параметры:
+	//   - ctx: контекст запроса для отмены операции
+	//   - id: идентификатор корня удаляемого поддерева
+	//   - authorID: идентификатор пользователя, выполняющего удаление
+	//
+	// Возвращает:
+	//   - []uuid.UUID: идентификаторы всех удаленных комментариев, начиная с корня
+	//     поддерева, затем удаленные надгробия предков
+	//   - error: ошибка прав доступа или системная ошибка
+	//
+	// Возможные ошибки:
+	//   - model.NotFoundError: комментарий не найден
+	//   - model.ForbiddenError: пользователь не является автором корневого комментария
+	//   - model.InternalError: проблемы с базой данных
+	DeleteCommentsTree(ctx context.Context, id uuid.UUID, authorID uuid.UUID) ([]uuid.UUID, error)
+
+	// DeleteCommentsBatch удаляет несколько комментариев поста вместе с ответами.
+	//
+	// До удаления проверяется каждый ID: комментарий существует, принадлежит посту
+	// postID, а пользователь является его автором или модератором. В атомарном режиме
+	// комментарии удаляются в одной транзакции только если удалить можно все; в обычном
+	// режиме удаляются все прошедшие проверку. Итог каждого ID возвращается в результате,
+	// а не ошибкой. Для каждого удаленного комментария отправляется событие DELETED.
+	//
+	// Параметры:
+	//   - ctx: контекст запроса для отмены операции
+	//   - postID: идентификатор поста, которому должны принадлежать комментарии
+	//   - ids: идентификаторы удаляемых комментариев (не более model.MaxCommentBatchSize)
+	//   - actor: пользователь, выполняющий удаление
+	//   - atomic: удалить все или ничего
+	//
+	// Возвращает:
+	//   - *model.CommentBatchDeleteResult: итог каждого ID и все удаленные комментарии
+	//   - error: ошибка всей операции
+	//
+	// Возможные ошибки:
+	//   - model.NotFoundError: пост не найден
+	//   - model.UnauthorizedError: пользователь не указан
+	//   - model.ValidationError: пустой или слишком большой пакет
+	//   - model.InternalError: проблемы с базой данных
+	DeleteCommentsBatch(ctx context.Context, postID uuid.UUID, ids []uuid.UUID, actor *model.Principal, atomic bool) (*model.CommentBatchDeleteResult, error)
+
+	// GetFullCommentTree возвращает полное дерево комментариев для поста.
 	//
 	// Метод загружает все комментарии к указанному посту и строит из них
-	// иерархическую структуру с заполненными полями Children. Корневые
-	// комментарии возвращаются в порядке создания.
+	// иерархическую структуру с заполненными полями Children. Комментарии
+	// каждого уровня упорядочены по order (по умолчанию - в порядке создания).
+	// Глубина и число ответов не ограничиваются; для постраничной загрузки
+	// ветки используйте GetCommentTree.
 	//
 	// Параметры:
 	//   - ctx: контекст запроса для отмены операции
 	//   - postID: уникальный идентификатор поста
+	//   - order: порядок сортировки комментариев одного уровня
 	//
 	// Возвращает:
 	//   - []*model.Comment: список корневых комментариев с построенным деревом
@@ -349,25 +448,89 @@ type CommentService interface {
 	//   - model.InternalError: проблемы с базой данных
 	//
 	// Пример использования:
-	//   tree, err := service.GetCommentsTree(ctx, postID)
+	//   tree, err := service.GetFullCommentTree(ctx, postID, model.CommentOrderMostReplies)
 	//   for _, rootComment := range tree {
 	//       printCommentTree(rootComment, 0) // рекурсивный вывод
 	//   }
-	GetCommentsTree(ctx context.Context, postID uuid.UUID) ([]*model.Comment, error)
+	GetFullCommentTree(ctx context.Context, postID uuid.UUID, order model.CommentOrder) ([]*model.Comment, error)
+
+	// GetCommentTree загружает ветку дерева комментариев с ограничением глубины.
+	//
+	// Верхний уровень ветки - корневые комментарии поста или ответы на query.ParentID,
+	// он разбивается на страницы query.First/query.After. Ниже загружается не более
+	// query.Depth уровней и не более query.RepliesFirst ответов на каждый комментарий;
+	// фильтр и порядок применяются на каждом уровне. Узел, у которого загружены не все
+	// ответы, получает MoreRepliesCursor, а узел последнего уровня с ответами -
+	// признак ContinueThread.
+	//
+	// Параметры:
+	//   - ctx: контекст запроса для отмены операции
+	//   - query: пост, родитель, фильтр, ограничения глубины и размеров уровней
+	//
+	// Возвращает:
+	//   - *model.CommentTree: узлы ветки в порядке обхода в глубину и пагинация верхнего уровня
+	//   - error: ошибка валидации или загрузки данных
+	//
+	// Возможные ошибки:
+	//   - model.ValidationError: неверные ограничения, курсор или порядок; ветка больше
+	//     model.MaxCommentTreeNodes комментариев
+	//   - model.NotFoundError: пост не найден или родитель принадлежит другому посту
+	//   - model.InternalError: проблемы с базой данных
+	//
+	// Пример использования:
+	//   tree, err := service.GetCommentTree(ctx, model.CommentTreeQuery{PostID: postID})
+	//   for _, node := range tree.Nodes {
+	//       if node.ContinueThread {
+	//           // ссылка "продолжить ветку" на GetCommentTree с ParentID = node.Comment.ID
+	//       }
+	//   }
+	GetCommentTree(ctx context.Context, query model.CommentTreeQuery) (*model.CommentTree, error)
+
+	// GetCommentContext загружает комментарий в контексте обсуждения для постоянной ссылки.
+	//
+	// Возвращает комментарий, не более ancestors ближайших предков (от дальнего к родителю)
+	// и первую страницу из не более replies прямых ответов. Остальные ответы загружаются
+	// через GetCommentTree с ParentID = id и After = Replies.PageInfo.EndCursor.
+	//
+	// Параметры:
+	//   - ctx: контекст запроса для отмены операции
+	//   - id: идентификатор комментария
+	//   - ancestors: количество предков, по умолчанию model.DefaultCommentContextAncestors
+	//   - replies: количество ответов, по умолчанию model.DefaultCommentTreePageSize
+	//
+	// Возвращает:
+	//   - *model.CommentContext: комментарий, предки и ответы
+	//   - error: ошибка валидации или загрузки данных
+	//
+	// Возможные ошибки:
+	//   - model.ValidationError: ancestors или replies вне допустимого диапазона
+	//   - model.NotFoundError: комментарий не найден
+	//   - model.InternalError: проблемы с базой данных
+	//
+	// Пример использования:
+	//   ancestors := 3
+	//   commentCtx, err := service.GetCommentContext(ctx, commentID, &ancestors, nil)
+	GetCommentContext(ctx context.Context, id uuid.UUID, ancestors, replies *int) (*model.CommentContext, error)
 
 	// GetCommentStats возвращает статистику комментариев для поста.
 	//
-	// Метод подсчитывает общее количество комментариев к посту,
-	// включая все уровни вложенности. Полезно для отображения счетчиков.
+	// Статистика вычисляется агрегатными запросами в хранилище по всем комментариям
+	// поста, включая все уровни вложенности, без загрузки самих комментариев:
+	// количество корневых комментариев и ответов, максимальная и средняя глубина,
+	// число авторов, время последнего комментария и распределение по глубине.
 	//
 	// Параметры:
 	//   - ctx: контекст запроса для отмены операции
 	//   - postID: уникальный идентификатор поста
 	//
 	// Возвращает:
-	//   - int: общее количество комментариев (включая ответы)
+	//   - *model.CommentStats: статистика комментариев (нулевая для поста без комментариев)
 	//   - error: ошибка подсчета
-	GetCommentStats(ctx context.Context, postID uuid.UUID) (int, error)
+	//
+	// Возможные ошибки:
+	//   - model.NotFoundError: пост не найден
+	//   - model.InternalError: проблемы с базой данных
+	GetCommentStats(ctx context.Context, postID uuid.UUID) (*model.CommentStats, error)
 }
 
 //go:generate mockery --name SubscriptionService --output ./mocks --filename mock_subscription_service.go
@@ -428,10 +591,118 @@ type SubscriptionService interface {
 	//           fmt.Printf("Обновлен комментарий: %s\n", event.Comment.Content)
 	//       case "DELETED":
 	//           fmt.Printf("Удален комментарий: %s\n", event.Comment.ID)
+	//       case "TOMBSTONED":
+	//           fmt.Printf("Комментарий стал надгробием: %s\n", event.Comment.ID)
 	//       }
 	//   }
 	Subscribe(ctx context.Context, postID uuid.UUID) (<-chan *model.CommentSubscriptionPayload, error)
 
+	// SubscribeAll создает подписку на события комментариев всех постов.
+	//
+	// Предназначен для панелей модерации, которым нужно видеть всю активность
+	// в реальном времени. Каждый подписчик имеет собственный буфер; если он не
+	// успевает читать события, они обрабатываются по политике медленного подписчика
+	// (subscription.WithSlowConsumerPolicy или конфигурация) и потери учитываются в метриках.
+	//
+	// Параметры:
+	//   - ctx: контекст подписки, отмена приводит к закрытию канала
+	//
+	// Возвращает:
+	//   - <-chan *model.CommentSubscriptionPayload: канал событий всех постов
+	//   - error: ошибка создания подписки
+	//
+	// Пример использования:
+	//   events, err := service.SubscribeAll(ctx)
+	//   for event := range events {
+	//       fmt.Printf("%s: комментарий %s в посте %s\n", event.ActionType, event.Comment.ID, event.PostID)
+	//   }
+	SubscribeAll(ctx context.Context) (<-chan *model.CommentSubscriptionPayload, error)
+
+	// SubscribeToPost создает подписку на события жизненного цикла поста.
+	//
+	// Клиент получает события UPDATED, COMMENTS_TOGGLED и DELETED для указанного поста.
+	// Событие DELETED является терминальным: после его доставки канал закрывается.
+	//
+	// Параметры:
+	//   - ctx: контекст подписки, отмена приводит к закрытию канала
+	//   - postID: идентификатор отслеживаемого поста
+	//
+	// Возвращает:
+	//   - <-chan *model.PostSubscriptionPayload: канал событий поста
+	//   - error: ошибка валидации (например, пустой postID)
+	//
+	// Пример использования:
+	//   events, err := service.SubscribeToPost(ctx, postID)
+	//   for event := range events {
+	//       if event.ActionType == model.PostEventDeleted {
+	//           fmt.Println("Пост удален, подписка завершена")
+	//       }
+	//   }
+	SubscribeToPost(ctx context.Context, postID uuid.UUID) (<-chan *model.PostSubscriptionPayload, error)
+
+	// SubscribeToAllPosts создает подписку на события жизненного цикла всех постов.
+	//
+	// Используется, например, для ленты новых постов: клиент фильтрует события CREATED.
+	//
+	// Параметры:
+	//   - ctx: контекст подписки, отмена приводит к закрытию канала
+	//
+	// Возвращает:
+	//   - <-chan *model.PostSubscriptionPayload: канал событий всех постов
+	//   - error: ошибка создания подписки
+	SubscribeToAllPosts(ctx context.Context) (<-chan *model.PostSubscriptionPayload, error)
+
+	// SubscribeToPostStats создает подписку на статистику комментариев поста.
+	//
+	// Первым сообщением подписчик получает текущую статистику. Функция load вызывается
+	// только если статистика поста еще не отслеживается сервисом; дальше счетчики
+	// обновляются по событиям создания и удаления комментариев и переключения
+	// комментариев без повторного подсчета в хранилище. Частые изменения объединяются:
+	// для одного поста отправляется не больше одного обновления за настроенный интервал.
+	// После удаления поста канал закрывается.
+	//
+	// Параметры:
+	//   - ctx: контекст подписки, отмена приводит к закрытию канала
+	//   - postID: идентификатор поста
+	//   - load: загрузка актуальной статистики из хранилища
+	//
+	// Возвращает:
+	//   - <-chan *model.PostStats: канал обновлений статистики
+	//   - error: ошибка валидации или ошибка, возвращенная load
+	//
+	// Пример использования:
+	//   stats, err := service.SubscribeToPostStats(ctx, postID, func(ctx context.Context) (*model.PostStats, error) {
+	//       return loadStats(ctx, postID)
+	//   })
+	SubscribeToPostStats(ctx context.Context, postID uuid.UUID, load func(ctx context.Context) (*model.PostStats, error)) (<-chan *model.PostStats, error)
+
+	// SubscribeFrom создает подписку на события комментариев поста с повторной доставкой пропущенных.
+	//
+	// Сначала подписчик получает из журнала событий все события поста с EventID больше
+	// afterEventID в порядке возрастания, затем - события в реальном времени. События,
+	// уже доставленные из журнала, повторно не отправляются. Журнал хранит ограниченное
+	// количество последних событий каждого поста (repository.CommentEventRetention),
+	// более старые события не восстанавливаются: если события после afterEventID уже
+	// вытеснены, подписка не создается и возвращается ошибка EVENT_CURSOR_EXPIRED, после
+	// которой клиент заново загружает комментарии. afterEventID = 0 означает подписку
+	// без повторной доставки.
+	//
+	// Параметры:
+	//   - ctx: контекст подписки, отмена приводит к закрытию канала
+	//   - postID: идентификатор поста
+	//   - afterEventID: последний EventID, полученный клиентом
+	//
+	// Возвращает:
+	//   - <-chan *model.CommentSubscriptionPayload: канал событий с заполненным EventID
+	//   - error: ошибка валидации, устаревший курсор или ошибка чтения журнала событий
+	//
+	// Пример использования:
+	//   events, err := service.SubscribeFrom(ctx, postID, lastEventID)
+	//   for event := range events {
+	//       lastEventID = event.EventID
+	//   }
+	SubscribeFrom(ctx context.Context, postID uuid.UUID, afterEventID int64) (<-chan *model.CommentSubscriptionPayload, error)
+
 	// Publish отправляет событие всем подписчикам указанного поста.
 	//
 	// Метод рассылает уведомление о событии комментария всем активным
@@ -457,6 +728,35 @@ type SubscriptionService interface {
 	//   service.Publish(postID, payload)
 	Publish(postID uuid.UUID, payload *model.CommentSubscriptionPayload)
 
+	// PublishBatch отправляет несколько событий комментариев в порядке следования.
+	//
+	// В отличие от последовательных вызовов Publish, события сохраняются в журнал
+	// одной записью. PostID каждого события должен быть заполнен.
+	//
+	// Пример использования:
+	//   service.PublishBatch([]*model.CommentSubscriptionPayload{
+	//       {PostID: postID, Comment: root, ActionType: "DELETED", DeletedCount: 1},
+	//       {PostID: postID, Comment: reply, ActionType: "DELETED", DeletedCount: 1},
+	//   })
+	PublishBatch(payloads []*model.CommentSubscriptionPayload)
+
+	// PublishPost отправляет событие жизненного цикла поста.
+	//
+	// Событие получают подписчики поста и подписчики на все посты. После события
+	// DELETED подписки на этот пост завершаются.
+	//
+	// Параметры:
+	//   - postID: идентификатор поста
+	//   - payload: данные события
+	//
+	// Пример использования:
+	//   service.PublishPost(post.ID, &model.PostSubscriptionPayload{
+	//       PostID:     post.ID,
+	//       Post:       post,
+	//       ActionType: model.PostEventCreated,
+	//   })
+	PublishPost(postID uuid.UUID, payload *model.PostSubscriptionPayload)
+
 	// GetSubscriberCount возвращает количество активных подписчиков для поста.
 	//
 	// Метод подсчитывает количество активных WebSocket соединений,
@@ -494,6 +794,89 @@ type SubscriptionService interface {
 	Shutdown()
 }
 
+//go:generate mockery --name SearchService --output ./mocks --filename mock_search_service.go
+
+// SearchService определяет интерфейс полнотекстового поиска постов и комментариев.
+//
+// Результаты упорядочены по убыванию релевантности и содержат фрагмент текста
+// с подсвеченными совпадениями. Документ индексируется с учетом своего языка
+// (model.Language), поэтому запрос находит другие словоформы: "комментарии"
+// находит "комментарий". Поддерживается только прямая пагинация (first/after):
+// курсор кодирует релевантность и ID результата.
+//
+// Пример использования:
+//   first := 10
+//   results, err := searchService.SearchPosts(ctx, `graphql -rest`, "", model.PaginationInput{First: &first})
+//   for _, edge := range results.Edges {
+//       fmt.Printf("%.3f %s: %s\n", edge.Rank, edge.Node.Title, edge.Snippet)
+//   }
+type SearchService interface {
+	// SearchPosts ищет посты по заголовку и содержимому.
+	//
+	// Совпадения в заголовке весят больше совпадений в содержимом.
+	//
+	// Параметры:
+	//   - ctx: контекст запроса
+	//   - query: поисковый запрос (слова, "фразы", OR, -исключения)
+	//   - language: язык искомых постов; пустое значение - посты на всех языках
+	//   - pagination: параметры пагинации, допускаются только first и after
+	//
+	// Возвращает:
+	//   - *model.PostSearchConnection: страница найденных постов
+	//   - error: ошибка валидации запроса, курсора или пагинации либо внутренняя ошибка
+	SearchPosts(ctx context.Context, query string, language model.Language, pagination model.PaginationInput) (*model.PostSearchConnection, error)
+
+	// SearchComments ищет комментарии поста по содержимому.
+	//
+	// Параметры:
+	//   - ctx: контекст запроса
+	//   - postID: идентификатор поста
+	//   - query: поисковый запрос
+	//   - language: язык искомых комментариев; пустое значение - комментарии на всех языках
+	//   - pagination: параметры пагинации, допускаются только first и after
+	//
+	// Возвращает:
+	//   - *model.CommentSearchConnection: страница найденных комментариев
+	//   - error: ошибка валидации, model.NotFoundError если пост не существует, внутренняя ошибка
+	SearchComments(ctx context.Context, postID uuid.UUID, query string, language model.Language, pagination model.PaginationInput) (*model.CommentSearchConnection, error)
+}
+
+//go:generate mockery --name AnalyticsService --output ./mocks --filename mock_analytics_service.go
+
+// AnalyticsService определяет интерфейс аналитики постов для редакционной панели.
+//
+// Аналитика содержит сводку обсуждения каждого поста: количество комментариев,
+// комментариев верхнего уровня, максимальную глубину и время последнего комментария.
+// В PostgreSQL она читается из материализованного представления post_analytics и
+// отстает от данных не больше чем на интервал фонового обновления.
+//
+// Пример использования:
+//   first := 10
+//   items, err := analyticsService.ListPostAnalytics(ctx, model.PostAnalyticsFilter{
+//       Order: model.PostAnalyticsOrderDeepest,
+//   }, &first)
+type AnalyticsService interface {
+	// ListPostAnalytics возвращает аналитику постов.
+	//
+	// Параметры:
+	//   - ctx: контекст запроса
+	//   - filter: фильтр и порядок сортировки, по умолчанию MOST_COMMENTED
+	//   - first: количество постов, по умолчанию model.DefaultPostAnalyticsLimit,
+	//     не больше model.MaxPostAnalyticsLimit
+	//
+	// Возвращает:
+	//   - []*model.PostAnalytics: аналитика постов в порядке сортировки
+	//   - error: ошибка валидации фильтра или first либо внутренняя ошибка
+	ListPostAnalytics(ctx context.Context, filter model.PostAnalyticsFilter, first *int) ([]*model.PostAnalytics, error)
+
+	// RefreshPostAnalytics немедленно обновляет данные аналитики, не дожидаясь
+	// фонового обновления.
+	//
+	// Возвращает:
+	//   - error: внутренняя ошибка обновления
+	RefreshPostAnalytics(ctx context.Context) error
+}
+
 // Services объединяет все сервисы приложения в единую структуру.
 //
 // Эта структура используется для передачи всех сервисов в слои представления
@@ -516,4 +899,10 @@ type Services struct {
 
 	// Subscription - сервис для управления real-time подписками
 	Subscription SubscriptionService
+
+	// Search - сервис полнотекстового поиска
+	Search SearchService
+
+	// Analytics - сервис аналитики постов
+	Analytics AnalyticsService
 }
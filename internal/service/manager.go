@@ -3,9 +3,13 @@ package service
 import (
 	"context"
 
+	"github.com/NarthurN/habbr/internal/config"
+	"github.com/NarthurN/habbr/internal/pubsub"
 	"github.com/NarthurN/habbr/internal/repository"
+	"github.com/NarthurN/habbr/internal/service/analytics"
 	"github.com/NarthurN/habbr/internal/service/comment"
 	"github.com/NarthurN/habbr/internal/service/post"
+	"github.com/NarthurN/habbr/internal/service/search"
 	"github.com/NarthurN/habbr/internal/service/subscription"
 	"go.uber.org/zap"
 )
@@ -16,8 +20,14 @@ type Manager struct {
 	logger   *zap.Logger
 }
 
-// NewManager создает новый менеджер сервисов
-func NewManager(repos *repository.Repositories, logger *zap.Logger) *Manager {
+// NewManager создает новый менеджер сервисов.
+//
+// transactor выполняет многошаговые изменения постов и комментариев атомарно
+// (обычно это менеджер репозиториев); nil означает выполнение без транзакций.
+// broker определяет доставку событий подписок между экземплярами сервиса;
+// nil означает in-memory брокер. analyticsCfg задает интервал фонового
+// обновления аналитики постов.
+func NewManager(repos *repository.Repositories, transactor repository.Transactor, cfg config.SubscriptionConfig, broker pubsub.Broker, analyticsCfg config.AnalyticsConfig, logger *zap.Logger) *Manager {
 	if logger == nil {
 		logger = zap.NewNop()
 	}
@@ -25,16 +35,20 @@ func NewManager(repos *repository.Repositories, logger *zap.Logger) *Manager {
 	logger.Info("Initializing service manager")
 
 	// Создаем сервис подписок
-	subscriptionService := subscription.NewService(logger.Named("subscription"))
+	subscriptionService := subscription.NewService(cfg, broker, repos.CommentEvent, logger.Named("subscription"))
 
 	// Создаем сервисы с dependency injection
-	postService := post.NewService(repos, logger.Named("post"))
-	commentService := comment.NewService(repos, logger.Named("comment"), subscriptionService)
+	postService := post.NewService(repos, transactor, logger.Named("post"), subscriptionService)
+	commentService := comment.NewService(repos, transactor, logger.Named("comment"), subscriptionService)
+	searchService := search.NewService(repos, logger.Named("search"))
+	analyticsService := analytics.NewService(repos, analyticsCfg, logger.Named("analytics"))
 
 	services := &Services{
 		Post:         postService,
 		Comment:      commentService,
 		Subscription: subscriptionService,
+		Search:       searchService,
+		Analytics:    analyticsService,
 	}
 
 	logger.Info("Service manager initialized successfully")
@@ -75,7 +89,10 @@ func (m *Manager) GetMetrics() map[string]interface{} {
 		metrics["subscription"] = subscriptionService.GetMetrics()
 	}
 
-	// Можно добавить метрики других сервисов в будущем
+	// Получаем метрики обновления аналитики
+	if analyticsService, ok := m.services.Analytics.(*analytics.Service); ok {
+		metrics["analytics"] = analyticsService.GetMetrics()
+	}
 
 	return metrics
 }
@@ -89,5 +106,10 @@ func (m *Manager) Close() {
 		subscriptionService.Close()
 	}
 
+	// Останавливаем фоновое обновление аналитики
+	if analyticsService, ok := m.services.Analytics.(*analytics.Service); ok {
+		analyticsService.Close()
+	}
+
 	m.logger.Info("Service manager shutdown completed")
 }
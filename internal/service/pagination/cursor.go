@@ -0,0 +1,147 @@
+// Package pagination реализует курсоры и разбор параметров keyset-пагинации
+// для списков постов и комментариев.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"time"
+
+	"github.com/NarthurN/habbr/internal/model"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+	"github.com/google/uuid"
+)
+
+// cursorVersion - версия формата курсора; курсоры других версий отклоняются.
+// Версия 2 добавила ключ сортировки.
+const cursorVersion byte = 2
+
+// cursorSize - размер курсора в байтах: версия, ключ сортировки, значение ключа, ID и контрольная сумма
+const cursorSize = 1 + 1 + 8 + 16 + 4
+
+// sortKeyCodes - коды ключей сортировки в курсоре
+var sortKeyCodes = map[repomodel.SortKey]byte{
+	repomodel.SortByCreatedAt:    1,
+	repomodel.SortByUpdatedAt:    2,
+	repomodel.SortByCommentCount: 3,
+	repomodel.SortByReplyCount:   4,
+}
+
+// EncodeCursor кодирует позицию записи в непрозрачный курсор.
+//
+// Курсор содержит версию формата, ключ сортировки, значение ключа (время с точностью
+// до наносекунд или счетчик), ID записи и контрольную сумму CRC32,
+// закодированные в base64 без дополнения.
+func EncodeCursor(cursor repomodel.Cursor) string {
+	value := uint64(cursor.Time.UnixNano())
+	if cursor.Key.IsCount() {
+		value = uint64(cursor.Count)
+	}
+
+	data := make([]byte, 0, cursorSize)
+	data = append(data, cursorVersion, sortKeyCodes[cursor.Key.OrDefault()])
+	data = binary.BigEndian.AppendUint64(data, value)
+	data = append(data, cursor.ID[:]...)
+	data = binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(data))
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor декодирует курсор, созданный EncodeCursor для ключа сортировки key.
+//
+// Поврежденный или измененный курсор, а также курсор другого порядка сортировки
+// возвращают ошибку.
+func DecodeCursor(cursor string, key repomodel.SortKey) (*repomodel.Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(data) != cursorSize {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	payload, checksum := data[:cursorSize-4], binary.BigEndian.Uint32(data[cursorSize-4:])
+	if crc32.ChecksumIEEE(payload) != checksum {
+		return nil, fmt.Errorf("cursor checksum mismatch")
+	}
+	if payload[0] != cursorVersion {
+		return nil, fmt.Errorf("unsupported cursor version %d", payload[0])
+	}
+
+	key = key.OrDefault()
+	if payload[1] != sortKeyCodes[key] {
+		return nil, fmt.Errorf("cursor was issued for a different order")
+	}
+
+	id, err := uuid.FromBytes(payload[10:])
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor ID")
+	}
+
+	result := &repomodel.Cursor{Key: key, ID: id}
+	value := binary.BigEndian.Uint64(payload[2:10])
+	if key.IsCount() {
+		result.Count = int64(value)
+	} else {
+		result.Time = time.Unix(0, int64(value)).UTC()
+	}
+
+	return result, nil
+}
+
+// Page проверяет параметры пагинации и преобразует их в страницу репозитория
+// для порядка сортировки по ключу key.
+//
+// Если не заданы ни first, ни last, выбираются первые defaultSize записей.
+// Размер страницы не может превышать maxSize.
+func Page(pagination model.PaginationInput, key repomodel.SortKey, defaultSize, maxSize int) (repomodel.Page, error) {
+	if pagination.First != nil && pagination.Last != nil {
+		return repomodel.Page{}, model.NewValidationError("pagination", "cannot specify both first and last")
+	}
+
+	if err := validateSize("first", pagination.First, maxSize); err != nil {
+		return repomodel.Page{}, err
+	}
+	if err := validateSize("last", pagination.Last, maxSize); err != nil {
+		return repomodel.Page{}, err
+	}
+
+	page := repomodel.Page{
+		First: pagination.First,
+		Last:  pagination.Last,
+	}
+	if page.First == nil && page.Last == nil {
+		page.First = &defaultSize
+	}
+
+	if pagination.After != nil {
+		after, err := DecodeCursor(*pagination.After, key)
+		if err != nil {
+			return repomodel.Page{}, model.NewValidationError("after", err.Error())
+		}
+		page.After = after
+	}
+
+	if pagination.Before != nil {
+		before, err := DecodeCursor(*pagination.Before, key)
+		if err != nil {
+			return repomodel.Page{}, model.NewValidationError("before", err.Error())
+		}
+		page.Before = before
+	}
+
+	return page, nil
+}
+
+// validateSize проверяет размер страницы first или last
+func validateSize(field string, size *int, maxSize int) error {
+	if size == nil {
+		return nil
+	}
+	if *size < 0 {
+		return model.NewValidationError(field, field+" must be non-negative")
+	}
+	if *size > maxSize {
+		return model.NewValidationError(field, fmt.Sprintf("%s cannot exceed %d", field, maxSize))
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NarthurN/habbr/internal/model"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2025, 3, 14, 15, 9, 26, 535897932, time.UTC)
+	id := uuid.New()
+
+	cursor, err := DecodeCursor(EncodeCursor(repomodel.Cursor{Key: repomodel.SortByCreatedAt, Time: createdAt, ID: id}), repomodel.SortByCreatedAt)
+	require.NoError(t, err)
+	assert.True(t, createdAt.Equal(cursor.Time), "sub-second precision must be preserved")
+	assert.Equal(t, id, cursor.ID)
+
+	counted := repomodel.Cursor{Key: repomodel.SortByCommentCount, Count: 42, ID: id}
+	cursor, err = DecodeCursor(EncodeCursor(counted), repomodel.SortByCommentCount)
+	require.NoError(t, err)
+	assert.Equal(t, counted, *cursor)
+}
+
+func TestDecodeCursorRejectsInvalid(t *testing.T) {
+	valid := EncodeCursor(repomodel.Cursor{Key: repomodel.SortByCreatedAt, Time: time.Now(), ID: uuid.New()})
+	data, err := base64.RawURLEncoding.DecodeString(valid)
+	require.NoError(t, err)
+
+	tampered := append([]byte(nil), data...)
+	tampered[5] ^= 0x01
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"empty", ""},
+		{"not base64", "!!!"},
+		{"legacy format", base64.StdEncoding.EncodeToString([]byte("1700000000_" + uuid.NewString()))},
+		{"no separator", base64.StdEncoding.EncodeToString([]byte("garbage"))},
+		{"truncated", base64.RawURLEncoding.EncodeToString(data[:len(data)-1])},
+		{"tampered", base64.RawURLEncoding.EncodeToString(tampered)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeCursor(tt.cursor, repomodel.SortByCreatedAt)
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("different order", func(t *testing.T) {
+		_, err := DecodeCursor(valid, repomodel.SortByUpdatedAt)
+		assert.Error(t, err)
+	})
+}
+
+func TestPage(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+	stringPtr := func(v string) *string { return &v }
+
+	t.Run("default size", func(t *testing.T) {
+		page, err := Page(model.PaginationInput{}, repomodel.SortByCreatedAt, 20, 100)
+		require.NoError(t, err)
+		require.NotNil(t, page.First)
+		assert.Equal(t, 20, *page.First)
+		assert.Nil(t, page.Last)
+	})
+
+	t.Run("cursors decoded", func(t *testing.T) {
+		id := uuid.New()
+		page, err := Page(model.PaginationInput{
+			Last:   intPtr(5),
+			Before: stringPtr(EncodeCursor(repomodel.Cursor{Key: repomodel.SortByUpdatedAt, Time: time.Now(), ID: id})),
+		}, repomodel.SortByUpdatedAt, 20, 100)
+		require.NoError(t, err)
+		assert.Nil(t, page.First)
+		assert.Equal(t, 5, *page.Last)
+		require.NotNil(t, page.Before)
+		assert.Equal(t, id, page.Before.ID)
+	})
+
+	invalid := []struct {
+		name       string
+		pagination model.PaginationInput
+		field      string
+	}{
+		{"first and last", model.PaginationInput{First: intPtr(1), Last: intPtr(1)}, "pagination"},
+		{"negative first", model.PaginationInput{First: intPtr(-1)}, "first"},
+		{"last too large", model.PaginationInput{Last: intPtr(101)}, "last"},
+		{"bad after", model.PaginationInput{After: stringPtr("bad")}, "after"},
+		{"bad before", model.PaginationInput{Before: stringPtr("bad")}, "before"},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Page(tt.pagination, repomodel.SortByCreatedAt, 20, 100)
+			require.Error(t, err)
+
+			var domainErr *model.DomainError
+			require.ErrorAs(t, err, &domainErr)
+			assert.Equal(t, "VALIDATION_ERROR", domainErr.Type)
+			assert.Equal(t, tt.field, domainErr.Details["field"])
+		})
+	}
+}
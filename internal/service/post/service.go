@@ -2,36 +2,53 @@ package post
 
 import (
 	"context"
-	"encoding/base64"
+	"errors"
 	"fmt"
-	"strconv"
-	"strings"
-	"time"
 
 	"github.com/NarthurN/habbr/internal/model"
 	"github.com/NarthurN/habbr/internal/repository"
 	"github.com/NarthurN/habbr/internal/repository/converter"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+	"github.com/NarthurN/habbr/internal/service/pagination"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// Размеры страницы списка постов
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
 // Service реализует бизнес-логику для работы с постами
 type Service struct {
-	postRepo    repository.PostRepository
-	commentRepo repository.CommentRepository
-	logger      *zap.Logger
+	postRepo        repository.PostRepository
+	commentRepo     repository.CommentRepository
+	transactor      repository.Transactor
+	logger          *zap.Logger
+	subscriptionSvc SubscriptionNotifier
+}
+
+// SubscriptionNotifier определяет интерфейс для отправки уведомлений о событиях жизненного цикла постов
+type SubscriptionNotifier interface {
+	PublishPost(postID uuid.UUID, payload *model.PostSubscriptionPayload)
 }
 
-// NewService создает новый сервис постов
-func NewService(repos *repository.Repositories, logger *zap.Logger) *Service {
+// NewService создает новый сервис постов.
+//
+// transactor выполняет многошаговые изменения атомарно; nil означает выполнение
+// без транзакции.
+func NewService(repos *repository.Repositories, transactor repository.Transactor, logger *zap.Logger, subscriptionSvc SubscriptionNotifier) *Service {
 	if logger == nil {
 		logger = zap.NewNop()
 	}
 
 	return &Service{
-		postRepo:    repos.Post,
-		commentRepo: repos.Comment,
-		logger:      logger,
+		postRepo:        repos.Post,
+		commentRepo:     repos.Comment,
+		transactor:      transactor,
+		logger:          logger,
+		subscriptionSvc: subscriptionSvc,
 	}
 }
 
@@ -78,6 +95,9 @@ func (s *Service) CreatePost(ctx context.Context, input model.PostInput) (*model
 		zap.String("author_id", post.AuthorID.String()),
 	)
 
+	// Отправляем уведомление о новом посте
+	s.publish(post, model.PostEventCreated)
+
 	return post, nil
 }
 
@@ -114,23 +134,24 @@ func (s *Service) GetPost(ctx context.Context, id uuid.UUID) (*model.Post, error
 }
 
 // ListPosts возвращает список постов с пагинацией
-func (s *Service) ListPosts(ctx context.Context, filter model.PostFilter, pagination model.PaginationInput) (*model.PostConnection, error) {
+func (s *Service) ListPosts(ctx context.Context, filter model.PostFilter, paginationInput model.PaginationInput) (*model.PostConnection, error) {
 	s.logger.Debug("Listing posts",
 		zap.Any("filter", filter),
-		zap.Any("pagination", pagination),
+		zap.Any("pagination", paginationInput),
 	)
 
-	// Валидация пагинации
-	if err := s.validatePagination(pagination); err != nil {
+	// Валидация порядка и пагинации, декодирование курсоров
+	page, err := s.buildPage(filter, paginationInput)
+	if err != nil {
 		s.logger.Warn("Invalid pagination parameters", zap.Error(err))
 		return nil, err
 	}
 
 	// Конвертация фильтра
-	repoFilter := converter.PostFilterToRepo(filter, pagination)
+	repoFilter := converter.PostFilterToRepo(filter, page)
 
-	// Получение постов
-	repoPosts, err := s.postRepo.List(ctx, repoFilter)
+	// Получение страницы постов
+	repoPage, err := s.postRepo.List(ctx, repoFilter)
 	if err != nil {
 		s.logger.Error("Failed to list posts from repository",
 			zap.Error(err),
@@ -139,26 +160,16 @@ func (s *Service) ListPosts(ctx context.Context, filter model.PostFilter, pagina
 		return nil, model.NewInternalError(fmt.Sprintf("failed to list posts: %v", err))
 	}
 
-	// Получение общего количества для более точной пагинации
-	totalCount, err := s.postRepo.Count(ctx, repoFilter)
-	if err != nil {
-		s.logger.Error("Failed to count posts from repository",
-			zap.Error(err),
-			zap.Any("filter", repoFilter),
-		)
-		return nil, model.NewInternalError(fmt.Sprintf("failed to count posts: %v", err))
-	}
-
 	// Конвертация в доменные модели
-	posts := converter.PostsFromRepo(repoPosts)
+	posts := converter.PostsFromRepo(repoPage.Posts)
 
 	// Создание connection с пагинацией
-	connection := s.buildPostConnection(posts, pagination, totalCount)
+	connection := s.buildPostConnection(posts, repoPage)
 
 	s.logger.Debug("Posts listed successfully",
 		zap.Int("count", len(posts)),
-		zap.Int("total_count", totalCount),
 		zap.Bool("has_next_page", connection.PageInfo.HasNextPage),
+		zap.Bool("has_previous_page", connection.PageInfo.HasPreviousPage),
 	)
 
 	return connection, nil
@@ -166,6 +177,35 @@ func (s *Service) ListPosts(ctx context.Context, filter model.PostFilter, pagina
 
 // UpdatePost обновляет пост
 func (s *Service) UpdatePost(ctx context.Context, id uuid.UUID, input model.PostUpdateInput, authorID uuid.UUID) (*model.Post, error) {
+	updatedPost, err := s.updatePost(ctx, id, input, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Отправляем уведомление об обновлении поста
+	s.publish(updatedPost, model.PostEventUpdated)
+
+	return updatedPost, nil
+}
+
+// updatePost проверяет права и сохраняет изменения поста в одной транзакции
+// без отправки уведомлений
+func (s *Service) updatePost(ctx context.Context, id uuid.UUID, input model.PostUpdateInput, authorID uuid.UUID) (*model.Post, error) {
+	var post *model.Post
+	err := s.withinTx(ctx, func(tx *Service) error {
+		var err error
+		post, err = tx.applyPostUpdate(ctx, id, input, authorID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+// applyPostUpdate выполняет шаги updatePost через репозитории сервиса
+func (s *Service) applyPostUpdate(ctx context.Context, id uuid.UUID, input model.PostUpdateInput, authorID uuid.UUID) (*model.Post, error) {
 	s.logger.Debug("Updating post",
 		zap.String("post_id", id.String()),
 		zap.String("author_id", authorID.String()),
@@ -260,10 +300,38 @@ func (s *Service) DeletePost(ctx context.Context, id uuid.UUID, authorID uuid.UU
 		return model.NewValidationError("author_id", "author ID is required")
 	}
 
+	// Проверка прав и удаление поста с комментариями выполняются в одной транзакции
+	var post *model.Post
+	var commentCount int
+	err := s.withinTx(ctx, func(tx *Service) error {
+		var err error
+		post, commentCount, err = tx.deletePost(ctx, id, authorID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Post deleted successfully",
+		zap.String("post_id", id.String()),
+		zap.String("title", post.Title),
+		zap.String("author_id", authorID.String()),
+		zap.Int("deleted_comments", commentCount),
+	)
+
+	// Отправляем терминальное уведомление об удалении поста
+	s.publish(post, model.PostEventDeleted)
+
+	return nil
+}
+
+// deletePost проверяет права и удаляет пост с комментариями через репозитории сервиса.
+// Возвращает удаленный пост и количество его комментариев
+func (s *Service) deletePost(ctx context.Context, id uuid.UUID, authorID uuid.UUID) (*model.Post, int, error) {
 	// Получение поста для проверки прав
 	post, err := s.GetPost(ctx, id)
 	if err != nil {
-		return err
+		return nil, 0, err
 	}
 
 	// Проверка прав на удаление
@@ -273,7 +341,7 @@ func (s *Service) DeletePost(ctx context.Context, id uuid.UUID, authorID uuid.UU
 			zap.String("post_author", post.AuthorID.String()),
 			zap.String("requesting_user", authorID.String()),
 		)
-		return model.NewForbiddenError("delete post")
+		return nil, 0, model.NewForbiddenError("delete post")
 	}
 
 	// Подсчет комментариев для логирования
@@ -293,30 +361,23 @@ func (s *Service) DeletePost(ctx context.Context, id uuid.UUID, authorID uuid.UU
 			zap.Error(err),
 			zap.String("post_id", id.String()),
 		)
-		return model.NewInternalError(fmt.Sprintf("failed to delete post comments: %v", err))
+		return nil, 0, model.NewInternalError(fmt.Sprintf("failed to delete post comments: %v", err))
 	}
 
 	// Удаление поста
 	if err := s.postRepo.Delete(ctx, id); err != nil {
 		if err == repository.ErrNotFound {
-			return model.NewNotFoundError("post", id)
+			return nil, 0, model.NewNotFoundError("post", id)
 		}
 
 		s.logger.Error("Failed to delete post from repository",
 			zap.Error(err),
 			zap.String("post_id", id.String()),
 		)
-		return model.NewInternalError(fmt.Sprintf("failed to delete post: %v", err))
+		return nil, 0, model.NewInternalError(fmt.Sprintf("failed to delete post: %v", err))
 	}
 
-	s.logger.Info("Post deleted successfully",
-		zap.String("post_id", id.String()),
-		zap.String("title", post.Title),
-		zap.String("author_id", authorID.String()),
-		zap.Int("deleted_comments", commentCount),
-	)
-
-	return nil
+	return post, commentCount, nil
 }
 
 // ToggleComments переключает возможность комментирования поста
@@ -331,7 +392,7 @@ func (s *Service) ToggleComments(ctx context.Context, postID uuid.UUID, authorID
 		CommentsEnabled: &enabled,
 	}
 
-	updatedPost, err := s.UpdatePost(ctx, postID, input, authorID)
+	updatedPost, err := s.updatePost(ctx, postID, input, authorID)
 	if err != nil {
 		return nil, err
 	}
@@ -341,14 +402,60 @@ func (s *Service) ToggleComments(ctx context.Context, postID uuid.UUID, authorID
 		zap.Bool("comments_enabled", enabled),
 	)
 
+	// Отправляем уведомление о переключении комментариев
+	s.publish(updatedPost, model.PostEventCommentsToggled)
+
 	return updatedPost, nil
 }
 
+// withinTx выполняет fn в транзакции с копией сервиса, работающей через репозитории
+// транзакции. Уведомления отправляются после возврата из withinTx, чтобы подписчики
+// не узнавали об откаченных изменениях
+func (s *Service) withinTx(ctx context.Context, fn func(tx *Service) error) error {
+	if s.transactor == nil {
+		return fn(s)
+	}
+
+	err := s.transactor.WithinTx(ctx, func(repos *repository.Repositories) error {
+		tx := *s
+		tx.postRepo = repos.Post
+		tx.commentRepo = repos.Comment
+		return fn(&tx)
+	})
+
+	// Ошибки начала и фиксации транзакции не являются доменными
+	var domainErr *model.DomainError
+	if err != nil && !errors.As(err, &domainErr) {
+		s.logger.Error("Post transaction failed", zap.Error(err))
+		return model.NewInternalError(fmt.Sprintf("post transaction failed: %v", err))
+	}
+	return err
+}
+
+// publish отправляет событие жизненного цикла поста, если настроен сервис подписок
+func (s *Service) publish(post *model.Post, actionType string) {
+	if s.subscriptionSvc == nil {
+		return
+	}
+
+	s.subscriptionSvc.PublishPost(post.ID, &model.PostSubscriptionPayload{
+		PostID:     post.ID,
+		Post:       post,
+		ActionType: actionType,
+	})
+}
+
 // GetPostWithCommentCounts возвращает посты с количеством комментариев
-func (s *Service) GetPostWithCommentCounts(ctx context.Context, filter model.PostFilter, pagination model.PaginationInput) ([]*model.Post, error) {
+func (s *Service) GetPostWithCommentCounts(ctx context.Context, filter model.PostFilter, paginationInput model.PaginationInput) ([]*model.Post, error) {
 	s.logger.Debug("Getting posts with comment counts", zap.Any("filter", filter))
 
-	repoFilter := converter.PostFilterToRepo(filter, pagination)
+	page, err := s.buildPage(filter, paginationInput)
+	if err != nil {
+		s.logger.Warn("Invalid pagination parameters", zap.Error(err))
+		return nil, err
+	}
+
+	repoFilter := converter.PostFilterToRepo(filter, page)
 
 	repoPostsWithCounts, err := s.postRepo.ListWithCommentCounts(ctx, repoFilter)
 	if err != nil {
@@ -372,70 +479,36 @@ func (s *Service) GetPostWithCommentCounts(ctx context.Context, filter model.Pos
 	return posts, nil
 }
 
-// validatePagination проверяет корректность параметров пагинации
-func (s *Service) validatePagination(pagination model.PaginationInput) error {
-	const maxPageSize = 100
-
-	if pagination.First != nil {
-		if *pagination.First < 0 {
-			return model.NewValidationError("first", "first must be non-negative")
-		}
-		if *pagination.First > maxPageSize {
-			return model.NewValidationError("first", fmt.Sprintf("first cannot exceed %d", maxPageSize))
-		}
-	}
-
-	if pagination.Last != nil {
-		if *pagination.Last < 0 {
-			return model.NewValidationError("last", "last must be non-negative")
-		}
-		if *pagination.Last > maxPageSize {
-			return model.NewValidationError("last", fmt.Sprintf("last cannot exceed %d", maxPageSize))
-		}
-	}
-
-	if pagination.First != nil && pagination.Last != nil {
-		return model.NewValidationError("pagination", "cannot specify both first and last")
+// buildPage проверяет порядок сортировки и параметры пагинации
+// и преобразует их в страницу репозитория
+func (s *Service) buildPage(filter model.PostFilter, paginationInput model.PaginationInput) (repomodel.Page, error) {
+	if !filter.Order.OrDefault().IsValid() {
+		return repomodel.Page{}, model.NewValidationError("order", fmt.Sprintf("unsupported post order %q", filter.Order))
 	}
 
-	return nil
+	key, _ := converter.PostOrderToRepo(filter.Order)
+	return pagination.Page(paginationInput, key, defaultPageSize, maxPageSize)
 }
 
-// buildPostConnection строит PostConnection с правильной пагинацией
-func (s *Service) buildPostConnection(posts []*model.Post, pagination model.PaginationInput, totalCount int) *model.PostConnection {
+// buildPostConnection строит PostConnection по странице, полученной из репозитория
+func (s *Service) buildPostConnection(posts []*model.Post, page *repomodel.PostPage) *model.PostConnection {
 	edges := make([]*model.PostEdge, len(posts))
 
 	for i, post := range posts {
-		// Создание курсора (base64 кодированный timestamp + ID для стабильной сортировки)
-		cursorData := fmt.Sprintf("%d_%s", post.CreatedAt.Unix(), post.ID.String())
-		cursor := base64.StdEncoding.EncodeToString([]byte(cursorData))
-
 		edges[i] = &model.PostEdge{
 			Node:   post,
-			Cursor: cursor,
+			Cursor: pagination.EncodeCursor(page.Cursors[i]),
 		}
 	}
 
-	// Определение информации о пагинации
 	pageInfo := &model.PageInfo{
-		HasNextPage:     false,
-		HasPreviousPage: false,
+		HasNextPage:     page.HasNextPage,
+		HasPreviousPage: page.HasPreviousPage,
 	}
 
 	if len(edges) > 0 {
 		pageInfo.StartCursor = &edges[0].Cursor
 		pageInfo.EndCursor = &edges[len(edges)-1].Cursor
-
-		// Более точное определение наличия следующей/предыдущей страницы
-		if pagination.First != nil {
-			requestedSize := *pagination.First
-			pageInfo.HasNextPage = len(edges) == requestedSize && totalCount > requestedSize
-		}
-
-		if pagination.Last != nil {
-			requestedSize := *pagination.Last
-			pageInfo.HasPreviousPage = len(edges) == requestedSize && totalCount > requestedSize
-		}
 	}
 
 	return &model.PostConnection{
@@ -443,28 +516,3 @@ func (s *Service) buildPostConnection(posts []*model.Post, pagination model.Pagi
 		PageInfo: pageInfo,
 	}
 }
-
-// decodeCursor декодирует курсор и возвращает timestamp и ID
-func (s *Service) decodeCursor(cursor string) (time.Time, uuid.UUID, error) {
-	decoded, err := base64.StdEncoding.DecodeString(cursor)
-	if err != nil {
-		return time.Time{}, uuid.Nil, fmt.Errorf("invalid cursor: %w", err)
-	}
-
-	parts := string(decoded)
-	// Ожидаем формат "timestamp_uuid"
-	timestampStr := parts[:strings.Index(parts, "_")]
-	uuidStr := parts[strings.Index(parts, "_")+1:]
-
-	timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
-	if err != nil {
-		return time.Time{}, uuid.Nil, fmt.Errorf("invalid cursor timestamp: %w", err)
-	}
-
-	id, err := uuid.Parse(uuidStr)
-	if err != nil {
-		return time.Time{}, uuid.Nil, fmt.Errorf("invalid cursor UUID: %w", err)
-	}
-
-	return time.Unix(timestamp, 0), id, nil
-}
@@ -0,0 +1,256 @@
+package search
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/NarthurN/habbr/internal/repository"
+	"github.com/NarthurN/habbr/internal/repository/converter"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultPageSize - размер страницы результатов, если first не указан
+	defaultPageSize = 20
+
+	// maxPageSize - максимальный размер страницы результатов
+	maxPageSize = 100
+)
+
+// Service реализует полнотекстовый поиск постов и комментариев.
+//
+// Запрос разбирается с учетом языка каждого документа: стемминг и стоп-слова
+// запроса совпадают с использованными при индексации документа.
+// Ранжирование и подсветку выполняет репозиторий: PostgreSQL - средствами
+// ts_rank/ts_headline, in-memory хранилище - по инвертированному индексу.
+// Курсор результата кодирует релевантность и ID, поэтому страницы не пересекаются,
+// даже если между запросами появились новые результаты.
+type Service struct {
+	postRepo    repository.PostRepository
+	commentRepo repository.CommentRepository
+	logger      *zap.Logger
+}
+
+// NewService создает новый сервис поиска
+func NewService(repos *repository.Repositories, logger *zap.Logger) *Service {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	return &Service{
+		postRepo:    repos.Post,
+		commentRepo: repos.Comment,
+		logger:      logger,
+	}
+}
+
+// SearchPosts ищет посты по заголовку и содержимому
+func (s *Service) SearchPosts(ctx context.Context, query string, language model.Language, pagination model.PaginationInput) (*model.PostSearchConnection, error) {
+	s.logger.Debug("Searching posts",
+		zap.String("query", query),
+		zap.String("language", string(language)),
+		zap.Any("pagination", pagination),
+	)
+
+	filter, err := s.buildFilter(query, language, pagination)
+	if err != nil {
+		s.logger.Warn("Invalid search parameters", zap.Error(err))
+		return nil, err
+	}
+
+	hits, err := s.postRepo.Search(ctx, filter)
+	if err != nil {
+		s.logger.Error("Failed to search posts in repository",
+			zap.Error(err),
+			zap.String("query", query),
+		)
+		return nil, model.NewInternalError(fmt.Sprintf("failed to search posts: %v", err))
+	}
+
+	hasNextPage := len(hits) >= filter.Limit
+	if hasNextPage {
+		hits = hits[:filter.Limit-1]
+	}
+
+	edges := make([]*model.PostSearchEdge, len(hits))
+	for i, hit := range hits {
+		edges[i] = &model.PostSearchEdge{
+			Node:    converter.PostFromRepo(&hit.Post),
+			Cursor:  encodeCursor(hit.Rank, hit.ID),
+			Rank:    float64(hit.Rank),
+			Snippet: hit.Snippet,
+		}
+	}
+
+	connection := &model.PostSearchConnection{
+		Edges:    edges,
+		PageInfo: &model.PageInfo{HasNextPage: hasNextPage, HasPreviousPage: pagination.After != nil},
+	}
+	if len(edges) > 0 {
+		connection.PageInfo.StartCursor = &edges[0].Cursor
+		connection.PageInfo.EndCursor = &edges[len(edges)-1].Cursor
+	}
+
+	s.logger.Debug("Posts searched successfully",
+		zap.String("query", query),
+		zap.Int("count", len(edges)),
+		zap.Bool("has_next_page", hasNextPage),
+	)
+
+	return connection, nil
+}
+
+// SearchComments ищет комментарии поста по содержимому
+func (s *Service) SearchComments(ctx context.Context, postID uuid.UUID, query string, language model.Language, pagination model.PaginationInput) (*model.CommentSearchConnection, error) {
+	s.logger.Debug("Searching comments",
+		zap.String("post_id", postID.String()),
+		zap.String("query", query),
+		zap.String("language", string(language)),
+		zap.Any("pagination", pagination),
+	)
+
+	if postID == uuid.Nil {
+		return nil, model.NewValidationError("post_id", "post ID cannot be empty")
+	}
+
+	filter, err := s.buildFilter(query, language, pagination)
+	if err != nil {
+		s.logger.Warn("Invalid search parameters", zap.Error(err))
+		return nil, err
+	}
+	filter.PostID = &postID
+
+	exists, err := s.postRepo.Exists(ctx, postID)
+	if err != nil {
+		s.logger.Error("Failed to check post existence", zap.Error(err), zap.String("post_id", postID.String()))
+		return nil, model.NewInternalError(fmt.Sprintf("failed to check post existence: %v", err))
+	}
+	if !exists {
+		return nil, model.NewNotFoundError("post", postID)
+	}
+
+	hits, err := s.commentRepo.Search(ctx, filter)
+	if err != nil {
+		s.logger.Error("Failed to search comments in repository",
+			zap.Error(err),
+			zap.String("post_id", postID.String()),
+			zap.String("query", query),
+		)
+		return nil, model.NewInternalError(fmt.Sprintf("failed to search comments: %v", err))
+	}
+
+	hasNextPage := len(hits) >= filter.Limit
+	if hasNextPage {
+		hits = hits[:filter.Limit-1]
+	}
+
+	edges := make([]*model.CommentSearchEdge, len(hits))
+	for i, hit := range hits {
+		edges[i] = &model.CommentSearchEdge{
+			Node:    converter.CommentFromRepo(&hit.Comment),
+			Cursor:  encodeCursor(hit.Rank, hit.ID),
+			Rank:    float64(hit.Rank),
+			Snippet: hit.Snippet,
+		}
+	}
+
+	connection := &model.CommentSearchConnection{
+		Edges:    edges,
+		PageInfo: &model.PageInfo{HasNextPage: hasNextPage, HasPreviousPage: pagination.After != nil},
+	}
+	if len(edges) > 0 {
+		connection.PageInfo.StartCursor = &edges[0].Cursor
+		connection.PageInfo.EndCursor = &edges[len(edges)-1].Cursor
+	}
+
+	s.logger.Debug("Comments searched successfully",
+		zap.String("post_id", postID.String()),
+		zap.String("query", query),
+		zap.Int("count", len(edges)),
+	)
+
+	return connection, nil
+}
+
+// buildFilter проверяет запрос, язык и пагинацию и строит фильтр репозитория.
+//
+// Limit фильтра на единицу больше размера страницы: лишний результат означает,
+// что есть следующая страница.
+func (s *Service) buildFilter(query string, language model.Language, pagination model.PaginationInput) (repomodel.SearchFilter, error) {
+	if err := model.SearchQuery(query).Validate(); err != nil {
+		return repomodel.SearchFilter{}, model.NewValidationError("query", err.Error())
+	}
+
+	if language != "" && !language.IsValid() {
+		return repomodel.SearchFilter{}, model.NewValidationError("language", fmt.Sprintf("unsupported language: %s", language))
+	}
+
+	if pagination.Last != nil || pagination.Before != nil {
+		return repomodel.SearchFilter{}, model.NewValidationError("pagination", "search results support only forward pagination (first/after)")
+	}
+
+	pageSize := defaultPageSize
+	if pagination.First != nil {
+		if *pagination.First <= 0 {
+			return repomodel.SearchFilter{}, model.NewValidationError("first", "first must be positive")
+		}
+		if *pagination.First > maxPageSize {
+			return repomodel.SearchFilter{}, model.NewValidationError("first", fmt.Sprintf("first cannot exceed %d", maxPageSize))
+		}
+		pageSize = *pagination.First
+	}
+
+	filter := repomodel.SearchFilter{
+		Query:    strings.TrimSpace(query),
+		Language: string(language),
+		Limit:    pageSize + 1,
+	}
+
+	if pagination.After != nil {
+		rank, id, err := decodeCursor(*pagination.After)
+		if err != nil {
+			return repomodel.SearchFilter{}, model.NewValidationError("after", err.Error())
+		}
+		filter.AfterRank = &rank
+		filter.AfterID = &id
+	}
+
+	return filter, nil
+}
+
+// encodeCursor кодирует позицию результата поиска: релевантность и ID
+func encodeCursor(rank float32, id uuid.UUID) string {
+	data := strconv.FormatFloat(float64(rank), 'g', -1, 32) + "_" + id.String()
+	return base64.StdEncoding.EncodeToString([]byte(data))
+}
+
+// decodeCursor декодирует курсор, созданный encodeCursor
+func decodeCursor(cursor string) (float32, uuid.UUID, error) {
+	decoded, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, uuid.Nil, fmt.Errorf("invalid cursor")
+	}
+
+	rankStr, idStr, ok := strings.Cut(string(decoded), "_")
+	if !ok {
+		return 0, uuid.Nil, fmt.Errorf("invalid cursor")
+	}
+
+	rank, err := strconv.ParseFloat(rankStr, 32)
+	if err != nil {
+		return 0, uuid.Nil, fmt.Errorf("invalid cursor rank")
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return 0, uuid.Nil, fmt.Errorf("invalid cursor ID")
+	}
+
+	return float32(rank), id, nil
+}
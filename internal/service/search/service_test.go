@@ -0,0 +1,207 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/NarthurN/habbr/internal/repository/converter"
+	"github.com/NarthurN/habbr/internal/repository/memory"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
+)
+
+func TestSearchPosts(t *testing.T) {
+	ctx := context.Background()
+	repos := memory.NewManager().GetRepositories()
+	service := NewService(repos, zap.NewNop())
+
+	createPost := func(title, content string) uuid.UUID {
+		post := &repomodel.Post{
+			ID:        uuid.New(),
+			Title:     title,
+			Content:   content,
+			AuthorID:  uuid.New(),
+			Language:  string(model.DetectLanguage(title, content)),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		require.NoError(t, repos.Post.Create(ctx, post))
+		return post.ID
+	}
+
+	inTitle := createPost("GraphQL subscriptions", "How we deliver events over websockets.")
+	inContent := createPost("Weekly notes", "Some thoughts about graphql schemas and resolvers.")
+	withRest := createPost("REST versus GraphQL", "Comparing graphql with rest APIs.")
+	createPost("Cooking", "Nothing relevant here.")
+
+	t.Run("ranked by relevance with snippets", func(t *testing.T) {
+		result, err := service.SearchPosts(ctx, "graphql", "", model.PaginationInput{})
+		require.NoError(t, err)
+		require.Len(t, result.Edges, 3)
+
+		for i := 1; i < len(result.Edges); i++ {
+			assert.GreaterOrEqual(t, result.Edges[i-1].Rank, result.Edges[i].Rank)
+		}
+		assert.NotEqual(t, inContent, result.Edges[0].Node.ID, "title match must outrank content-only match")
+
+		for _, edge := range result.Edges {
+			if edge.Node.ID == inContent {
+				assert.Contains(t, edge.Snippet, "<mark>graphql</mark>")
+			}
+		}
+	})
+
+	t.Run("exclusion and stemming", func(t *testing.T) {
+		result, err := service.SearchPosts(ctx, "graphql -rest", "", model.PaginationInput{})
+		require.NoError(t, err)
+		for _, edge := range result.Edges {
+			assert.NotEqual(t, withRest, edge.Node.ID)
+		}
+
+		result, err = service.SearchPosts(ctx, "subscription", "", model.PaginationInput{})
+		require.NoError(t, err)
+		require.Len(t, result.Edges, 1)
+		assert.Equal(t, inTitle, result.Edges[0].Node.ID)
+	})
+
+	t.Run("cursor pagination", func(t *testing.T) {
+		first := 2
+		page, err := service.SearchPosts(ctx, "graphql", "", model.PaginationInput{First: &first})
+		require.NoError(t, err)
+		require.Len(t, page.Edges, 2)
+		assert.True(t, page.PageInfo.HasNextPage)
+
+		next, err := service.SearchPosts(ctx, "graphql", "", model.PaginationInput{First: &first, After: page.PageInfo.EndCursor})
+		require.NoError(t, err)
+		require.Len(t, next.Edges, 1)
+		assert.False(t, next.PageInfo.HasNextPage)
+
+		seen := map[uuid.UUID]bool{page.Edges[0].Node.ID: true, page.Edges[1].Node.ID: true}
+		assert.False(t, seen[next.Edges[0].Node.ID])
+	})
+
+	t.Run("index follows updates and deletes", func(t *testing.T) {
+		post, err := repos.Post.GetByID(ctx, inTitle)
+		require.NoError(t, err)
+		post.Title = "Realtime events"
+		require.NoError(t, repos.Post.Update(ctx, post))
+		require.NoError(t, repos.Post.Delete(ctx, withRest))
+
+		result, err := service.SearchPosts(ctx, "graphql", "", model.PaginationInput{})
+		require.NoError(t, err)
+		require.Len(t, result.Edges, 1)
+		assert.Equal(t, inContent, result.Edges[0].Node.ID)
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		last := 5
+		invalidCursor := "not-a-cursor"
+		for _, tc := range []struct {
+			name       string
+			query      string
+			language   model.Language
+			pagination model.PaginationInput
+		}{
+			{name: "empty query", query: "  "},
+			{name: "backward pagination", query: "graphql", pagination: model.PaginationInput{Last: &last}},
+			{name: "invalid cursor", query: "graphql", pagination: model.PaginationInput{After: &invalidCursor}},
+			{name: "unsupported language", query: "graphql", language: "klingon"},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := service.SearchPosts(ctx, tc.query, tc.language, tc.pagination)
+				var domainErr *model.DomainError
+				require.ErrorAs(t, err, &domainErr)
+				assert.Equal(t, "VALIDATION_ERROR", domainErr.Type)
+			})
+		}
+	})
+}
+
+func TestSearchComments(t *testing.T) {
+	ctx := context.Background()
+	repos := memory.NewManager().GetRepositories()
+	service := NewService(repos, zap.NewNop())
+
+	postID, otherPostID := uuid.New(), uuid.New()
+	for _, id := range []uuid.UUID{postID, otherPostID} {
+		require.NoError(t, repos.Post.Create(ctx, &repomodel.Post{ID: id, Title: "Post", Content: "Content"}))
+	}
+
+	createComment := func(postID uuid.UUID, content string) uuid.UUID {
+		comment := &repomodel.Comment{
+			ID:       uuid.New(),
+			PostID:   postID,
+			Content:   content,
+			AuthorID:  uuid.New(),
+			Language:  string(model.DetectLanguage(content)),
+			CreatedAt: time.Now(),
+		}
+		require.NoError(t, repos.Comment.Create(ctx, comment))
+		return comment.ID
+	}
+
+	matching := createComment(postID, "Great explanation of database indexes")
+	createComment(postID, "Thanks for sharing")
+	createComment(otherPostID, "Indexes are great")
+
+	result, err := service.SearchComments(ctx, postID, "index", "", model.PaginationInput{})
+	require.NoError(t, err)
+	require.Len(t, result.Edges, 1)
+	assert.Equal(t, matching, result.Edges[0].Node.ID)
+	assert.Contains(t, result.Edges[0].Snippet, "<mark>indexes</mark>")
+
+	_, err = service.SearchComments(ctx, uuid.New(), "index", "", model.PaginationInput{})
+	var domainErr *model.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "NOT_FOUND", domainErr.Type)
+}
+
+func TestSearchMultilingual(t *testing.T) {
+	ctx := context.Background()
+	repos := memory.NewManager().GetRepositories()
+	service := NewService(repos, zap.NewNop())
+
+	createPost := func(title, content string, language model.Language) uuid.UUID {
+		post := model.NewPost(model.PostInput{Title: title, Content: content, AuthorID: uuid.New(), Language: language})
+		require.NoError(t, repos.Post.Create(ctx, converter.PostToRepo(post)))
+		return post.ID
+	}
+
+	russian := createPost("Модерация комментариев", "Как мы проверяем новые комментарии к статьям GraphQL API.", "")
+	english := createPost("Comment moderation", "How we review new comments on articles.", "")
+	simple := createPost("Заметки", "Черновик: комментарии", model.LanguageSimple)
+
+	t.Run("russian word forms", func(t *testing.T) {
+		result, err := service.SearchPosts(ctx, "комментарий", "", model.PaginationInput{})
+		require.NoError(t, err)
+		require.Len(t, result.Edges, 1)
+		assert.Equal(t, russian, result.Edges[0].Node.ID)
+		assert.Equal(t, model.LanguageRussian, result.Edges[0].Node.Language)
+		assert.Contains(t, result.Edges[0].Snippet, "<mark>комментарии</mark>")
+	})
+
+	t.Run("latin words in russian posts", func(t *testing.T) {
+		result, err := service.SearchPosts(ctx, "graphql", model.LanguageRussian, model.PaginationInput{})
+		require.NoError(t, err)
+		require.Len(t, result.Edges, 1)
+		assert.Equal(t, russian, result.Edges[0].Node.ID)
+	})
+
+	t.Run("language filter", func(t *testing.T) {
+		result, err := service.SearchPosts(ctx, "comment", model.LanguageEnglish, model.PaginationInput{})
+		require.NoError(t, err)
+		require.Len(t, result.Edges, 1)
+		assert.Equal(t, english, result.Edges[0].Node.ID)
+
+		result, err = service.SearchPosts(ctx, "комментарии", model.LanguageSimple, model.PaginationInput{})
+		require.NoError(t, err)
+		require.Len(t, result.Edges, 1)
+		assert.Equal(t, simple, result.Edges[0].Node.ID, "simple configuration matches exact words only")
+	})
+}
@@ -0,0 +1,476 @@
+package subscription
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Subscriber представляет подписчика на события типа T
+type Subscriber[T any] struct {
+	ID        string
+	Topic     uuid.UUID // uuid.Nil для подписчиков на события всех топиков
+	Channel   chan T
+	Policy    model.SlowConsumerPolicy
+	Dropped   int64 // события, не доставленные подписчику из-за заполненного буфера
+	CreatedAt time.Time
+	LastSeen  time.Time
+
+	options *subscriptionOptions // параметры из контекста подписки, nil если не заданы
+}
+
+// HubMetrics содержит метрики одного потока событий
+type HubMetrics struct {
+	TotalSubscribers    int               `json:"total_subscribers"`
+	ActiveConnections   map[uuid.UUID]int `json:"active_connections"`   // topic -> count
+	FirehoseSubscribers int               `json:"firehose_subscribers"` // подписчики на события всех топиков
+	MessagesSent        int64             `json:"messages_sent"`
+	MessagesDropped     int64             `json:"messages_dropped"`
+	SubscriptionsTotal  int64             `json:"subscriptions_total"`
+
+	// SubscriberDrops - количество отброшенных событий для активных подписчиков,
+	// у которых были потери (subscriberID -> count)
+	SubscriberDrops map[string]int64 `json:"subscriber_drops"`
+
+	// SlowConsumerDisconnects - подписки, завершенные политикой disconnect
+	SlowConsumerDisconnects int64 `json:"slow_consumer_disconnects"`
+}
+
+// hub реализует pub/sub для событий одного типа.
+//
+// События публикуются в топик (идентификатор поста). Подписчики бывают двух видов:
+// подписанные на конкретный топик и подписанные на все топики (firehose).
+// У каждого подписчика собственный буфер. Если буфер подписчика заполнен, событие
+// обрабатывается по его политике медленного подписчика (model.SlowConsumerPolicy),
+// а потери учитываются в метриках потока и подписчика.
+type hub[T any] struct {
+	mu       sync.RWMutex
+	name     string
+	topics   map[uuid.UUID]map[string]*Subscriber[T] // topic -> subscriberID -> subscriber
+	firehose map[string]*Subscriber[T]               // subscriberID -> подписчик на все топики
+	delivery delivery
+	logger   *zap.Logger
+	metrics  HubMetrics
+}
+
+// newHub создает поток событий с указанными размером буфера и политикой доставки
+func newHub[T any](name string, delivery delivery, logger *zap.Logger) *hub[T] {
+	return &hub[T]{
+		name:     name,
+		topics:   make(map[uuid.UUID]map[string]*Subscriber[T]),
+		firehose: make(map[string]*Subscriber[T]),
+		delivery: delivery,
+		logger:   logger.With(zap.String("stream", name)),
+		metrics: HubMetrics{
+			ActiveConnections: make(map[uuid.UUID]int),
+		},
+	}
+}
+
+// subscribe регистрирует подписчика на топик (uuid.Nil - на все топики).
+//
+// События initial помещаются в буфер подписчика до регистрации и будут прочитаны
+// первыми. Политика медленного подписчика берется из контекста (WithSlowConsumerPolicy),
+// иначе используется политика потока. Подписка автоматически удаляется при отмене контекста.
+func (h *hub[T]) subscribe(ctx context.Context, topic uuid.UUID, initial ...T) <-chan T {
+	now := time.Now()
+	subscriber := &Subscriber[T]{
+		ID:        uuid.New().String(),
+		Topic:     topic,
+		Channel:   make(chan T, max(h.delivery.channelSize, len(initial))),
+		Policy:    h.delivery.policy,
+		CreatedAt: now,
+		LastSeen:  now,
+		options:   optionsFromContext(ctx),
+	}
+	if subscriber.options != nil && subscriber.options.policy.IsValid() {
+		subscriber.Policy = subscriber.options.policy
+	}
+	for _, payload := range initial {
+		subscriber.Channel <- payload
+	}
+
+	h.mu.Lock()
+	if topic == uuid.Nil {
+		h.firehose[subscriber.ID] = subscriber
+		h.metrics.FirehoseSubscribers = len(h.firehose)
+	} else {
+		if h.topics[topic] == nil {
+			h.topics[topic] = make(map[string]*Subscriber[T])
+		}
+		h.topics[topic][subscriber.ID] = subscriber
+		h.metrics.ActiveConnections[topic] = len(h.topics[topic])
+	}
+	h.metrics.TotalSubscribers++
+	h.metrics.SubscriptionsTotal++
+	totalSubscribers := h.metrics.TotalSubscribers
+	h.mu.Unlock()
+
+	h.logger.Info("Subscription created successfully",
+		zap.String("topic", topicName(topic)),
+		zap.String("subscriber_id", subscriber.ID),
+		zap.String("slow_consumer_policy", string(subscriber.Policy)),
+		zap.Int("total_subscribers", totalSubscribers),
+	)
+
+	// Автоматическая отписка при отмене контекста
+	go func() {
+		<-ctx.Done()
+		h.unsubscribe(topic, subscriber.ID)
+	}()
+
+	return subscriber.Channel
+}
+
+// unsubscribe удаляет подписчика и закрывает его канал
+func (h *hub[T]) unsubscribe(topic uuid.UUID, subscriberID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var subscriber *Subscriber[T]
+	if topic == uuid.Nil {
+		subscriber = h.firehose[subscriberID]
+	} else {
+		subscriber = h.topics[topic][subscriberID]
+	}
+	if subscriber == nil {
+		return // подписчик уже удален
+	}
+
+	h.removeLocked(subscriber)
+
+	h.logger.Info("Subscription removed successfully",
+		zap.String("topic", topicName(topic)),
+		zap.String("subscriber_id", subscriberID),
+		zap.Duration("subscription_duration", time.Since(subscriber.CreatedAt)),
+	)
+}
+
+// publish отправляет событие подписчикам топика и подписчикам на все топики.
+//
+// Возвращает количество доставленных и отброшенных сообщений.
+func (h *hub[T]) publish(topic uuid.UUID, payload T) (sent, dropped int) {
+	h.mu.RLock()
+	topicSubscribers := h.topics[topic]
+	if len(topicSubscribers) == 0 && len(h.firehose) == 0 {
+		h.mu.RUnlock()
+		return 0, 0 // нет подписчиков
+	}
+
+	// Создаем копию списка подписчиков для безопасной итерации
+	subscribers := make([]*Subscriber[T], 0, len(topicSubscribers)+len(h.firehose))
+	for _, subscriber := range topicSubscribers {
+		subscribers = append(subscribers, subscriber)
+	}
+	for _, subscriber := range h.firehose {
+		subscribers = append(subscribers, subscriber)
+	}
+	h.mu.RUnlock()
+
+	for _, subscriber := range subscribers {
+		delivered, lost := h.deliver(subscriber, payload)
+
+		h.mu.Lock()
+		if delivered {
+			sent++
+			// Обновляем время последней активности
+			subscriber.LastSeen = time.Now()
+		}
+		subscriber.Dropped += int64(lost)
+		h.mu.Unlock()
+
+		if lost > 0 {
+			dropped += lost
+			h.logger.Warn("Message dropped for subscriber",
+				zap.String("subscriber_id", subscriber.ID),
+				zap.String("topic", topic.String()),
+				zap.String("slow_consumer_policy", string(subscriber.Policy)),
+			)
+		}
+	}
+
+	h.mu.Lock()
+	h.metrics.MessagesSent += int64(sent)
+	h.metrics.MessagesDropped += int64(dropped)
+	h.mu.Unlock()
+
+	return sent, dropped
+}
+
+// deliver отправляет событие подписчику с учетом его политики медленного подписчика.
+//
+// Возвращает, доставлено ли событие, и количество потерянных событий: для drop-oldest
+// событие доставляется ценой вытесненного из буфера.
+func (h *hub[T]) deliver(subscriber *Subscriber[T], payload T) (delivered bool, lost int) {
+	if trySend(subscriber.Channel, payload) {
+		return true, 0
+	}
+
+	switch subscriber.Policy {
+	case model.SlowConsumerDropOldest:
+		if tryEvict(subscriber.Channel) {
+			lost++
+		}
+		if trySend(subscriber.Channel, payload) {
+			return true, lost
+		}
+		return false, lost + 1
+	case model.SlowConsumerBlock:
+		if sendWithTimeout(subscriber.Channel, payload, h.delivery.blockTimeout) {
+			return true, 0
+		}
+		return false, 1
+	case model.SlowConsumerDisconnect:
+		h.disconnect(subscriber)
+		return false, 1
+	default:
+		return false, 1
+	}
+}
+
+// disconnect завершает подписку медленного подписчика с ошибкой SLOW_CONSUMER.
+//
+// События, уже находящиеся в буфере, будут прочитаны до закрытия канала.
+func (h *hub[T]) disconnect(subscriber *Subscriber[T]) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.registeredLocked(subscriber) {
+		return // подписчик уже удален
+	}
+
+	subscriber.options.fail(model.NewSlowConsumerError())
+	h.removeLocked(subscriber)
+	h.metrics.SlowConsumerDisconnects++
+
+	h.logger.Warn("Slow subscriber disconnected",
+		zap.String("subscriber_id", subscriber.ID),
+		zap.String("topic", topicName(subscriber.Topic)),
+		zap.Int64("dropped", subscriber.Dropped),
+	)
+}
+
+// registeredLocked проверяет, что подписчик еще не удален. Вызывается под h.mu.
+func (h *hub[T]) registeredLocked(subscriber *Subscriber[T]) bool {
+	if subscriber.Topic == uuid.Nil {
+		return h.firehose[subscriber.ID] == subscriber
+	}
+	return h.topics[subscriber.Topic][subscriber.ID] == subscriber
+}
+
+// closeTopic завершает все подписки на топик.
+//
+// Используется после терминального события (например, удаления поста):
+// события, уже находящиеся в буфере подписчика, будут прочитаны до закрытия канала.
+func (h *hub[T]) closeTopic(topic uuid.UUID) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	closed := 0
+	for _, subscriber := range h.topics[topic] {
+		h.removeLocked(subscriber)
+		closed++
+	}
+
+	return closed
+}
+
+// cleanupIdle удаляет подписчиков, не получавших событий дольше maxIdleTime
+func (h *hub[T]) cleanupIdle(maxIdleTime time.Duration) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	idle := make([]*Subscriber[T], 0)
+	for _, topicSubscribers := range h.topics {
+		for _, subscriber := range topicSubscribers {
+			if now.Sub(subscriber.LastSeen) > maxIdleTime {
+				idle = append(idle, subscriber)
+			}
+		}
+	}
+	for _, subscriber := range h.firehose {
+		if now.Sub(subscriber.LastSeen) > maxIdleTime {
+			idle = append(idle, subscriber)
+		}
+	}
+
+	for _, subscriber := range idle {
+		h.logger.Debug("Cleaning up idle subscriber",
+			zap.String("subscriber_id", subscriber.ID),
+			zap.String("topic", topicName(subscriber.Topic)),
+			zap.Duration("idle_time", now.Sub(subscriber.LastSeen)),
+		)
+		h.removeLocked(subscriber)
+	}
+
+	return len(idle)
+}
+
+// closeAll закрывает все подписки потока
+func (h *hub[T]) closeAll() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	closed := 0
+	for _, topicSubscribers := range h.topics {
+		for _, subscriber := range topicSubscribers {
+			h.removeLocked(subscriber)
+			closed++
+		}
+	}
+	for _, subscriber := range h.firehose {
+		h.removeLocked(subscriber)
+		closed++
+	}
+
+	return closed
+}
+
+// removeLocked удаляет подписчика и обновляет метрики. Вызывается под h.mu.Lock.
+func (h *hub[T]) removeLocked(subscriber *Subscriber[T]) {
+	safeCloseChannel(h.logger, subscriber.Channel)
+
+	if subscriber.Topic == uuid.Nil {
+		delete(h.firehose, subscriber.ID)
+		h.metrics.FirehoseSubscribers = len(h.firehose)
+	} else {
+		topicSubscribers := h.topics[subscriber.Topic]
+		delete(topicSubscribers, subscriber.ID)
+		if len(topicSubscribers) == 0 {
+			delete(h.topics, subscriber.Topic)
+			delete(h.metrics.ActiveConnections, subscriber.Topic)
+		} else {
+			h.metrics.ActiveConnections[subscriber.Topic] = len(topicSubscribers)
+		}
+	}
+
+	h.metrics.TotalSubscribers--
+}
+
+// count возвращает количество подписчиков топика
+func (h *hub[T]) count(topic uuid.UUID) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.topics[topic])
+}
+
+// snapshot возвращает копию метрик потока
+func (h *hub[T]) snapshot() HubMetrics {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	metrics := h.metrics
+	metrics.ActiveConnections = make(map[uuid.UUID]int, len(h.metrics.ActiveConnections))
+	for topic, count := range h.metrics.ActiveConnections {
+		metrics.ActiveConnections[topic] = count
+	}
+
+	metrics.SubscriberDrops = make(map[string]int64)
+	collect := func(subscriber *Subscriber[T]) {
+		if subscriber.Dropped > 0 {
+			metrics.SubscriberDrops[subscriber.ID] = subscriber.Dropped
+		}
+	}
+	for _, topicSubscribers := range h.topics {
+		for _, subscriber := range topicSubscribers {
+			collect(subscriber)
+		}
+	}
+	for _, subscriber := range h.firehose {
+		collect(subscriber)
+	}
+
+	return metrics
+}
+
+// consistent проверяет, что счетчики метрик совпадают с фактическим числом подписчиков
+func (h *hub[T]) consistent() (actual, counted int, ok bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	actual = len(h.firehose)
+	for _, topicSubscribers := range h.topics {
+		actual += len(topicSubscribers)
+	}
+
+	return actual, h.metrics.TotalSubscribers, actual == h.metrics.TotalSubscribers
+}
+
+// trySend выполняет неблокирующую отправку события подписчику.
+//
+// Подписчик может быть отписан и его канал закрыт между копированием списка
+// подписчиков и отправкой, поэтому паника отправки в закрытый канал перехватывается
+// и считается отброшенным сообщением.
+func trySend[T any](ch chan T, payload T) (sent bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			sent = false
+		}
+	}()
+
+	select {
+	case ch <- payload:
+		return true
+	default:
+		return false
+	}
+}
+
+// tryEvict удаляет из буфера самое старое событие, если буфер не пуст
+func tryEvict[T any](ch chan T) bool {
+	select {
+	case _, ok := <-ch:
+		return ok
+	default:
+		return false
+	}
+}
+
+// sendWithTimeout ждет места в буфере подписчика не дольше timeout.
+//
+// Как и trySend, перехватывает панику отправки в закрытый канал.
+func sendWithTimeout[T any](ch chan T, payload T, timeout time.Duration) (sent bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			sent = false
+		}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case ch <- payload:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// safeCloseChannel безопасно закрывает канал
+func safeCloseChannel[T any](logger *zap.Logger, ch chan T) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Warn("Recovered from panic while closing channel", zap.Any("panic", r))
+		}
+	}()
+
+	// Повторное закрытие перехватывается recover выше. Проверка через чтение
+	// из канала здесь не подходит: для буферизованного канала с непрочитанными
+	// событиями она извлекла бы событие и оставила канал открытым навсегда.
+	close(ch)
+}
+
+// topicName возвращает имя топика для логов
+func topicName(topic uuid.UUID) string {
+	if topic == uuid.Nil {
+		return "*"
+	}
+	return topic.String()
+}
@@ -0,0 +1,81 @@
+package subscription
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/NarthurN/habbr/internal/model"
+)
+
+// delivery задает буферизацию и поведение потока событий при медленных подписчиках
+type delivery struct {
+	channelSize  int
+	policy       model.SlowConsumerPolicy // политика для подписок, не выбравших свою
+	blockTimeout time.Duration            // ожидание места в буфере для политики block
+}
+
+// subscriptionOptions - параметры подписки, переданные через контекст
+type subscriptionOptions struct {
+	policy model.SlowConsumerPolicy
+
+	mu  sync.Mutex
+	err error
+}
+
+type optionsKey struct{}
+
+// WithSlowConsumerPolicy возвращает контекст, подписки с которым используют указанную
+// политику медленного подписчика вместо политики из конфигурации.
+//
+// Пустая политика означает политику из конфигурации. Контекст, подготовленный
+// этой функцией, также позволяет узнать через Err, почему сервис завершил подписку.
+//
+// Пример использования:
+//
+//	ctx = subscription.WithSlowConsumerPolicy(ctx, model.SlowConsumerDisconnect)
+//	events, err := service.Subscribe(ctx, postID)
+//	for range events {
+//	}
+//	if err := subscription.Err(ctx); err != nil {
+//	    // подписка закрыта сервисом, например, с ошибкой SLOW_CONSUMER
+//	}
+func WithSlowConsumerPolicy(ctx context.Context, policy model.SlowConsumerPolicy) context.Context {
+	return context.WithValue(ctx, optionsKey{}, &subscriptionOptions{policy: policy})
+}
+
+// Err возвращает ошибку, с которой сервис завершил подписку, созданную с контекстом ctx.
+//
+// nil означает обычное завершение: отмену контекста, удаление поста или остановку сервиса.
+// Ошибка сохраняется только для контекстов, подготовленных WithSlowConsumerPolicy.
+func Err(ctx context.Context) error {
+	options := optionsFromContext(ctx)
+	if options == nil {
+		return nil
+	}
+
+	options.mu.Lock()
+	defer options.mu.Unlock()
+
+	return options.err
+}
+
+// optionsFromContext возвращает параметры подписки из контекста или nil
+func optionsFromContext(ctx context.Context) *subscriptionOptions {
+	options, _ := ctx.Value(optionsKey{}).(*subscriptionOptions)
+	return options
+}
+
+// fail сохраняет ошибку завершения подписки
+func (o *subscriptionOptions) fail(err error) {
+	if o == nil {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.err == nil {
+		o.err = err
+	}
+}
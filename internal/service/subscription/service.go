@@ -2,58 +2,126 @@ package subscription
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"sync"
 	"time"
 
+	"github.com/NarthurN/habbr/internal/config"
 	"github.com/NarthurN/habbr/internal/model"
+	"github.com/NarthurN/habbr/internal/pubsub"
+	"github.com/NarthurN/habbr/internal/repository"
+	"github.com/NarthurN/habbr/internal/repository/converter"
+	"github.com/NarthurN/habbr/internal/repository/memory"
+	repomodel "github.com/NarthurN/habbr/internal/repository/model"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
-// Subscriber представляет подписчика
-type Subscriber struct {
-	ID        string
-	PostID    uuid.UUID
-	Channel   chan *model.CommentSubscriptionPayload
-	CreatedAt time.Time
-	LastSeen  time.Time
-}
-
-// SubscriptionMetrics содержит метрики подписок
+// SubscriptionMetrics содержит метрики подписок.
+//
+// Поля верхнего уровня относятся к событиям комментариев, Posts - к событиям
+// жизненного цикла постов, Stats - к обновлениям статистики постов.
 type SubscriptionMetrics struct {
-	TotalSubscribers   int
-	ActiveConnections  map[uuid.UUID]int // postID -> count
-	MessagesSent       int64
-	MessagesDropped    int64
-	SubscriptionsTotal int64
+	HubMetrics
+	Posts HubMetrics `json:"posts"`
+	Stats HubMetrics `json:"stats"`
+
+	// TrackedPostStats - количество постов, статистика которых поддерживается для подписчиков
+	TrackedPostStats int `json:"tracked_post_stats"`
 }
 
-// Service реализует сервис подписок с pub/sub системой
+// Service реализует сервис подписок с pub/sub системой.
+//
+// Сервис обслуживает два независимых потока событий: события комментариев
+// и события жизненного цикла постов. Оба потока построены на общем механизме
+// (hub), который поддерживает подписку на конкретный пост и на все посты сразу,
+// буферизацию для каждого подписчика и учет отброшенных сообщений.
+//
+// Поверх этих событий сервис поддерживает статистику постов с подписчиками
+// и рассылает ее изменения не чаще одного раза за config.SubscriptionConfig.StatsInterval.
+//
+// Опубликованные события проходят через брокер сообщений (pubsub.Broker) и доставляются
+// локальным подписчикам только после получения из брокера. Так событие, созданное
+// на одной реплике сервиса, получают подписчики всех реплик.
+//
+// Перед публикацией событие комментария записывается в журнал событий
+// (repository.CommentEventRepository) и получает EventID; по журналу клиент,
+// потерявший соединение, получает пропущенные события (SubscribeFrom).
 type Service struct {
-	mu              sync.RWMutex
-	subscribers     map[uuid.UUID]map[string]*Subscriber // postID -> subscriberID -> subscriber
+	comments        *hub[*model.CommentSubscriptionPayload]
+	posts           *hub[*model.PostSubscriptionPayload]
+	stats           *statsAggregator
+	broker          pubsub.Broker
+	events          repository.CommentEventRepository
+	commentsChannel string
+	postsChannel    string
+	publishTimeout  time.Duration
 	logger          *zap.Logger
-	metrics         *SubscriptionMetrics
 	channelSize     int
 	cleanupInterval time.Duration
 	maxIdleTime     time.Duration
+	stop            chan struct{}
+	stopOnce        sync.Once
 }
 
-// NewService создает новый сервис подписок
-func NewService(logger *zap.Logger) *Service {
+// NewService создает новый сервис подписок.
+//
+// Если broker равен nil, используется in-memory брокер и события доставляются
+// только внутри процесса. Брокер принадлежит вызывающему коду и не закрывается в Close.
+// Если events равен nil, используется in-memory журнал событий; при нескольких репликах
+// журнал должен быть общим (PostgreSQL), иначе реплика восстановит только собственные события.
+func NewService(cfg config.SubscriptionConfig, broker pubsub.Broker, events repository.CommentEventRepository, logger *zap.Logger) *Service {
 	if logger == nil {
 		logger = zap.NewNop()
 	}
+	if broker == nil {
+		broker = pubsub.NewMemoryBroker()
+	}
+	if events == nil {
+		events = memory.NewCommentEventRepository(repository.CommentEventRetention)
+	}
+
+	prefix := cfg.ChannelPrefix
+	if prefix == "" {
+		prefix = "habbr"
+	}
 
 	service := &Service{
-		subscribers:     make(map[uuid.UUID]map[string]*Subscriber),
+		broker:          broker,
+		events:          events,
+		commentsChannel: prefix + "_comments",
+		postsChannel:    prefix + "_posts",
+		publishTimeout:  5 * time.Second,
 		logger:          logger,
 		channelSize:     100,              // размер буфера канала
 		cleanupInterval: 30 * time.Minute, // интервал очистки неактивных соединений
 		maxIdleTime:     60 * time.Minute, // максимальное время бездействия
-		metrics: &SubscriptionMetrics{
-			ActiveConnections: make(map[uuid.UUID]int),
-		},
+		stop:            make(chan struct{}),
+	}
+
+	streamDelivery := delivery{
+		channelSize:  service.channelSize,
+		policy:       model.SlowConsumerPolicy(cfg.SlowConsumerPolicy),
+		blockTimeout: cfg.SlowConsumerTimeout,
+	}
+	if !streamDelivery.policy.IsValid() {
+		streamDelivery.policy = model.SlowConsumerDropNewest
+	}
+	if streamDelivery.blockTimeout <= 0 {
+		streamDelivery.blockTimeout = 100 * time.Millisecond
+	}
+
+	service.comments = newHub[*model.CommentSubscriptionPayload]("comments", streamDelivery, logger)
+	service.posts = newHub[*model.PostSubscriptionPayload]("posts", streamDelivery, logger)
+	service.stats = newStatsAggregator(cfg.StatsInterval, streamDelivery, logger)
+
+	// Получаем события всех реплик, включая собственные
+	if err := broker.Subscribe(service.commentsChannel, service.receiveComment); err != nil {
+		logger.Error("Failed to subscribe to comment events channel", zap.Error(err))
+	}
+	if err := broker.Subscribe(service.postsChannel, service.receivePost); err != nil {
+		logger.Error("Failed to subscribe to post events channel", zap.Error(err))
 	}
 
 	// Запуск фоновой очистки
@@ -63,6 +131,8 @@ func NewService(logger *zap.Logger) *Service {
 		zap.Int("channel_size", service.channelSize),
 		zap.Duration("cleanup_interval", service.cleanupInterval),
 		zap.Duration("max_idle_time", service.maxIdleTime),
+		zap.Duration("stats_interval", cfg.StatsInterval),
+		zap.String("slow_consumer_policy", string(streamDelivery.policy)),
 	)
 
 	return service
@@ -75,231 +145,317 @@ func (s *Service) SubscribeToComments(ctx context.Context, postID uuid.UUID) (<-
 		return nil, model.NewValidationError("post_id", "post ID is required")
 	}
 
-	s.logger.Debug("Creating new subscription", zap.String("post_id", postID.String()))
-
-	// Создание канала для подписчика
-	channel := make(chan *model.CommentSubscriptionPayload, s.channelSize)
+	return s.comments.subscribe(ctx, postID), nil
+}
 
-	// Генерация уникального ID подписчика
-	subscriberID := uuid.New().String()
+// Unsubscribe отписывает от комментариев
+func (s *Service) Unsubscribe(ctx context.Context, postID uuid.UUID, subscriberID string) error {
+	s.comments.unsubscribe(postID, subscriberID)
+	return nil
+}
 
-	// Создание подписчика
-	now := time.Now()
-	subscriber := &Subscriber{
-		ID:        subscriberID,
-		PostID:    postID,
-		Channel:   channel,
-		CreatedAt: now,
-		LastSeen:  now,
+// SubscribeFrom создает подписку на комментарии к посту с повторной доставкой событий после afterEventID.
+//
+// Подписка на живые события регистрируется до чтения журнала, поэтому событие,
+// опубликованное во время чтения, не теряется; события, уже отправленные из журнала,
+// отбрасываются по EventID. Журнал фиксирует события поста в порядке EventID, поэтому
+// живое событие с EventID не больше последнего прочитанного уже было в журнале.
+// Если события после afterEventID уже вытеснены из журнала, возвращается ошибка
+// EVENT_CURSOR_EXPIRED.
+func (s *Service) SubscribeFrom(ctx context.Context, postID uuid.UUID, afterEventID int64) (<-chan *model.CommentSubscriptionPayload, error) {
+	if postID == uuid.Nil {
+		s.logger.Warn("Attempt to subscribe with nil post ID")
+		return nil, model.NewValidationError("post_id", "post ID is required")
 	}
-
-	s.mu.Lock()
-	// Инициализация карты подписчиков для поста, если она не существует
-	if s.subscribers[postID] == nil {
-		s.subscribers[postID] = make(map[string]*Subscriber)
+	if afterEventID < 0 {
+		return nil, model.NewValidationError("after_event_id", "event ID cannot be negative")
 	}
+	if afterEventID == 0 {
+		return s.comments.subscribe(ctx, postID), nil
+	}
+
+	subscriptionCtx, cancel := context.WithCancel(ctx)
+	live := s.comments.subscribe(subscriptionCtx, postID)
 
-	// Добавление подписчика
-	s.subscribers[postID][subscriberID] = subscriber
+	records, err := s.events.ListAfter(ctx, postID, afterEventID, repository.CommentEventRetention)
+	if errors.Is(err, repository.ErrEventCursorExpired) {
+		cancel()
+		s.logger.Info("Comment events cursor expired",
+			zap.String("post_id", postID.String()),
+			zap.Int64("after_event_id", afterEventID),
+		)
+		return nil, model.NewEventCursorExpiredError(afterEventID)
+	}
+	if err != nil {
+		cancel()
+		s.logger.Error("Failed to load missed comment events",
+			zap.String("post_id", postID.String()),
+			zap.Int64("after_event_id", afterEventID),
+			zap.Error(err),
+		)
+		return nil, model.NewInternalError("failed to load missed comment events")
+	}
 
-	// Обновление метрик
-	s.metrics.TotalSubscribers++
-	s.metrics.ActiveConnections[postID] = len(s.subscribers[postID])
-	s.metrics.SubscriptionsTotal++
-	s.mu.Unlock()
+	missed := make([]*model.CommentSubscriptionPayload, 0, len(records))
+	for _, record := range records {
+		payload, err := converter.CommentEventFromRepo(record)
+		if err != nil {
+			s.logger.Warn("Skipping undecodable comment event", zap.Error(err))
+			continue
+		}
+		missed = append(missed, payload)
+	}
 
-	s.logger.Info("Subscription created successfully",
+	s.logger.Debug("Replaying missed comment events",
 		zap.String("post_id", postID.String()),
-		zap.String("subscriber_id", subscriberID),
-		zap.Int("post_subscribers", s.metrics.ActiveConnections[postID]),
-		zap.Int("total_subscribers", s.metrics.TotalSubscribers),
+		zap.Int64("after_event_id", afterEventID),
+		zap.Int("events", len(missed)),
 	)
 
-	// Запуск горутины для мониторинга контекста
-	go s.monitorContext(ctx, postID, subscriberID)
+	out := make(chan *model.CommentSubscriptionPayload, s.channelSize)
+	go func() {
+		defer close(out)
+		defer cancel()
+
+		lastID := afterEventID
+		forward := func(payload *model.CommentSubscriptionPayload) bool {
+			select {
+			case out <- payload:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
 
-	return channel, nil
-}
+		for _, payload := range missed {
+			if !forward(payload) {
+				return
+			}
+			lastID = max(lastID, payload.EventID)
+		}
 
-// Unsubscribe отписывает от комментариев
-func (s *Service) Unsubscribe(ctx context.Context, postID uuid.UUID, subscriberID string) error {
-	s.logger.Debug("Unsubscribing",
-		zap.String("post_id", postID.String()),
-		zap.String("subscriber_id", subscriberID),
-	)
+		for payload := range live {
+			// Событие без EventID не попало в журнал и не могло быть доставлено повторно
+			if payload.EventID != 0 && payload.EventID <= lastID {
+				continue
+			}
+			if !forward(payload) {
+				return
+			}
+		}
+	}()
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	return out, nil
+}
 
-	// Проверка существования подписок для поста
-	postSubscribers, exists := s.subscribers[postID]
-	if !exists {
-		s.logger.Debug("No subscribers found for post", zap.String("post_id", postID.String()))
-		return nil // подписка уже не существует
-	}
+// SubscribeAll создает подписку на события комментариев всех постов.
+//
+// Подписчик получает каждое событие, опубликованное для любого поста, с собственным
+// буфером того же размера, что и у подписок на отдельный пост. Если подписчик не успевает
+// читать события и буфер заполнен, событие обрабатывается по политике медленного подписчика
+// (по умолчанию новое событие отбрасывается) и потеря учитывается в MessagesDropped.
+func (s *Service) SubscribeAll(ctx context.Context) (<-chan *model.CommentSubscriptionPayload, error) {
+	return s.comments.subscribe(ctx, uuid.Nil), nil
+}
 
-	// Получение подписчика
-	subscriber, exists := postSubscribers[subscriberID]
-	if !exists {
-		s.logger.Debug("Subscriber not found",
-			zap.String("post_id", postID.String()),
-			zap.String("subscriber_id", subscriberID),
-		)
-		return nil // подписчик уже не существует
+// SubscribeToPost создает подписку на события жизненного цикла поста.
+//
+// После события DELETED канал закрывается: удаленный пост больше не изменится.
+func (s *Service) SubscribeToPost(ctx context.Context, postID uuid.UUID) (<-chan *model.PostSubscriptionPayload, error) {
+	if postID == uuid.Nil {
+		s.logger.Warn("Attempt to subscribe with nil post ID")
+		return nil, model.NewValidationError("post_id", "post ID is required")
 	}
 
-	// Закрытие канала и удаление подписчика
-	s.safeCloseChannel(subscriber.Channel)
-	delete(postSubscribers, subscriberID)
+	return s.posts.subscribe(ctx, postID), nil
+}
 
-	// Обновление метрик
-	s.metrics.TotalSubscribers--
-	if len(postSubscribers) == 0 {
-		delete(s.subscribers, postID)
-		delete(s.metrics.ActiveConnections, postID)
-	} else {
-		s.metrics.ActiveConnections[postID] = len(postSubscribers)
-	}
+// SubscribeToAllPosts создает подписку на события жизненного цикла всех постов
+func (s *Service) SubscribeToAllPosts(ctx context.Context) (<-chan *model.PostSubscriptionPayload, error) {
+	return s.posts.subscribe(ctx, uuid.Nil), nil
+}
 
-	s.logger.Info("Subscription removed successfully",
-		zap.String("post_id", postID.String()),
-		zap.String("subscriber_id", subscriberID),
-		zap.Duration("subscription_duration", time.Since(subscriber.CreatedAt)),
-		zap.Int("remaining_post_subscribers", len(postSubscribers)),
-	)
+// SubscribeToPostStats создает подписку на статистику поста.
+//
+// Первым сообщением подписчик получает текущую статистику. Если статистика поста
+// еще не отслеживается, она загружается через load; дальше счетчики обновляются
+// по событиям комментариев и поста без обращения к хранилищу.
+func (s *Service) SubscribeToPostStats(ctx context.Context, postID uuid.UUID, load PostStatsLoader) (<-chan *model.PostStats, error) {
+	if postID == uuid.Nil {
+		s.logger.Warn("Attempt to subscribe with nil post ID")
+		return nil, model.NewValidationError("post_id", "post ID is required")
+	}
 
-	return nil
+	return s.stats.subscribe(ctx, postID, load)
 }
 
 // NotifyCommentCreated уведомляет о создании нового комментария
 func (s *Service) NotifyCommentCreated(ctx context.Context, comment *model.Comment) error {
-	s.logger.Debug("Notifying comment created",
-		zap.String("comment_id", comment.ID.String()),
-		zap.String("post_id", comment.PostID.String()),
-	)
-
-	payload := &model.CommentSubscriptionPayload{
+	s.Publish(comment.PostID, &model.CommentSubscriptionPayload{
 		PostID:     comment.PostID,
 		Comment:    comment,
 		ActionType: "CREATED",
-	}
-
-	return s.notifySubscribers(comment.PostID, payload)
+	})
+	return nil
 }
 
 // NotifyCommentUpdated уведомляет об обновлении комментария
 func (s *Service) NotifyCommentUpdated(ctx context.Context, comment *model.Comment) error {
-	s.logger.Debug("Notifying comment updated",
-		zap.String("comment_id", comment.ID.String()),
-		zap.String("post_id", comment.PostID.String()),
-	)
-
-	payload := &model.CommentSubscriptionPayload{
+	s.Publish(comment.PostID, &model.CommentSubscriptionPayload{
 		PostID:     comment.PostID,
 		Comment:    comment,
 		ActionType: "UPDATED",
-	}
-
-	return s.notifySubscribers(comment.PostID, payload)
+	})
+	return nil
 }
 
 // NotifyCommentDeleted уведомляет об удалении комментария
 func (s *Service) NotifyCommentDeleted(ctx context.Context, postID uuid.UUID, commentID uuid.UUID) error {
-	s.logger.Debug("Notifying comment deleted",
-		zap.String("comment_id", commentID.String()),
-		zap.String("post_id", postID.String()),
-	)
-
-	payload := &model.CommentSubscriptionPayload{
+	s.Publish(postID, &model.CommentSubscriptionPayload{
 		PostID: postID,
 		Comment: &model.Comment{
 			ID:     commentID,
 			PostID: postID,
 		},
-		ActionType: "DELETED",
+		ActionType:   "DELETED",
+		DeletedCount: 1,
+	})
+	return nil
+}
+
+// Publish отправляет событие комментария через брокер подписчикам поста
+// и подписчикам на все посты на всех репликах
+func (s *Service) Publish(postID uuid.UUID, payload *model.CommentSubscriptionPayload) {
+	if payload.PostID == uuid.Nil {
+		payload.PostID = postID
 	}
+	s.record(payload)
+	s.broadcast(s.commentsChannel, postID, payload)
+}
 
-	return s.notifySubscribers(postID, payload)
+// PublishBatch отправляет события комментариев с заполненным PostID в порядке
+// следования, сохраняя их в журнал одной записью
+func (s *Service) PublishBatch(payloads []*model.CommentSubscriptionPayload) {
+	s.record(payloads...)
+	for _, payload := range payloads {
+		s.broadcast(s.commentsChannel, payload.PostID, payload)
+	}
 }
 
-// notifySubscribers отправляет уведомление всем подписчикам поста
-func (s *Service) notifySubscribers(postID uuid.UUID, payload *model.CommentSubscriptionPayload) error {
-	s.mu.RLock()
+// record сохраняет события комментариев в журнал и заполняет EventID.
+//
+// Ошибка журнала не мешает доставке: события отправляются подписчикам без EventID
+// и не будут доставлены повторно после переподключения.
+func (s *Service) record(payloads ...*model.CommentSubscriptionPayload) {
+	if len(payloads) == 0 {
+		return
+	}
 
-	// Получение подписчиков для поста
-	postSubscribers, exists := s.subscribers[postID]
-	if !exists {
-		s.mu.RUnlock()
-		s.logger.Debug("No subscribers to notify", zap.String("post_id", postID.String()))
-		return nil // нет подписчиков
+	events := make([]*repomodel.CommentEvent, len(payloads))
+	var err error
+	for i, payload := range payloads {
+		if events[i], err = converter.CommentEventToRepo(payload); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), s.publishTimeout)
+		err = s.events.AppendBatch(ctx, events)
+		cancel()
+	}
+	if err != nil {
+		s.logger.Error("Failed to record comment events",
+			zap.String("post_id", payloads[0].PostID.String()),
+			zap.String("action", payloads[0].ActionType),
+			zap.Int("count", len(payloads)),
+			zap.Error(err),
+		)
+		return
 	}
 
-	// Создаем копию списка подписчиков для безопасной итерации
-	subscribersCopy := make([]*Subscriber, 0, len(postSubscribers))
-	for _, subscriber := range postSubscribers {
-		subscribersCopy = append(subscribersCopy, subscriber)
+	for i, payload := range payloads {
+		payload.EventID = events[i].ID
 	}
-	s.mu.RUnlock()
+}
 
-	// Отправка уведомления всем подписчикам
-	sentCount := 0
-	droppedCount := 0
+// PublishPost отправляет событие жизненного цикла поста через брокер.
+//
+// Для события DELETED подписки на этот пост завершаются после доставки события,
+// подписки на все посты продолжают работать.
+func (s *Service) PublishPost(postID uuid.UUID, payload *model.PostSubscriptionPayload) {
+	if payload.PostID == uuid.Nil {
+		payload.PostID = postID
+	}
+	s.broadcast(s.postsChannel, postID, payload)
+}
 
-	for _, subscriber := range subscribersCopy {
-		select {
-		case subscriber.Channel <- payload:
-			sentCount++
-			// Обновляем время последней активности
-			s.mu.Lock()
-			if sub, exists := s.subscribers[postID][subscriber.ID]; exists {
-				sub.LastSeen = time.Now()
-			}
-			s.mu.Unlock()
-		default:
-			// Канал заблокирован или закрыт, пропускаем
-			droppedCount++
-			s.logger.Warn("Message dropped for subscriber",
-				zap.String("subscriber_id", subscriber.ID),
-				zap.String("post_id", postID.String()),
-				zap.String("action", payload.ActionType),
-			)
-		}
+// broadcast кодирует событие и публикует его в канал брокера
+func (s *Service) broadcast(channel string, postID uuid.UUID, payload any) {
+	message, err := json.Marshal(payload)
+	if err != nil {
+		s.logger.Error("Failed to encode subscription event",
+			zap.String("channel", channel),
+			zap.String("post_id", postID.String()),
+			zap.Error(err),
+		)
+		return
 	}
 
-	// Обновление метрик
-	s.mu.Lock()
-	s.metrics.MessagesSent += int64(sentCount)
-	s.metrics.MessagesDropped += int64(droppedCount)
-	s.mu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), s.publishTimeout)
+	defer cancel()
 
-	s.logger.Debug("Notification sent to subscribers",
-		zap.String("post_id", postID.String()),
+	if err := s.broker.Publish(ctx, channel, message); err != nil {
+		s.logger.Error("Failed to publish subscription event",
+			zap.String("channel", channel),
+			zap.String("post_id", postID.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+// receiveComment доставляет событие комментария, полученное из брокера, локальным подписчикам
+func (s *Service) receiveComment(message []byte) {
+	var payload model.CommentSubscriptionPayload
+	if err := json.Unmarshal(message, &payload); err != nil {
+		s.logger.Error("Failed to decode comment event", zap.Error(err))
+		return
+	}
+
+	sent, dropped := s.comments.publish(payload.PostID, &payload)
+	s.stats.onComment(&payload)
+
+	s.logger.Debug("Comment event published",
+		zap.String("post_id", payload.PostID.String()),
 		zap.String("action", payload.ActionType),
-		zap.Int("sent", sentCount),
-		zap.Int("dropped", droppedCount),
+		zap.Int("sent", sent),
+		zap.Int("dropped", dropped),
 	)
-
-	return nil
 }
 
-// monitorContext отслеживает отмену контекста и автоматически отписывает
-func (s *Service) monitorContext(ctx context.Context, postID uuid.UUID, subscriberID string) {
-	<-ctx.Done()
+// receivePost доставляет событие поста, полученное из брокера, локальным подписчикам
+func (s *Service) receivePost(message []byte) {
+	var payload model.PostSubscriptionPayload
+	if err := json.Unmarshal(message, &payload); err != nil {
+		s.logger.Error("Failed to decode post event", zap.Error(err))
+		return
+	}
 
-	s.logger.Debug("Context cancelled, unsubscribing",
-		zap.String("post_id", postID.String()),
-		zap.String("subscriber_id", subscriberID),
-		zap.Error(ctx.Err()),
-	)
+	postID := payload.PostID
+	sent, dropped := s.posts.publish(postID, &payload)
 
-	// Автоматическая отписка при отмене контекста
-	if err := s.Unsubscribe(context.Background(), postID, subscriberID); err != nil {
-		s.logger.Warn("Failed to unsubscribe on context cancellation",
-			zap.Error(err),
-			zap.String("post_id", postID.String()),
-			zap.String("subscriber_id", subscriberID),
-		)
+	closed := 0
+	if payload.ActionType == model.PostEventDeleted {
+		closed = s.posts.closeTopic(postID)
 	}
+	s.stats.onPost(&payload)
+
+	s.logger.Debug("Post event published",
+		zap.String("post_id", postID.String()),
+		zap.String("action", payload.ActionType),
+		zap.Int("sent", sent),
+		zap.Int("dropped", dropped),
+		zap.Int("closed_subscriptions", closed),
+	)
 }
 
 // startCleanupRoutine запускает фоновую очистку неактивных соединений
@@ -307,8 +463,13 @@ func (s *Service) startCleanupRoutine() {
 	ticker := time.NewTicker(s.cleanupInterval)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		s.cleanupIdleSubscribers()
+	for {
+		select {
+		case <-ticker.C:
+			s.cleanupIdleSubscribers()
+		case <-s.stop:
+			return
+		}
 	}
 }
 
@@ -316,121 +477,55 @@ func (s *Service) startCleanupRoutine() {
 func (s *Service) cleanupIdleSubscribers() {
 	s.logger.Debug("Starting cleanup of idle subscribers")
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	now := time.Now()
-	cleanedCount := 0
-
-	for postID, postSubscribers := range s.subscribers {
-		for subscriberID, subscriber := range postSubscribers {
-			if now.Sub(subscriber.LastSeen) > s.maxIdleTime {
-				s.logger.Debug("Cleaning up idle subscriber",
-					zap.String("subscriber_id", subscriberID),
-					zap.String("post_id", postID.String()),
-					zap.Duration("idle_time", now.Sub(subscriber.LastSeen)),
-				)
-
-				// Закрытие канала
-				s.safeCloseChannel(subscriber.Channel)
-				delete(postSubscribers, subscriberID)
-				cleanedCount++
-			}
-		}
-
-		// Удаление пустых карт
-		if len(postSubscribers) == 0 {
-			delete(s.subscribers, postID)
-			delete(s.metrics.ActiveConnections, postID)
-		} else {
-			s.metrics.ActiveConnections[postID] = len(postSubscribers)
-		}
-	}
-
-	s.metrics.TotalSubscribers -= cleanedCount
+	cleanedCount := s.comments.cleanupIdle(s.maxIdleTime) +
+		s.posts.cleanupIdle(s.maxIdleTime) +
+		s.stats.hub.cleanupIdle(s.maxIdleTime)
 
 	if cleanedCount > 0 {
 		s.logger.Info("Cleanup completed",
 			zap.Int("cleaned_subscribers", cleanedCount),
-			zap.Int("remaining_subscribers", s.metrics.TotalSubscribers),
+			zap.Int("remaining_subscribers", s.GetTotalSubscriberCount()),
 		)
 	}
 }
 
-// safeCloseChannel безопасно закрывает канал
-func (s *Service) safeCloseChannel(ch chan *model.CommentSubscriptionPayload) {
-	defer func() {
-		if r := recover(); r != nil {
-			s.logger.Warn("Recovered from panic while closing channel", zap.Any("panic", r))
-		}
-	}()
-
-	select {
-	case <-ch:
-		// Канал уже закрыт
-	default:
-		close(ch)
-	}
-}
-
-// GetSubscriberCount возвращает количество подписчиков для поста
+// GetSubscriberCount возвращает количество подписчиков на комментарии поста
 func (s *Service) GetSubscriberCount(postID uuid.UUID) int {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	postSubscribers, exists := s.subscribers[postID]
-	if !exists {
-		return 0
-	}
-
-	return len(postSubscribers)
+	return s.comments.count(postID)
 }
 
 // GetTotalSubscriberCount возвращает общее количество подписчиков
 func (s *Service) GetTotalSubscriberCount() int {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	return s.metrics.TotalSubscribers
+	return s.comments.snapshot().TotalSubscribers +
+		s.posts.snapshot().TotalSubscribers +
+		s.stats.hub.snapshot().TotalSubscribers
 }
 
 // GetMetrics возвращает метрики подписок
 func (s *Service) GetMetrics() SubscriptionMetrics {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	// Создаем копию для безопасного возврата
-	metrics := SubscriptionMetrics{
-		TotalSubscribers:   s.metrics.TotalSubscribers,
-		ActiveConnections:  make(map[uuid.UUID]int),
-		MessagesSent:       s.metrics.MessagesSent,
-		MessagesDropped:    s.metrics.MessagesDropped,
-		SubscriptionsTotal: s.metrics.SubscriptionsTotal,
+	return SubscriptionMetrics{
+		HubMetrics:       s.comments.snapshot(),
+		Posts:            s.posts.snapshot(),
+		Stats:            s.stats.hub.snapshot(),
+		TrackedPostStats: s.stats.tracked(),
 	}
-
-	for postID, count := range s.metrics.ActiveConnections {
-		metrics.ActiveConnections[postID] = count
-	}
-
-	return metrics
 }
 
 // HealthCheck проверяет состояние сервис подписок
 func (s *Service) HealthCheck() error {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	totalSubs := 0
-	for _, postSubs := range s.subscribers {
-		totalSubs += len(postSubs)
-	}
-
-	if totalSubs != s.metrics.TotalSubscribers {
-		s.logger.Error("Metrics mismatch detected",
-			zap.Int("actual_subscribers", totalSubs),
-			zap.Int("metric_subscribers", s.metrics.TotalSubscribers),
-		)
-		return model.NewInternalError("subscription service metrics inconsistency")
+	for name, check := range map[string]func() (int, int, bool){
+		"comments": s.comments.consistent,
+		"posts":    s.posts.consistent,
+		"stats":    s.stats.hub.consistent,
+	} {
+		if actual, counted, ok := check(); !ok {
+			s.logger.Error("Metrics mismatch detected",
+				zap.String("stream", name),
+				zap.Int("actual_subscribers", actual),
+				zap.Int("metric_subscribers", counted),
+			)
+			return model.NewInternalError("subscription service metrics inconsistency")
+		}
 	}
 
 	return nil
@@ -440,30 +535,18 @@ func (s *Service) HealthCheck() error {
 func (s *Service) Close() {
 	s.logger.Info("Shutting down subscription service")
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	totalClosed := 0
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
 
-	// Закрытие всех каналов
-	for postID, postSubscribers := range s.subscribers {
-		for subscriberID, subscriber := range postSubscribers {
-			s.safeCloseChannel(subscriber.Channel)
-			delete(postSubscribers, subscriberID)
-			totalClosed++
-		}
-		delete(s.subscribers, postID)
-	}
-
-	// Очистка метрик
-	s.metrics.TotalSubscribers = 0
-	s.metrics.ActiveConnections = make(map[uuid.UUID]int)
+	totalClosed := s.comments.closeAll() + s.posts.closeAll() + s.stats.close()
+	metrics := s.GetMetrics()
 
 	s.logger.Info("Subscription service shutdown completed",
 		zap.Int("closed_subscriptions", totalClosed),
-		zap.Int64("total_messages_sent", s.metrics.MessagesSent),
-		zap.Int64("total_messages_dropped", s.metrics.MessagesDropped),
-		zap.Int64("total_subscriptions_created", s.metrics.SubscriptionsTotal),
+		zap.Int64("total_messages_sent", metrics.MessagesSent+metrics.Posts.MessagesSent+metrics.Stats.MessagesSent),
+		zap.Int64("total_messages_dropped", metrics.MessagesDropped+metrics.Posts.MessagesDropped+metrics.Stats.MessagesDropped),
+		zap.Int64("total_subscriptions_created", metrics.SubscriptionsTotal+metrics.Posts.SubscriptionsTotal+metrics.Stats.SubscriptionsTotal),
 	)
 }
 
@@ -472,17 +555,6 @@ func (s *Service) Subscribe(ctx context.Context, postID uuid.UUID) (<-chan *mode
 	return s.SubscribeToComments(ctx, postID)
 }
 
-// Publish отправляет событие всем подписчикам указанного поста
-func (s *Service) Publish(postID uuid.UUID, payload *model.CommentSubscriptionPayload) {
-	if err := s.notifySubscribers(postID, payload); err != nil {
-		s.logger.Warn("Failed to publish message to subscribers",
-			zap.Error(err),
-			zap.String("post_id", postID.String()),
-			zap.String("action_type", payload.ActionType),
-		)
-	}
-}
-
 // Shutdown корректно завершает работу сервиса подписок
 func (s *Service) Shutdown() {
 	s.Close()
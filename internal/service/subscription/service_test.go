@@ -0,0 +1,488 @@
+package subscription
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/NarthurN/habbr/internal/config"
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/NarthurN/habbr/internal/pubsub"
+	"github.com/NarthurN/habbr/internal/repository/memory"
+)
+
+func TestSubscribeAll(t *testing.T) {
+	service := NewService(config.SubscriptionConfig{}, nil, nil, zap.NewNop())
+	defer service.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := service.SubscribeAll(ctx)
+	require.NoError(t, err)
+
+	firstPost, secondPost := uuid.New(), uuid.New()
+	for _, postID := range []uuid.UUID{firstPost, secondPost} {
+		service.Publish(postID, &model.CommentSubscriptionPayload{
+			PostID:     postID,
+			Comment:    &model.Comment{ID: uuid.New(), PostID: postID},
+			ActionType: "CREATED",
+		})
+	}
+
+	assert.Equal(t, firstPost, (<-events).PostID)
+	assert.Equal(t, secondPost, (<-events).PostID)
+
+	metrics := service.GetMetrics()
+	assert.Equal(t, 1, metrics.FirehoseSubscribers)
+	assert.Equal(t, int64(2), metrics.MessagesSent)
+	require.NoError(t, service.HealthCheck())
+
+	t.Run("slow subscriber drops events", func(t *testing.T) {
+		for i := 0; i < service.channelSize+5; i++ {
+			service.Publish(firstPost, &model.CommentSubscriptionPayload{
+				PostID:     firstPost,
+				Comment:    &model.Comment{ID: uuid.New(), PostID: firstPost},
+				ActionType: "CREATED",
+			})
+		}
+
+		assert.Equal(t, int64(5), service.GetMetrics().MessagesDropped)
+	})
+
+	t.Run("cancel closes channel", func(t *testing.T) {
+		cancel()
+
+		require.Eventually(t, func() bool {
+			return service.GetMetrics().FirehoseSubscribers == 0
+		}, time.Second, 10*time.Millisecond)
+
+		for range events {
+			// вычитываем буфер до закрытия канала
+		}
+		assert.Equal(t, 0, service.GetTotalSubscriberCount())
+	})
+}
+
+func TestPostEvents(t *testing.T) {
+	service := NewService(config.SubscriptionConfig{}, nil, nil, zap.NewNop())
+	defer service.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	post := &model.Post{ID: uuid.New(), Title: "Post"}
+
+	postEvents, err := service.SubscribeToPost(ctx, post.ID)
+	require.NoError(t, err)
+	allEvents, err := service.SubscribeToAllPosts(ctx)
+	require.NoError(t, err)
+
+	for _, action := range []string{model.PostEventCommentsToggled, model.PostEventDeleted} {
+		service.PublishPost(post.ID, &model.PostSubscriptionPayload{
+			PostID:     post.ID,
+			Post:       post,
+			ActionType: action,
+		})
+	}
+
+	t.Run("deleted event is terminal for post subscribers", func(t *testing.T) {
+		var actions []string
+		for event := range postEvents {
+			actions = append(actions, event.ActionType)
+		}
+		assert.Equal(t, []string{model.PostEventCommentsToggled, model.PostEventDeleted}, actions)
+	})
+
+	t.Run("firehose subscribers stay open", func(t *testing.T) {
+		assert.Equal(t, model.PostEventCommentsToggled, (<-allEvents).ActionType)
+		assert.Equal(t, model.PostEventDeleted, (<-allEvents).ActionType)
+
+		metrics := service.GetMetrics()
+		assert.Equal(t, 1, metrics.Posts.TotalSubscribers)
+		assert.Equal(t, 1, metrics.Posts.FirehoseSubscribers)
+		require.NoError(t, service.HealthCheck())
+	})
+
+	t.Run("nil post ID is rejected", func(t *testing.T) {
+		_, err := service.SubscribeToPost(ctx, uuid.Nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestPostStatsUpdates(t *testing.T) {
+	service := NewService(config.SubscriptionConfig{StatsInterval: time.Hour}, nil, nil, zap.NewNop())
+	defer service.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	postID := uuid.New()
+	loads := 0
+	load := func(ctx context.Context) (*model.PostStats, error) {
+		loads++
+		return &model.PostStats{TotalComments: 3, CommentsEnabled: true}, nil
+	}
+
+	stats, err := service.SubscribeToPostStats(ctx, postID, load)
+	require.NoError(t, err)
+
+	initial := <-stats
+	assert.Equal(t, postID, initial.PostID)
+	assert.Equal(t, 3, initial.TotalComments)
+
+	t.Run("first change is sent immediately", func(t *testing.T) {
+		comment := &model.Comment{ID: uuid.New(), PostID: postID, CreatedAt: time.Now()}
+		service.Publish(postID, &model.CommentSubscriptionPayload{PostID: postID, Comment: comment, ActionType: "CREATED"})
+
+		update := <-stats
+		assert.Equal(t, 4, update.TotalComments)
+		assert.Equal(t, 1, update.RootComments)
+		require.NotNil(t, update.LastCommentAt)
+		assert.True(t, comment.CreatedAt.Equal(*update.LastCommentAt))
+	})
+
+	t.Run("changes within interval are coalesced", func(t *testing.T) {
+		service.Publish(postID, &model.CommentSubscriptionPayload{
+			PostID:       postID,
+			Comment:      &model.Comment{ID: uuid.New(), PostID: postID},
+			ActionType:   "DELETED",
+			DeletedCount: 2,
+		})
+		service.PublishPost(postID, &model.PostSubscriptionPayload{
+			PostID:     postID,
+			Post:       &model.Post{ID: postID, CommentsEnabled: false},
+			ActionType: model.PostEventCommentsToggled,
+		})
+
+		select {
+		case update := <-stats:
+			t.Fatalf("unexpected update before interval elapsed: %+v", update)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		// Завершаем интервал досрочно
+		service.stats.mu.Lock()
+		state := service.stats.states[postID]
+		state.timer.Reset(0)
+		service.stats.mu.Unlock()
+
+		update := <-stats
+		assert.Equal(t, 2, update.TotalComments)
+		assert.False(t, update.CommentsEnabled)
+	})
+
+	t.Run("second subscriber reuses tracked stats", func(t *testing.T) {
+		second, err := service.SubscribeToPostStats(ctx, postID, load)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, (<-second).TotalComments)
+		assert.Equal(t, 1, loads)
+		assert.Equal(t, 1, service.GetMetrics().TrackedPostStats)
+	})
+
+	t.Run("post deletion closes stats subscriptions", func(t *testing.T) {
+		service.PublishPost(postID, &model.PostSubscriptionPayload{
+			PostID:     postID,
+			Post:       &model.Post{ID: postID},
+			ActionType: model.PostEventDeleted,
+		})
+
+		_, ok := <-stats
+		assert.False(t, ok)
+		assert.Equal(t, 0, service.GetMetrics().TrackedPostStats)
+		require.NoError(t, service.HealthCheck())
+	})
+}
+
+func TestPostStatsLastCommentReload(t *testing.T) {
+	service := NewService(config.SubscriptionConfig{}, nil, nil, zap.NewNop())
+	defer service.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	postID := uuid.New()
+	earlier := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	// Хранилище: после удаления последнего комментария самым новым остается earlier
+	load := func(ctx context.Context) (*model.PostStats, error) {
+		return &model.PostStats{TotalComments: 1, RootComments: 1, LastCommentAt: &earlier}, nil
+	}
+
+	stats, err := service.SubscribeToPostStats(ctx, postID, load)
+	require.NoError(t, err)
+	<-stats
+
+	latest := &model.Comment{ID: uuid.New(), PostID: postID, CreatedAt: time.Now()}
+	service.Publish(postID, &model.CommentSubscriptionPayload{PostID: postID, Comment: latest, ActionType: "CREATED"})
+	update := <-stats
+	require.NotNil(t, update.LastCommentAt)
+	assert.True(t, latest.CreatedAt.Equal(*update.LastCommentAt))
+
+	service.Publish(postID, &model.CommentSubscriptionPayload{PostID: postID, Comment: latest, ActionType: "DELETED", DeletedCount: 1})
+
+	require.Eventually(t, func() bool {
+		select {
+		case update := <-stats:
+			return update.LastCommentAt != nil && update.LastCommentAt.Equal(earlier)
+		default:
+			return false
+		}
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestReplicasShareBroker(t *testing.T) {
+	broker := pubsub.NewMemoryBroker()
+	defer broker.Close()
+
+	replicaA := NewService(config.SubscriptionConfig{}, broker, nil, zap.NewNop())
+	defer replicaA.Close()
+	replicaB := NewService(config.SubscriptionConfig{}, broker, nil, zap.NewNop())
+	defer replicaB.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	postID := uuid.New()
+	events, err := replicaB.Subscribe(ctx, postID)
+	require.NoError(t, err)
+	posts, err := replicaB.SubscribeToPost(ctx, postID)
+	require.NoError(t, err)
+
+	comment := &model.Comment{ID: uuid.New(), PostID: postID, Content: "Привет", CreatedAt: time.Now()}
+	replicaA.Publish(postID, &model.CommentSubscriptionPayload{PostID: postID, Comment: comment, ActionType: "CREATED"})
+	replicaA.PublishPost(postID, &model.PostSubscriptionPayload{
+		PostID:     postID,
+		Post:       &model.Post{ID: postID, Title: "Post"},
+		ActionType: model.PostEventDeleted,
+	})
+
+	event := <-events
+	assert.Equal(t, comment.ID, event.Comment.ID)
+	assert.Equal(t, comment.Content, event.Comment.Content)
+
+	assert.Equal(t, model.PostEventDeleted, (<-posts).ActionType)
+	_, ok := <-posts
+	assert.False(t, ok, "post subscription should be closed after DELETED on another replica")
+}
+
+func TestSubscribeFrom(t *testing.T) {
+	service := NewService(config.SubscriptionConfig{}, nil, memory.NewCommentEventRepository(3), zap.NewNop())
+	defer service.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	postID := uuid.New()
+	publish := func() *model.CommentSubscriptionPayload {
+		payload := &model.CommentSubscriptionPayload{
+			PostID:     postID,
+			Comment:    &model.Comment{ID: uuid.New(), PostID: postID},
+			ActionType: "CREATED",
+		}
+		service.Publish(postID, payload)
+		return payload
+	}
+
+	published := make([]*model.CommentSubscriptionPayload, 0, 4)
+	for i := 0; i < 4; i++ {
+		published = append(published, publish())
+	}
+	for i := 1; i < len(published); i++ {
+		assert.Greater(t, published[i].EventID, published[i-1].EventID)
+	}
+
+	// Журнал хранит три последних события: первое вытеснено, второе уже получено клиентом
+	events, err := service.SubscribeFrom(ctx, postID, published[1].EventID)
+	require.NoError(t, err)
+
+	for _, expected := range published[2:] {
+		event := <-events
+		assert.Equal(t, expected.EventID, event.EventID)
+		assert.Equal(t, expected.Comment.ID, event.Comment.ID)
+	}
+
+	live := publish()
+	event := <-events
+	assert.Equal(t, live.EventID, event.EventID)
+	assert.Equal(t, live.Comment.ID, event.Comment.ID)
+
+	t.Run("validation", func(t *testing.T) {
+		_, err := service.SubscribeFrom(ctx, uuid.Nil, 1)
+		assert.Error(t, err)
+
+		_, err = service.SubscribeFrom(ctx, postID, -1)
+		assert.Error(t, err)
+	})
+
+	t.Run("expired cursor", func(t *testing.T) {
+		// Второе событие вытеснено последним: события после первого доставить нельзя
+		_, err := service.SubscribeFrom(ctx, postID, published[0].EventID)
+		var domainErr *model.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "EVENT_CURSOR_EXPIRED", domainErr.Type)
+
+		// Курсор на последнем вытесненном событии ничего не пропустил
+		resumed, err := service.SubscribeFrom(ctx, postID, published[1].EventID)
+		require.NoError(t, err)
+		assert.Equal(t, published[2].EventID, (<-resumed).EventID)
+	})
+
+	t.Run("cancel closes channel", func(t *testing.T) {
+		cancel()
+
+		for range events {
+			// вычитываем буфер до закрытия канала
+		}
+		require.Eventually(t, func() bool {
+			return service.GetSubscriberCount(postID) == 0
+		}, time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestPublishBatch(t *testing.T) {
+	service := NewService(config.SubscriptionConfig{}, nil, memory.NewCommentEventRepository(10), zap.NewNop())
+	defer service.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	postID := uuid.New()
+	live, err := service.Subscribe(ctx, postID)
+	require.NoError(t, err)
+
+	payloads := make([]*model.CommentSubscriptionPayload, 3)
+	for i := range payloads {
+		payloads[i] = &model.CommentSubscriptionPayload{
+			PostID:       postID,
+			Comment:      &model.Comment{ID: uuid.New(), PostID: postID},
+			ActionType:   "DELETED",
+			DeletedCount: 1,
+		}
+	}
+	service.PublishBatch(payloads)
+
+	for i, expected := range payloads {
+		if i > 0 {
+			assert.Greater(t, expected.EventID, payloads[i-1].EventID)
+		}
+		event := <-live
+		assert.Equal(t, expected.EventID, event.EventID)
+		assert.Equal(t, expected.Comment.ID, event.Comment.ID)
+	}
+
+	// События пакета доступны для повторной доставки
+	replayed, err := service.SubscribeFrom(ctx, postID, payloads[0].EventID)
+	require.NoError(t, err)
+	for _, expected := range payloads[1:] {
+		assert.Equal(t, expected.EventID, (<-replayed).EventID)
+	}
+}
+
+func TestSlowConsumerPolicies(t *testing.T) {
+	publishAll := func(h *hub[int], topic uuid.UUID, values ...int) {
+		for _, value := range values {
+			h.publish(topic, value)
+		}
+	}
+	drain := func(ch <-chan int) []int {
+		values := make([]int, 0)
+		for {
+			select {
+			case value, ok := <-ch:
+				if !ok {
+					return values
+				}
+				values = append(values, value)
+			default:
+				return values
+			}
+		}
+	}
+
+	tests := []struct {
+		name     string
+		policy   model.SlowConsumerPolicy
+		expected []int
+	}{
+		{name: "drop newest", policy: model.SlowConsumerDropNewest, expected: []int{1, 2}},
+		{name: "drop oldest", policy: model.SlowConsumerDropOldest, expected: []int{2, 3}},
+		{name: "block times out", policy: model.SlowConsumerBlock, expected: []int{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHub[int]("test", delivery{channelSize: 2, policy: tt.policy, blockTimeout: 10 * time.Millisecond}, zap.NewNop())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			topic := uuid.New()
+			ch := h.subscribe(ctx, topic)
+			publishAll(h, topic, 1, 2, 3)
+
+			assert.Equal(t, tt.expected, drain(ch))
+
+			metrics := h.snapshot()
+			assert.Equal(t, int64(1), metrics.MessagesDropped)
+			require.Len(t, metrics.SubscriberDrops, 1)
+			for _, dropped := range metrics.SubscriberDrops {
+				assert.Equal(t, int64(1), dropped)
+			}
+		})
+	}
+
+	t.Run("block waits for reader", func(t *testing.T) {
+		h := newHub[int]("test", delivery{channelSize: 1, policy: model.SlowConsumerBlock, blockTimeout: time.Second}, zap.NewNop())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		topic := uuid.New()
+		ch := h.subscribe(ctx, topic)
+		publishAll(h, topic, 1)
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			<-ch
+		}()
+		sent, dropped := h.publish(topic, 2)
+
+		assert.Equal(t, 1, sent)
+		assert.Equal(t, 0, dropped)
+		assert.Equal(t, 2, <-ch)
+	})
+
+	t.Run("disconnect from context policy", func(t *testing.T) {
+		// Политика подписки из контекста важнее политики потока
+		h := newHub[int]("test", delivery{channelSize: 2, policy: model.SlowConsumerDropNewest}, zap.NewNop())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		ctx = WithSlowConsumerPolicy(ctx, model.SlowConsumerDisconnect)
+
+		topic := uuid.New()
+		ch := h.subscribe(ctx, topic)
+		publishAll(h, topic, 1, 2, 3, 4)
+
+		// События из буфера дочитываются, затем канал закрыт
+		assert.Equal(t, []int{1, 2}, drain(ch))
+		_, ok := <-ch
+		assert.False(t, ok)
+
+		var domainErr *model.DomainError
+		require.ErrorAs(t, Err(ctx), &domainErr)
+		assert.Equal(t, "SLOW_CONSUMER", domainErr.Type)
+
+		metrics := h.snapshot()
+		assert.Equal(t, int64(1), metrics.SlowConsumerDisconnects)
+		assert.Equal(t, 0, metrics.TotalSubscribers)
+		assert.Empty(t, metrics.SubscriberDrops)
+	})
+}
@@ -0,0 +1,288 @@
+package subscription
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/NarthurN/habbr/internal/model"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// PostStatsLoader загружает актуальную статистику поста из хранилища.
+//
+// Вызывается при появлении первого подписчика на статистику поста, дальше статистика
+// поддерживается инкрементально по событиям. После удаления последнего комментария
+// загрузчик вызывается повторно, чтобы получить новое время последнего комментария.
+type PostStatsLoader = func(ctx context.Context) (*model.PostStats, error)
+
+// statsReloadTimeout ограничивает повторную загрузку статистики после удаления комментария
+const statsReloadTimeout = 5 * time.Second
+
+// postStatsState содержит инкрементально поддерживаемую статистику одного поста
+type postStatsState struct {
+	stats       model.PostStats
+	load        PostStatsLoader
+	subscribers int
+	lastSent    time.Time
+	timer       *time.Timer
+	staleLast   *time.Time // LastCommentAt удаленного комментария, пока идет перезагрузка
+}
+
+// statsAggregator поддерживает счетчики статистики постов и рассылает их обновления.
+//
+// Статистика хранится только для постов, у которых есть подписчики. Обновления
+// объединяются: для каждого поста отправляется не больше одного обновления за interval,
+// изменения, пришедшие в течение интервала, доставляются одним сообщением в его конце.
+type statsAggregator struct {
+	mu       sync.Mutex
+	hub      *hub[*model.PostStats]
+	states   map[uuid.UUID]*postStatsState
+	interval time.Duration
+	logger   *zap.Logger
+}
+
+// newStatsAggregator создает агрегатор статистики с указанным интервалом объединения обновлений
+func newStatsAggregator(interval time.Duration, delivery delivery, logger *zap.Logger) *statsAggregator {
+	return &statsAggregator{
+		hub:      newHub[*model.PostStats]("stats", delivery, logger),
+		states:   make(map[uuid.UUID]*postStatsState),
+		interval: interval,
+		logger:   logger.With(zap.String("stream", "stats")),
+	}
+}
+
+// subscribe создает подписку на статистику поста.
+//
+// Если статистика поста еще не отслеживается, она загружается через load.
+// Текущее значение статистики доставляется подписчику первым сообщением.
+func (a *statsAggregator) subscribe(ctx context.Context, postID uuid.UUID, load PostStatsLoader) (<-chan *model.PostStats, error) {
+	a.mu.Lock()
+	_, tracked := a.states[postID]
+	a.mu.Unlock()
+
+	var loaded *model.PostStats
+	if !tracked {
+		stats, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		loaded = stats
+	}
+
+	a.mu.Lock()
+	state, ok := a.states[postID]
+	if !ok {
+		if loaded == nil {
+			// Статистика перестала отслеживаться, пока мы ее не загружали
+			a.mu.Unlock()
+			return a.subscribe(ctx, postID, load)
+		}
+		state = &postStatsState{stats: *loaded, load: load}
+		state.stats.PostID = postID
+		a.states[postID] = state
+	}
+	state.subscribers++
+	current := state.stats
+	a.mu.Unlock()
+
+	ch := a.hub.subscribe(ctx, postID, &current)
+
+	go func() {
+		<-ctx.Done()
+		a.release(postID)
+	}()
+
+	return ch, nil
+}
+
+// release уменьшает счетчик подписчиков и прекращает отслеживание статистики поста без подписчиков
+func (a *statsAggregator) release(postID uuid.UUID) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	state, ok := a.states[postID]
+	if !ok {
+		return
+	}
+
+	state.subscribers--
+	if state.subscribers <= 0 {
+		a.dropLocked(postID, state)
+	}
+}
+
+// onComment обновляет счетчики по событию комментария. Удаление без родителя в событии
+// (например, ветки целиком) уменьшает счетчик комментариев верхнего уровня
+func (a *statsAggregator) onComment(payload *model.CommentSubscriptionPayload) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	state, ok := a.states[payload.PostID]
+	if !ok {
+		return // статистику поста никто не отслеживает
+	}
+
+	switch payload.ActionType {
+	case "CREATED":
+		state.stats.TotalComments++
+		if payload.Comment != nil {
+			createdAt := payload.Comment.CreatedAt
+			if state.stats.LastCommentAt == nil || createdAt.After(*state.stats.LastCommentAt) {
+				state.stats.LastCommentAt = &createdAt
+			}
+		}
+		if payload.Comment != nil && payload.Comment.ParentID != nil {
+			state.stats.Replies++
+		} else {
+			state.stats.RootComments++
+		}
+	case "DELETED":
+		deleted := max(payload.DeletedCount, 1)
+		state.stats.TotalComments = max(state.stats.TotalComments-deleted, 0)
+		if payload.Comment != nil && payload.Comment.ParentID != nil {
+			state.stats.Replies = max(state.stats.Replies-deleted, 0)
+		} else {
+			state.stats.RootComments = max(state.stats.RootComments-deleted, 0)
+		}
+		// Удален последний комментарий: время предыдущего известно только хранилищу
+		last := state.stats.LastCommentAt
+		if payload.Comment != nil && last != nil && !payload.Comment.CreatedAt.Before(*last) && state.staleLast == nil {
+			state.staleLast = last
+			go a.reloadLastComment(payload.PostID, state)
+		}
+	default:
+		return // остальные события, включая TOMBSTONED, не меняют статистику
+	}
+
+	a.scheduleLocked(payload.PostID, state)
+}
+
+// reloadLastComment перечитывает время последнего комментария поста из хранилища.
+//
+// Если за время загрузки был создан новый комментарий, его время остается в статистике.
+func (a *statsAggregator) reloadLastComment(postID uuid.UUID, state *postStatsState) {
+	ctx, cancel := context.WithTimeout(context.Background(), statsReloadTimeout)
+	defer cancel()
+
+	loaded, err := state.load(ctx)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Состояние могло быть удалено или заменено, пока шла загрузка
+	if a.states[postID] != state {
+		return
+	}
+	stale := state.staleLast
+	state.staleLast = nil
+
+	if err != nil {
+		a.logger.Warn("Failed to reload post stats",
+			zap.String("post_id", postID.String()),
+			zap.Error(err),
+		)
+		return
+	}
+	if state.stats.LastCommentAt != stale {
+		return
+	}
+
+	state.stats.LastCommentAt = loaded.LastCommentAt
+	a.scheduleLocked(postID, state)
+}
+
+// onPost обновляет статистику по событию жизненного цикла поста.
+//
+// После удаления поста подписки на его статистику завершаются.
+func (a *statsAggregator) onPost(payload *model.PostSubscriptionPayload) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	state, ok := a.states[payload.PostID]
+	if !ok {
+		return
+	}
+
+	switch payload.ActionType {
+	case model.PostEventUpdated, model.PostEventCommentsToggled:
+		if payload.Post == nil || payload.Post.CommentsEnabled == state.stats.CommentsEnabled {
+			return
+		}
+		state.stats.CommentsEnabled = payload.Post.CommentsEnabled
+		a.scheduleLocked(payload.PostID, state)
+	case model.PostEventDeleted:
+		a.dropLocked(payload.PostID, state)
+		a.hub.closeTopic(payload.PostID)
+	}
+}
+
+// scheduleLocked отправляет обновление сразу или откладывает его до конца интервала.
+// Вызывается под a.mu.
+func (a *statsAggregator) scheduleLocked(postID uuid.UUID, state *postStatsState) {
+	if state.timer != nil {
+		return // обновление уже запланировано и заберет текущее состояние
+	}
+
+	wait := a.interval - time.Since(state.lastSent)
+	if wait <= 0 {
+		a.sendLocked(postID, state)
+		return
+	}
+
+	state.timer = time.AfterFunc(wait, func() {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+
+		// Состояние могло быть удалено или заменено, пока таймер ждал
+		if a.states[postID] != state {
+			return
+		}
+		state.timer = nil
+		a.sendLocked(postID, state)
+	})
+}
+
+// sendLocked рассылает текущую статистику поста. Вызывается под a.mu.
+func (a *statsAggregator) sendLocked(postID uuid.UUID, state *postStatsState) {
+	stats := state.stats
+	state.lastSent = time.Now()
+
+	sent, dropped := a.hub.publish(postID, &stats)
+
+	a.logger.Debug("Post stats published",
+		zap.String("post_id", postID.String()),
+		zap.Int("total_comments", stats.TotalComments),
+		zap.Int("sent", sent),
+		zap.Int("dropped", dropped),
+	)
+}
+
+// dropLocked прекращает отслеживание статистики поста. Вызывается под a.mu.
+func (a *statsAggregator) dropLocked(postID uuid.UUID, state *postStatsState) {
+	if state.timer != nil {
+		state.timer.Stop()
+		state.timer = nil
+	}
+	delete(a.states, postID)
+}
+
+// tracked возвращает количество постов, статистика которых отслеживается
+func (a *statsAggregator) tracked() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return len(a.states)
+}
+
+// close останавливает отложенные обновления и закрывает все подписки на статистику
+func (a *statsAggregator) close() int {
+	a.mu.Lock()
+	for postID, state := range a.states {
+		a.dropLocked(postID, state)
+	}
+	a.mu.Unlock()
+
+	return a.hub.closeAll()
+}
@@ -1,4 +1,4 @@
--- Migration: 002_rollback_initial.sql
+-- Migration: 001_initial_schema.down.sql
 -- Description: Rollback migration for initial schema
 -- Author: Auto-generated
 -- Date: 2023
@@ -1,4 +1,4 @@
--- Migration: 001_initial_schema.sql
+-- Migration: 001_initial_schema.up.sql
 -- Description: Initial schema for Habbr posts and comments system
 -- Author: Auto-generated
 -- Date: 2023
@@ -0,0 +1,6 @@
+-- Migration: 002_comment_events.down.sql
+-- Description: Rollback comment events log
+-- Date: 2026
+
+DROP INDEX IF EXISTS idx_comment_events_post_id;
+DROP TABLE IF EXISTS comment_events;
@@ -0,0 +1,15 @@
+-- Migration: 002_comment_events.up.sql
+-- Description: Comment events log for resumable subscriptions
+-- Date: 2026
+
+-- Журнал событий комментариев: клиент, потерявший соединение, получает
+-- события с ID больше последнего увиденного (commentEvents(postID, afterEventID))
+CREATE TABLE IF NOT EXISTS comment_events (
+    id BIGSERIAL PRIMARY KEY,
+    post_id UUID NOT NULL,
+    action_type VARCHAR(20) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_events_post_id ON comment_events(post_id, id);
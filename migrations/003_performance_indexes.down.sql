@@ -0,0 +1,19 @@
+-- Migration: 003_performance_indexes.down.sql
+-- Description: Rollback additional performance indexes and optimizations
+-- Date: 2026
+
+-- Drop functions
+DROP FUNCTION IF EXISTS get_comment_thread(UUID, INTEGER);
+DROP FUNCTION IF EXISTS get_popular_posts(INTEGER, INTEGER);
+DROP FUNCTION IF EXISTS refresh_post_analytics();
+
+-- Drop materialized view (its indexes are dropped with it)
+DROP MATERIALIZED VIEW IF EXISTS post_analytics;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_comments_count_by_post;
+DROP INDEX IF EXISTS idx_comments_hierarchical;
+DROP INDEX IF EXISTS idx_comments_content_search;
+DROP INDEX IF EXISTS idx_posts_content_search;
+DROP INDEX IF EXISTS idx_posts_title_search;
+DROP INDEX IF EXISTS idx_posts_enabled_comments_only;
@@ -1,4 +1,4 @@
--- Migration: 003_performance_indexes.sql
+-- Migration: 003_performance_indexes.up.sql
 -- Description: Additional performance indexes and optimizations
 -- Author: Auto-generated
 -- Date: 2023
@@ -8,10 +8,6 @@ CREATE INDEX idx_posts_enabled_comments_only
 ON posts(created_at DESC)
 WHERE comments_enabled = true;
 
-CREATE INDEX idx_posts_by_author_recent
-ON posts(author_id, created_at DESC)
-WHERE created_at > (CURRENT_TIMESTAMP - INTERVAL '30 days');
-
 -- Full-text search indexes (if needed)
 CREATE INDEX idx_posts_title_search
 ON posts USING gin(to_tsvector('english', title));
@@ -52,6 +48,8 @@ LEFT JOIN comments c ON p.id = c.post_id
 GROUP BY p.id, p.title, p.author_id, p.created_at;
 
 -- Index on materialized view
+-- Unique index is required for REFRESH MATERIALIZED VIEW CONCURRENTLY
+CREATE UNIQUE INDEX idx_post_analytics_id ON post_analytics(id);
 CREATE INDEX idx_post_analytics_author ON post_analytics(author_id);
 CREATE INDEX idx_post_analytics_date ON post_analytics(created_date DESC);
 CREATE INDEX idx_post_analytics_comments ON post_analytics(total_comments DESC);
@@ -0,0 +1,22 @@
+-- Migration: 004_multilingual_search.down.sql
+-- Description: Rollback per-document search language and stored tsvector columns
+-- Date: 2026
+
+DROP INDEX IF EXISTS idx_comments_search_vector;
+DROP INDEX IF EXISTS idx_posts_search_vector;
+
+ALTER TABLE comments DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE posts DROP COLUMN IF EXISTS search_vector;
+
+ALTER TABLE comments DROP COLUMN IF EXISTS language;
+ALTER TABLE posts DROP COLUMN IF EXISTS language;
+
+-- Восстанавливаем индексы из 003_performance_indexes
+CREATE INDEX idx_posts_title_search
+ON posts USING gin(to_tsvector('english', title));
+
+CREATE INDEX idx_posts_content_search
+ON posts USING gin(to_tsvector('english', content));
+
+CREATE INDEX idx_comments_content_search
+ON comments USING gin(to_tsvector('english', content));
@@ -0,0 +1,75 @@
+-- Migration: 004_multilingual_search.up.sql
+-- Description: Per-document search language and stored tsvector columns
+-- Date: 2026
+
+-- Язык документа определяет конфигурацию полнотекстового поиска (стемминг и стоп-слова).
+-- Значения совпадают с именами конфигураций PostgreSQL.
+ALTER TABLE posts
+    ADD COLUMN language TEXT NOT NULL DEFAULT 'simple'
+    CHECK (language IN ('russian', 'english', 'simple'));
+
+ALTER TABLE comments
+    ADD COLUMN language TEXT NOT NULL DEFAULT 'simple'
+    CHECK (language IN ('russian', 'english', 'simple'));
+
+-- Заполняем язык существующих документов по тому же правилу, что и model.DetectLanguage:
+-- русский, если букв кириллицы не меньше, чем латиницы, английский, если есть латиница.
+-- Учитываются основные блоки Unicode обоих алфавитов.
+CREATE FUNCTION pg_temp.detect_language(document TEXT) RETURNS TEXT AS $$
+DECLARE
+    cyrillic INT := length(regexp_replace(document, '[^\u0400-\u052F\u1C80-\u1C8F\u2DE0-\u2DFF\uA640-\uA69F]', '', 'g'));
+    latin INT := length(regexp_replace(document, '[^A-Za-z\u00AA\u00BA\u00C0-\u00D6\u00D8-\u00F6\u00F8-\u024F\u1E00-\u1EFF]', '', 'g'));
+BEGIN
+    IF cyrillic > 0 AND cyrillic >= latin THEN
+        RETURN 'russian';
+    ELSIF latin > 0 THEN
+        RETURN 'english';
+    END IF;
+    RETURN 'simple';
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+-- Заполнение языка не является правкой документа: триггеры updated_at отключаются
+ALTER TABLE posts DISABLE TRIGGER update_posts_updated_at;
+ALTER TABLE comments DISABLE TRIGGER update_comments_updated_at;
+
+UPDATE posts SET language = pg_temp.detect_language(title || ' ' || content);
+UPDATE comments SET language = pg_temp.detect_language(content);
+
+ALTER TABLE posts ENABLE TRIGGER update_posts_updated_at;
+ALTER TABLE comments ENABLE TRIGGER update_comments_updated_at;
+
+DROP FUNCTION pg_temp.detect_language(TEXT);
+
+-- Хранимые tsvector строятся конфигурацией языка документа.
+-- Конфигурации перечислены константами: приведение столбца к regconfig
+-- не является immutable и недопустимо в генерируемом столбце.
+ALTER TABLE posts ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    CASE language
+        WHEN 'russian' THEN
+            setweight(to_tsvector('russian', title), 'A') || setweight(to_tsvector('russian', content), 'B')
+        WHEN 'english' THEN
+            setweight(to_tsvector('english', title), 'A') || setweight(to_tsvector('english', content), 'B')
+        ELSE
+            setweight(to_tsvector('simple', title), 'A') || setweight(to_tsvector('simple', content), 'B')
+    END
+) STORED;
+
+ALTER TABLE comments ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    CASE language
+        WHEN 'russian' THEN to_tsvector('russian', content)
+        WHEN 'english' THEN to_tsvector('english', content)
+        ELSE to_tsvector('simple', content)
+    END
+) STORED;
+
+CREATE INDEX idx_posts_search_vector ON posts USING gin(search_vector);
+CREATE INDEX idx_comments_search_vector ON comments USING gin(search_vector);
+
+-- Индексы по английской конфигурации заменены хранимыми tsvector
+DROP INDEX IF EXISTS idx_posts_title_search;
+DROP INDEX IF EXISTS idx_posts_content_search;
+DROP INDEX IF EXISTS idx_comments_content_search;
+
+ANALYZE posts;
+ANALYZE comments;
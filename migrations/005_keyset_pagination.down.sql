@@ -0,0 +1,8 @@
+-- Migration: 005_keyset_pagination.down.sql
+-- Description: Rollback composite indexes for keyset pagination
+-- Date: 2026
+
+DROP INDEX IF EXISTS idx_comments_parent_created_at_id;
+DROP INDEX IF EXISTS idx_comments_post_created_at_id;
+DROP INDEX IF EXISTS idx_posts_author_created_at_id;
+DROP INDEX IF EXISTS idx_posts_created_at_id;
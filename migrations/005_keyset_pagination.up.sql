@@ -0,0 +1,10 @@
+-- Migration: 005_keyset_pagination.up.sql
+-- Description: Composite indexes for keyset pagination by (created_at, id)
+-- Date: 2026
+
+-- Страницы выбираются сравнением строк (created_at, id) > ($1, $2):
+-- индексы покрывают сортировку и условие курсора в обоих направлениях
+CREATE INDEX idx_posts_created_at_id ON posts(created_at, id);
+CREATE INDEX idx_posts_author_created_at_id ON posts(author_id, created_at, id);
+CREATE INDEX idx_comments_post_created_at_id ON comments(post_id, created_at, id);
+CREATE INDEX idx_comments_parent_created_at_id ON comments(parent_id, created_at, id);
@@ -0,0 +1,28 @@
+-- Migration: 006_sort_orders.down.sql
+-- Description: Rollback composite indexes and counters for keyset pagination
+-- Date: 2026
+
+DROP TRIGGER IF EXISTS update_comments_updated_at ON comments;
+CREATE TRIGGER update_comments_updated_at
+    BEFORE UPDATE ON comments
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_posts_updated_at ON posts;
+CREATE TRIGGER update_posts_updated_at
+    BEFORE UPDATE ON posts
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_comments_counters ON comments;
+DROP FUNCTION IF EXISTS update_comment_counters();
+
+DROP INDEX IF EXISTS idx_comments_parent_reply_count_id;
+DROP INDEX IF EXISTS idx_comments_post_reply_count_id;
+DROP INDEX IF EXISTS idx_posts_comment_count_id;
+
+ALTER TABLE comments DROP COLUMN IF EXISTS reply_count;
+ALTER TABLE posts DROP COLUMN IF EXISTS comment_count;
+
+DROP INDEX IF EXISTS idx_comments_post_updated_at_id;
+DROP INDEX IF EXISTS idx_posts_updated_at_id;
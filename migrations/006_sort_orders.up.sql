@@ -0,0 +1,68 @@
+-- Migration: 006_sort_orders.up.sql
+-- Description: Composite indexes and counters for keyset pagination by update time and counts
+-- Date: 2026
+
+-- Порядок RECENTLY_UPDATED выбирает страницы сравнением (updated_at, id) с курсором.
+CREATE INDEX idx_posts_updated_at_id ON posts(updated_at, id);
+CREATE INDEX idx_comments_post_updated_at_id ON comments(post_id, updated_at, id);
+
+-- Порядки MOST_COMMENTED и MOST_REPLIES сортируют по счетчикам, которые триггеры
+-- обновляют при вставке и удалении комментариев, поэтому страница выбирается по индексу
+-- без подсчета комментариев каждого поста.
+ALTER TABLE posts ADD COLUMN comment_count BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE comments ADD COLUMN reply_count BIGINT NOT NULL DEFAULT 0;
+
+-- Изменение счетчика не является изменением поста или комментария: updated_at не обновляется.
+-- Триггеры пересоздаются до заполнения счетчиков, чтобы заполнение не сдвинуло updated_at
+DROP TRIGGER update_posts_updated_at ON posts;
+CREATE TRIGGER update_posts_updated_at
+    BEFORE UPDATE ON posts
+    FOR EACH ROW
+    WHEN (OLD.comment_count = NEW.comment_count)
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER update_comments_updated_at ON comments;
+CREATE TRIGGER update_comments_updated_at
+    BEFORE UPDATE ON comments
+    FOR EACH ROW
+    WHEN (OLD.reply_count = NEW.reply_count)
+    EXECUTE FUNCTION update_updated_at_column();
+
+UPDATE posts p
+SET comment_count = counts.total
+FROM (SELECT post_id, COUNT(*) AS total FROM comments GROUP BY post_id) counts
+WHERE p.id = counts.post_id;
+
+UPDATE comments c
+SET reply_count = counts.total
+FROM (SELECT parent_id, COUNT(*) AS total FROM comments WHERE parent_id IS NOT NULL GROUP BY parent_id) counts
+WHERE c.id = counts.parent_id;
+
+CREATE INDEX idx_posts_comment_count_id ON posts(comment_count, id);
+CREATE INDEX idx_comments_post_reply_count_id ON comments(post_id, reply_count, id);
+CREATE INDEX idx_comments_parent_reply_count_id ON comments(parent_id, reply_count, id);
+
+CREATE OR REPLACE FUNCTION update_comment_counters()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE posts SET comment_count = comment_count + 1 WHERE id = NEW.post_id;
+        IF NEW.parent_id IS NOT NULL THEN
+            UPDATE comments SET reply_count = reply_count + 1 WHERE id = NEW.parent_id;
+        END IF;
+        RETURN NEW;
+    END IF;
+
+    -- При каскадном удалении строки поста или родителя уже может не быть: UPDATE ничего не изменит
+    UPDATE posts SET comment_count = comment_count - 1 WHERE id = OLD.post_id;
+    IF OLD.parent_id IS NOT NULL THEN
+        UPDATE comments SET reply_count = reply_count - 1 WHERE id = OLD.parent_id;
+    END IF;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER update_comments_counters
+    AFTER INSERT OR DELETE ON comments
+    FOR EACH ROW
+    EXECUTE FUNCTION update_comment_counters();
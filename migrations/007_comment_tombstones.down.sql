@@ -0,0 +1,5 @@
+-- Migration: 007_comment_tombstones.down.sql
+-- Description: Rollback soft delete of comments
+-- Date: 2026
+
+ALTER TABLE comments DROP COLUMN IF EXISTS deleted_at;
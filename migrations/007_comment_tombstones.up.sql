@@ -0,0 +1,11 @@
+-- Migration: 007_comment_tombstones.up.sql
+-- Description: Soft delete of comments with replies as tombstones
+-- Date: 2026
+
+-- Мягко удаленный комментарий с ответами остается в дереве надгробием:
+-- содержимое заменено заглушкой, author_id обнулен, deleted_at указывает время удаления.
+-- Полнотекстовый поиск исключает надгробия условием deleted_at IS NULL.
+-- Счетчики comment_count и reply_count (триггер update_comment_counters из 006),
+-- представление post_analytics и статистика комментариев учитывают надгробия, как
+-- и in-memory хранилище: надгробие остается узлом дерева, пока у него есть ответы.
+ALTER TABLE comments ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
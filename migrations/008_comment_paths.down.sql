@@ -0,0 +1,18 @@
+-- Migration: 008_comment_paths.down.sql
+-- Description: Rollback materialized ltree paths for comment hierarchies
+-- Date: 2026
+
+DROP TRIGGER IF EXISTS guard_comments_path ON comments;
+DROP FUNCTION IF EXISTS guard_comment_path();
+
+DROP TRIGGER IF EXISTS set_comments_path ON comments;
+DROP FUNCTION IF EXISTS set_comment_path();
+
+DROP INDEX IF EXISTS idx_comments_post_path;
+DROP INDEX IF EXISTS idx_comments_path_gist;
+
+ALTER TABLE comments DROP COLUMN IF EXISTS path;
+
+DROP FUNCTION IF EXISTS comment_path_label(UUID, TIMESTAMP WITH TIME ZONE);
+
+-- Расширение ltree не удаляется: его могут использовать другие объекты
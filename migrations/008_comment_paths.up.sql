@@ -0,0 +1,96 @@
+-- Migration: 008_comment_paths.up.sql
+-- Description: Materialized ltree paths for comment hierarchies
+-- Date: 2026
+
+CREATE EXTENSION IF NOT EXISTS ltree;
+
+-- Путь комментария - метки всех его предков и его собственная метка, от корня ветки.
+-- Метка - время создания в целых микросекундах от начала эпохи (16 цифр, дробная часть
+-- отбрасывается, как в Go time.UnixMicro) и ID без дефисов, поэтому сортировка по path
+-- дает обход ветки в глубину с ответами в порядке (created_at, id). Время до 1970 года
+-- дало бы отрицательное число, недопустимое в метке ltree, и отклоняется.
+CREATE OR REPLACE FUNCTION comment_path_label(comment_id UUID, comment_created_at TIMESTAMP WITH TIME ZONE)
+RETURNS ltree AS $$
+BEGIN
+    IF comment_created_at < 'epoch'::timestamptz THEN
+        RAISE EXCEPTION 'Comment created_at % is before the Unix epoch', comment_created_at;
+    END IF;
+
+    RETURN text2ltree(
+        lpad(floor(extract(epoch FROM comment_created_at) * 1000000)::bigint::text, 16, '0')
+        || '_' || replace(comment_id::text, '-', '')
+    );
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+ALTER TABLE comments ADD COLUMN path ltree;
+
+-- Заполняем пути существующих комментариев от корней вниз. Заполнение не является
+-- правкой комментария: триггер updated_at на время заполнения отключается
+ALTER TABLE comments DISABLE TRIGGER update_comments_updated_at;
+
+WITH RECURSIVE paths AS (
+    SELECT id, comment_path_label(id, created_at) AS path
+    FROM comments
+    WHERE parent_id IS NULL
+
+    UNION ALL
+
+    SELECT c.id, p.path || comment_path_label(c.id, c.created_at)
+    FROM comments c
+    INNER JOIN paths p ON c.parent_id = p.id
+)
+UPDATE comments c
+SET path = paths.path
+FROM paths
+WHERE c.id = paths.id;
+
+ALTER TABLE comments ENABLE TRIGGER update_comments_updated_at;
+
+ALTER TABLE comments ALTER COLUMN path SET NOT NULL;
+
+-- GiST индекс обслуживает выборки поддеревьев (<@) и предков (@>),
+-- B-tree индекс - обход веток поста в порядке path
+CREATE INDEX idx_comments_path_gist ON comments USING GIST (path);
+CREATE INDEX idx_comments_post_path ON comments (post_id, path);
+
+-- Путь вычисляется при вставке из parent_id и created_at
+CREATE OR REPLACE FUNCTION set_comment_path()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.parent_id IS NULL THEN
+        NEW.path = comment_path_label(NEW.id, NEW.created_at);
+    ELSE
+        SELECT path || comment_path_label(NEW.id, NEW.created_at) INTO NEW.path
+        FROM comments
+        WHERE id = NEW.parent_id;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER set_comments_path
+    BEFORE INSERT ON comments
+    FOR EACH ROW
+    EXECUTE FUNCTION set_comment_path();
+
+-- Путь не пересчитывается, поэтому parent_id, created_at и сам путь после вставки
+-- изменять нельзя: перенос ветки оформляется удалением и вставкой
+CREATE OR REPLACE FUNCTION guard_comment_path()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.parent_id IS DISTINCT FROM OLD.parent_id
+        OR NEW.created_at IS DISTINCT FROM OLD.created_at
+        OR NEW.path IS DISTINCT FROM OLD.path THEN
+        RAISE EXCEPTION 'Comment parent_id, created_at and path cannot be changed';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER guard_comments_path
+    BEFORE UPDATE ON comments
+    FOR EACH ROW
+    EXECUTE FUNCTION guard_comment_path();
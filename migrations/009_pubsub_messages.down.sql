@@ -0,0 +1,5 @@
+-- Migration: 009_pubsub_messages.down.sql
+-- Description: Rollback storage for pub/sub messages
+-- Date: 2026
+
+DROP TABLE IF EXISTS pubsub_messages;
@@ -0,0 +1,15 @@
+-- Migration: 009_pubsub_messages.up.sql
+-- Description: Storage for pub/sub messages exceeding the NOTIFY payload limit
+-- Date: 2026
+
+-- Брокер SUBSCRIPTION_BROKER=postgres сохраняет сюда сообщения, которые не помещаются
+-- в payload уведомления, и передает в NOTIFY только идентификатор записи. Записи нужны
+-- несколько минут, поэтому таблица UNLOGGED.
+CREATE UNLOGGED TABLE pubsub_messages (
+    id BIGSERIAL PRIMARY KEY,
+    channel TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_pubsub_messages_created_at ON pubsub_messages(created_at);
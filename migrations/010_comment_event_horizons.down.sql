@@ -0,0 +1,5 @@
+-- Migration: 010_comment_event_horizons.down.sql
+-- Description: Rollback tracking of trimmed comment events
+-- Date: 2026
+
+DROP TABLE IF EXISTS comment_event_horizons;
@@ -0,0 +1,20 @@
+-- Migration: 010_comment_event_horizons.up.sql
+-- Description: Track trimmed comment events so expired replay cursors are detected
+-- Date: 2026
+
+-- ID последнего события поста, удаленного из журнала comment_events при превышении
+-- лимита. Курсор повторной доставки меньше trimmed_id устарел: часть событий после
+-- него уже удалена, и клиент должен заново загрузить комментарии.
+CREATE TABLE IF NOT EXISTS comment_event_horizons (
+    post_id UUID PRIMARY KEY,
+    trimmed_id BIGINT NOT NULL
+);
+
+-- Журналы, заполненные до лимита (repository.CommentEventRetention), могли быть сокращены
+-- до миграции: границей считается событие перед самым старым сохраненным
+INSERT INTO comment_event_horizons (post_id, trimmed_id)
+SELECT post_id, MIN(id) - 1
+FROM comment_events
+GROUP BY post_id
+HAVING COUNT(*) >= 1000
+ON CONFLICT (post_id) DO NOTHING;
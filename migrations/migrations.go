@@ -0,0 +1,17 @@
+// Package migrations содержит версионированные SQL миграции схемы PostgreSQL.
+//
+// Каждая миграция состоит из пары файлов NNN_name.up.sql и NNN_name.down.sql,
+// где NNN - номер версии. Файлы встраиваются в бинарный файл и применяются
+// postgres.Migrator при старте сервера или командой cmd/migrate.
+//
+// Заполнение новых столбцов существующих строк не является правкой поста или
+// комментария и не должно менять updated_at: на время заполнения триггеры
+// update_posts_updated_at и update_comments_updated_at отключаются.
+package migrations
+
+import "embed"
+
+// FS содержит SQL файлы миграций
+//
+//go:embed *.sql
+var FS embed.FS
@@ -61,11 +61,7 @@ until docker compose exec postgres pg_isready -U habbr_user -d habbr > /dev/null
 done
 echo ""
 
-# Run migrations
-print_status "Running database migrations..."
-docker compose exec postgres psql -U habbr_user -d habbr -f /docker-entrypoint-initdb.d/001_initial_schema.sql > /dev/null 2>&1 || {
-    print_warning "Initial migration might already be applied"
-}
+# Migrations are applied by the API on startup (see cmd/migrate for manual control)
 
 # Start development tools (optional)
 if [ "$1" = "--with-tools" ]; then
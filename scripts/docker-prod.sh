@@ -75,15 +75,7 @@ until docker compose exec redis redis-cli ping > /dev/null 2>&1; do
 done
 echo ""
 
-# Run database migrations
-print_status "Running database migrations..."
-docker compose exec postgres psql -U habbr_user -d habbr -f /docker-entrypoint-initdb.d/001_initial_schema.sql > /dev/null 2>&1 || {
-    print_warning "Initial migration might already be applied"
-}
-
-docker compose exec postgres psql -U habbr_user -d habbr -f /docker-entrypoint-initdb.d/003_performance_indexes.sql > /dev/null 2>&1 || {
-    print_warning "Performance migration might already be applied"
-}
+# Migrations are applied by the API on startup (see cmd/migrate for manual control)
 
 # Start the application
 print_status "Starting Habbr API in production mode..."
@@ -36,7 +36,7 @@ func TestPostService_Integration(t *testing.T) {
 		Comment: commentRepo,
 	}
 
-	serviceManager := service.NewManager(repos, logger)
+	serviceManager := service.NewManager(repos, nil, config.SubscriptionConfig{}, nil, config.AnalyticsConfig{}, logger)
 	services := serviceManager.GetServices()
 	defer serviceManager.Close()
 
@@ -168,7 +168,7 @@ func TestPostService_Integration(t *testing.T) {
 		assert.Len(t, commentConnection.Edges, 2)
 
 		// Get comments tree
-		commentsTree, err := commentService.GetCommentsTree(ctx, post.ID, nil)
+		commentsTree, err := commentService.GetFullCommentTree(ctx, post.ID, "")
 		require.NoError(t, err)
 		assert.Len(t, commentsTree, 2)
 
@@ -289,7 +289,7 @@ func TestCommentDepthValidation_Integration(t *testing.T) {
 		Comment: commentRepo,
 	}
 
-	serviceManager := service.NewManager(repos, logger)
+	serviceManager := service.NewManager(repos, nil, config.SubscriptionConfig{}, nil, config.AnalyticsConfig{}, logger)
 	services := serviceManager.GetServices()
 	defer serviceManager.Close()
 
@@ -334,7 +334,7 @@ func TestCommentDepthValidation_Integration(t *testing.T) {
 	assert.Equal(t, 9, lastComment.Depth)
 
 	// Get the comments tree and verify structure
-	commentsTree, err := commentService.GetCommentsTree(ctx, post.ID, nil)
+	commentsTree, err := commentService.GetFullCommentTree(ctx, post.ID, "")
 	require.NoError(t, err)
 	assert.Len(t, commentsTree, 10)
 
@@ -355,7 +355,7 @@ func TestServiceHealthCheck_Integration(t *testing.T) {
 		Comment: commentRepo,
 	}
 
-	serviceManager := service.NewManager(repos, logger)
+	serviceManager := service.NewManager(repos, nil, config.SubscriptionConfig{}, nil, config.AnalyticsConfig{}, logger)
 	defer serviceManager.Close()
 
 	ctx := context.Background()